@@ -14,22 +14,36 @@ import (
 	_ "github.com/anigmaa/backend/docs"
 	"github.com/anigmaa/backend/internal/delivery/http/handler"
 	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	userDomain "github.com/anigmaa/backend/internal/domain/user"
 	"github.com/anigmaa/backend/internal/infrastructure/cache"
 	"github.com/anigmaa/backend/internal/infrastructure/database"
 	"github.com/anigmaa/backend/internal/infrastructure/payment"
+	"github.com/anigmaa/backend/internal/infrastructure/scheduler"
 	"github.com/anigmaa/backend/internal/infrastructure/storage"
 	"github.com/anigmaa/backend/internal/repository/postgres"
+	redisRepo "github.com/anigmaa/backend/internal/repository/redis"
+	"github.com/anigmaa/backend/internal/usecase/account"
+	"github.com/anigmaa/backend/internal/usecase/admin"
 	"github.com/anigmaa/backend/internal/usecase/analytics"
+	"github.com/anigmaa/backend/internal/usecase/block"
+	"github.com/anigmaa/backend/internal/usecase/calendar"
 	"github.com/anigmaa/backend/internal/usecase/community"
 	"github.com/anigmaa/backend/internal/usecase/event"
 	"github.com/anigmaa/backend/internal/usecase/feed_ranking"
+	"github.com/anigmaa/backend/internal/usecase/moderation"
+	"github.com/anigmaa/backend/internal/usecase/payout"
 	"github.com/anigmaa/backend/internal/usecase/post"
+	"github.com/anigmaa/backend/internal/usecase/promo"
 	"github.com/anigmaa/backend/internal/usecase/qna"
+	"github.com/anigmaa/backend/internal/usecase/search"
 	"github.com/anigmaa/backend/internal/usecase/ticket"
 	"github.com/anigmaa/backend/internal/usecase/user"
+	"github.com/anigmaa/backend/internal/usecase/waitlist"
 	"github.com/anigmaa/backend/pkg/jwt"
+	"github.com/anigmaa/backend/pkg/qrcode"
 	"github.com/anigmaa/backend/pkg/validator"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -92,6 +106,15 @@ func main() {
 	// Initialize JWT manager
 	jwtManager := jwt.NewJWTManager(cfg.JWT.Secret, cfg.JWT.Expiration, cfg.JWT.RefreshExpiration)
 
+	// Initialize ticket QR signer
+	qrSigner := qrcode.NewSigner(cfg.Ticket.QRSecret)
+
+	// Initialize check-in snapshot signer
+	snapshotSigner, err := qrcode.NewSnapshotSigner(cfg.Ticket.SnapshotKey)
+	if err != nil {
+		log.Fatalf("Failed to initialize check-in snapshot signer: %v", err)
+	}
+
 	// Initialize storage
 	storageService, err := storage.NewStorage(&cfg.Storage)
 	if err != nil {
@@ -113,15 +136,52 @@ func main() {
 	qnaRepo := postgres.NewQnARepository(db)
 	communityRepo := postgres.NewCommunityRepository(db)
 	authTokenRepo := postgres.NewAuthTokenRepository(db)
+	waitlistRepo := postgres.NewWaitlistRepository(db)
+	promoRepo := postgres.NewPromoRepository(db)
+	payoutRepo := postgres.NewPayoutRepository(db)
+	searchRepo := postgres.NewSearchRepository(db)
+	calendarRepo := postgres.NewCalendarRepository(db)
+	blockRepo := postgres.NewBlockRepository(db)
+	moderationRepo := postgres.NewModerationRepository(db)
+	accountRepo := postgres.NewAccountRepository(db)
+	cacheRepo := redisRepo.NewCacheRepository(redisClient.GetClient())
+
+	// Bootstrap the first admins from ADMIN_USER_IDS
+	// Only while no admin exists, so later demotions through the admin API stick across restarts
+	if len(cfg.Admin.UserIDs) > 0 {
+		admins, err := userRepo.CountByRole(context.Background(), userDomain.RoleAdmin)
+		if err != nil {
+			log.Printf("⚠ Failed to count admins: %v", err)
+		} else if admins == 0 {
+			for _, id := range cfg.Admin.UserIDs {
+				adminID, err := uuid.Parse(id)
+				if err == nil {
+					err = userRepo.SetRole(context.Background(), adminID, userDomain.RoleAdmin)
+				}
+				if err != nil {
+					log.Printf("⚠ Failed to grant admin role to %s: %v", id, err)
+				}
+			}
+		}
+	}
 
 	// Initialize use cases
-	userUsecase := user.NewUsecase(userRepo, authTokenRepo, jwtManager, cfg.Google.ClientID)
-	eventUsecase := event.NewUsecase(eventRepo, userRepo)
-	postUsecase := post.NewUsecase(postRepo, commentRepo, interactionRepo, eventRepo, userRepo)
-	ticketUsecase := ticket.NewUsecase(ticketRepo, eventRepo, userRepo, midtransClient)
-	analyticsUsecase := analytics.NewUsecase(eventRepo, ticketRepo)
+	userUsecase := user.NewUsecase(userRepo, authTokenRepo, blockRepo, jwtManager, cfg.Google.ClientID)
+	waitlistUsecase := waitlist.NewUsecase(waitlistRepo, eventRepo, ticketRepo, cfg.Ticket.WaitlistClaimWindow)
+	postUsecase := post.NewUsecase(postRepo, commentRepo, interactionRepo, eventRepo, userRepo, blockRepo, cfg.Post.EditWindow, cfg.Post.EditEngagementLimit)
+	promoUsecase := promo.NewUsecase(promoRepo, eventRepo)
+	payoutUsecase := payout.NewUsecase(payoutRepo, eventRepo, cfg.Payout.PlatformFeePercent, cfg.Payout.HoldPeriod)
+	analyticsUsecase := analytics.NewUsecase(eventRepo, ticketRepo, waitlistRepo, promoRepo, interactionRepo, cacheRepo)
+	ticketUsecase := ticket.NewUsecase(ticketRepo, eventRepo, userRepo, blockRepo, midtransClient, waitlistUsecase, promoUsecase, payoutUsecase, analyticsUsecase, qrSigner, snapshotSigner, cfg.Ticket.PendingTTL)
+	eventUsecase := event.NewUsecase(eventRepo, userRepo, waitlistUsecase, ticketUsecase, cfg.Event.SeriesHorizon)
 	qnaUsecase := qna.NewUsecase(qnaRepo, eventRepo)
 	communityUsecase := community.NewUsecase(communityRepo)
+	searchUsecase := search.NewUsecase(searchRepo)
+	calendarUsecase := calendar.NewUsecase(eventRepo, calendarRepo, cfg.Server.PublicURL)
+	blockUsecase := block.NewUsecase(blockRepo, userRepo)
+	moderationUsecase := moderation.NewUsecase(moderationRepo, userRepo, cfg.Moderation.AutoHideThreshold)
+	adminUsecase := admin.NewUsecase(userRepo, eventRepo, ticketRepo, communityRepo, moderationRepo, ticketUsecase)
+	accountUsecase := account.NewUsecase(accountRepo, userRepo, eventRepo, communityRepo, ticketUsecase, cfg.Account.DeletionGracePeriod, cfg.Account.ExportRetention, cfg.Server.PublicURL)
 	feedRanker := feed_ranking.NewRanker()
 
 	// Initialize HTTP handlers
@@ -135,8 +195,28 @@ func main() {
 	qnaHandler := handler.NewQnAHandler(qnaUsecase, validate)
 	uploadHandler := handler.NewUploadHandler(storageService)
 	communityHandler := handler.NewCommunityHandler(communityUsecase, validate)
-	paymentHandler := handler.NewPaymentHandler(midtransClient, ticketRepo, eventRepo, userRepo)
+	paymentHandler := handler.NewPaymentHandler(midtransClient, ticketUsecase)
 	feedRankingHandler := handler.NewFeedRankingHandler(feedRanker)
+	waitlistHandler := handler.NewWaitlistHandler(waitlistUsecase)
+	promoHandler := handler.NewPromoHandler(promoUsecase)
+	payoutHandler := handler.NewPayoutHandler(payoutUsecase)
+	searchHandler := handler.NewSearchHandler(searchUsecase)
+	seriesHandler := handler.NewSeriesHandler(eventUsecase, validate)
+	calendarHandler := handler.NewCalendarHandler(calendarUsecase)
+	blockHandler := handler.NewBlockHandler(blockUsecase)
+	moderationHandler := handler.NewModerationHandler(moderationUsecase)
+	adminHandler := handler.NewAdminHandler(adminUsecase)
+	accountHandler := handler.NewAccountHandler(accountUsecase)
+
+	// Start background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	scheduler.Every(jobsCtx, "expire-pending-tickets", time.Minute, ticketUsecase.ExpirePendingTickets)
+	scheduler.Every(jobsCtx, "expire-waitlist-offers", time.Minute, waitlistUsecase.ExpireOffers)
+	scheduler.Every(jobsCtx, "extend-event-series", time.Hour, eventUsecase.ExtendSeries)
+	scheduler.Every(jobsCtx, "process-account-deletions", time.Hour, accountUsecase.ProcessDeletions)
+	scheduler.Every(jobsCtx, "process-data-exports", time.Minute, accountUsecase.ProcessExports)
+	scheduler.Every(jobsCtx, "publish-scheduled-posts", time.Minute, postUsecase.PublishScheduledPosts)
 
 	// Setup router
 	router := gin.Default()
@@ -213,7 +293,10 @@ func main() {
 		}
 
 		// Protected routes (auth required)
-		authMiddleware := middleware.JWTAuth(jwtManager)
+		authMiddleware := middleware.JWTAuth(jwtManager, userRepo)
+
+		// File downloads stream for longer than the server write timeout allows
+		exportTimeout := middleware.WriteTimeout(5 * time.Minute)
 
 		// Auth routes (with authentication)
 		authProtected := v1.Group("/auth")
@@ -229,23 +312,53 @@ func main() {
 		{
 			users.GET("/me", userHandler.GetMe)
 			users.PUT("/me", userHandler.UpdateMe)
+			users.DELETE("/me", accountHandler.DeleteAccount)
+			users.POST("/me/deletion/cancel", accountHandler.CancelAccountDeletion)
+			users.GET("/me/export", accountHandler.GetDataExport)
+			users.GET("/me/export/download", exportTimeout, accountHandler.DownloadDataExport)
+			users.PUT("/me/username", userHandler.ChangeUsername)
+			users.GET("/me/username/suggestions", userHandler.GetUsernameSuggestions)
+			users.GET("/me/username/history", userHandler.GetUsernameHistory)
 			users.PUT("/me/settings", userHandler.UpdateSettings)
+			users.PUT("/me/privacy", userHandler.UpdatePrivacy)
+			users.GET("/me/follow-requests", userHandler.GetFollowRequests)
+			users.POST("/me/follow-requests/:id/approve", userHandler.ApproveFollowRequest)
+			users.DELETE("/me/follow-requests/:id", userHandler.DeclineFollowRequest)
+			users.GET("/me/calendar", calendarHandler.GetCalendarFeed)
+			users.POST("/me/calendar/reset", calendarHandler.ResetCalendarFeed)
+			users.GET("/me/blocked", blockHandler.GetBlockedUsers)
+			users.GET("/me/muted", blockHandler.GetMutedUsers)
 			users.GET("/search", userHandler.SearchUsers)
+			users.GET("/username/check", userHandler.CheckUsername)
 			users.GET("/:id", userHandler.GetUserByID)
 			users.GET("/:id/followers", userHandler.GetFollowers)
 			users.GET("/:id/following", userHandler.GetFollowing)
 			users.POST("/:id/follow", userHandler.FollowUser)
 			users.DELETE("/:id/follow", userHandler.UnfollowUser)
 			users.GET("/:id/stats", userHandler.GetUserStats)
+			users.POST("/:id/block", blockHandler.BlockUser)
+			users.DELETE("/:id/block", blockHandler.UnblockUser)
+			users.POST("/:id/mute", blockHandler.MuteUser)
+			users.DELETE("/:id/mute", blockHandler.UnmuteUser)
 		}
 
+		// Unified search (public, signed-in users don't see users they blocked or were blocked by)
+		v1.GET("/search", middleware.OptionalJWTAuth(jwtManager, userRepo), searchHandler.Search)
+
+		// Calendar subscription feed (authenticated by the secret token in the path)
+		v1.GET("/calendar/:token", calendarHandler.GetFeedCalendar)
+
 		// Event routes
 		events := v1.Group("/events")
+		events.Use(middleware.OptionalJWTAuth(jwtManager, userRepo))
 		{
 			events.GET("", eventHandler.GetEvents)
 			events.GET("/nearby", eventHandler.GetNearbyEvents)
+			events.GET("/map", eventHandler.GetMapEvents)
 			events.GET("/:id", eventHandler.GetEventByID)
 			events.GET("/:id/attendees", eventHandler.GetEventAttendees)
+			events.GET("/:id/tiers", ticketHandler.GetEventTiers)
+			events.GET("/:id/calendar.ics", calendarHandler.GetEventCalendar)
 		}
 
 		eventsProtected := v1.Group("/events")
@@ -254,6 +367,8 @@ func main() {
 			eventsProtected.POST("", eventHandler.CreateEvent)
 			eventsProtected.PUT("/:id", eventHandler.UpdateEvent)
 			eventsProtected.DELETE("/:id", eventHandler.DeleteEvent)
+			eventsProtected.POST("/:id/cancel", eventHandler.CancelEvent)
+			eventsProtected.PUT("/:id/following", seriesHandler.UpdateFollowingOccurrences)
 			eventsProtected.POST("/:id/join", eventHandler.JoinEvent)
 			eventsProtected.DELETE("/:id/join", eventHandler.LeaveEvent)
 			eventsProtected.GET("/my-events", eventHandler.GetMyEvents)
@@ -267,6 +382,25 @@ func main() {
 			// Event Q&A endpoints
 			eventsProtected.GET("/:id/qna", qnaHandler.GetEventQnA)
 			eventsProtected.POST("/:id/qna", qnaHandler.AskQuestion)
+
+			// Event waitlist endpoints
+			eventsProtected.GET("/:id/waitlist", waitlistHandler.GetWaitlistStatus)
+			eventsProtected.POST("/:id/waitlist", waitlistHandler.JoinWaitlist)
+			eventsProtected.DELETE("/:id/waitlist", waitlistHandler.LeaveWaitlist)
+
+			// Check-in and staff endpoints (host or staff)
+			eventsProtected.POST("/:id/check-in", ticketHandler.CheckIn)
+			eventsProtected.GET("/:id/check-in/snapshot", ticketHandler.GetCheckInSnapshot)
+			eventsProtected.POST("/:id/check-in/sync", ticketHandler.SyncOfflineCheckIns)
+			eventsProtected.GET("/:id/staff", eventHandler.GetEventStaff)
+			eventsProtected.POST("/:id/staff", eventHandler.AddEventStaff)
+			eventsProtected.PUT("/:id/staff/:userId", eventHandler.UpdateEventStaff)
+			eventsProtected.DELETE("/:id/staff/:userId", eventHandler.RemoveEventStaff)
+
+			// Ticket tier management endpoints
+			eventsProtected.POST("/:id/tiers", ticketHandler.CreateTier)
+			eventsProtected.PUT("/:id/tiers/:tierId", ticketHandler.UpdateTier)
+			eventsProtected.DELETE("/:id/tiers/:tierId", ticketHandler.DeleteTier)
 		}
 
 		// Post routes
@@ -280,6 +414,9 @@ func main() {
 			// Bookmarks endpoint (must be before :id routes)
 			posts.GET("/bookmarks", postHandler.GetBookmarks)
 
+			// Drafts and scheduled posts (must be before :id routes)
+			posts.GET("/drafts", postHandler.GetDrafts)
+
 			// Create post
 			posts.POST("", postHandler.CreatePost)
 
@@ -288,6 +425,8 @@ func main() {
 
 			// Comment endpoints
 			posts.POST("/comments", postHandler.AddComment)
+			posts.GET("/comments/:commentId/replies", postHandler.GetCommentReplies)
+			posts.GET("/comments/:commentId/revisions", postHandler.GetCommentRevisions)
 			posts.PUT("/comments/:commentId", postHandler.UpdateComment)
 			posts.DELETE("/comments/:commentId", postHandler.DeleteComment)
 
@@ -295,6 +434,9 @@ func main() {
 			posts.GET("/:id", postHandler.GetPostByID)
 			posts.PUT("/:id", postHandler.UpdatePost)
 			posts.DELETE("/:id", postHandler.DeletePost)
+			posts.GET("/:id/revisions", postHandler.GetPostRevisions)
+			posts.POST("/:id/publish", postHandler.PublishPost)
+			posts.DELETE("/:id/schedule", postHandler.CancelScheduledPost)
 
 			// Post interactions
 			posts.POST("/:id/like", postHandler.LikePost)
@@ -302,6 +444,12 @@ func main() {
 			posts.POST("/:id/undo-repost", postHandler.UndoRepost)
 			posts.POST("/:id/bookmark", postHandler.BookmarkPost)
 			posts.DELETE("/:id/bookmark", postHandler.RemoveBookmark)
+			posts.POST("/:id/share", postHandler.SharePost)
+
+			// Who interacted with a post
+			posts.GET("/:id/likes", postHandler.GetLikers)
+			posts.GET("/:id/reposts", postHandler.GetReposters)
+			posts.GET("/:id/shares", postHandler.GetShareSummary)
 
 			// Get comments for a post
 			posts.GET("/:id/comments", postHandler.GetComments)
@@ -318,9 +466,51 @@ func main() {
 			tickets.POST("/purchase", ticketHandler.PurchaseTicket)
 			tickets.GET("/my-tickets", ticketHandler.GetMyTickets)
 			tickets.GET("/:id", ticketHandler.GetTicketByID)
-			tickets.POST("/check-in", ticketHandler.CheckIn)
+			tickets.POST("/check-in", ticketHandler.CheckIn) // Event ID in body; prefer /events/:id/check-in
+			tickets.GET("/check-in/public-key", ticketHandler.GetCheckInPublicKey)
 			tickets.POST("/:id/cancel", ticketHandler.CancelTicket)
 			tickets.GET("/transactions/:id", ticketHandler.GetTransaction)
+
+			// Group orders: hand unassigned tickets to other users
+			tickets.GET("/orders/:id", ticketHandler.GetOrder)
+			tickets.POST("/:id/assign", ticketHandler.AssignTicket)
+			tickets.POST("/:id/invite", ticketHandler.CreateTicketInvite)
+			tickets.POST("/claim/:token", ticketHandler.ClaimTicketInvite)
+
+			// Transfers: the recipient has to accept before the ticket moves
+			tickets.POST("/:id/transfer", ticketHandler.TransferTicket)
+			tickets.GET("/transfers", ticketHandler.GetIncomingTransfers)
+			tickets.POST("/transfers/:id/accept", ticketHandler.AcceptTransfer)
+			tickets.POST("/transfers/:id/decline", ticketHandler.DeclineTransfer)
+			tickets.DELETE("/transfers/:id", ticketHandler.CancelTransfer)
+		}
+
+		// Promo code routes (host only)
+		promoCodes := v1.Group("/promo-codes")
+		promoCodes.Use(authMiddleware)
+		{
+			promoCodes.POST("", promoHandler.CreatePromoCode)
+			promoCodes.GET("", promoHandler.GetMyPromoCodes)
+			promoCodes.GET("/:id", promoHandler.GetPromoCode)
+			promoCodes.PUT("/:id", promoHandler.UpdatePromoCode)
+			promoCodes.DELETE("/:id", promoHandler.DeactivatePromoCode)
+		}
+
+		// Recurring event series routes
+		seriesPublic := v1.Group("/series")
+		seriesPublic.Use(middleware.OptionalJWTAuth(jwtManager, userRepo))
+		{
+			seriesPublic.GET("/:id", seriesHandler.GetSeries)
+		}
+
+		series := v1.Group("/series")
+		series.Use(authMiddleware)
+		{
+			series.POST("", seriesHandler.CreateSeries)
+			series.GET("/following", seriesHandler.GetFollowedSeries)
+			series.DELETE("/:id", seriesHandler.EndSeries)
+			series.POST("/:id/follow", seriesHandler.FollowSeries)
+			series.DELETE("/:id/follow", seriesHandler.UnfollowSeries)
 		}
 
 		// Event tickets (host only)
@@ -332,14 +522,16 @@ func main() {
 		{
 			analytics.GET("/events/:id", analyticsHandler.GetEventAnalytics)
 			analytics.GET("/events/:id/transactions", analyticsHandler.GetEventTransactions)
+			analytics.GET("/events/:id/export/attendees", exportTimeout, analyticsHandler.ExportEventAttendees)
+			analytics.GET("/events/:id/export/transactions", exportTimeout, analyticsHandler.ExportEventTransactions)
 			analytics.GET("/host/revenue", analyticsHandler.GetHostRevenueSummary)
 			analytics.GET("/host/events", analyticsHandler.GetHostEventsList)
+			analytics.GET("/host/events/export", exportTimeout, analyticsHandler.ExportHostEventRevenue)
 		}
 
-		// Profile routes (DEPRECATED - username lookup removed in Google OAuth migration)
-		// These routes will always return 404 since usernames no longer exist
-		// TODO: Replace with user ID-based routes (e.g., /users/:id/profile)
+		// Profile routes by username; previous usernames and user IDs redirect to the current username
 		profile := v1.Group("/profile")
+		profile.Use(middleware.OptionalJWTAuth(jwtManager, userRepo)) // Viewer decides which private fields are shown
 		{
 			profile.GET("/:username", profileHandler.GetProfileByUsername)
 			profile.GET("/:username/posts", profileHandler.GetProfilePosts)
@@ -396,6 +588,70 @@ func main() {
 		{
 			payments.GET("/transactions/:order_id/status", paymentHandler.GetTransactionStatus)
 		}
+
+		// Payout routes (host balance, bank accounts and withdrawals)
+		payouts := v1.Group("/payouts")
+		payouts.Use(authMiddleware)
+		{
+			payouts.GET("/balance", payoutHandler.GetBalance)
+			payouts.GET("/ledger", payoutHandler.GetStatement)
+			payouts.GET("/bank-accounts", payoutHandler.GetBankAccounts)
+			payouts.POST("/bank-accounts", payoutHandler.AddBankAccount)
+			payouts.DELETE("/bank-accounts/:id", payoutHandler.RemoveBankAccount)
+			payouts.POST("", payoutHandler.RequestPayout)
+			payouts.GET("", payoutHandler.GetMyPayouts)
+			payouts.DELETE("/:id", payoutHandler.CancelPayout)
+		}
+
+		// Report routes
+		reports := v1.Group("/reports")
+		reports.Use(authMiddleware)
+		{
+			reports.POST("", moderationHandler.CreateReport)
+		}
+
+		// Admin routes (platform staff, each route requires a permission of the role stored for the user, read on every request)
+		adminRoutes := v1.Group("/admin")
+		adminRoutes.Use(authMiddleware)
+		{
+			moderate := middleware.RequirePermission(userDomain.PermModerateContent)
+			adminRoutes.GET("/reports", moderate, moderationHandler.GetReports)
+			adminRoutes.GET("/reports/:id", moderate, moderationHandler.GetReport)
+			adminRoutes.POST("/reports/:id/dismiss", moderate, moderationHandler.DismissReport)
+			adminRoutes.POST("/reports/:id/remove", moderate, moderationHandler.RemoveReportedContent)
+			adminRoutes.GET("/moderation/actions", moderate, moderationHandler.GetModerationActions)
+
+			suspend := middleware.RequirePermission(userDomain.PermSuspendUsers)
+			adminRoutes.POST("/users/:id/suspend", suspend, moderationHandler.SuspendUser)
+			adminRoutes.POST("/users/:id/unsuspend", suspend, moderationHandler.UnsuspendUser)
+
+			verify := middleware.RequirePermission(userDomain.PermVerifyUsers)
+			adminRoutes.POST("/users/:id/verify", verify, adminHandler.VerifyUser)
+			adminRoutes.DELETE("/users/:id/verify", verify, adminHandler.UnverifyUser)
+			adminRoutes.PUT("/users/:id/role", middleware.RequirePermission(userDomain.PermManageRoles), adminHandler.SetUserRole)
+
+			manageEvents := middleware.RequirePermission(userDomain.PermManageEvents)
+			adminRoutes.POST("/events/:id/feature", manageEvents, adminHandler.FeatureEvent)
+			adminRoutes.DELETE("/events/:id/feature", manageEvents, adminHandler.UnfeatureEvent)
+			adminRoutes.POST("/events/:id/unpublish", manageEvents, adminHandler.UnpublishEvent)
+			adminRoutes.POST("/events/:id/republish", manageEvents, adminHandler.RepublishEvent)
+
+			viewTransactions := middleware.RequirePermission(userDomain.PermViewTransactions)
+			adminRoutes.GET("/transactions", viewTransactions, adminHandler.GetTransactions)
+			adminRoutes.GET("/transactions/:id", viewTransactions, adminHandler.GetTransaction)
+			adminRoutes.POST("/transactions/:id/refund", middleware.RequirePermission(userDomain.PermRefundTransactions), adminHandler.RefundTransaction)
+
+			manageCommunities := middleware.RequirePermission(userDomain.PermManageCommunities)
+			adminRoutes.PUT("/communities/:id", manageCommunities, adminHandler.UpdateCommunity)
+			adminRoutes.DELETE("/communities/:id", manageCommunities, adminHandler.DeleteCommunity)
+			adminRoutes.POST("/communities/:id/transfer", manageCommunities, adminHandler.TransferCommunity)
+
+			managePayouts := middleware.RequirePermission(userDomain.PermManagePayouts)
+			adminRoutes.GET("/payouts", managePayouts, payoutHandler.GetPayoutQueue)
+			adminRoutes.GET("/payouts/reconciliation", managePayouts, payoutHandler.GetReconciliationReport)
+			adminRoutes.POST("/payouts/:id/paid", managePayouts, payoutHandler.MarkPayoutPaid)
+			adminRoutes.POST("/payouts/:id/reject", managePayouts, payoutHandler.RejectPayout)
+		}
 	}
 
 	// Start server
@@ -404,8 +660,8 @@ func main() {
 		Addr:           addr,
 		Handler:        router,
 		ReadTimeout:    10 * time.Second,
-		WriteTimeout:   10 * time.Second,
-		MaxHeaderBytes: 1 << 20, // 1 MB
+		WriteTimeout:   10 * time.Second, // Raised per route for file downloads, see exportTimeout
+		MaxHeaderBytes: 1 << 20,          // 1 MB
 	}
 
 	// Graceful shutdown
@@ -425,6 +681,9 @@ func main() {
 
 	log.Println("🛑 Shutting down server...")
 
+	// Stop background jobs
+	stopJobs()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
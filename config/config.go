@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -12,21 +13,29 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	Storage  StorageConfig
-	Midtrans MidtransConfig
-	Google   GoogleConfig
-	CORS     CORSConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	JWT        JWTConfig
+	Storage    StorageConfig
+	Midtrans   MidtransConfig
+	Google     GoogleConfig
+	CORS       CORSConfig
+	Ticket     TicketConfig
+	Event      EventConfig
+	Payout     PayoutConfig
+	Admin      AdminConfig
+	Moderation ModerationConfig
+	Account    AccountConfig
+	Post       PostConfig
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port     string
-	Env      string
-	LogLevel string
+	Port      string
+	Env       string
+	LogLevel  string
+	PublicURL string // Externally reachable base URL, used in links handed to other apps
 }
 
 // DatabaseConfig holds database configuration
@@ -79,6 +88,47 @@ type GoogleConfig struct {
 	ClientID string
 }
 
+// TicketConfig holds ticketing configuration
+type TicketConfig struct {
+	PendingTTL          time.Duration // How long an unpaid ticket holds its seat
+	WaitlistClaimWindow time.Duration // How long a waitlist seat offer stays claimable
+	QRSecret            string        // Key used to sign ticket QR codes
+	SnapshotKey         string        // Base64 Ed25519 seed used to sign offline check-in snapshots
+}
+
+// EventConfig holds event configuration
+type EventConfig struct {
+	SeriesHorizon time.Duration // How far ahead occurrences of recurring events are generated
+}
+
+// PayoutConfig holds host payout configuration
+type PayoutConfig struct {
+	PlatformFeePercent float64       // Share of every ticket sale kept by the platform
+	HoldPeriod         time.Duration // How long after an event ends its sales are held before payout
+}
+
+// AdminConfig holds platform administration configuration
+type AdminConfig struct {
+	UserIDs []string // Users granted the admin role at startup while no admin exists
+}
+
+// ModerationConfig holds content moderation configuration
+type ModerationConfig struct {
+	AutoHideThreshold int // Reports from distinct users before content is hidden pending review, 0 disables
+}
+
+// AccountConfig holds account deletion and data export configuration
+type AccountConfig struct {
+	DeletionGracePeriod time.Duration // How long a scheduled account deletion can be cancelled
+	ExportRetention     time.Duration // How long a data export archive can be downloaded
+}
+
+// PostConfig holds post and comment configuration
+type PostConfig struct {
+	EditWindow          time.Duration // How long after posting content can be edited, 0 for no limit
+	EditEngagementLimit int           // Interactions after which content can no longer be edited, 0 for no limit
+}
+
 // CORSConfig holds CORS configuration
 type CORSConfig struct {
 	AllowedOrigins []string
@@ -93,9 +143,10 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Port:     getEnv("PORT", "8080"),
-			Env:      getEnv("ENV", "development"),
-			LogLevel: getEnv("LOG_LEVEL", "debug"),
+			Port:      getEnv("PORT", "8080"),
+			Env:       getEnv("ENV", "development"),
+			LogLevel:  getEnv("LOG_LEVEL", "debug"),
+			PublicURL: strings.TrimSuffix(getEnv("PUBLIC_URL", "http://localhost:8080"), "/"),
 		},
 		Database: DatabaseConfig{
 			Host:         getEnv("DB_HOST", "localhost"),
@@ -138,6 +189,33 @@ func Load() (*Config, error) {
 		CORS: CORSConfig{
 			AllowedOrigins: getEnvAsSlice("ALLOWED_ORIGINS", []string{"http://localhost:3000"}),
 		},
+		Ticket: TicketConfig{
+			PendingTTL:          parseDuration(getEnv("TICKET_PENDING_TTL", "30m")),
+			WaitlistClaimWindow: parseDuration(getEnv("WAITLIST_CLAIM_WINDOW", "2h")),
+			QRSecret:            getEnv("TICKET_QR_SECRET", ""),
+			SnapshotKey:         getEnv("CHECKIN_SNAPSHOT_KEY", ""),
+		},
+		Event: EventConfig{
+			SeriesHorizon: time.Duration(getEnvAsInt("EVENT_SERIES_HORIZON_DAYS", 90)) * 24 * time.Hour,
+		},
+		Payout: PayoutConfig{
+			PlatformFeePercent: getEnvAsFloat("PLATFORM_FEE_PERCENT", 5),
+			HoldPeriod:         time.Duration(getEnvAsInt("PAYOUT_HOLD_DAYS", 7)) * 24 * time.Hour,
+		},
+		Admin: AdminConfig{
+			UserIDs: getEnvAsSlice("ADMIN_USER_IDS", nil),
+		},
+		Moderation: ModerationConfig{
+			AutoHideThreshold: getEnvAsInt("REPORT_AUTO_HIDE_THRESHOLD", 5),
+		},
+		Account: AccountConfig{
+			DeletionGracePeriod: time.Duration(getEnvAsInt("ACCOUNT_DELETION_GRACE_DAYS", 30)) * 24 * time.Hour,
+			ExportRetention:     time.Duration(getEnvAsInt("ACCOUNT_EXPORT_RETENTION_DAYS", 7)) * 24 * time.Hour,
+		},
+		Post: PostConfig{
+			EditWindow:          time.Duration(getEnvAsInt("POST_EDIT_WINDOW_MINUTES", 60)) * time.Minute,
+			EditEngagementLimit: getEnvAsInt("POST_EDIT_ENGAGEMENT_LIMIT", 50),
+		},
 	}
 
 	// Validate required config
@@ -159,6 +237,16 @@ func (c *Config) Validate() error {
 	if c.JWT.Secret == "" || c.JWT.Secret == "your-secret-key" {
 		return fmt.Errorf("JWT_SECRET must be set with a secure value")
 	}
+	// A key shared with the JWT signer would let anyone holding it mint access tokens
+	if c.Ticket.QRSecret == "" || c.Ticket.QRSecret == c.JWT.Secret {
+		return fmt.Errorf("TICKET_QR_SECRET must be set and differ from JWT_SECRET")
+	}
+	if c.Ticket.SnapshotKey == "" {
+		return fmt.Errorf("CHECKIN_SNAPSHOT_KEY is required")
+	}
+	if c.Payout.PlatformFeePercent < 0 || c.Payout.PlatformFeePercent > 100 {
+		return fmt.Errorf("PLATFORM_FEE_PERCENT must be between 0 and 100")
+	}
 	return nil
 }
 
@@ -199,6 +287,14 @@ func getEnvAsInt64(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseFloat(valueStr, 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	valueStr := getEnv(key, "")
 	if value, err := strconv.ParseBool(valueStr); err == nil {
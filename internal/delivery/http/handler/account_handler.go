@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	"github.com/anigmaa/backend/internal/domain/account"
+	accountUsecase "github.com/anigmaa/backend/internal/usecase/account"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AccountHandler handles account deletion and personal data export HTTP requests
+type AccountHandler struct {
+	accountUsecase *accountUsecase.Usecase
+}
+
+// NewAccountHandler creates a new account handler
+func NewAccountHandler(accountUsecase *accountUsecase.Usecase) *AccountHandler {
+	return &AccountHandler{
+		accountUsecase: accountUsecase,
+	}
+}
+
+// DeleteAccount godoc
+// @Summary Delete account
+// @Description Schedule the deletion of your account. It can be cancelled until the scheduled time. Then your hosted events that did not start are cancelled and refunded, your tickets for upcoming events are refunded, your communities are handed to their longest-standing admin or deleted when nobody can take over, and your posts, comments, reviews, questions and follows are removed. Your name and personal data are erased; tickets, payments and payouts are kept anonymized for the financial records.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=account.Deletion}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me [delete]
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userID, ok := h.parseUserID(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	deletion, err := h.accountUsecase.ScheduleDeletion(c.Request.Context(), userID)
+	if err != nil {
+		if err == accountUsecase.ErrUserNotFound {
+			response.NotFound(c, "User not found")
+			return
+		}
+		response.InternalError(c, "Failed to schedule account deletion", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Account deletion scheduled successfully", deletion)
+}
+
+// CancelAccountDeletion godoc
+// @Summary Cancel account deletion
+// @Description Keep your account when its deletion is still scheduled
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/deletion/cancel [post]
+func (h *AccountHandler) CancelAccountDeletion(c *gin.Context) {
+	userID, ok := h.parseUserID(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	if err := h.accountUsecase.CancelDeletion(c.Request.Context(), userID); err != nil {
+		if err == accountUsecase.ErrDeletionNotScheduled {
+			response.NotFound(c, "Account deletion is not scheduled")
+			return
+		}
+		response.InternalError(c, "Failed to cancel account deletion", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Account deletion cancelled successfully", nil)
+}
+
+// GetDataExport godoc
+// @Summary Export your data
+// @Description Request an archive of all your data: profile, settings, social graph, posts and interactions, events, tickets, payments, payouts, communities and notifications. The archive is built in the background; poll this endpoint until the status is ready, then download it from download_url before it expires. A new export is started when the previous one expired or failed.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=account.DataExport} "Archive ready"
+// @Success 202 {object} response.Response{data=account.DataExport} "Archive being built"
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/export [get]
+func (h *AccountHandler) GetDataExport(c *gin.Context) {
+	userID, ok := h.parseUserID(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	export, err := h.accountUsecase.RequestExport(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalError(c, "Failed to export data", err.Error())
+		return
+	}
+
+	if export.Status != account.ExportReady {
+		response.Success(c, http.StatusAccepted, "Data export is being prepared", export)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Data export retrieved successfully", export)
+}
+
+// DownloadDataExport godoc
+// @Summary Download data export
+// @Description Download the zip archive of your ready data export, with one JSON file per kind of data
+// @Tags users
+// @Produce application/zip
+// @Security BearerAuth
+// @Success 200 {file} file "Zip archive"
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/export/download [get]
+func (h *AccountHandler) DownloadDataExport(c *gin.Context) {
+	userID, ok := h.parseUserID(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	export, archive, err := h.accountUsecase.GetExportArchive(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case accountUsecase.ErrExportNotFound:
+			response.NotFound(c, "Data export not found")
+		case accountUsecase.ErrExportNotReady:
+			response.Conflict(c, "Data export is not ready yet", err.Error())
+		default:
+			response.InternalError(c, "Failed to download data export", err.Error())
+		}
+		return
+	}
+
+	filename := "anigmaa-data-" + export.CreatedAt.Format("2006-01-02") + ".zip"
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+// parseUserID gets the authenticated user's ID
+func (h *AccountHandler) parseUserID(c *gin.Context) (uuid.UUID, bool) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
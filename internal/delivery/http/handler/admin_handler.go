@@ -0,0 +1,569 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	"github.com/anigmaa/backend/internal/domain/community"
+	"github.com/anigmaa/backend/internal/domain/moderation"
+	"github.com/anigmaa/backend/internal/domain/ticket"
+	"github.com/anigmaa/backend/internal/domain/user"
+	adminUsecase "github.com/anigmaa/backend/internal/usecase/admin"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AdminHandler handles platform administration by staff
+type AdminHandler struct {
+	adminUsecase *adminUsecase.Usecase
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(adminUsecase *adminUsecase.Usecase) *AdminHandler {
+	return &AdminHandler{
+		adminUsecase: adminUsecase,
+	}
+}
+
+// VerifyUser godoc
+// @Summary Verify user (admin)
+// @Description Grant a user the verified badge. Requires the users:verify permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" format(uuid)
+// @Param request body moderation.AdminActionRequest false "Optional note for the audit trail"
+// @Success 200 {object} response.Response{data=user.User}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/users/{id}/verify [post]
+func (h *AdminHandler) VerifyUser(c *gin.Context) {
+	h.setUserVerified(c, true)
+}
+
+// UnverifyUser godoc
+// @Summary Unverify user (admin)
+// @Description Revoke a user's verified badge. Requires the users:verify permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" format(uuid)
+// @Param request body moderation.AdminActionRequest false "Optional note for the audit trail"
+// @Success 200 {object} response.Response{data=user.User}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/users/{id}/verify [delete]
+func (h *AdminHandler) UnverifyUser(c *gin.Context) {
+	h.setUserVerified(c, false)
+}
+
+func (h *AdminHandler) setUserVerified(c *gin.Context, verified bool) {
+	adminID, userID, req, ok := h.parseAction(c, "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	u, err := h.adminUsecase.SetUserVerified(c.Request.Context(), adminID, userID, verified, req.Note)
+	if err != nil {
+		h.handleAdminError(c, err, "Failed to update verification")
+		return
+	}
+
+	message := "User verified successfully"
+	if !verified {
+		message = "User unverified successfully"
+	}
+	response.Success(c, http.StatusOK, message, u)
+}
+
+// SetUserRole godoc
+// @Summary Set user role (admin)
+// @Description Change a user's platform role (user, moderator, admin). The new permissions apply from the user's next request. Requires the users:manage_roles permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" format(uuid)
+// @Param request body user.SetRoleRequest true "New role"
+// @Success 200 {object} response.Response{data=user.User}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/users/{id}/role [put]
+func (h *AdminHandler) SetUserRole(c *gin.Context) {
+	adminID, userID, ok := h.parseAdminAndID(c, "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	var req user.SetRoleRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	u, err := h.adminUsecase.SetUserRole(c.Request.Context(), adminID, userID, &req)
+	if err != nil {
+		h.handleAdminError(c, err, "Failed to set user role")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User role updated successfully", u)
+}
+
+// FeatureEvent godoc
+// @Summary Feature event (admin)
+// @Description Feature an event; featured events can be listed with featured=true. Requires the events:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param request body moderation.AdminActionRequest false "Optional note for the audit trail"
+// @Success 200 {object} response.Response{data=event.Event}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/events/{id}/feature [post]
+func (h *AdminHandler) FeatureEvent(c *gin.Context) {
+	h.setEventFeatured(c, true)
+}
+
+// UnfeatureEvent godoc
+// @Summary Unfeature event (admin)
+// @Description Remove an event from the featured events. Requires the events:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param request body moderation.AdminActionRequest false "Optional note for the audit trail"
+// @Success 200 {object} response.Response{data=event.Event}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/events/{id}/feature [delete]
+func (h *AdminHandler) UnfeatureEvent(c *gin.Context) {
+	h.setEventFeatured(c, false)
+}
+
+func (h *AdminHandler) setEventFeatured(c *gin.Context, featured bool) {
+	adminID, eventID, req, ok := h.parseAction(c, "Invalid event ID")
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	evt, err := h.adminUsecase.SetEventFeatured(c.Request.Context(), adminID, eventID, featured, req.Note)
+	if err != nil {
+		h.handleAdminError(c, err, "Failed to update event")
+		return
+	}
+
+	message := "Event featured successfully"
+	if !featured {
+		message = "Event unfeatured successfully"
+	}
+	response.Success(c, http.StatusOK, message, evt)
+}
+
+// UnpublishEvent godoc
+// @Summary Unpublish event (admin)
+// @Description Hide an event from listings, the map and search. Ticket holders can still open it. Requires the events:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param request body moderation.AdminActionRequest false "Optional note for the audit trail"
+// @Success 200 {object} response.Response{data=event.Event}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/events/{id}/unpublish [post]
+func (h *AdminHandler) UnpublishEvent(c *gin.Context) {
+	h.setEventPublished(c, false)
+}
+
+// RepublishEvent godoc
+// @Summary Republish event (admin)
+// @Description Publish an unpublished event again. Requires the events:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param request body moderation.AdminActionRequest false "Optional note for the audit trail"
+// @Success 200 {object} response.Response{data=event.Event}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/events/{id}/republish [post]
+func (h *AdminHandler) RepublishEvent(c *gin.Context) {
+	h.setEventPublished(c, true)
+}
+
+func (h *AdminHandler) setEventPublished(c *gin.Context, published bool) {
+	adminID, eventID, req, ok := h.parseAction(c, "Invalid event ID")
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	evt, err := h.adminUsecase.SetEventPublished(c.Request.Context(), adminID, eventID, published, req.Note)
+	if err != nil {
+		h.handleAdminError(c, err, "Failed to update event")
+		return
+	}
+
+	message := "Event republished successfully"
+	if !published {
+		message = "Event unpublished successfully"
+	}
+	response.Success(c, http.StatusOK, message, evt)
+}
+
+// GetTransactions godoc
+// @Summary Get transactions (admin)
+// @Description Get ticket transactions across all events, most recent first. Requires the transactions:view permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Transaction status (pending, success, failed, refunded)"
+// @Param event_id query string false "Event ID" format(uuid)
+// @Param user_id query string false "Ticket holder ID" format(uuid)
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]ticket.TransactionWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/transactions [get]
+func (h *AdminHandler) GetTransactions(c *gin.Context) {
+	// Parse query parameters
+	var filter ticket.TransactionFilter
+
+	if status := c.Query("status"); status != "" {
+		s := ticket.TransactionStatus(status)
+		switch s {
+		case ticket.TransactionPending, ticket.TransactionSuccess, ticket.TransactionFailed, ticket.TransactionRefunded:
+		default:
+			response.BadRequest(c, "Invalid status parameter", "Valid values: pending, success, failed, refunded")
+			return
+		}
+		filter.Status = &s
+	}
+
+	if eventID := c.Query("event_id"); eventID != "" {
+		id, err := uuid.Parse(eventID)
+		if err != nil {
+			response.BadRequest(c, "Invalid event_id parameter", err.Error())
+			return
+		}
+		filter.EventID = &id
+	}
+
+	if userID := c.Query("user_id"); userID != "" {
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			response.BadRequest(c, "Invalid user_id parameter", err.Error())
+			return
+		}
+		filter.UserID = &id
+	}
+
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	filter.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Get total count for pagination
+	total, err := h.adminUsecase.CountTransactions(c.Request.Context(), &filter)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	// Get transactions
+	transactions, err := h.adminUsecase.GetTransactions(c.Request.Context(), &filter)
+	if err != nil {
+		response.InternalError(c, "Failed to get transactions", err.Error())
+		return
+	}
+
+	// Create pagination metadata with correct total
+	meta := response.NewPaginationMeta(total, filter.Limit, filter.Offset, len(transactions))
+	response.Paginated(c, http.StatusOK, "Transactions retrieved successfully", transactions, meta)
+}
+
+// GetTransaction godoc
+// @Summary Get transaction (admin)
+// @Description Get any ticket transaction with its ticket, event and buyer. Requires the transactions:view permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transaction ID" format(uuid)
+// @Success 200 {object} response.Response{data=ticket.TransactionWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/transactions/{id} [get]
+func (h *AdminHandler) GetTransaction(c *gin.Context) {
+	// Parse transaction ID from path
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid transaction ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	transaction, err := h.adminUsecase.GetTransaction(c.Request.Context(), transactionID)
+	if err != nil {
+		h.handleAdminError(c, err, "Failed to get transaction")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Transaction retrieved successfully", transaction)
+}
+
+// RefundTransaction godoc
+// @Summary Force refund (admin)
+// @Description Refund the ticket paid by a successful transaction, even if the ticket was used or the event already started. Requires the transactions:refund permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transaction ID" format(uuid)
+// @Param request body moderation.AdminActionRequest false "Optional note for the audit trail"
+// @Success 200 {object} response.Response{data=ticket.Ticket}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/transactions/{id}/refund [post]
+func (h *AdminHandler) RefundTransaction(c *gin.Context) {
+	adminID, transactionID, req, ok := h.parseAction(c, "Invalid transaction ID")
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	t, err := h.adminUsecase.RefundTransaction(c.Request.Context(), adminID, transactionID, req.Note)
+	if err != nil {
+		h.handleAdminError(c, err, "Failed to refund transaction")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket refunded successfully", t)
+}
+
+// UpdateCommunity godoc
+// @Summary Update community (admin)
+// @Description Update any community. Requires the communities:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Community ID" format(uuid)
+// @Param request body community.UpdateCommunityRequest true "Community update data"
+// @Success 200 {object} response.Response{data=community.Community}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/communities/{id} [put]
+func (h *AdminHandler) UpdateCommunity(c *gin.Context) {
+	adminID, communityID, ok := h.parseAdminAndID(c, "Invalid community ID")
+	if !ok {
+		return
+	}
+
+	var req community.UpdateCommunityRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	comm, err := h.adminUsecase.UpdateCommunity(c.Request.Context(), adminID, communityID, &req)
+	if err != nil {
+		h.handleAdminError(c, err, "Failed to update community")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Community updated successfully", comm)
+}
+
+// DeleteCommunity godoc
+// @Summary Delete community (admin)
+// @Description Delete any community. Requires the communities:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Community ID" format(uuid)
+// @Param request body moderation.AdminActionRequest false "Optional note for the audit trail"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/communities/{id} [delete]
+func (h *AdminHandler) DeleteCommunity(c *gin.Context) {
+	adminID, communityID, req, ok := h.parseAction(c, "Invalid community ID")
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	if err := h.adminUsecase.DeleteCommunity(c.Request.Context(), adminID, communityID, req.Note); err != nil {
+		h.handleAdminError(c, err, "Failed to delete community")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Community deleted successfully", nil)
+}
+
+// TransferCommunity godoc
+// @Summary Transfer community ownership (admin)
+// @Description Make another user the owner of a community. The previous owner stays on as an admin. Requires the communities:manage permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Community ID" format(uuid)
+// @Param request body community.TransferCommunityRequest true "New owner"
+// @Success 200 {object} response.Response{data=community.Community}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/communities/{id}/transfer [post]
+func (h *AdminHandler) TransferCommunity(c *gin.Context) {
+	adminID, communityID, ok := h.parseAdminAndID(c, "Invalid community ID")
+	if !ok {
+		return
+	}
+
+	var req community.TransferCommunityRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	comm, err := h.adminUsecase.TransferCommunity(c.Request.Context(), adminID, communityID, &req)
+	if err != nil {
+		h.handleAdminError(c, err, "Failed to transfer community")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Community transferred successfully", comm)
+}
+
+// parseAction reads the current admin, the ID in the path and the optional note, writing the error response on failure
+func (h *AdminHandler) parseAction(c *gin.Context, invalidIDMessage string) (uuid.UUID, uuid.UUID, *moderation.AdminActionRequest, bool) {
+	adminID, targetID, ok := h.parseAdminAndID(c, invalidIDMessage)
+	if !ok {
+		return uuid.Nil, uuid.Nil, nil, false
+	}
+
+	var req moderation.AdminActionRequest
+
+	// The note is optional, so an empty body is fine
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return uuid.Nil, uuid.Nil, nil, false
+	}
+
+	return adminID, targetID, &req, true
+}
+
+// parseAdminAndID reads the current admin and the ID in the path, writing the error response on failure
+func (h *AdminHandler) parseAdminAndID(c *gin.Context, invalidIDMessage string) (uuid.UUID, uuid.UUID, bool) {
+	// Get user ID from context
+	adminIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	// Parse ID from path
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, invalidIDMessage, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return adminID, targetID, true
+}
+
+// handleAdminError maps admin errors to HTTP responses
+func (h *AdminHandler) handleAdminError(c *gin.Context, err error, message string) {
+	switch err {
+	case adminUsecase.ErrUserNotFound:
+		response.NotFound(c, "User not found")
+	case adminUsecase.ErrEventNotFound:
+		response.NotFound(c, "Event not found")
+	case adminUsecase.ErrTransactionNotFound:
+		response.NotFound(c, "Transaction not found")
+	case adminUsecase.ErrCommunityNotFound:
+		response.NotFound(c, "Community not found")
+	case adminUsecase.ErrNewOwnerNotFound:
+		response.NotFound(c, "New owner not found")
+	case adminUsecase.ErrCannotChangeOwnRole:
+		response.BadRequest(c, "Cannot change your own role", err.Error())
+	case adminUsecase.ErrNewOwnerSuspended:
+		response.BadRequest(c, "New owner is suspended", err.Error())
+	case adminUsecase.ErrNotRefundable:
+		response.Conflict(c, "Transaction cannot be refunded", err.Error())
+	case adminUsecase.ErrAlreadyOwner:
+		response.Conflict(c, "User already owns this community", err.Error())
+	default:
+		response.InternalError(c, message, err.Error())
+	}
+}
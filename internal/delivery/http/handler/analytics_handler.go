@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/anigmaa/backend/internal/delivery/http/middleware"
 	analyticsUsecase "github.com/anigmaa/backend/internal/usecase/analytics"
 	"github.com/anigmaa/backend/pkg/response"
+	"github.com/anigmaa/backend/pkg/spreadsheet"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -26,12 +28,14 @@ func NewAnalyticsHandler(analyticsUsecase *analyticsUsecase.Usecase) *AnalyticsH
 
 // GetEventAnalytics godoc
 // @Summary Get event analytics
-// @Description Get comprehensive analytics for a specific event (host only)
+// @Description Get comprehensive analytics for a specific event (host only), including the view to check-in funnel and sales grouped by hour, day or week
 // @Tags analytics
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Event ID" format(uuid)
+// @Param bucket query string false "Timeline bucket size (hour, day, week)" default(day)
+// @Param timezone query string false "IANA timezone of the timeline buckets, e.g. Asia/Jakarta" default(UTC)
 // @Success 200 {object} response.Response{data=analytics.EventAnalytics}
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
@@ -61,15 +65,27 @@ func (h *AnalyticsHandler) GetEventAnalytics(c *gin.Context) {
 		return
 	}
 
+	// Parse query parameters
+	bucket := analyticsUsecase.TimeBucket(c.DefaultQuery("bucket", "day"))
+	timezone := c.DefaultQuery("timezone", "UTC")
+
 	// Call usecase
-	analytics, err := h.analyticsUsecase.GetEventAnalytics(c.Request.Context(), eventID, hostID)
+	analytics, err := h.analyticsUsecase.GetEventAnalytics(c.Request.Context(), eventID, hostID, bucket, timezone)
 	if err != nil {
+		if err == analyticsUsecase.ErrInvalidBucket {
+			response.BadRequest(c, "Invalid bucket parameter", "Valid values: hour, day, week")
+			return
+		}
+		if err == analyticsUsecase.ErrInvalidTimezone {
+			response.BadRequest(c, "Invalid timezone parameter", "Use an IANA timezone such as Asia/Jakarta")
+			return
+		}
 		if err == analyticsUsecase.ErrEventNotFound {
 			response.NotFound(c, "Event not found")
 			return
 		}
 		if err == analyticsUsecase.ErrUnauthorized {
-			response.Forbidden(c, "Only the event host can view analytics")
+			response.Forbidden(c, "Only the event host and staff with analytics access can view analytics")
 			return
 		}
 		response.InternalError(c, "Failed to get event analytics", err.Error())
@@ -139,7 +155,7 @@ func (h *AnalyticsHandler) GetEventTransactions(c *gin.Context) {
 			return
 		}
 		if err == analyticsUsecase.ErrUnauthorized {
-			response.Forbidden(c, "Only the event host can view transactions")
+			response.Forbidden(c, "Only the event host and staff with analytics access can view transactions")
 			return
 		}
 		response.InternalError(c, "Failed to get event transactions", err.Error())
@@ -153,7 +169,7 @@ func (h *AnalyticsHandler) GetEventTransactions(c *gin.Context) {
 
 // GetHostRevenueSummary godoc
 // @Summary Get host revenue summary
-// @Description Get comprehensive revenue summary for all events created by the host
+// @Description Get comprehensive revenue summary for all events created by the host. Periods other than "all" are compared with the period before them.
 // @Tags analytics
 // @Accept json
 // @Produce json
@@ -198,22 +214,39 @@ func (h *AnalyticsHandler) GetHostRevenueSummary(c *gin.Context) {
 	var startDate, endDate *time.Time
 	now := time.Now()
 
+	// The previous period covers the same stretch of time one month or year earlier,
+	// so a month in progress is compared with the same days of the month before
+	var previousStart, previousEnd *time.Time
+
 	switch period {
 	case "this_month":
 		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 		startDate = &start
+		prevStart := start.AddDate(0, -1, 0)
+		prevEnd := now.AddDate(0, -1, 0)
+		if !prevEnd.Before(start) {
+			// e.g. March 31 has no February counterpart
+			prevEnd = start.Add(-time.Second)
+		}
+		previousStart, previousEnd = &prevStart, &prevEnd
 	case "last_month":
 		start := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location())
 		end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Add(-time.Second)
 		startDate = &start
 		endDate = &end
+		prevStart := start.AddDate(0, -1, 0)
+		prevEnd := start.Add(-time.Second)
+		previousStart, previousEnd = &prevStart, &prevEnd
 	case "this_year":
 		start := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
 		startDate = &start
+		prevStart := start.AddDate(-1, 0, 0)
+		prevEnd := now.AddDate(-1, 0, 0)
+		previousStart, previousEnd = &prevStart, &prevEnd
 	}
 
 	// Call usecase
-	summary, err := h.analyticsUsecase.GetHostRevenueSummary(c.Request.Context(), hostID, startDate, endDate)
+	summary, err := h.analyticsUsecase.GetHostRevenueSummary(c.Request.Context(), hostID, startDate, endDate, previousStart, previousEnd)
 	if err != nil {
 		response.InternalError(c, "Failed to get revenue summary", err.Error())
 		return
@@ -274,3 +307,197 @@ func (h *AnalyticsHandler) GetHostEventsList(c *gin.Context) {
 	meta := response.NewPaginationMeta(total, limit, offset, len(events))
 	response.Paginated(c, http.StatusOK, "Host events retrieved successfully", events, meta)
 }
+
+// ExportEventAttendees godoc
+// @Summary Export event attendees
+// @Description Download the attendee list of an event with check-in status as CSV or XLSX (host or view_analytics staff). Names and emails are masked unless the attendee agreed to share them with hosts.
+// @Tags analytics
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param format query string false "File format (csv, xlsx)" default(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /analytics/events/{id}/export/attendees [get]
+func (h *AnalyticsHandler) ExportEventAttendees(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	format, err := spreadsheet.ParseFormat(c.Query("format"))
+	if err != nil {
+		response.BadRequest(c, "Invalid format parameter", "Valid values: csv, xlsx")
+		return
+	}
+
+	// Stream the export
+	err = h.streamExport(c, format, "attendees-"+eventID.String(), "Attendees", func(w spreadsheet.Writer) error {
+		return h.analyticsUsecase.ExportEventAttendees(c.Request.Context(), eventID, userID, w)
+	})
+	if err != nil {
+		switch err {
+		case analyticsUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case analyticsUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the event host and staff can export attendees")
+		default:
+			response.InternalError(c, "Failed to export attendees", err.Error())
+		}
+	}
+}
+
+// ExportEventTransactions godoc
+// @Summary Export event transactions
+// @Description Download the payment transactions of an event as CSV or XLSX. Buyer names and emails are masked unless the attendee agreed to share them with hosts.
+// @Tags analytics
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param format query string false "File format (csv, xlsx)" default(csv)
+// @Param status query string false "Filter by status (success, pending, failed, refunded)"
+// @Success 200 {file} file
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /analytics/events/{id}/export/transactions [get]
+func (h *AnalyticsHandler) ExportEventTransactions(c *gin.Context) {
+	// Get user ID from context
+	hostIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	hostID, err := uuid.Parse(hostIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	format, err := spreadsheet.ParseFormat(c.Query("format"))
+	if err != nil {
+		response.BadRequest(c, "Invalid format parameter", "Valid values: csv, xlsx")
+		return
+	}
+	status := c.Query("status")
+
+	// Stream the export
+	err = h.streamExport(c, format, "transactions-"+eventID.String(), "Transactions", func(w spreadsheet.Writer) error {
+		return h.analyticsUsecase.ExportEventTransactions(c.Request.Context(), eventID, hostID, status, w)
+	})
+	if err != nil {
+		switch err {
+		case analyticsUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case analyticsUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the event host and staff with analytics access can export transactions")
+		default:
+			response.InternalError(c, "Failed to export transactions", err.Error())
+		}
+	}
+}
+
+// ExportHostEventRevenue godoc
+// @Summary Export revenue per event
+// @Description Download revenue, refunds, discounts and attendance for every event of the host as CSV or XLSX
+// @Tags analytics
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "File format (csv, xlsx)" default(csv)
+// @Param status query string false "Filter by event status (upcoming, ongoing, completed, cancelled)"
+// @Success 200 {file} file
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /analytics/host/events/export [get]
+func (h *AnalyticsHandler) ExportHostEventRevenue(c *gin.Context) {
+	// Get user ID from context
+	hostIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	hostID, err := uuid.Parse(hostIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	format, err := spreadsheet.ParseFormat(c.Query("format"))
+	if err != nil {
+		response.BadRequest(c, "Invalid format parameter", "Valid values: csv, xlsx")
+		return
+	}
+	status := c.Query("status")
+
+	// Stream the export
+	filename := "event-revenue-" + time.Now().Format("2006-01-02")
+	err = h.streamExport(c, format, filename, "Event Revenue", func(w spreadsheet.Writer) error {
+		return h.analyticsUsecase.ExportHostEventRevenue(c.Request.Context(), hostID, status, w)
+	})
+	if err != nil {
+		response.InternalError(c, "Failed to export event revenue", err.Error())
+	}
+}
+
+// streamExport streams a spreadsheet written by export as a file download
+// It returns the error only while nothing has been sent yet, so the caller can still respond
+// with JSON. A failure after the download started is recorded on the context and the file
+// is left incomplete.
+func (h *AnalyticsHandler) streamExport(c *gin.Context, format spreadsheet.Format, filename, sheetName string, export func(w spreadsheet.Writer) error) error {
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, format.Filename(filename)))
+
+	w, err := spreadsheet.NewWriter(format, c.Writer, sheetName)
+	if err == nil {
+		err = export(w)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		return nil
+	}
+
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		return err
+	}
+
+	_ = c.Error(err)
+	return nil
+}
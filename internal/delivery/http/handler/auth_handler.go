@@ -55,6 +55,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
@@ -75,6 +76,10 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 			response.Unauthorized(c, "Invalid or expired refresh token")
 			return
 		}
+		if err == userUsecase.ErrUserSuspended {
+			response.Forbidden(c, "Account is suspended")
+			return
+		}
 		response.InternalError(c, "Failed to refresh token", err.Error())
 		return
 	}
@@ -219,6 +224,7 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /auth/google [post]
 func (h *AuthHandler) LoginWithGoogle(c *gin.Context) {
@@ -239,6 +245,10 @@ func (h *AuthHandler) LoginWithGoogle(c *gin.Context) {
 	// Call usecase
 	authResp, err := h.userUsecase.LoginWithGoogle(c.Request.Context(), &req)
 	if err != nil {
+		if err == userUsecase.ErrUserSuspended {
+			response.Forbidden(c, "Account is suspended")
+			return
+		}
 		response.Error(c, http.StatusUnauthorized, err.Error(), "UNAUTHORIZED", "")
 		return
 	}
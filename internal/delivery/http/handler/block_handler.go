@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	blockUsecase "github.com/anigmaa/backend/internal/usecase/block"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BlockHandler handles blocking and muting users
+type BlockHandler struct {
+	blockUsecase *blockUsecase.Usecase
+}
+
+// NewBlockHandler creates a new block handler
+func NewBlockHandler(blockUsecase *blockUsecase.Usecase) *BlockHandler {
+	return &BlockHandler{
+		blockUsecase: blockUsecase,
+	}
+}
+
+// BlockUser godoc
+// @Summary Block user
+// @Description Block a user. Both users stop seeing each other's posts, comments, Q&A and profiles, existing follows between them are removed and neither can follow, invite or transfer tickets to the other.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/{id}/block [post]
+func (h *BlockHandler) BlockUser(c *gin.Context) {
+	userID, targetID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	if err := h.blockUsecase.Block(c.Request.Context(), userID, targetID); err != nil {
+		switch err {
+		case blockUsecase.ErrCannotBlockSelf:
+			response.BadRequest(c, "Cannot block yourself", err.Error())
+		case blockUsecase.ErrUserNotFound:
+			response.NotFound(c, "User not found")
+		case blockUsecase.ErrAlreadyBlocked:
+			response.Conflict(c, "User is already blocked", err.Error())
+		default:
+			response.InternalError(c, "Failed to block user", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User blocked successfully", nil)
+}
+
+// UnblockUser godoc
+// @Summary Unblock user
+// @Description Remove a block. Follows removed by the block are not restored.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/{id}/block [delete]
+func (h *BlockHandler) UnblockUser(c *gin.Context) {
+	userID, targetID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	if err := h.blockUsecase.Unblock(c.Request.Context(), userID, targetID); err != nil {
+		if err == blockUsecase.ErrNotBlocked {
+			response.NotFound(c, "User is not blocked")
+			return
+		}
+		response.InternalError(c, "Failed to unblock user", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User unblocked successfully", nil)
+}
+
+// GetBlockedUsers godoc
+// @Summary Get blocked users
+// @Description Get the users the current user blocked, most recent first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]block.ListedUser}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/blocked [get]
+func (h *BlockHandler) GetBlockedUsers(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Get total count for pagination
+	total, err := h.blockUsecase.CountBlocked(c.Request.Context(), userID)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	// Get blocked users
+	users, err := h.blockUsecase.GetBlocked(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		response.InternalError(c, "Failed to get blocked users", err.Error())
+		return
+	}
+
+	// Create pagination metadata with correct total
+	meta := response.NewPaginationMeta(total, limit, offset, len(users))
+	response.Paginated(c, http.StatusOK, "Blocked users retrieved successfully", users, meta)
+}
+
+// MuteUser godoc
+// @Summary Mute user
+// @Description Mute a user. Their posts leave your feed and you stop getting notifications from them; they are not told and can still see your content.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/{id}/mute [post]
+func (h *BlockHandler) MuteUser(c *gin.Context) {
+	userID, targetID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	if err := h.blockUsecase.Mute(c.Request.Context(), userID, targetID); err != nil {
+		switch err {
+		case blockUsecase.ErrCannotMuteSelf:
+			response.BadRequest(c, "Cannot mute yourself", err.Error())
+		case blockUsecase.ErrUserNotFound:
+			response.NotFound(c, "User not found")
+		case blockUsecase.ErrAlreadyMuted:
+			response.Conflict(c, "User is already muted", err.Error())
+		default:
+			response.InternalError(c, "Failed to mute user", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User muted successfully", nil)
+}
+
+// UnmuteUser godoc
+// @Summary Unmute user
+// @Description Remove a mute
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/{id}/mute [delete]
+func (h *BlockHandler) UnmuteUser(c *gin.Context) {
+	userID, targetID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	if err := h.blockUsecase.Unmute(c.Request.Context(), userID, targetID); err != nil {
+		if err == blockUsecase.ErrNotMuted {
+			response.NotFound(c, "User is not muted")
+			return
+		}
+		response.InternalError(c, "Failed to unmute user", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User unmuted successfully", nil)
+}
+
+// GetMutedUsers godoc
+// @Summary Get muted users
+// @Description Get the users the current user muted, most recent first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]block.ListedUser}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/muted [get]
+func (h *BlockHandler) GetMutedUsers(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Get total count for pagination
+	total, err := h.blockUsecase.CountMuted(c.Request.Context(), userID)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	// Get muted users
+	users, err := h.blockUsecase.GetMuted(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		response.InternalError(c, "Failed to get muted users", err.Error())
+		return
+	}
+
+	// Create pagination metadata with correct total
+	meta := response.NewPaginationMeta(total, limit, offset, len(users))
+	response.Paginated(c, http.StatusOK, "Muted users retrieved successfully", users, meta)
+}
+
+// parseIDs reads the current user and the target user from the path, writing the error response on failure
+func (h *BlockHandler) parseIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	// Parse target user ID from path
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, targetID, true
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	calendarUsecase "github.com/anigmaa/backend/internal/usecase/calendar"
+	"github.com/anigmaa/backend/pkg/ical"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// CalendarHandler handles iCalendar export HTTP requests
+type CalendarHandler struct {
+	calendarUsecase *calendarUsecase.Usecase
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(calendarUsecase *calendarUsecase.Usecase) *CalendarHandler {
+	return &CalendarHandler{
+		calendarUsecase: calendarUsecase,
+	}
+}
+
+// GetEventCalendar godoc
+// @Summary Add event to calendar
+// @Description Download an event as an iCalendar (.ics) file for Google Calendar, Apple Calendar and others
+// @Tags calendar
+// @Produce text/calendar
+// @Param id path string true "Event ID" format(uuid)
+// @Success 200 {file} file "iCalendar file"
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/calendar.ics [get]
+func (h *CalendarHandler) GetEventCalendar(c *gin.Context) {
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	cal, err := h.calendarUsecase.GetEventCalendar(c.Request.Context(), eventID)
+	if err != nil {
+		if err == calendarUsecase.ErrEventNotFound {
+			response.NotFound(c, "Event not found")
+			return
+		}
+		response.InternalError(c, "Failed to export event", err.Error())
+		return
+	}
+
+	writeCalendar(c, cal, "attachment; filename=\"event-"+eventID.String()+".ics\"")
+}
+
+// GetCalendarFeed godoc
+// @Summary Get calendar subscription
+// @Description Get the secret subscription URLs of your calendar feed with the events you host and joined. The feed is created on first use. Anyone with the URL can read the feed.
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=calendar.Feed}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/calendar [get]
+func (h *CalendarHandler) GetCalendarFeed(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	feed, err := h.calendarUsecase.GetFeed(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalError(c, "Failed to get calendar feed", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Calendar feed retrieved successfully", feed)
+}
+
+// ResetCalendarFeed godoc
+// @Summary Reset calendar subscription
+// @Description Replace the secret of your calendar feed. The previous subscription URL stops working.
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=calendar.Feed}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/calendar/reset [post]
+func (h *CalendarHandler) ResetCalendarFeed(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	feed, err := h.calendarUsecase.ResetFeed(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalError(c, "Failed to reset calendar feed", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Calendar feed reset successfully", feed)
+}
+
+// GetFeedCalendar godoc
+// @Summary Calendar subscription feed
+// @Description iCalendar feed polled by calendar apps. The token in the path is the secret from GET /users/me/calendar; no other authentication is needed.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token, optionally followed by .ics"
+// @Success 200 {file} file "iCalendar feed"
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /calendar/{token} [get]
+func (h *CalendarHandler) GetFeedCalendar(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	// Call usecase
+	cal, err := h.calendarUsecase.GetFeedCalendar(c.Request.Context(), token)
+	if err != nil {
+		if err == calendarUsecase.ErrFeedNotFound {
+			response.NotFound(c, "Calendar feed not found")
+			return
+		}
+		response.InternalError(c, "Failed to get calendar feed", err.Error())
+		return
+	}
+
+	writeCalendar(c, cal, "inline; filename=\"anigmaa.ics\"")
+}
+
+// writeCalendar renders the calendar before sending anything, so a failure still gets a JSON error
+func writeCalendar(c *gin.Context, cal *ical.Calendar, disposition string) {
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		response.InternalError(c, "Failed to render calendar", err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", disposition)
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, calendarContentType, buf.Bytes())
+}
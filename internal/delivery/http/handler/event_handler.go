@@ -94,6 +94,7 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 // @Produce json
 // @Param category query string false "Event category"
 // @Param is_free query bool false "Filter free events"
+// @Param featured query bool false "Filter events featured by the platform"
 // @Param status query string false "Event status"
 // @Param mode query string false "Discovery mode: trending (popular events), for_you (personalized), chill (intimate/small events)"
 // @Param lat query number false "Latitude for location-based search"
@@ -241,7 +242,7 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 			return
 		}
 		if err == eventUsecase.ErrUnauthorized {
-			response.Forbidden(c, "Only the event host can update this event")
+			response.Forbidden(c, "Only the event host and staff with edit access can update this event")
 			return
 		}
 		if err == eventUsecase.ErrInvalidTimeRange {
@@ -489,6 +490,102 @@ func (h *EventHandler) GetNearbyEvents(c *gin.Context) {
 	response.Paginated(c, http.StatusOK, "Nearby events retrieved successfully", events, meta)
 }
 
+// CancelEvent godoc
+// @Summary Cancel event
+// @Description Cancel an upcoming event (host only). For a recurring event this cancels only this occurrence; the rest of the series is unchanged.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/cancel [post]
+func (h *EventHandler) CancelEvent(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.eventUsecase.CancelEvent(c.Request.Context(), eventID, userID); err != nil {
+		switch err {
+		case eventUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case eventUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the event host can cancel this event")
+		case eventUsecase.ErrCannotCancelPast:
+			response.BadRequest(c, "Cannot cancel past event", err.Error())
+		default:
+			response.InternalError(c, "Failed to cancel event", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Event cancelled successfully", nil)
+}
+
+// GetMapEvents godoc
+// @Summary Get events in a map viewport
+// @Description Get events inside a bounding box, clustered server-side by zoom level. Each cluster has its centroid, event count, extent and the most popular event in it. From zoom 16 on every event is its own pin. Accepts the same filters as the events list.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param min_lat query number true "South edge of the viewport"
+// @Param min_lng query number true "West edge of the viewport"
+// @Param max_lat query number true "North edge of the viewport"
+// @Param max_lng query number true "East edge of the viewport"
+// @Param zoom query int false "Map zoom level (0-22)" default(12)
+// @Param category query string false "Event category"
+// @Param is_free query bool false "Filter free events"
+// @Param featured query bool false "Filter events featured by the platform"
+// @Param status query string false "Event status"
+// @Param start_date query string false "Events starting at or after (RFC3339)"
+// @Param end_date query string false "Events starting at or before (RFC3339)"
+// @Param limit query int false "Maximum clusters" default(300)
+// @Success 200 {object} response.Response{data=event.MapView}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/map [get]
+func (h *EventHandler) GetMapEvents(c *gin.Context) {
+	var filter event.EventFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		response.BadRequest(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	view, err := h.eventUsecase.GetMapView(c.Request.Context(), &filter)
+	if err != nil {
+		if err == eventUsecase.ErrInvalidBounds {
+			response.BadRequest(c, "Invalid map bounds", err.Error())
+			return
+		}
+		response.InternalError(c, "Failed to get map events", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Map events retrieved successfully", view)
+}
+
 // GetMyEvents godoc
 // @Summary Get my events
 // @Description Get events created by the current user
@@ -640,7 +737,7 @@ func (h *EventHandler) GetJoinedEvents(c *gin.Context) {
 
 // GetEventAttendees godoc
 // @Summary Get event attendees
-// @Description Get list of attendees for an event
+// @Description Get list of attendees for an event. Attendees who hide their events are only listed to themselves and the host.
 // @Tags events
 // @Accept json
 // @Produce json
@@ -666,14 +763,14 @@ func (h *EventHandler) GetEventAttendees(c *gin.Context) {
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
 	// Get total count for pagination
-	total, err := h.eventUsecase.CountAttendees(c.Request.Context(), eventID)
+	total, err := h.eventUsecase.CountAttendees(c.Request.Context(), eventID, viewerID(c))
 	if err != nil {
 		// If count fails, default to 0 but continue
 		total = 0
 	}
 
 	// Get attendees
-	attendees, err := h.eventUsecase.GetAttendees(c.Request.Context(), eventID, limit, offset)
+	attendees, err := h.eventUsecase.GetAttendees(c.Request.Context(), eventID, viewerID(c), limit, offset)
 	if err != nil {
 		if err == eventUsecase.ErrEventNotFound {
 			response.NotFound(c, "Event not found")
@@ -795,7 +892,7 @@ func (h *EventHandler) AddEventImages(c *gin.Context) {
 			return
 		}
 		if err == eventUsecase.ErrUnauthorized {
-			response.Forbidden(c, "Only the event host can add images")
+			response.Forbidden(c, "Only the event host and staff with edit access can add images")
 			return
 		}
 		response.InternalError(c, "Failed to add images", err.Error())
@@ -858,7 +955,7 @@ func (h *EventHandler) DeleteEventImage(c *gin.Context) {
 			return
 		}
 		if err == eventUsecase.ErrUnauthorized {
-			response.Forbidden(c, "Only the event host can delete images")
+			response.Forbidden(c, "Only the event host and staff with edit access can delete images")
 			return
 		}
 		response.InternalError(c, "Failed to delete image", err.Error())
@@ -867,3 +964,265 @@ func (h *EventHandler) DeleteEventImage(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Image deleted successfully", nil)
 }
+
+// GetEventStaff godoc
+// @Summary Get event staff
+// @Description Get the co-hosts and staff of an event with their permissions (host or staff only)
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Success 200 {object} response.Response{data=[]event.StaffMember}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/staff [get]
+func (h *EventHandler) GetEventStaff(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventIDStr := c.Param("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	staff, err := h.eventUsecase.GetStaff(c.Request.Context(), eventID, userID)
+	if err != nil {
+		if err == eventUsecase.ErrEventNotFound {
+			response.NotFound(c, "Event not found")
+			return
+		}
+		if err == eventUsecase.ErrUnauthorized {
+			response.Forbidden(c, "Only the event team can view staff")
+			return
+		}
+		response.InternalError(c, "Failed to get event staff", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Event staff retrieved successfully", staff)
+}
+
+// AddEventStaff godoc
+// @Summary Add event staff
+// @Description Add a co-host or a staff member with scoped permissions to an event (host only)
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param request body event.AddStaffRequest true "Staff member"
+// @Success 201 {object} response.Response{data=[]event.StaffMember}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/staff [post]
+func (h *EventHandler) AddEventStaff(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventIDStr := c.Param("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	var req event.AddStaffRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	staff, err := h.eventUsecase.AddStaff(c.Request.Context(), eventID, userID, &req)
+	if err != nil {
+		switch err {
+		case eventUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case eventUsecase.ErrUserNotFound:
+			response.NotFound(c, "User not found")
+		case eventUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the event host can add staff")
+		case eventUsecase.ErrHostIsStaff:
+			response.BadRequest(c, "Host already manages this event", err.Error())
+		case eventUsecase.ErrInvalidPermission:
+			response.BadRequest(c, "Invalid staff permission", err.Error())
+		default:
+			response.InternalError(c, "Failed to add event staff", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Event staff added successfully", staff)
+}
+
+// UpdateEventStaff godoc
+// @Summary Update event staff
+// @Description Change the role or permissions of an event staff member (host only)
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param userId path string true "Staff user ID" format(uuid)
+// @Param request body event.UpdateStaffRequest true "Role and permissions"
+// @Success 200 {object} response.Response{data=[]event.StaffMember}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/staff/{userId} [put]
+func (h *EventHandler) UpdateEventStaff(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventIDStr := c.Param("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Parse staff user ID from path
+	staffUserID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		response.BadRequest(c, "Invalid staff user ID", err.Error())
+		return
+	}
+
+	var req event.UpdateStaffRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	staff, err := h.eventUsecase.UpdateStaff(c.Request.Context(), eventID, userID, staffUserID, &req)
+	if err != nil {
+		switch err {
+		case eventUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case eventUsecase.ErrStaffNotFound:
+			response.NotFound(c, "Staff member not found")
+		case eventUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the event host can update staff")
+		case eventUsecase.ErrInvalidPermission:
+			response.BadRequest(c, "Invalid staff permission", err.Error())
+		default:
+			response.InternalError(c, "Failed to update event staff", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Event staff updated successfully", staff)
+}
+
+// RemoveEventStaff godoc
+// @Summary Remove event staff
+// @Description Remove a staff member from an event (host only)
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param userId path string true "Staff user ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/staff/{userId} [delete]
+func (h *EventHandler) RemoveEventStaff(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventIDStr := c.Param("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Parse staff user ID from path
+	staffUserID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		response.BadRequest(c, "Invalid staff user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.eventUsecase.RemoveStaff(c.Request.Context(), eventID, userID, staffUserID); err != nil {
+		switch err {
+		case eventUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case eventUsecase.ErrStaffNotFound:
+			response.NotFound(c, "Staff member not found")
+		case eventUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the event host can remove staff")
+		default:
+			response.InternalError(c, "Failed to remove event staff", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Event staff removed successfully", nil)
+}
@@ -0,0 +1,475 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	"github.com/anigmaa/backend/internal/domain/moderation"
+	moderationUsecase "github.com/anigmaa/backend/internal/usecase/moderation"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ModerationHandler handles content reports and admin moderation
+type ModerationHandler struct {
+	moderationUsecase *moderationUsecase.Usecase
+}
+
+// NewModerationHandler creates a new moderation handler
+func NewModerationHandler(moderationUsecase *moderationUsecase.Usecase) *ModerationHandler {
+	return &ModerationHandler{
+		moderationUsecase: moderationUsecase,
+	}
+}
+
+// CreateReport godoc
+// @Summary Report content or a user
+// @Description Report a post, comment, event, Q&A entry or profile. Content reported by enough users is hidden until an admin reviews it.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body moderation.CreateReportRequest true "Report data"
+// @Success 201 {object} response.Response{data=moderation.Filing}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /reports [post]
+func (h *ModerationHandler) CreateReport(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req moderation.CreateReportRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	filing, err := h.moderationUsecase.Report(c.Request.Context(), userID, &req)
+	if err != nil {
+		switch err {
+		case moderationUsecase.ErrTargetNotFound:
+			response.NotFound(c, "Reported content not found")
+		case moderationUsecase.ErrCannotReportSelf:
+			response.BadRequest(c, "Cannot report your own content", err.Error())
+		case moderationUsecase.ErrAlreadyReported:
+			response.Conflict(c, "You already reported this", err.Error())
+		default:
+			response.InternalError(c, "Failed to create report", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Report submitted successfully", filing)
+}
+
+// GetReports godoc
+// @Summary Get moderation queue (admin)
+// @Description Get reports by status, most reported first. Defaults to open reports.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Report status (open, dismissed, actioned)" default(open)
+// @Param target_type query string false "Target type (post, comment, event, qna, user)"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]moderation.Report}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/reports [get]
+func (h *ModerationHandler) GetReports(c *gin.Context) {
+	// Parse query parameters
+	filter := moderation.ReportFilter{
+		Status: moderation.ReportStatus(c.DefaultQuery("status", string(moderation.StatusOpen))),
+	}
+	switch filter.Status {
+	case moderation.StatusOpen, moderation.StatusDismissed, moderation.StatusActioned:
+	default:
+		response.BadRequest(c, "Invalid status parameter", "Valid values: open, dismissed, actioned")
+		return
+	}
+
+	if targetType := c.Query("target_type"); targetType != "" {
+		t, ok := parseTargetType(targetType)
+		if !ok {
+			response.BadRequest(c, "Invalid target_type parameter", "Valid values: post, comment, event, qna, user")
+			return
+		}
+		filter.TargetType = &t
+	}
+
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	filter.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Get total count for pagination
+	total, err := h.moderationUsecase.CountReports(c.Request.Context(), &filter)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	// Get reports
+	reports, err := h.moderationUsecase.GetReports(c.Request.Context(), &filter)
+	if err != nil {
+		response.InternalError(c, "Failed to get reports", err.Error())
+		return
+	}
+
+	// Create pagination metadata with correct total
+	meta := response.NewPaginationMeta(total, filter.Limit, filter.Offset, len(reports))
+	response.Paginated(c, http.StatusOK, "Reports retrieved successfully", reports, meta)
+}
+
+// GetReport godoc
+// @Summary Get report (admin)
+// @Description Get a report with every individual filing and the owner of the reported target
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report ID" format(uuid)
+// @Success 200 {object} response.Response{data=moderation.ReportWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/reports/{id} [get]
+func (h *ModerationHandler) GetReport(c *gin.Context) {
+	// Parse report ID from path
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid report ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	report, err := h.moderationUsecase.GetReport(c.Request.Context(), reportID)
+	if err != nil {
+		if err == moderationUsecase.ErrReportNotFound {
+			response.NotFound(c, "Report not found")
+			return
+		}
+		response.InternalError(c, "Failed to get report", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Report retrieved successfully", report)
+}
+
+// DismissReport godoc
+// @Summary Dismiss report (admin)
+// @Description Close a report without action. Content hidden by the report becomes visible again.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report ID" format(uuid)
+// @Param request body moderation.ResolveReportRequest false "Moderator note"
+// @Success 200 {object} response.Response{data=moderation.Report}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/reports/{id}/dismiss [post]
+func (h *ModerationHandler) DismissReport(c *gin.Context) {
+	adminID, reportID, req, ok := h.parseResolve(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	report, err := h.moderationUsecase.DismissReport(c.Request.Context(), reportID, adminID, req)
+	if err != nil {
+		h.handleModerationError(c, err, "Failed to dismiss report")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Report dismissed successfully", report)
+}
+
+// RemoveReportedContent godoc
+// @Summary Remove reported content (admin)
+// @Description Close a report and keep the reported post, comment, event or Q&A entry hidden from everyone but its author
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report ID" format(uuid)
+// @Param request body moderation.ResolveReportRequest false "Moderator note"
+// @Success 200 {object} response.Response{data=moderation.Report}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/reports/{id}/remove [post]
+func (h *ModerationHandler) RemoveReportedContent(c *gin.Context) {
+	adminID, reportID, req, ok := h.parseResolve(c)
+	if !ok {
+		return
+	}
+
+	// Call usecase
+	report, err := h.moderationUsecase.RemoveContent(c.Request.Context(), reportID, adminID, req)
+	if err != nil {
+		h.handleModerationError(c, err, "Failed to remove content")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Content removed successfully", report)
+}
+
+// SuspendUser godoc
+// @Summary Suspend user (admin)
+// @Description Suspend a user for a number of days or indefinitely. Suspended users cannot sign in and their profile is hidden. Passing a report ID resolves that report.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" format(uuid)
+// @Param request body moderation.SuspendUserRequest true "Suspension data"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/users/{id}/suspend [post]
+func (h *ModerationHandler) SuspendUser(c *gin.Context) {
+	adminID, userID, ok := h.parseAdminAndTarget(c, "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	var req moderation.SuspendUserRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.moderationUsecase.SuspendUser(c.Request.Context(), userID, adminID, &req); err != nil {
+		h.handleModerationError(c, err, "Failed to suspend user")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User suspended successfully", nil)
+}
+
+// UnsuspendUser godoc
+// @Summary Lift user suspension (admin)
+// @Description Lift a user's suspension
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" format(uuid)
+// @Param request body moderation.UnsuspendUserRequest false "Moderator note"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/users/{id}/unsuspend [post]
+func (h *ModerationHandler) UnsuspendUser(c *gin.Context) {
+	adminID, userID, ok := h.parseAdminAndTarget(c, "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	var req moderation.UnsuspendUserRequest
+
+	// The note is optional, so an empty body is fine
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.moderationUsecase.UnsuspendUser(c.Request.Context(), userID, adminID, &req); err != nil {
+		h.handleModerationError(c, err, "Failed to lift suspension")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Suspension lifted successfully", nil)
+}
+
+// GetModerationActions godoc
+// @Summary Get moderation audit trail (admin)
+// @Description Get every moderation action, most recent first, optionally for one target or admin
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param target_type query string false "Target type (post, comment, event, qna, user, transaction, community)"
+// @Param target_id query string false "Target ID" format(uuid)
+// @Param actor_id query string false "Admin user ID" format(uuid)
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]moderation.Action}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/moderation/actions [get]
+func (h *ModerationHandler) GetModerationActions(c *gin.Context) {
+	// Parse query parameters
+	var filter moderation.ActionFilter
+
+	if targetType := c.Query("target_type"); targetType != "" {
+		t, ok := parseTargetType(targetType)
+		if !ok && (targetType == string(moderation.TargetTransaction) || targetType == string(moderation.TargetCommunity)) {
+			t, ok = moderation.TargetType(targetType), true
+		}
+		if !ok {
+			response.BadRequest(c, "Invalid target_type parameter", "Valid values: post, comment, event, qna, user, transaction, community")
+			return
+		}
+		filter.TargetType = &t
+	}
+
+	if targetID := c.Query("target_id"); targetID != "" {
+		id, err := uuid.Parse(targetID)
+		if err != nil {
+			response.BadRequest(c, "Invalid target_id parameter", err.Error())
+			return
+		}
+		filter.TargetID = &id
+	}
+
+	if actorID := c.Query("actor_id"); actorID != "" {
+		id, err := uuid.Parse(actorID)
+		if err != nil {
+			response.BadRequest(c, "Invalid actor_id parameter", err.Error())
+			return
+		}
+		filter.ActorID = &id
+	}
+
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	filter.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Get total count for pagination
+	total, err := h.moderationUsecase.CountActions(c.Request.Context(), &filter)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	// Get actions
+	actions, err := h.moderationUsecase.GetActions(c.Request.Context(), &filter)
+	if err != nil {
+		response.InternalError(c, "Failed to get moderation actions", err.Error())
+		return
+	}
+
+	// Create pagination metadata with correct total
+	meta := response.NewPaginationMeta(total, filter.Limit, filter.Offset, len(actions))
+	response.Paginated(c, http.StatusOK, "Moderation actions retrieved successfully", actions, meta)
+}
+
+// parseResolve reads the admin, the report from the path and the optional note, writing the error response on failure
+func (h *ModerationHandler) parseResolve(c *gin.Context) (uuid.UUID, uuid.UUID, *moderation.ResolveReportRequest, bool) {
+	adminID, reportID, ok := h.parseAdminAndTarget(c, "Invalid report ID")
+	if !ok {
+		return uuid.Nil, uuid.Nil, nil, false
+	}
+
+	var req moderation.ResolveReportRequest
+
+	// The note is optional, so an empty body is fine
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return uuid.Nil, uuid.Nil, nil, false
+	}
+
+	return adminID, reportID, &req, true
+}
+
+// parseAdminAndTarget reads the current admin and the ID in the path, writing the error response on failure
+func (h *ModerationHandler) parseAdminAndTarget(c *gin.Context, invalidIDMessage string) (uuid.UUID, uuid.UUID, bool) {
+	// Get user ID from context
+	adminIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	// Parse ID from path
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, invalidIDMessage, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return adminID, targetID, true
+}
+
+// handleModerationError maps moderation errors to HTTP responses
+func (h *ModerationHandler) handleModerationError(c *gin.Context, err error, message string) {
+	switch err {
+	case moderationUsecase.ErrReportNotFound:
+		response.NotFound(c, "Report not found")
+	case moderationUsecase.ErrUserNotFound:
+		response.NotFound(c, "User not found")
+	case moderationUsecase.ErrReportResolved:
+		response.Conflict(c, "Report is already resolved", err.Error())
+	case moderationUsecase.ErrNotSuspended:
+		response.Conflict(c, "User is not suspended", err.Error())
+	case moderationUsecase.ErrNotContent:
+		response.BadRequest(c, "Profiles cannot be removed, suspend the user instead", err.Error())
+	case moderationUsecase.ErrReportMismatch:
+		response.BadRequest(c, "Report is not about this user", err.Error())
+	case moderationUsecase.ErrCannotSuspendSelf:
+		response.BadRequest(c, "Cannot suspend yourself", err.Error())
+	case moderationUsecase.ErrCannotSuspendStaff:
+		response.Forbidden(c, "Platform staff cannot be suspended")
+	default:
+		response.InternalError(c, message, err.Error())
+	}
+}
+
+// parseTargetType validates a report target type from a query parameter
+func parseTargetType(s string) (moderation.TargetType, bool) {
+	t := moderation.TargetType(s)
+	switch t {
+	case moderation.TargetPost, moderation.TargetComment, moderation.TargetEvent, moderation.TargetQnA, moderation.TargetUser:
+		return t, true
+	}
+	return "", false
+}
@@ -2,32 +2,25 @@ package handler
 
 import (
 	"net/http"
-	"time"
 
-	"github.com/anigmaa/backend/internal/domain/event"
 	"github.com/anigmaa/backend/internal/domain/ticket"
-	"github.com/anigmaa/backend/internal/domain/user"
 	"github.com/anigmaa/backend/internal/infrastructure/payment"
+	ticketUsecase "github.com/anigmaa/backend/internal/usecase/ticket"
 	"github.com/anigmaa/backend/pkg/response"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 // PaymentHandler handles payment-related HTTP requests
 type PaymentHandler struct {
 	midtransClient *payment.MidtransClient
-	ticketRepo     ticket.Repository
-	eventRepo      event.Repository
-	userRepo       user.Repository
+	ticketUsecase  *ticketUsecase.Usecase
 }
 
 // NewPaymentHandler creates a new payment handler
-func NewPaymentHandler(midtransClient *payment.MidtransClient, ticketRepo ticket.Repository, eventRepo event.Repository, userRepo user.Repository) *PaymentHandler {
+func NewPaymentHandler(midtransClient *payment.MidtransClient, ticketUsecase *ticketUsecase.Usecase) *PaymentHandler {
 	return &PaymentHandler{
 		midtransClient: midtransClient,
-		ticketRepo:     ticketRepo,
-		eventRepo:      eventRepo,
-		userRepo:       userRepo,
+		ticketUsecase:  ticketUsecase,
 	}
 }
 
@@ -89,73 +82,16 @@ func (h *PaymentHandler) MidtransWebhook(c *gin.Context) {
 		return
 	}
 
-	// Update transaction status in database
-	err := h.ticketRepo.UpdateTransactionStatus(c.Request.Context(), notification.TransactionID, txnStatus)
-	if err != nil {
-		// Log error but still return 200 to Midtrans to prevent retries
-		c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Transaction not found, ignoring"})
-		return
-	}
-
-	// If payment is successful, we need to activate the ticket
-	if txnStatus == ticket.TransactionSuccess {
-		// Get transaction to find the ticket ID
-		transaction, err := h.ticketRepo.GetTransaction(c.Request.Context(), notification.TransactionID)
-		if err != nil {
-			// Transaction not found, but we already updated status, so return success
-			c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Transaction processed"})
-			return
-		}
-
-		// Get the ticket
-		tkt, err := h.ticketRepo.GetByID(c.Request.Context(), transaction.TicketID)
-		if err != nil {
-			// Ticket not found, but transaction is updated, return success
-			c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Transaction processed"})
-			return
-		}
-
-		// Update ticket status to active (it might have been pending)
-		tkt.Status = ticket.StatusActive
-		err = h.ticketRepo.Update(c.Request.Context(), tkt)
-		if err != nil {
-			// Failed to update ticket, but transaction is recorded, log and return success
-			c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Transaction processed, ticket update pending"})
+	// Call usecase
+	if err := h.ticketUsecase.ProcessPaymentCallback(c.Request.Context(), notification.TransactionID, txnStatus); err != nil {
+		if err == ticketUsecase.ErrTransactionNotFound {
+			// Still return 200 to Midtrans to prevent retries
+			c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Transaction not found, ignoring"})
 			return
 		}
-
-		// Update transaction completed_at timestamp
-		now := time.Now()
-		transaction.CompletedAt = &now
-		_ = h.ticketRepo.CreateTransaction(c.Request.Context(), transaction)
-
-		// Join the event (create attendee record)
-		attendee := &event.EventAttendee{
-			ID:       uuid.New(),
-			EventID:  tkt.EventID,
-			UserID:   tkt.UserID,
-			JoinedAt: now,
-			Status:   event.AttendeeConfirmed,
-		}
-		_ = h.eventRepo.Join(c.Request.Context(), attendee)
-
-		// Increment events attended for user stats
-		_ = h.userRepo.IncrementEventsAttended(c.Request.Context(), tkt.UserID)
-	}
-
-	// If payment failed, cancel the ticket
-	if txnStatus == ticket.TransactionFailed {
-		// Get transaction to find the ticket ID
-		transaction, err := h.ticketRepo.GetTransaction(c.Request.Context(), notification.TransactionID)
-		if err == nil {
-			// Get the ticket
-			tkt, err := h.ticketRepo.GetByID(c.Request.Context(), transaction.TicketID)
-			if err == nil {
-				// Update ticket status to cancelled
-				tkt.Status = ticket.StatusCancelled
-				_ = h.ticketRepo.Update(c.Request.Context(), tkt)
-			}
-		}
+		// Processing is idempotent, let Midtrans retry
+		response.InternalError(c, "Failed to process payment notification", err.Error())
+		return
 	}
 
 	// Return success to Midtrans
@@ -0,0 +1,597 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	"github.com/anigmaa/backend/internal/domain/payout"
+	payoutUsecase "github.com/anigmaa/backend/internal/usecase/payout"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PayoutHandler handles host balance, bank account and payout HTTP requests
+type PayoutHandler struct {
+	payoutUsecase *payoutUsecase.Usecase
+}
+
+// NewPayoutHandler creates a new payout handler
+func NewPayoutHandler(payoutUsecase *payoutUsecase.Usecase) *PayoutHandler {
+	return &PayoutHandler{
+		payoutUsecase: payoutUsecase,
+	}
+}
+
+// GetBalance godoc
+// @Summary Get payout balance
+// @Description Get what the platform owes the current host. Sales stay held until the payout hold period after their event ends.
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=payout.Balance}
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /payouts/balance [get]
+func (h *PayoutHandler) GetBalance(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	balance, err := h.payoutUsecase.GetBalance(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalError(c, "Failed to get balance", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Balance retrieved successfully", balance)
+}
+
+// GetStatement godoc
+// @Summary Get balance statement
+// @Description Get the ledger entries behind the current host's balance (sales, refunds and payouts)
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]payout.StatementEntry}
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /payouts/ledger [get]
+func (h *PayoutHandler) GetStatement(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Get total count for pagination
+	total, err := h.payoutUsecase.CountStatement(c.Request.Context(), userID)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	// Call usecase
+	entries, err := h.payoutUsecase.GetStatement(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		response.InternalError(c, "Failed to get statement", err.Error())
+		return
+	}
+
+	meta := response.NewPaginationMeta(total, limit, offset, len(entries))
+	response.Paginated(c, http.StatusOK, "Statement retrieved successfully", entries, meta)
+}
+
+// GetBankAccounts godoc
+// @Summary Get bank accounts
+// @Description Get the bank accounts the current host receives payouts on
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]payout.BankAccount}
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /payouts/bank-accounts [get]
+func (h *PayoutHandler) GetBankAccounts(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	accounts, err := h.payoutUsecase.GetBankAccounts(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalError(c, "Failed to get bank accounts", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank accounts retrieved successfully", accounts)
+}
+
+// AddBankAccount godoc
+// @Summary Add bank account
+// @Description Register a bank account to receive payouts on
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body payout.CreateBankAccountRequest true "Bank account data"
+// @Success 201 {object} response.Response{data=payout.BankAccount}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /payouts/bank-accounts [post]
+func (h *PayoutHandler) AddBankAccount(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req payout.CreateBankAccountRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	account, err := h.payoutUsecase.AddBankAccount(c.Request.Context(), userID, &req)
+	if err != nil {
+		response.InternalError(c, "Failed to add bank account", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Bank account added successfully", account)
+}
+
+// RemoveBankAccount godoc
+// @Summary Remove bank account
+// @Description Stop using a bank account for payouts. Past payouts keep referring to it.
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bank account ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /payouts/bank-accounts/{id} [delete]
+func (h *PayoutHandler) RemoveBankAccount(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse bank account ID from path
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid bank account ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.payoutUsecase.RemoveBankAccount(c.Request.Context(), accountID, userID); err != nil {
+		switch err {
+		case payoutUsecase.ErrBankAccountNotFound:
+			response.NotFound(c, "Bank account not found")
+		case payoutUsecase.ErrUnauthorized:
+			response.Forbidden(c, "You can only remove your own bank accounts")
+		default:
+			response.InternalError(c, "Failed to remove bank account", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank account removed successfully", nil)
+}
+
+// RequestPayout godoc
+// @Summary Request payout
+// @Description Withdraw part of the available balance to one of your bank accounts. The amount leaves the balance right away and returns if the payout is rejected or cancelled.
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body payout.RequestPayoutRequest true "Payout data"
+// @Success 201 {object} response.Response{data=payout.Payout}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /payouts [post]
+func (h *PayoutHandler) RequestPayout(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req payout.RequestPayoutRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	p, err := h.payoutUsecase.RequestPayout(c.Request.Context(), userID, &req)
+	if err != nil {
+		switch err {
+		case payoutUsecase.ErrBankAccountNotFound:
+			response.NotFound(c, "Bank account not found")
+		case payoutUsecase.ErrInsufficientBalance:
+			response.BadRequest(c, "Insufficient available balance", err.Error())
+		case payoutUsecase.ErrInvalidAmount:
+			response.BadRequest(c, "Invalid payout amount", err.Error())
+		default:
+			response.InternalError(c, "Failed to request payout", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Payout requested successfully", p)
+}
+
+// GetMyPayouts godoc
+// @Summary Get my payouts
+// @Description Get the payouts requested by the current host
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]payout.PayoutWithDetails}
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /payouts [get]
+func (h *PayoutHandler) GetMyPayouts(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Call usecase
+	payouts, err := h.payoutUsecase.GetPayouts(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		response.InternalError(c, "Failed to get payouts", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payouts retrieved successfully", payouts)
+}
+
+// CancelPayout godoc
+// @Summary Cancel payout
+// @Description Cancel a payout request that has not been processed yet; the amount returns to the balance
+// @Tags payouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payout ID" format(uuid)
+// @Success 200 {object} response.Response{data=payout.Payout}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /payouts/{id} [delete]
+func (h *PayoutHandler) CancelPayout(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse payout ID from path
+	payoutID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid payout ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	p, err := h.payoutUsecase.CancelPayout(c.Request.Context(), payoutID, userID)
+	if err != nil {
+		h.handleResolveError(c, err, "Failed to cancel payout")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payout cancelled successfully", p)
+}
+
+// GetPayoutQueue godoc
+// @Summary Get payout queue (admin)
+// @Description Get payouts by status, oldest first. Defaults to payouts waiting to be sent.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Payout status (requested, paid, rejected, cancelled)" default(requested)
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]payout.PayoutWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/payouts [get]
+func (h *PayoutHandler) GetPayoutQueue(c *gin.Context) {
+	// Parse query parameters
+	status := payout.PayoutStatus(c.DefaultQuery("status", string(payout.StatusRequested)))
+	switch status {
+	case payout.StatusRequested, payout.StatusPaid, payout.StatusRejected, payout.StatusCancelled:
+	default:
+		response.BadRequest(c, "Invalid status parameter", "Valid values: requested, paid, rejected, cancelled")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Call usecase
+	payouts, err := h.payoutUsecase.GetPayoutQueue(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		response.InternalError(c, "Failed to get payouts", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payouts retrieved successfully", payouts)
+}
+
+// MarkPayoutPaid godoc
+// @Summary Mark payout paid (admin)
+// @Description Record that a requested payout was transferred to the host's bank account
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payout ID" format(uuid)
+// @Param request body payout.MarkPaidRequest true "Bank transfer reference"
+// @Success 200 {object} response.Response{data=payout.Payout}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/payouts/{id}/paid [post]
+func (h *PayoutHandler) MarkPayoutPaid(c *gin.Context) {
+	// Get user ID from context
+	adminIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse payout ID from path
+	payoutID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid payout ID", err.Error())
+		return
+	}
+
+	var req payout.MarkPaidRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	p, err := h.payoutUsecase.MarkPayoutPaid(c.Request.Context(), payoutID, adminID, &req)
+	if err != nil {
+		h.handleResolveError(c, err, "Failed to mark payout paid")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payout marked paid successfully", p)
+}
+
+// RejectPayout godoc
+// @Summary Reject payout (admin)
+// @Description Refuse a requested payout; the amount returns to the host's balance
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payout ID" format(uuid)
+// @Param request body payout.RejectPayoutRequest true "Rejection reason"
+// @Success 200 {object} response.Response{data=payout.Payout}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/payouts/{id}/reject [post]
+func (h *PayoutHandler) RejectPayout(c *gin.Context) {
+	// Get user ID from context
+	adminIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse payout ID from path
+	payoutID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid payout ID", err.Error())
+		return
+	}
+
+	var req payout.RejectPayoutRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	p, err := h.payoutUsecase.RejectPayout(c.Request.Context(), payoutID, adminID, &req)
+	if err != nil {
+		h.handleResolveError(c, err, "Failed to reject payout")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Payout rejected successfully", p)
+}
+
+// GetReconciliationReport godoc
+// @Summary Get ledger reconciliation report (admin)
+// @Description Compare the settlement ledger with ticket_transactions for tickets purchased in the period and list every ticket that does not match
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD), inclusive"
+// @Success 200 {object} response.Response{data=payout.ReconciliationReport}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/payouts/reconciliation [get]
+func (h *PayoutHandler) GetReconciliationReport(c *gin.Context) {
+	// Parse query parameters
+	var startDate, endDate *time.Time
+	if s := c.Query("start_date"); s != "" {
+		start, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			response.BadRequest(c, "Invalid start_date", "Use YYYY-MM-DD")
+			return
+		}
+		startDate = &start
+	}
+	if s := c.Query("end_date"); s != "" {
+		end, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			response.BadRequest(c, "Invalid end_date", "Use YYYY-MM-DD")
+			return
+		}
+		// Include the whole end day
+		end = end.Add(24*time.Hour - time.Nanosecond)
+		endDate = &end
+	}
+
+	// Call usecase
+	report, err := h.payoutUsecase.GetReconciliationReport(c.Request.Context(), startDate, endDate)
+	if err != nil {
+		response.InternalError(c, "Failed to build reconciliation report", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Reconciliation report retrieved successfully", report)
+}
+
+// handleResolveError maps payout resolution errors to HTTP responses
+func (h *PayoutHandler) handleResolveError(c *gin.Context, err error, message string) {
+	switch err {
+	case payoutUsecase.ErrPayoutNotFound:
+		response.NotFound(c, "Payout not found")
+	case payoutUsecase.ErrUnauthorized:
+		response.Forbidden(c, "You can only cancel your own payouts")
+	case payoutUsecase.ErrPayoutNotOpen:
+		response.Conflict(c, "Payout has already been processed", err.Error())
+	default:
+		response.InternalError(c, message, err.Error())
+	}
+}
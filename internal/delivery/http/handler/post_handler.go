@@ -6,6 +6,7 @@ import (
 
 	"github.com/anigmaa/backend/internal/delivery/http/middleware"
 	"github.com/anigmaa/backend/internal/domain/comment"
+	"github.com/anigmaa/backend/internal/domain/interaction"
 	"github.com/anigmaa/backend/internal/domain/post"
 	postUsecase "github.com/anigmaa/backend/internal/usecase/post"
 	"github.com/anigmaa/backend/pkg/response"
@@ -86,7 +87,7 @@ func (h *PostHandler) GetFeed(c *gin.Context) {
 
 // CreatePost godoc
 // @Summary Create a new post
-// @Description Create a new social media post
+// @Description Create a new social media post. Posts can be saved as drafts or scheduled by giving a future publish time.
 // @Tags posts
 // @Accept json
 // @Produce json
@@ -138,6 +139,10 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 			response.NotFound(c, "Attached event not found")
 			return
 		}
+		if err == postUsecase.ErrInvalidSchedule {
+			response.BadRequest(c, "Invalid schedule", err.Error())
+			return
+		}
 		response.InternalError(c, "Failed to create post", err.Error())
 		return
 	}
@@ -190,7 +195,7 @@ func (h *PostHandler) GetPostByID(c *gin.Context) {
 
 // UpdatePost godoc
 // @Summary Update post
-// @Description Update an existing post (author only)
+// @Description Update an existing post (author only). Content edits keep the previous content as a revision and are refused once the edit window has passed or the post has a lot of engagement. Drafts and scheduled posts can be edited freely and (re)scheduled.
 // @Tags posts
 // @Accept json
 // @Produce json
@@ -202,6 +207,7 @@ func (h *PostHandler) GetPostByID(c *gin.Context) {
 // @Failure 401 {object} response.Response
 // @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /posts/{id} [put]
 func (h *PostHandler) UpdatePost(c *gin.Context) {
@@ -251,6 +257,18 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 			response.Forbidden(c, "Only the post author can update this post")
 			return
 		}
+		if err == postUsecase.ErrEditWindowClosed || err == postUsecase.ErrEditLocked {
+			response.Forbidden(c, "Post "+err.Error())
+			return
+		}
+		if err == postUsecase.ErrInvalidSchedule {
+			response.BadRequest(c, "Invalid schedule", err.Error())
+			return
+		}
+		if err == postUsecase.ErrAlreadyPublished {
+			response.Conflict(c, "Post already published", err.Error())
+			return
+		}
 		response.InternalError(c, "Failed to update post", err.Error())
 		return
 	}
@@ -258,6 +276,228 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Post updated successfully", updatedPost)
 }
 
+// PublishPost godoc
+// @Summary Publish post
+// @Description Publish a draft or scheduled post right away (author only)
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Post ID" format(uuid)
+// @Success 200 {object} response.Response{data=post.Post}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/{id}/publish [post]
+func (h *PostHandler) PublishPost(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse post ID from path
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid post ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	publishedPost, err := h.postUsecase.PublishPost(c.Request.Context(), postID, userID)
+	if err != nil {
+		if err == postUsecase.ErrPostNotFound {
+			response.NotFound(c, "Post not found")
+			return
+		}
+		if err == postUsecase.ErrUnauthorized {
+			response.Forbidden(c, "Only the post author can publish this post")
+			return
+		}
+		if err == postUsecase.ErrAlreadyPublished {
+			response.Conflict(c, "Post already published", err.Error())
+			return
+		}
+		response.InternalError(c, "Failed to publish post", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Post published successfully", publishedPost)
+}
+
+// CancelScheduledPost godoc
+// @Summary Cancel scheduled post
+// @Description Cancel the publication of a scheduled post, keeping it as a draft (author only)
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Post ID" format(uuid)
+// @Success 200 {object} response.Response{data=post.Post}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/{id}/schedule [delete]
+func (h *PostHandler) CancelScheduledPost(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse post ID from path
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid post ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	draft, err := h.postUsecase.CancelScheduledPost(c.Request.Context(), postID, userID)
+	if err != nil {
+		if err == postUsecase.ErrPostNotFound {
+			response.NotFound(c, "Post not found")
+			return
+		}
+		if err == postUsecase.ErrUnauthorized {
+			response.Forbidden(c, "Only the post author can cancel this post")
+			return
+		}
+		if err == postUsecase.ErrNotScheduled {
+			response.Conflict(c, "Post is not scheduled", err.Error())
+			return
+		}
+		response.InternalError(c, "Failed to cancel scheduled post", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Scheduled post cancelled successfully", draft)
+}
+
+// GetDrafts godoc
+// @Summary Get drafts and scheduled posts
+// @Description Get the current user's drafts and scheduled posts, next to be published first
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]post.PostResponse}
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/drafts [get]
+func (h *PostHandler) GetDrafts(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Get total count for pagination
+	total, err := h.postUsecase.CountDrafts(c.Request.Context(), userID)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	// Get drafts
+	posts, err := h.postUsecase.GetDrafts(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		response.InternalError(c, "Failed to get drafts", err.Error())
+		return
+	}
+
+	// Transform to Flutter-compatible response format
+	postResponses := make([]post.PostResponse, len(posts))
+	for i, p := range posts {
+		postResponses[i] = p.ToResponse()
+	}
+
+	meta := response.NewPaginationMeta(total, limit, offset, len(posts))
+	response.Paginated(c, http.StatusOK, "Drafts retrieved successfully", postResponses, meta)
+}
+
+// GetPostRevisions godoc
+// @Summary Get post edit history
+// @Description Get the previous contents of an edited post, most recent first
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Post ID" format(uuid)
+// @Success 200 {object} response.Response{data=[]post.Revision}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/{id}/revisions [get]
+func (h *PostHandler) GetPostRevisions(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse post ID from path
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid post ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	revisions, err := h.postUsecase.GetPostRevisions(c.Request.Context(), postID, userID)
+	if err != nil {
+		if err == postUsecase.ErrPostNotFound {
+			response.NotFound(c, "Post not found")
+			return
+		}
+		response.InternalError(c, "Failed to get post revisions", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Post revisions retrieved successfully", revisions)
+}
+
 // DeletePost godoc
 // @Summary Delete post
 // @Description Delete a post (author only)
@@ -536,6 +776,243 @@ func (h *PostHandler) UndoRepost(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Repost undone successfully", nil)
 }
 
+// GetLikers godoc
+// @Summary Get post likers
+// @Description Get the users who liked a post, newest first, with whether the current user follows them
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Post ID" format(uuid)
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.PaginatedResponse{data=[]interaction.Interactor}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/{id}/likes [get]
+func (h *PostHandler) GetLikers(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse post ID from path
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid post ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Call usecase
+	users, err := h.postUsecase.GetLikers(c.Request.Context(), postID, userID, limit, offset)
+	if err != nil {
+		if err == postUsecase.ErrPostNotFound {
+			response.NotFound(c, "Post not found")
+			return
+		}
+		response.InternalError(c, "Failed to get likers", err.Error())
+		return
+	}
+
+	// Get total count for pagination
+	total, err := h.postUsecase.CountLikers(c.Request.Context(), postID, userID)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	meta := response.NewPaginationMeta(total, limit, offset, len(users))
+	response.Paginated(c, http.StatusOK, "Likers retrieved successfully", users, meta)
+}
+
+// GetReposters godoc
+// @Summary Get post reposters
+// @Description Get the users who reposted a post, newest first, with whether the current user follows them
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Post ID" format(uuid)
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.PaginatedResponse{data=[]interaction.Interactor}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/{id}/reposts [get]
+func (h *PostHandler) GetReposters(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse post ID from path
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid post ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Call usecase
+	users, err := h.postUsecase.GetReposters(c.Request.Context(), postID, userID, limit, offset)
+	if err != nil {
+		if err == postUsecase.ErrPostNotFound {
+			response.NotFound(c, "Post not found")
+			return
+		}
+		response.InternalError(c, "Failed to get reposters", err.Error())
+		return
+	}
+
+	// Get total count for pagination
+	total, err := h.postUsecase.CountReposters(c.Request.Context(), postID, userID)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	meta := response.NewPaginationMeta(total, limit, offset, len(users))
+	response.Paginated(c, http.StatusOK, "Reposters retrieved successfully", users, meta)
+}
+
+// SharePost godoc
+// @Summary Share post
+// @Description Record that the current user shared a post and on which platform
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Post ID" format(uuid)
+// @Param request body interaction.ShareRequest true "Share data"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/{id}/share [post]
+func (h *PostHandler) SharePost(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse post ID from path
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid post ID", err.Error())
+		return
+	}
+
+	var req interaction.ShareRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.postUsecase.SharePost(c.Request.Context(), postID, userID, &req.Platform); err != nil {
+		if err == postUsecase.ErrPostNotFound {
+			response.NotFound(c, "Post not found")
+			return
+		}
+		response.InternalError(c, "Failed to share post", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Post shared successfully", nil)
+}
+
+// GetShareSummary godoc
+// @Summary Get post share counts
+// @Description Get how often a post was shared per platform (author only)
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Post ID" format(uuid)
+// @Success 200 {object} response.Response{data=interaction.ShareSummary}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/{id}/shares [get]
+func (h *PostHandler) GetShareSummary(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse post ID from path
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid post ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	summary, err := h.postUsecase.GetShareSummary(c.Request.Context(), postID, userID)
+	if err != nil {
+		if err == postUsecase.ErrPostNotFound {
+			response.NotFound(c, "Post not found")
+			return
+		}
+		if err == postUsecase.ErrUnauthorized {
+			response.Forbidden(c, "Only the post author can see share counts")
+			return
+		}
+		response.InternalError(c, "Failed to get share counts", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Share counts retrieved successfully", summary)
+}
+
 // AddComment godoc
 // @Summary Add comment to post
 // @Description Add a comment or reply to a post
@@ -589,6 +1066,10 @@ func (h *PostHandler) AddComment(c *gin.Context) {
 			response.NotFound(c, "Parent comment not found")
 			return
 		}
+		if err == postUsecase.ErrReplyTooDeep {
+			response.BadRequest(c, "Reply nested too deeply", err.Error())
+			return
+		}
 		response.InternalError(c, "Failed to add comment", err.Error())
 		return
 	}
@@ -604,6 +1085,7 @@ func (h *PostHandler) AddComment(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Post ID" format(uuid)
+// @Param sort query string false "Sort order (top, newest, oldest)" default(top)
 // @Param limit query int false "Limit" default(20)
 // @Param offset query int false "Offset" default(0)
 // @Success 200 {object} response.Response{data=[]comment.CommentWithDetails}
@@ -625,16 +1107,21 @@ func (h *PostHandler) GetComments(c *gin.Context) {
 	userID, _ := uuid.Parse(userIDStr)
 
 	// Parse query parameters
+	sort := comment.Sort(c.DefaultQuery("sort", string(comment.SortTop)))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
 	// Call usecase
-	comments, err := h.postUsecase.GetCommentsByPost(c.Request.Context(), postID, userID, limit, offset)
+	comments, err := h.postUsecase.GetCommentsByPost(c.Request.Context(), postID, userID, sort, limit, offset)
 	if err != nil {
 		if err == postUsecase.ErrPostNotFound {
 			response.NotFound(c, "Post not found")
 			return
 		}
+		if err == postUsecase.ErrInvalidSort {
+			response.BadRequest(c, "Invalid sort parameter", "Valid values: top, newest, oldest")
+			return
+		}
 		response.InternalError(c, "Failed to get comments", err.Error())
 		return
 	}
@@ -647,9 +1134,116 @@ func (h *PostHandler) GetComments(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Comments retrieved successfully", comments)
 }
 
+// GetCommentReplies godoc
+// @Summary Get comment replies
+// @Description Get the direct replies to a comment, oldest first. Pass meta.nextCursor back as cursor for the next page.
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param commentId path string true "Comment ID" format(uuid)
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Limit" default(20)
+// @Success 200 {object} response.CursorPaginatedResponse{data=[]comment.CommentWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/comments/{commentId}/replies [get]
+func (h *PostHandler) GetCommentReplies(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse comment ID from path
+	commentID, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		response.BadRequest(c, "Invalid comment ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	// Call usecase
+	replies, nextCursor, err := h.postUsecase.GetCommentReplies(c.Request.Context(), commentID, userID, c.Query("cursor"), limit)
+	if err != nil {
+		if err == postUsecase.ErrCommentNotFound || err == postUsecase.ErrPostNotFound {
+			response.NotFound(c, "Comment not found")
+			return
+		}
+		if err == comment.ErrInvalidCursor {
+			response.BadRequest(c, "Invalid cursor", err.Error())
+			return
+		}
+		response.InternalError(c, "Failed to get replies", err.Error())
+		return
+	}
+
+	response.CursorPaginated(c, http.StatusOK, "Replies retrieved successfully", replies, response.NewCursorMeta(limit, nextCursor))
+}
+
+// GetCommentRevisions godoc
+// @Summary Get comment edit history
+// @Description Get the previous contents of an edited comment, most recent first
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param commentId path string true "Comment ID" format(uuid)
+// @Success 200 {object} response.Response{data=[]comment.Revision}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /posts/comments/{commentId}/revisions [get]
+func (h *PostHandler) GetCommentRevisions(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse comment ID from path
+	commentID, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		response.BadRequest(c, "Invalid comment ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	revisions, err := h.postUsecase.GetCommentRevisions(c.Request.Context(), commentID, userID)
+	if err != nil {
+		if err == postUsecase.ErrCommentNotFound || err == postUsecase.ErrPostNotFound {
+			response.NotFound(c, "Comment not found")
+			return
+		}
+		response.InternalError(c, "Failed to get comment revisions", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Comment revisions retrieved successfully", revisions)
+}
+
 // UpdateComment godoc
 // @Summary Update comment
-// @Description Update a comment (author only)
+// @Description Update a comment (author only). Edits keep the previous content as a revision and are refused once the edit window has passed or the comment has a lot of likes.
 // @Tags posts
 // @Accept json
 // @Produce json
@@ -710,6 +1304,10 @@ func (h *PostHandler) UpdateComment(c *gin.Context) {
 			response.Forbidden(c, "Only the comment author can update this comment")
 			return
 		}
+		if err == postUsecase.ErrEditWindowClosed || err == postUsecase.ErrEditLocked {
+			response.Forbidden(c, "Comment "+err.Error())
+			return
+		}
 		response.InternalError(c, "Failed to update comment", err.Error())
 		return
 	}
@@ -719,7 +1317,7 @@ func (h *PostHandler) UpdateComment(c *gin.Context) {
 
 // DeleteComment godoc
 // @Summary Delete comment
-// @Description Delete a comment (author only)
+// @Description Delete a comment (author only). A comment with replies is kept as a tombstone without content or author.
 // @Tags posts
 // @Accept json
 // @Produce json
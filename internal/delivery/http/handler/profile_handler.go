@@ -2,16 +2,16 @@ package handler
 
 import (
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
-	"github.com/anigmaa/backend/internal/delivery/http/middleware"
 	"github.com/anigmaa/backend/internal/domain/post"
 	eventUsecase "github.com/anigmaa/backend/internal/usecase/event"
 	postUsecase "github.com/anigmaa/backend/internal/usecase/post"
 	userUsecase "github.com/anigmaa/backend/internal/usecase/user"
 	"github.com/anigmaa/backend/pkg/response"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 // ProfileHandler handles profile-related HTTP requests
@@ -36,12 +36,13 @@ func NewProfileHandler(
 
 // GetProfileByUsername godoc
 // @Summary Get user profile by username
-// @Description Get complete profile data for a user by their username
+// @Description Get complete profile data for a user by their username, ignoring case. Fields hidden by the user's privacy settings are omitted. A previous username or a user ID redirects to the current username.
 // @Tags profile
 // @Accept json
 // @Produce json
 // @Param username path string true "Username"
 // @Success 200 {object} response.Response{data=user.ProfileResponse}
+// @Success 302 {string} string "Redirect to the same path under the current username"
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /profile/{username} [get]
@@ -49,7 +50,7 @@ func (h *ProfileHandler) GetProfileByUsername(c *gin.Context) {
 	username := c.Param("username")
 
 	// Get profile by username
-	profile, err := h.userUsecase.GetProfileByUsername(c.Request.Context(), username)
+	profile, err := h.userUsecase.GetProfileByUsername(c.Request.Context(), username, viewerID(c))
 	if err != nil {
 		if err == userUsecase.ErrUserNotFound {
 			response.NotFound(c, "User not found")
@@ -58,6 +59,9 @@ func (h *ProfileHandler) GetProfileByUsername(c *gin.Context) {
 		response.InternalError(c, "Failed to get user profile", err.Error())
 		return
 	}
+	if redirectToUsername(c, username, profile.User.Username) {
+		return
+	}
 
 	// Convert to ProfileResponse with share link
 	baseURL := "https://app.anigmaa.com"
@@ -79,6 +83,8 @@ func (h *ProfileHandler) GetProfileByUsername(c *gin.Context) {
 // @Param limit query int false "Limit" default(20)
 // @Param offset query int false "Offset" default(0)
 // @Success 200 {object} response.Response{data=[]post.PostResponse}
+// @Success 302 {string} string "Redirect to the same path under the current username"
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /profile/{username}/posts [get]
@@ -99,11 +105,21 @@ func (h *ProfileHandler) GetProfilePosts(c *gin.Context) {
 		response.InternalError(c, "Failed to get user", err.Error())
 		return
 	}
+	if redirectToUsername(c, username, user.Username) {
+		return
+	}
 
 	// Get viewer ID (current user) for interaction flags
-	viewerID := uuid.Nil
-	if viewerIDStr, exists := middleware.GetUserID(c); exists {
-		viewerID, _ = uuid.Parse(viewerIDStr)
+	viewer := viewerID(c)
+
+	// Hidden profiles only show posts to the owner and followers
+	if err := h.userUsecase.EnsureProfileVisible(c.Request.Context(), user.ID, viewer); err != nil {
+		if err == userUsecase.ErrProfilePrivate {
+			response.Forbidden(c, "This profile is private")
+			return
+		}
+		response.InternalError(c, "Failed to get user", err.Error())
+		return
 	}
 
 	// Get total count for pagination
@@ -114,7 +130,7 @@ func (h *ProfileHandler) GetProfilePosts(c *gin.Context) {
 	}
 
 	// Get user posts
-	posts, err := h.postUsecase.GetUserPosts(c.Request.Context(), user.ID, viewerID, limit, offset)
+	posts, err := h.postUsecase.GetUserPosts(c.Request.Context(), user.ID, viewer, limit, offset)
 	if err != nil {
 		response.InternalError(c, "Failed to get user posts", err.Error())
 		return
@@ -140,6 +156,8 @@ func (h *ProfileHandler) GetProfilePosts(c *gin.Context) {
 // @Param limit query int false "Limit" default(20)
 // @Param offset query int false "Offset" default(0)
 // @Success 200 {object} response.Response{data=[]event.EventWithDetails}
+// @Success 302 {string} string "Redirect to the same path under the current username"
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /profile/{username}/events [get]
@@ -160,6 +178,19 @@ func (h *ProfileHandler) GetProfileEvents(c *gin.Context) {
 		response.InternalError(c, "Failed to get user", err.Error())
 		return
 	}
+	if redirectToUsername(c, username, user.Username) {
+		return
+	}
+
+	// Respect the user's events visibility
+	if err := h.userUsecase.EnsureEventsVisible(c.Request.Context(), user.ID, viewerID(c)); err != nil {
+		if err == userUsecase.ErrEventsPrivate {
+			response.Forbidden(c, "This user's events are private")
+			return
+		}
+		response.InternalError(c, "Failed to get user", err.Error())
+		return
+	}
 
 	// Get total count for pagination
 	total, err := h.eventUsecase.CountHostedEvents(c.Request.Context(), user.ID)
@@ -179,3 +210,20 @@ func (h *ProfileHandler) GetProfileEvents(c *gin.Context) {
 	meta := response.NewPaginationMeta(total, limit, offset, len(events))
 	response.Paginated(c, http.StatusOK, "Events retrieved successfully", events, meta)
 }
+
+// redirectToUsername redirects a request made with a previous username or a user ID
+// to the same path under the current username. Case differences are not redirected.
+func redirectToUsername(c *gin.Context, requested, current string) bool {
+	if strings.EqualFold(requested, current) {
+		return false
+	}
+
+	location := strings.Replace(c.Request.URL.Path, "/profile/"+requested, "/profile/"+url.PathEscape(current), 1)
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+
+	// Temporary, the previous username can later be taken by someone else
+	c.Redirect(http.StatusFound, location)
+	return true
+}
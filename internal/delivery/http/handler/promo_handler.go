@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	"github.com/anigmaa/backend/internal/domain/promo"
+	promoUsecase "github.com/anigmaa/backend/internal/usecase/promo"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PromoHandler handles promo code HTTP requests
+type PromoHandler struct {
+	promoUsecase *promoUsecase.Usecase
+}
+
+// NewPromoHandler creates a new promo code handler
+func NewPromoHandler(promoUsecase *promoUsecase.Usecase) *PromoHandler {
+	return &PromoHandler{
+		promoUsecase: promoUsecase,
+	}
+}
+
+// CreatePromoCode godoc
+// @Summary Create promo code
+// @Description Create a promo code for your events. Codes are case-insensitive and unique per host. Without event_ids the code applies to all of your events.
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body promo.CreateCodeRequest true "Promo code data"
+// @Success 201 {object} response.Response{data=promo.Code}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /promo-codes [post]
+func (h *PromoHandler) CreatePromoCode(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req promo.CreateCodeRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	code, err := h.promoUsecase.CreateCode(c.Request.Context(), userID, &req)
+	if err != nil {
+		switch err {
+		case promoUsecase.ErrCodeExists:
+			response.Conflict(c, "Promo code already exists", err.Error())
+		case promoUsecase.ErrInvalidDiscount:
+			response.BadRequest(c, "Invalid discount or validity window", err.Error())
+		case promoUsecase.ErrInvalidEvent:
+			response.BadRequest(c, "Invalid event restriction", err.Error())
+		default:
+			response.InternalError(c, "Failed to create promo code", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Promo code created successfully", code)
+}
+
+// GetMyPromoCodes godoc
+// @Summary Get my promo codes
+// @Description Get all promo codes created by the current user
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]promo.Code}
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /promo-codes [get]
+func (h *PromoHandler) GetMyPromoCodes(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Call usecase
+	codes, err := h.promoUsecase.GetHostCodes(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		response.InternalError(c, "Failed to get promo codes", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Promo codes retrieved successfully", codes)
+}
+
+// GetPromoCode godoc
+// @Summary Get promo code
+// @Description Get a promo code you created, including its redemption count
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Promo code ID" format(uuid)
+// @Success 200 {object} response.Response{data=promo.Code}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /promo-codes/{id} [get]
+func (h *PromoHandler) GetPromoCode(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse promo code ID from path
+	codeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid promo code ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	code, err := h.promoUsecase.GetCode(c.Request.Context(), codeID, userID)
+	if err != nil {
+		switch err {
+		case promoUsecase.ErrCodeNotFound:
+			response.NotFound(c, "Promo code not found")
+		case promoUsecase.ErrUnauthorized:
+			response.Forbidden(c, "You can only view your own promo codes")
+		default:
+			response.InternalError(c, "Failed to get promo code", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Promo code retrieved successfully", code)
+}
+
+// UpdatePromoCode godoc
+// @Summary Update promo code
+// @Description Update the limits, validity window, status or event restriction of a promo code. The discount itself cannot be changed.
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Promo code ID" format(uuid)
+// @Param request body promo.UpdateCodeRequest true "Promo code update data"
+// @Success 200 {object} response.Response{data=promo.Code}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /promo-codes/{id} [put]
+func (h *PromoHandler) UpdatePromoCode(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse promo code ID from path
+	codeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid promo code ID", err.Error())
+		return
+	}
+
+	var req promo.UpdateCodeRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	code, err := h.promoUsecase.UpdateCode(c.Request.Context(), codeID, userID, &req)
+	if err != nil {
+		switch err {
+		case promoUsecase.ErrCodeNotFound:
+			response.NotFound(c, "Promo code not found")
+		case promoUsecase.ErrUnauthorized:
+			response.Forbidden(c, "You can only update your own promo codes")
+		case promoUsecase.ErrInvalidDiscount:
+			response.BadRequest(c, "Invalid validity window", err.Error())
+		case promoUsecase.ErrInvalidEvent:
+			response.BadRequest(c, "Invalid event restriction", err.Error())
+		default:
+			response.InternalError(c, "Failed to update promo code", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Promo code updated successfully", code)
+}
+
+// DeactivatePromoCode godoc
+// @Summary Deactivate promo code
+// @Description Stop a promo code from being redeemed. Past redemptions are kept.
+// @Tags promo-codes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Promo code ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /promo-codes/{id} [delete]
+func (h *PromoHandler) DeactivatePromoCode(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse promo code ID from path
+	codeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid promo code ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.promoUsecase.DeactivateCode(c.Request.Context(), codeID, userID); err != nil {
+		switch err {
+		case promoUsecase.ErrCodeNotFound:
+			response.NotFound(c, "Promo code not found")
+		case promoUsecase.ErrUnauthorized:
+			response.Forbidden(c, "You can only deactivate your own promo codes")
+		default:
+			response.InternalError(c, "Failed to deactivate promo code", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Promo code deactivated successfully", nil)
+}
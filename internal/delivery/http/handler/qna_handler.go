@@ -264,7 +264,7 @@ func (h *QnAHandler) RemoveUpvote(c *gin.Context) {
 
 // AnswerQuestion godoc
 // @Summary Answer a question
-// @Description Answer a question (event organizer only)
+// @Description Answer a question (event host or staff with Q&A moderation permission)
 // @Tags qna
 // @Accept json
 // @Produce json
@@ -274,6 +274,7 @@ func (h *QnAHandler) RemoveUpvote(c *gin.Context) {
 // @Success 200 {object} response.Response{data=qna.QnA}
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /qna/{id}/answer [post]
@@ -324,6 +325,10 @@ func (h *QnAHandler) AnswerQuestion(c *gin.Context) {
 			response.Conflict(c, "Question already answered", err.Error())
 			return
 		}
+		if err == qnaUsecase.ErrUnauthorized {
+			response.Forbidden(c, "Only the event host and Q&A moderators can answer questions")
+			return
+		}
 		response.InternalError(c, "Failed to answer question", err.Error())
 		return
 	}
@@ -333,7 +338,7 @@ func (h *QnAHandler) AnswerQuestion(c *gin.Context) {
 
 // DeleteQuestion godoc
 // @Summary Delete a question
-// @Description Delete a question (author only)
+// @Description Delete a question (author or Q&A moderators)
 // @Tags qna
 // @Accept json
 // @Produce json
@@ -375,7 +380,7 @@ func (h *QnAHandler) DeleteQuestion(c *gin.Context) {
 			return
 		}
 		if err == qnaUsecase.ErrUnauthorized {
-			response.Forbidden(c, "Only the question author can delete this question")
+			response.Forbidden(c, "Only the question author or Q&A moderators can delete this question")
 			return
 		}
 		response.InternalError(c, "Failed to delete question", err.Error())
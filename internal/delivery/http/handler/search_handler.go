@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/anigmaa/backend/internal/domain/search"
+	searchUsecase "github.com/anigmaa/backend/internal/usecase/search"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler handles unified search HTTP requests
+type SearchHandler struct {
+	searchUsecase *searchUsecase.Usecase
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(searchUsecase *searchUsecase.Usecase) *SearchHandler {
+	return &SearchHandler{
+		searchUsecase: searchUsecase,
+	}
+}
+
+// Search godoc
+// @Summary Search everything
+// @Description Full-text search across events, posts, hashtags, users and communities. Indonesian and English words are stemmed and small typos in names and titles are tolerated. Results are ranked by relevance and recency, and by proximity when lat/lng are given. type=all returns the top 5 of each type; a specific type returns a page of that type. Facets always hold the total matches per type.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query" minlength(2) maxlength(100)
+// @Param type query string false "Result type" Enums(all, events, posts, hashtags, users, communities) default(all)
+// @Param lat query number false "Latitude for proximity ranking"
+// @Param lng query number false "Longitude for proximity ranking"
+// @Param limit query int false "Limit (ignored for type=all)" default(20)
+// @Param offset query int false "Offset (ignored for type=all)" default(0)
+// @Success 200 {object} response.Response{data=search.Results}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var req search.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, "Invalid search parameters", err.Error())
+		return
+	}
+
+	results, err := h.searchUsecase.Search(c.Request.Context(), &req, viewerID(c))
+	if err != nil {
+		switch err {
+		case searchUsecase.ErrQueryTooShort:
+			response.BadRequest(c, "Search query must be at least 2 characters", err.Error())
+		case searchUsecase.ErrInvalidType:
+			response.BadRequest(c, "Invalid search type", err.Error())
+		case searchUsecase.ErrInvalidLocation:
+			response.BadRequest(c, "Invalid location", err.Error())
+		default:
+			response.InternalError(c, "Failed to search", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Search completed successfully", results)
+}
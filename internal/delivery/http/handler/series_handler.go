@@ -0,0 +1,414 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	"github.com/anigmaa/backend/internal/domain/event"
+	eventUsecase "github.com/anigmaa/backend/internal/usecase/event"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/anigmaa/backend/pkg/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SeriesHandler handles recurring event series HTTP requests
+type SeriesHandler struct {
+	eventUsecase *eventUsecase.Usecase
+	validator    *validator.Validator
+}
+
+// NewSeriesHandler creates a new series handler
+func NewSeriesHandler(eventUsecase *eventUsecase.Usecase, validator *validator.Validator) *SeriesHandler {
+	return &SeriesHandler{
+		eventUsecase: eventUsecase,
+		validator:    validator,
+	}
+}
+
+// CreateSeries godoc
+// @Summary Create recurring event
+// @Description Create an event series from an RRULE recurrence (e.g. FREQ=WEEKLY;BYDAY=TU or FREQ=MONTHLY;BYDAY=1SA). start_time and end_time are those of the first occurrence. Occurrences are generated ahead of time as regular events, so tickets and attendance stay per occurrence.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body event.CreateSeriesRequest true "Series data"
+// @Success 201 {object} response.Response{data=event.SeriesWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /series [post]
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	// Get user ID from context
+	hostIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	hostID, err := uuid.Parse(hostIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req event.CreateSeriesRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Validate request
+	if err := h.validator.Validate(&req); err != nil {
+		response.BadRequest(c, "Validation failed", err.Error())
+		return
+	}
+
+	// Call usecase
+	series, err := h.eventUsecase.CreateSeries(c.Request.Context(), hostID, &req)
+	if err != nil {
+		switch err {
+		case eventUsecase.ErrInvalidTimeRange:
+			response.BadRequest(c, "End time must be after start time", err.Error())
+		case eventUsecase.ErrPastEvent:
+			response.BadRequest(c, "Cannot create event in the past", err.Error())
+		case eventUsecase.ErrInvalidRecurrence:
+			response.BadRequest(c, "Invalid recurrence rule", err.Error())
+		case eventUsecase.ErrInvalidTimezone:
+			response.BadRequest(c, "Invalid timezone", err.Error())
+		case eventUsecase.ErrNoOccurrences:
+			response.BadRequest(c, "Recurrence has no upcoming occurrences", err.Error())
+		default:
+			response.InternalError(c, "Failed to create event series", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Event series created successfully", series)
+}
+
+// GetSeries godoc
+// @Summary Get event series
+// @Description Get a recurring event series with its upcoming occurrences
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "Series ID" format(uuid)
+// @Success 200 {object} response.Response{data=event.SeriesWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /series/{id} [get]
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	// Parse series ID from path
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	// Get user ID from context (optional, for checking follow status)
+	userIDStr, _ := middleware.GetUserID(c)
+	userID, _ := uuid.Parse(userIDStr)
+
+	// Call usecase
+	series, err := h.eventUsecase.GetSeries(c.Request.Context(), seriesID, userID)
+	if err != nil {
+		if err == eventUsecase.ErrSeriesNotFound {
+			response.NotFound(c, "Event series not found")
+			return
+		}
+		response.InternalError(c, "Failed to get event series", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Event series retrieved successfully", series)
+}
+
+// EndSeries godoc
+// @Summary End event series
+// @Description Stop a recurring event (host or edit_event staff). No more occurrences are generated and upcoming occurrences are cancelled with their tickets refunded; past occurrences are kept.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Series ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /series/{id} [delete]
+func (h *SeriesHandler) EndSeries(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse series ID from path
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.eventUsecase.EndSeries(c.Request.Context(), seriesID, userID); err != nil {
+		switch err {
+		case eventUsecase.ErrSeriesNotFound:
+			response.NotFound(c, "Event series not found")
+		case eventUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the series host and staff with edit_event permission can end this series")
+		case eventUsecase.ErrSeriesEnded:
+			response.Conflict(c, "Event series has already ended", err.Error())
+		default:
+			response.InternalError(c, "Failed to end event series", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Event series ended successfully", nil)
+}
+
+// UpdateFollowingOccurrences godoc
+// @Summary Update this and following occurrences
+// @Description Apply a change to an occurrence and every later upcoming occurrence of its series (host or edit_event staff). Occurrences generated later use the new details too. A new start time moves every occurrence by the same amount but may not change its day. Use PUT /events/{id} to change a single occurrence.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID of the first occurrence to change" format(uuid)
+// @Param request body event.UpdateSeriesRequest true "Changes"
+// @Success 200 {object} response.Response{data=event.SeriesWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/following [put]
+func (h *SeriesHandler) UpdateFollowingOccurrences(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	var req event.UpdateSeriesRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Validate request
+	if err := h.validator.Validate(&req); err != nil {
+		response.BadRequest(c, "Validation failed", err.Error())
+		return
+	}
+
+	// Call usecase
+	series, err := h.eventUsecase.UpdateFollowingOccurrences(c.Request.Context(), eventID, userID, &req)
+	if err != nil {
+		switch err {
+		case eventUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case eventUsecase.ErrSeriesNotFound:
+			response.NotFound(c, "Event series not found")
+		case eventUsecase.ErrNotInSeries:
+			response.BadRequest(c, "Event is not part of a series", err.Error())
+		case eventUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the series host and staff with edit_event permission can update its occurrences")
+		case eventUsecase.ErrSeriesEnded:
+			response.Conflict(c, "Event series has ended", err.Error())
+		case eventUsecase.ErrInvalidTimeRange:
+			response.BadRequest(c, "End time must be after start time", err.Error())
+		case eventUsecase.ErrSeriesDayChange:
+			response.BadRequest(c, "Occurrences cannot move to another day", err.Error())
+		default:
+			response.InternalError(c, "Failed to update event series", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Event series updated successfully", series)
+}
+
+// FollowSeries godoc
+// @Summary Follow event series
+// @Description Follow a recurring event to keep its upcoming occurrences in your followed series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Series ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /series/{id}/follow [post]
+func (h *SeriesHandler) FollowSeries(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse series ID from path
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.eventUsecase.FollowSeries(c.Request.Context(), seriesID, userID); err != nil {
+		switch err {
+		case eventUsecase.ErrSeriesNotFound:
+			response.NotFound(c, "Event series not found")
+		case eventUsecase.ErrSeriesEnded:
+			response.Conflict(c, "Event series has ended", err.Error())
+		default:
+			response.InternalError(c, "Failed to follow event series", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Event series followed successfully", nil)
+}
+
+// UnfollowSeries godoc
+// @Summary Unfollow event series
+// @Description Stop following a recurring event
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Series ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /series/{id}/follow [delete]
+func (h *SeriesHandler) UnfollowSeries(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse series ID from path
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid series ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.eventUsecase.UnfollowSeries(c.Request.Context(), seriesID, userID); err != nil {
+		if err == eventUsecase.ErrSeriesNotFound {
+			response.NotFound(c, "Event series not found")
+			return
+		}
+		response.InternalError(c, "Failed to unfollow event series", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Event series unfollowed successfully", nil)
+}
+
+// GetFollowedSeries godoc
+// @Summary Get followed event series
+// @Description Get the recurring events the current user follows, soonest next occurrence first
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]event.SeriesWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /series/following [get]
+func (h *SeriesHandler) GetFollowedSeries(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Get total count for pagination
+	total, err := h.eventUsecase.CountFollowedSeries(c.Request.Context(), userID)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	// Call usecase
+	series, err := h.eventUsecase.GetFollowedSeries(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		response.InternalError(c, "Failed to get followed event series", err.Error())
+		return
+	}
+
+	meta := response.NewPaginationMeta(total, limit, offset, len(series))
+	response.Paginated(c, http.StatusOK, "Followed event series retrieved successfully", series, meta)
+}
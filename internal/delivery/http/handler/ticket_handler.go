@@ -6,6 +6,7 @@ import (
 
 	"github.com/anigmaa/backend/internal/delivery/http/middleware"
 	"github.com/anigmaa/backend/internal/domain/ticket"
+	promoUsecase "github.com/anigmaa/backend/internal/usecase/promo"
 	ticketUsecase "github.com/anigmaa/backend/internal/usecase/ticket"
 	"github.com/anigmaa/backend/pkg/response"
 	"github.com/anigmaa/backend/pkg/validator"
@@ -85,6 +86,34 @@ func (h *TicketHandler) PurchaseTicket(c *gin.Context) {
 			response.Conflict(c, "Already purchased ticket for this event", err.Error())
 			return
 		}
+		if err == ticketUsecase.ErrTierNotFound {
+			response.NotFound(c, "Ticket tier not found")
+			return
+		}
+		if err == ticketUsecase.ErrTierRequired {
+			response.BadRequest(c, "Ticket tier is required for this event", err.Error())
+			return
+		}
+		if err == ticketUsecase.ErrTierNotOnSale {
+			response.BadRequest(c, "Ticket tier is not on sale", err.Error())
+			return
+		}
+		if err == ticketUsecase.ErrTierSoldOut {
+			response.Conflict(c, "Ticket tier is sold out", err.Error())
+			return
+		}
+		if err == promoUsecase.ErrCodeNotFound {
+			response.NotFound(c, "Promo code not found")
+			return
+		}
+		if err == promoUsecase.ErrCodeNotValid {
+			response.BadRequest(c, "Promo code is not valid for this purchase", err.Error())
+			return
+		}
+		if err == promoUsecase.ErrCodeExhausted || err == promoUsecase.ErrUserLimitReached {
+			response.Conflict(c, "Promo code can no longer be used", err.Error())
+			return
+		}
 		response.InternalError(c, "Failed to purchase ticket", err.Error())
 		return
 	}
@@ -204,27 +233,32 @@ func (h *TicketHandler) GetTicketByID(c *gin.Context) {
 }
 
 // CheckIn godoc
-// @Summary Check in with ticket
-// @Description Check in to an event using attendance code
+// @Summary Check in attendee
+// @Description Check an attendee in at the door by scanning the ticket QR code or entering the attendance code (host or staff only). Scanning an already checked-in ticket succeeds again with already_checked_in set.
 // @Tags tickets
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param event_id path string true "Event ID" format(uuid)
+// @Param id path string true "Event ID" format(uuid)
 // @Param request body ticket.CheckInRequest true "Check-in data"
-// @Success 200 {object} response.Response{data=ticket.Ticket}
+// @Success 200 {object} response.Response{data=ticket.CheckInResult}
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
-// @Failure 409 {object} response.Response
 // @Failure 500 {object} response.Response
-// @Router /tickets/events/{event_id}/checkin [post]
+// @Router /events/{id}/check-in [post]
 func (h *TicketHandler) CheckIn(c *gin.Context) {
-	// Parse event ID from path
-	eventIDStr := c.Param("event_id")
-	eventID, err := uuid.Parse(eventIDStr)
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		response.BadRequest(c, "Invalid event ID", err.Error())
+		response.BadRequest(c, "Invalid user ID", err.Error())
 		return
 	}
 
@@ -236,36 +270,180 @@ func (h *TicketHandler) CheckIn(c *gin.Context) {
 		return
 	}
 
-	// Validate request
-	if err := h.validator.Validate(&req); err != nil {
-		response.BadRequest(c, "Validation failed", err.Error())
+	// Parse event ID from path, falling back to the body for /tickets/check-in
+	var eventID uuid.UUID
+	if eventIDStr := c.Param("id"); eventIDStr != "" {
+		eventID, err = uuid.Parse(eventIDStr)
+		if err != nil {
+			response.BadRequest(c, "Invalid event ID", err.Error())
+			return
+		}
+	} else if req.EventID != nil {
+		eventID = *req.EventID
+	} else {
+		response.BadRequest(c, "Event ID is required", "")
 		return
 	}
 
 	// Call usecase
-	checkedInTicket, err := h.ticketUsecase.CheckIn(c.Request.Context(), eventID, &req)
+	result, err := h.ticketUsecase.CheckIn(c.Request.Context(), eventID, userID, &req)
 	if err != nil {
-		if err == ticketUsecase.ErrTicketNotFound {
+		switch err {
+		case ticketUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case ticketUsecase.ErrTicketNotFound:
 			response.NotFound(c, "Ticket not found")
-			return
-		}
-		if err == ticketUsecase.ErrInvalidAttendanceCode {
+		case ticketUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the event host and staff can check attendees in")
+		case ticketUsecase.ErrInvalidQRCode:
+			response.BadRequest(c, "Invalid ticket QR code", err.Error())
+		case ticketUsecase.ErrInvalidAttendanceCode:
 			response.BadRequest(c, "Invalid attendance code", err.Error())
-			return
+		case ticketUsecase.ErrCheckInCodeRequired:
+			response.BadRequest(c, "QR code or attendance code is required", err.Error())
+		case ticketUsecase.ErrTicketUnassigned:
+			response.BadRequest(c, "Ticket has not been assigned to an attendee", err.Error())
+		case ticketUsecase.ErrTicketNotActive:
+			response.BadRequest(c, "Ticket is not active", err.Error())
+		default:
+			response.InternalError(c, "Failed to check in", err.Error())
 		}
-		if err == ticketUsecase.ErrAlreadyCheckedIn {
-			response.Conflict(c, "Ticket already checked in", err.Error())
-			return
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Checked in successfully", result)
+}
+
+// GetCheckInSnapshot godoc
+// @Summary Download offline check-in snapshot
+// @Description Download a signed, compact list of an event's valid tickets so a scanner device can check attendees in without a connection (host or staff only). Devices match scanned QR codes by ticket ID and verify the Ed25519 signature with the check-in public key; attendance codes are checked when scans are synced.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Success 200 {object} response.Response{data=ticket.CheckInSnapshot}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/check-in/snapshot [get]
+func (h *TicketHandler) GetCheckInSnapshot(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	snapshot, err := h.ticketUsecase.GetCheckInSnapshot(c.Request.Context(), eventID, userID)
+	if err != nil {
+		switch err {
+		case ticketUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case ticketUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the event host and staff can check attendees in")
+		default:
+			response.InternalError(c, "Failed to build check-in snapshot", err.Error())
 		}
-		if err == ticketUsecase.ErrTicketNotActive {
-			response.BadRequest(c, "Ticket is not active", err.Error())
-			return
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Check-in snapshot retrieved successfully", snapshot)
+}
+
+// GetCheckInPublicKey godoc
+// @Summary Get check-in public key
+// @Description Get the Ed25519 public key (base64url) scanner devices verify offline check-in snapshots with
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /tickets/check-in/public-key [get]
+func (h *TicketHandler) GetCheckInPublicKey(c *gin.Context) {
+	response.Success(c, http.StatusOK, "Check-in public key retrieved successfully", gin.H{
+		"algorithm":  "Ed25519",
+		"public_key": h.ticketUsecase.GetCheckInPublicKey(),
+	})
+}
+
+// SyncOfflineCheckIns godoc
+// @Summary Upload offline check-ins
+// @Description Upload check-ins a scanner device recorded while offline (host or staff only). The earliest scan of a ticket across all devices wins; every scan is reported back as accepted, duplicate or rejected.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param request body ticket.SyncCheckInsRequest true "Offline check-ins"
+// @Success 200 {object} response.Response{data=ticket.SyncCheckInsResult}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/check-in/sync [post]
+func (h *TicketHandler) SyncOfflineCheckIns(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	var req ticket.SyncCheckInsRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	result, err := h.ticketUsecase.SyncOfflineCheckIns(c.Request.Context(), eventID, userID, &req)
+	if err != nil {
+		switch err {
+		case ticketUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case ticketUsecase.ErrUnauthorized:
+			response.Forbidden(c, "Only the event host and staff can check attendees in")
+		default:
+			response.InternalError(c, "Failed to sync offline check-ins", err.Error())
 		}
-		response.InternalError(c, "Failed to check in", err.Error())
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Checked in successfully", checkedInTicket)
+	response.Success(c, http.StatusOK, "Offline check-ins synced successfully", result)
 }
 
 // CancelTicket godoc
@@ -569,3 +747,747 @@ func (h *TicketHandler) GetTransaction(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Transaction retrieved successfully", transaction)
 }
+
+// GetEventTiers godoc
+// @Summary Get event ticket tiers
+// @Description Get the ticket tiers of an event with remaining quota and sale status
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID" format(uuid)
+// @Success 200 {object} response.Response{data=[]ticket.TierWithAvailability}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/tiers [get]
+func (h *TicketHandler) GetEventTiers(c *gin.Context) {
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Get user ID from context (optional, anonymous visitors are counted too)
+	viewerIDStr, _ := middleware.GetUserID(c)
+	viewerID, _ := uuid.Parse(viewerIDStr)
+
+	// Call usecase
+	tiers, err := h.ticketUsecase.GetEventTiers(c.Request.Context(), eventID, viewerID)
+	if err != nil {
+		if err == ticketUsecase.ErrEventNotFound {
+			response.NotFound(c, "Event not found")
+			return
+		}
+		response.InternalError(c, "Failed to get ticket tiers", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket tiers retrieved successfully", tiers)
+}
+
+// CreateTier godoc
+// @Summary Create ticket tier
+// @Description Create a ticket tier for an event (host only). Once an event has tiers, purchases must pick one.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param request body ticket.CreateTierRequest true "Ticket tier data"
+// @Success 201 {object} response.Response{data=ticket.Tier}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/tiers [post]
+func (h *TicketHandler) CreateTier(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	var req ticket.CreateTierRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	tier, err := h.ticketUsecase.CreateTier(c.Request.Context(), eventID, userID, &req)
+	if err != nil {
+		if err == ticketUsecase.ErrEventNotFound {
+			response.NotFound(c, "Event not found")
+			return
+		}
+		if err == ticketUsecase.ErrUnauthorized {
+			response.Forbidden(c, "Only the event host and staff with edit access can manage ticket tiers")
+			return
+		}
+		if err == ticketUsecase.ErrInvalidTier {
+			response.BadRequest(c, "Invalid ticket tier", err.Error())
+			return
+		}
+		response.InternalError(c, "Failed to create ticket tier", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Ticket tier created successfully", tier)
+}
+
+// UpdateTier godoc
+// @Summary Update ticket tier
+// @Description Update a ticket tier (host only). Price cannot change once tickets were issued for the tier.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param tierId path string true "Tier ID" format(uuid)
+// @Param request body ticket.UpdateTierRequest true "Ticket tier update data"
+// @Success 200 {object} response.Response{data=ticket.Tier}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/tiers/{tierId} [put]
+func (h *TicketHandler) UpdateTier(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event and tier IDs from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	tierID, err := uuid.Parse(c.Param("tierId"))
+	if err != nil {
+		response.BadRequest(c, "Invalid tier ID", err.Error())
+		return
+	}
+
+	var req ticket.UpdateTierRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	tier, err := h.ticketUsecase.UpdateTier(c.Request.Context(), eventID, tierID, userID, &req)
+	if err != nil {
+		if err == ticketUsecase.ErrEventNotFound {
+			response.NotFound(c, "Event not found")
+			return
+		}
+		if err == ticketUsecase.ErrTierNotFound {
+			response.NotFound(c, "Ticket tier not found")
+			return
+		}
+		if err == ticketUsecase.ErrUnauthorized {
+			response.Forbidden(c, "Only the event host and staff with edit access can manage ticket tiers")
+			return
+		}
+		if err == ticketUsecase.ErrInvalidTier {
+			response.BadRequest(c, "Invalid ticket tier", err.Error())
+			return
+		}
+		if err == ticketUsecase.ErrTierHasTickets {
+			response.Conflict(c, "Cannot change the price of a tier with tickets", err.Error())
+			return
+		}
+		response.InternalError(c, "Failed to update ticket tier", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket tier updated successfully", tier)
+}
+
+// DeleteTier godoc
+// @Summary Delete ticket tier
+// @Description Delete a ticket tier that has no tickets (host only)
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Param tierId path string true "Tier ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/tiers/{tierId} [delete]
+func (h *TicketHandler) DeleteTier(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event and tier IDs from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	tierID, err := uuid.Parse(c.Param("tierId"))
+	if err != nil {
+		response.BadRequest(c, "Invalid tier ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.ticketUsecase.DeleteTier(c.Request.Context(), eventID, tierID, userID); err != nil {
+		if err == ticketUsecase.ErrEventNotFound {
+			response.NotFound(c, "Event not found")
+			return
+		}
+		if err == ticketUsecase.ErrTierNotFound {
+			response.NotFound(c, "Ticket tier not found")
+			return
+		}
+		if err == ticketUsecase.ErrUnauthorized {
+			response.Forbidden(c, "Only the event host and staff with edit access can manage ticket tiers")
+			return
+		}
+		if err == ticketUsecase.ErrTierHasTickets {
+			response.Conflict(c, "Cannot delete a tier with tickets", err.Error())
+			return
+		}
+		response.InternalError(c, "Failed to delete ticket tier", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket tier deleted successfully", nil)
+}
+
+// GetOrder godoc
+// @Summary Get ticket order
+// @Description Get an order with all of its tickets and their QR codes (buyer only)
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID" format(uuid)
+// @Success 200 {object} response.Response{data=ticket.OrderWithTickets}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tickets/orders/{id} [get]
+func (h *TicketHandler) GetOrder(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse order ID from path
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid order ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	order, err := h.ticketUsecase.GetOrder(c.Request.Context(), orderID, userID)
+	if err != nil {
+		if err == ticketUsecase.ErrOrderNotFound {
+			response.NotFound(c, "Order not found")
+			return
+		}
+		if err == ticketUsecase.ErrUnauthorized {
+			response.Forbidden(c, "You can only view your own orders")
+			return
+		}
+		response.InternalError(c, "Failed to get order", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Order retrieved successfully", order)
+}
+
+// AssignTicket godoc
+// @Summary Assign ticket to user
+// @Description Hand an unassigned ticket from your order to another user. The recipient joins the event.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Ticket ID" format(uuid)
+// @Param request body ticket.AssignTicketRequest true "Recipient"
+// @Success 200 {object} response.Response{data=ticket.TicketWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tickets/{id}/assign [post]
+func (h *TicketHandler) AssignTicket(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse ticket ID from path
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID", err.Error())
+		return
+	}
+
+	var req ticket.AssignTicketRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	assigned, err := h.ticketUsecase.AssignTicket(c.Request.Context(), ticketID, userID, req.UserID)
+	if err != nil {
+		h.handleAssignError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket assigned successfully", assigned)
+}
+
+// CreateTicketInvite godoc
+// @Summary Create ticket invite link
+// @Description Create a shareable link for an unassigned ticket from your order. Whoever claims it first gets the ticket. Creating a new link invalidates the previous one.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Ticket ID" format(uuid)
+// @Success 201 {object} response.Response{data=ticket.TicketInvite}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tickets/{id}/invite [post]
+func (h *TicketHandler) CreateTicketInvite(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse ticket ID from path
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	invite, err := h.ticketUsecase.CreateTicketInvite(c.Request.Context(), ticketID, userID)
+	if err != nil {
+		h.handleAssignError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Ticket invite created successfully", invite)
+}
+
+// ClaimTicketInvite godoc
+// @Summary Claim ticket from invite link
+// @Description Claim an unassigned ticket shared through an invite link. The ticket is assigned to you and you join the event.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param token path string true "Invite token"
+// @Success 200 {object} response.Response{data=ticket.TicketWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tickets/claim/{token} [post]
+func (h *TicketHandler) ClaimTicketInvite(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	claimed, err := h.ticketUsecase.ClaimTicketInvite(c.Request.Context(), c.Param("token"), userID)
+	if err != nil {
+		h.handleAssignError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket claimed successfully", claimed)
+}
+
+// handleAssignError maps ticket assignment errors to HTTP responses
+func (h *TicketHandler) handleAssignError(c *gin.Context, err error) {
+	switch err {
+	case ticketUsecase.ErrTicketNotFound:
+		response.NotFound(c, "Ticket not found")
+	case ticketUsecase.ErrInviteNotFound:
+		response.NotFound(c, "Ticket invite not found or already used")
+	case ticketUsecase.ErrRecipientNotFound:
+		response.NotFound(c, "Recipient not found")
+	case ticketUsecase.ErrUnauthorized:
+		response.Forbidden(c, "You can only hand over tickets from your own orders")
+	case ticketUsecase.ErrBlocked:
+		response.Forbidden(c, "You cannot hand a ticket to this user")
+	case ticketUsecase.ErrTicketAssigned:
+		response.Conflict(c, "Ticket is already assigned", err.Error())
+	case ticketUsecase.ErrRecipientHasTicket:
+		response.Conflict(c, "Recipient already has a ticket for this event", err.Error())
+	case ticketUsecase.ErrTicketNotActive:
+		response.BadRequest(c, "Ticket must be paid before it can be handed over", err.Error())
+	case ticketUsecase.ErrEventStarted:
+		response.BadRequest(c, "Event has already started", err.Error())
+	default:
+		response.InternalError(c, "Failed to assign ticket", err.Error())
+	}
+}
+
+// TransferTicket godoc
+// @Summary Transfer ticket to another user
+// @Description Offer your ticket to another user. The ticket moves once the recipient accepts, before the event starts, and gets a new attendance code and QR.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Ticket ID" format(uuid)
+// @Param request body ticket.TransferTicketRequest true "Recipient"
+// @Success 201 {object} response.Response{data=ticket.Transfer}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tickets/{id}/transfer [post]
+func (h *TicketHandler) TransferTicket(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse ticket ID from path
+	ticketID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid ticket ID", err.Error())
+		return
+	}
+
+	var req ticket.TransferTicketRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	transfer, err := h.ticketUsecase.TransferTicket(c.Request.Context(), ticketID, userID, &req)
+	if err != nil {
+		h.handleTransferError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Ticket transfer created successfully", transfer)
+}
+
+// GetIncomingTransfers godoc
+// @Summary Get incoming ticket transfers
+// @Description Get ticket transfers waiting for the current user to accept or decline
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]ticket.TransferWithDetails}
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tickets/transfers [get]
+func (h *TicketHandler) GetIncomingTransfers(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	transfers, err := h.ticketUsecase.GetIncomingTransfers(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalError(c, "Failed to get ticket transfers", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket transfers retrieved successfully", transfers)
+}
+
+// AcceptTransfer godoc
+// @Summary Accept ticket transfer
+// @Description Accept a ticket transfer. The ticket is moved to you with a new attendance code; the sender's code stops working.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transfer ID" format(uuid)
+// @Success 200 {object} response.Response{data=ticket.TicketWithDetails}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tickets/transfers/{id}/accept [post]
+func (h *TicketHandler) AcceptTransfer(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse transfer ID from path
+	transferID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid transfer ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	t, err := h.ticketUsecase.AcceptTransfer(c.Request.Context(), transferID, userID)
+	if err != nil {
+		h.handleTransferError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket transfer accepted successfully", t)
+}
+
+// DeclineTransfer godoc
+// @Summary Decline ticket transfer
+// @Description Decline a ticket transfer. The ticket stays with the sender.
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transfer ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tickets/transfers/{id}/decline [post]
+func (h *TicketHandler) DeclineTransfer(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse transfer ID from path
+	transferID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid transfer ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.ticketUsecase.DeclineTransfer(c.Request.Context(), transferID, userID); err != nil {
+		h.handleTransferError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket transfer declined successfully", nil)
+}
+
+// CancelTransfer godoc
+// @Summary Cancel ticket transfer
+// @Description Withdraw a ticket transfer that the recipient has not accepted yet
+// @Tags tickets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transfer ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tickets/transfers/{id} [delete]
+func (h *TicketHandler) CancelTransfer(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse transfer ID from path
+	transferID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid transfer ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.ticketUsecase.CancelTransfer(c.Request.Context(), transferID, userID); err != nil {
+		h.handleTransferError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Ticket transfer cancelled successfully", nil)
+}
+
+// handleTransferError maps ticket transfer errors to HTTP responses
+func (h *TicketHandler) handleTransferError(c *gin.Context, err error) {
+	switch err {
+	case ticketUsecase.ErrTicketNotFound:
+		response.NotFound(c, "Ticket not found")
+	case ticketUsecase.ErrTransferNotFound:
+		response.NotFound(c, "Ticket transfer not found")
+	case ticketUsecase.ErrEventNotFound:
+		response.NotFound(c, "Event not found")
+	case ticketUsecase.ErrRecipientNotFound:
+		response.NotFound(c, "Recipient not found")
+	case ticketUsecase.ErrUnauthorized:
+		response.Forbidden(c, "You are not part of this ticket transfer")
+	case ticketUsecase.ErrTransfersDisabled:
+		response.Forbidden(c, "The host has disabled ticket transfers for this event")
+	case ticketUsecase.ErrBlocked:
+		response.Forbidden(c, "You cannot transfer a ticket to this user")
+	case ticketUsecase.ErrTransferPending:
+		response.Conflict(c, "Ticket already has a pending transfer", err.Error())
+	case ticketUsecase.ErrTransferResolved:
+		response.Conflict(c, "Ticket transfer is no longer pending", err.Error())
+	case ticketUsecase.ErrRecipientHasTicket:
+		response.Conflict(c, "Recipient already has a ticket for this event", err.Error())
+	case ticketUsecase.ErrTicketNotTransferable:
+		response.BadRequest(c, "Only active, unused tickets can be transferred", err.Error())
+	case ticketUsecase.ErrCannotTransferToSelf:
+		response.BadRequest(c, "Cannot transfer a ticket to yourself", err.Error())
+	case ticketUsecase.ErrEventStarted:
+		response.BadRequest(c, "Event has already started", err.Error())
+	default:
+		response.InternalError(c, "Failed to process ticket transfer", err.Error())
+	}
+}
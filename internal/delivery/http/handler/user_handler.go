@@ -125,7 +125,7 @@ func (h *UserHandler) UpdateMe(c *gin.Context) {
 
 // GetUserByID godoc
 // @Summary Get user by ID
-// @Description Get a user's profile by their ID
+// @Description Get a user's profile by their ID. Fields hidden by the user's privacy settings are omitted.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -146,7 +146,7 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 	}
 
 	// Call usecase
-	profile, err := h.userUsecase.GetProfile(c.Request.Context(), userID)
+	profile, err := h.userUsecase.GetProfileForViewer(c.Request.Context(), userID, viewerID(c))
 	if err != nil {
 		if err == userUsecase.ErrUserNotFound {
 			response.NotFound(c, "User not found")
@@ -156,28 +156,6 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 		return
 	}
 
-	// Get current user ID from context (if authenticated)
-	currentUserIDStr, exists := middleware.GetUserID(c)
-	println("[DEBUG] GetUserByID - currentUserIDStr:", currentUserIDStr, "exists:", exists)
-	if exists {
-		currentUserID, err := uuid.Parse(currentUserIDStr)
-		println("[DEBUG] GetUserByID - currentUserID:", currentUserID.String(), "targetUserID:", userID.String(), "parseErr:", err)
-		if err == nil && currentUserID != userID {
-			// Check if current user is following this user
-			isFollowing, err := h.userUsecase.IsFollowing(c.Request.Context(), currentUserID, userID)
-			println("[DEBUG] GetUserByID - isFollowing:", isFollowing, "err:", err)
-			if err == nil {
-				profile.IsFollowing = &isFollowing
-				println("[DEBUG] GetUserByID - profile.IsFollowing set to:", *profile.IsFollowing)
-			}
-		} else if currentUserID == userID {
-			println("[DEBUG] GetUserByID - viewing own profile, leaving IsFollowing as nil")
-		}
-		// If viewing own profile or error checking, leave IsFollowing as nil
-	} else {
-		println("[DEBUG] GetUserByID - no current user in context")
-	}
-
 	response.Success(c, http.StatusOK, "User profile retrieved successfully", profile)
 }
 
@@ -193,6 +171,7 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 // @Param offset query int false "Offset" default(0)
 // @Success 200 {object} response.Response{data=[]user.User}
 // @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /users/{id}/followers [get]
@@ -217,12 +196,16 @@ func (h *UserHandler) GetFollowers(c *gin.Context) {
 	}
 
 	// Get followers
-	followers, err := h.userUsecase.GetFollowers(c.Request.Context(), userID, limit, offset)
+	followers, err := h.userUsecase.GetFollowers(c.Request.Context(), userID, viewerID(c), limit, offset)
 	if err != nil {
 		if err == userUsecase.ErrUserNotFound {
 			response.NotFound(c, "User not found")
 			return
 		}
+		if err == userUsecase.ErrProfilePrivate {
+			response.Forbidden(c, "This profile is private")
+			return
+		}
 		response.InternalError(c, "Failed to get followers", err.Error())
 		return
 	}
@@ -244,6 +227,7 @@ func (h *UserHandler) GetFollowers(c *gin.Context) {
 // @Param offset query int false "Offset" default(0)
 // @Success 200 {object} response.Response{data=[]user.User}
 // @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /users/{id}/following [get]
@@ -268,12 +252,16 @@ func (h *UserHandler) GetFollowing(c *gin.Context) {
 	}
 
 	// Get following
-	following, err := h.userUsecase.GetFollowing(c.Request.Context(), userID, limit, offset)
+	following, err := h.userUsecase.GetFollowing(c.Request.Context(), userID, viewerID(c), limit, offset)
 	if err != nil {
 		if err == userUsecase.ErrUserNotFound {
 			response.NotFound(c, "User not found")
 			return
 		}
+		if err == userUsecase.ErrProfilePrivate {
+			response.Forbidden(c, "This profile is private")
+			return
+		}
 		response.InternalError(c, "Failed to get following", err.Error())
 		return
 	}
@@ -285,15 +273,16 @@ func (h *UserHandler) GetFollowing(c *gin.Context) {
 
 // FollowUser godoc
 // @Summary Follow a user
-// @Description Follow another user
+// @Description Follow another user. Users who do not allow open following receive a follow request instead (status "requested").
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "User ID to follow" format(uuid)
-// @Success 200 {object} response.Response
+// @Success 200 {object} response.Response{data=user.FollowResult}
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 409 {object} response.Response
 // @Failure 500 {object} response.Response
@@ -321,7 +310,8 @@ func (h *UserHandler) FollowUser(c *gin.Context) {
 	}
 
 	// Call usecase
-	if err := h.userUsecase.Follow(c.Request.Context(), followerID, followingID); err != nil {
+	result, err := h.userUsecase.Follow(c.Request.Context(), followerID, followingID)
+	if err != nil {
 		if err == userUsecase.ErrCannotFollowSelf {
 			response.BadRequest(c, "Cannot follow yourself", err.Error())
 			return
@@ -330,6 +320,14 @@ func (h *UserHandler) FollowUser(c *gin.Context) {
 			response.Conflict(c, "Already following this user", err.Error())
 			return
 		}
+		if err == userUsecase.ErrFollowRequested {
+			response.Conflict(c, "Follow request already sent", err.Error())
+			return
+		}
+		if err == userUsecase.ErrBlocked {
+			response.Forbidden(c, "You cannot follow this user")
+			return
+		}
 		if err == userUsecase.ErrUserNotFound {
 			response.NotFound(c, "User not found")
 			return
@@ -338,12 +336,16 @@ func (h *UserHandler) FollowUser(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "User followed successfully", nil)
+	if result.Status == user.FollowStatusRequested {
+		response.Success(c, http.StatusOK, "Follow request sent successfully", result)
+		return
+	}
+	response.Success(c, http.StatusOK, "User followed successfully", result)
 }
 
 // UnfollowUser godoc
 // @Summary Unfollow a user
-// @Description Unfollow a user you are currently following
+// @Description Unfollow a user you are currently following, or withdraw a pending follow request
 // @Tags users
 // @Accept json
 // @Produce json
@@ -510,14 +512,14 @@ func (h *UserHandler) SearchUsers(c *gin.Context) {
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
 	// Get total count for pagination
-	total, err := h.userUsecase.CountSearchResults(c.Request.Context(), query)
+	total, err := h.userUsecase.CountSearchResults(c.Request.Context(), query, viewerID(c))
 	if err != nil {
 		// If count fails, default to 0 but continue
 		total = 0
 	}
 
 	// Search users
-	users, err := h.userUsecase.SearchUsers(c.Request.Context(), query, limit, offset)
+	users, err := h.userUsecase.SearchUsers(c.Request.Context(), query, viewerID(c), limit, offset)
 	if err != nil {
 		response.InternalError(c, "Failed to search users", err.Error())
 		return
@@ -532,3 +534,392 @@ func (h *UserHandler) SearchUsers(c *gin.Context) {
 	meta := response.NewPaginationMeta(total, limit, offset, len(users))
 	response.Paginated(c, http.StatusOK, "Users found successfully", users, meta)
 }
+
+// UpdatePrivacy godoc
+// @Summary Update privacy settings
+// @Description Update privacy settings for the current user. Allowing followers again approves all pending follow requests.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body user.UpdatePrivacyRequest true "Privacy update data"
+// @Success 200 {object} response.Response{data=user.UserPrivacy}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/privacy [put]
+func (h *UserHandler) UpdatePrivacy(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req user.UpdatePrivacyRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	privacy, err := h.userUsecase.UpdatePrivacy(c.Request.Context(), userID, &req)
+	if err != nil {
+		if err == userUsecase.ErrUserNotFound {
+			response.NotFound(c, "User not found")
+			return
+		}
+		response.InternalError(c, "Failed to update privacy settings", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Privacy settings updated successfully", privacy)
+}
+
+// GetFollowRequests godoc
+// @Summary Get follow requests
+// @Description Get pending follow requests sent to the current user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} response.Response{data=[]user.FollowRequestWithUser}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/follow-requests [get]
+func (h *UserHandler) GetFollowRequests(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	// Get total count for pagination
+	total, err := h.userUsecase.CountFollowRequests(c.Request.Context(), userID)
+	if err != nil {
+		// If count fails, default to 0 but continue
+		total = 0
+	}
+
+	// Get follow requests
+	requests, err := h.userUsecase.GetFollowRequests(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		response.InternalError(c, "Failed to get follow requests", err.Error())
+		return
+	}
+
+	// Create pagination metadata with correct total
+	meta := response.NewPaginationMeta(total, limit, offset, len(requests))
+	response.Paginated(c, http.StatusOK, "Follow requests retrieved successfully", requests, meta)
+}
+
+// ApproveFollowRequest godoc
+// @Summary Approve follow request
+// @Description Accept a pending follow request; the requester starts following the current user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requester user ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/follow-requests/{id}/approve [post]
+func (h *UserHandler) ApproveFollowRequest(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse requester ID from path
+	requesterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.userUsecase.ApproveFollowRequest(c.Request.Context(), userID, requesterID); err != nil {
+		if err == userUsecase.ErrRequestNotFound {
+			response.NotFound(c, "Follow request not found")
+			return
+		}
+		response.InternalError(c, "Failed to approve follow request", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Follow request approved successfully", nil)
+}
+
+// DeclineFollowRequest godoc
+// @Summary Decline follow request
+// @Description Reject a pending follow request
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requester user ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/follow-requests/{id} [delete]
+func (h *UserHandler) DeclineFollowRequest(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse requester ID from path
+	requesterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.userUsecase.DeclineFollowRequest(c.Request.Context(), userID, requesterID); err != nil {
+		if err == userUsecase.ErrRequestNotFound {
+			response.NotFound(c, "Follow request not found")
+			return
+		}
+		response.InternalError(c, "Failed to decline follow request", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Follow request declined successfully", nil)
+}
+
+// ChangeUsername godoc
+// @Summary Change username
+// @Description Change the current user's username: 3 to 30 letters, digits or underscores, unique regardless of case. Links to the previous username redirect to the new one, and nobody else can take it for 30 days. Usernames can be changed once every 7 days; changing only the casing is always allowed.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body user.UpdateUsernameRequest true "New username"
+// @Success 200 {object} response.Response{data=user.User}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/username [put]
+func (h *UserHandler) ChangeUsername(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req user.UpdateUsernameRequest
+
+	// Parse request body
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Call usecase
+	updatedUser, err := h.userUsecase.ChangeUsername(c.Request.Context(), userID, req.Username)
+	if err != nil {
+		switch err {
+		case userUsecase.ErrUserNotFound:
+			response.NotFound(c, "User not found")
+		case user.ErrUsernameInvalid, user.ErrUsernameReserved:
+			response.BadRequest(c, "Invalid username", err.Error())
+		case user.ErrUsernameTaken:
+			response.Conflict(c, "Username is already taken", err.Error())
+		case user.ErrUsernameCooldown:
+			response.Error(c, http.StatusTooManyRequests, "Username was changed too recently", "USERNAME_CHANGE_COOLDOWN", err.Error())
+		default:
+			response.InternalError(c, "Failed to change username", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Username changed successfully", updatedUser)
+}
+
+// CheckUsername godoc
+// @Summary Check username availability
+// @Description Check whether the current user can take a username. Available alternatives are suggested when it is taken.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param username query string true "Username to check"
+// @Success 200 {object} response.Response{data=user.UsernameAvailability}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/username/check [get]
+func (h *UserHandler) CheckUsername(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	username := c.Query("username")
+	if username == "" {
+		response.BadRequest(c, "Username is required", "")
+		return
+	}
+
+	// Call usecase
+	availability, err := h.userUsecase.CheckUsername(c.Request.Context(), userID, username)
+	if err != nil {
+		response.InternalError(c, "Failed to check username", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Username checked successfully", availability)
+}
+
+// GetUsernameSuggestions godoc
+// @Summary Get username suggestions
+// @Description Get available usernames based on the current user's name
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]string}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/username/suggestions [get]
+func (h *UserHandler) GetUsernameSuggestions(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	suggestions, err := h.userUsecase.SuggestUsernames(c.Request.Context(), userID)
+	if err != nil {
+		if err == userUsecase.ErrUserNotFound {
+			response.NotFound(c, "User not found")
+			return
+		}
+		response.InternalError(c, "Failed to suggest usernames", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Username suggestions retrieved successfully", suggestions)
+}
+
+// GetUsernameHistory godoc
+// @Summary Get username history
+// @Description Get the usernames the current user gave up, most recent first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]user.UsernameChange}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/me/username/history [get]
+func (h *UserHandler) GetUsernameHistory(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	history, err := h.userUsecase.GetUsernameHistory(c.Request.Context(), userID)
+	if err != nil {
+		response.InternalError(c, "Failed to get username history", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Username history retrieved successfully", history)
+}
+
+// viewerID returns the authenticated user's ID, or uuid.Nil for anonymous requests
+func viewerID(c *gin.Context) uuid.UUID {
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		return uuid.Nil
+	}
+	id, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
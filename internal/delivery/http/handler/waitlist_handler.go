@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/anigmaa/backend/internal/delivery/http/middleware"
+	waitlistUsecase "github.com/anigmaa/backend/internal/usecase/waitlist"
+	"github.com/anigmaa/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WaitlistHandler handles event waitlist HTTP requests
+type WaitlistHandler struct {
+	waitlistUsecase *waitlistUsecase.Usecase
+}
+
+// NewWaitlistHandler creates a new waitlist handler
+func NewWaitlistHandler(waitlistUsecase *waitlistUsecase.Usecase) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistUsecase: waitlistUsecase,
+	}
+}
+
+// JoinWaitlist godoc
+// @Summary Join event waitlist
+// @Description Join the waitlist of a full event. When a seat frees up the next user in line receives a time-limited offer to claim it via ticket purchase or join.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Success 201 {object} response.Response{data=waitlist.WaitlistStatus}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/waitlist [post]
+func (h *WaitlistHandler) JoinWaitlist(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	status, err := h.waitlistUsecase.JoinWaitlist(c.Request.Context(), eventID, userID)
+	if err != nil {
+		switch err {
+		case waitlistUsecase.ErrEventNotFound:
+			response.NotFound(c, "Event not found")
+		case waitlistUsecase.ErrEventStarted:
+			response.BadRequest(c, "Event has already started", err.Error())
+		case waitlistUsecase.ErrHostCannotJoin:
+			response.BadRequest(c, "Host cannot join their own event waitlist", err.Error())
+		case waitlistUsecase.ErrSeatsAvailable:
+			response.Conflict(c, "Event still has seats available", err.Error())
+		case waitlistUsecase.ErrAlreadyAttending:
+			response.Conflict(c, "Already attending this event", err.Error())
+		case waitlistUsecase.ErrAlreadyOnWaitlist:
+			response.Conflict(c, "Already on the waitlist for this event", err.Error())
+		default:
+			response.InternalError(c, "Failed to join waitlist", err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Joined waitlist successfully", status)
+}
+
+// LeaveWaitlist godoc
+// @Summary Leave event waitlist
+// @Description Leave an event waitlist. An unclaimed seat offer is passed to the next user in line.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/waitlist [delete]
+func (h *WaitlistHandler) LeaveWaitlist(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	if err := h.waitlistUsecase.LeaveWaitlist(c.Request.Context(), eventID, userID); err != nil {
+		if err == waitlistUsecase.ErrNotOnWaitlist {
+			response.NotFound(c, "Not on the waitlist for this event")
+			return
+		}
+		response.InternalError(c, "Failed to leave waitlist", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Left waitlist successfully", nil)
+}
+
+// GetWaitlistStatus godoc
+// @Summary Get my waitlist status
+// @Description Get the current user's waitlist position and any pending seat offer for an event
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID" format(uuid)
+// @Success 200 {object} response.Response{data=waitlist.WaitlistStatus}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /events/{id}/waitlist [get]
+func (h *WaitlistHandler) GetWaitlistStatus(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := middleware.GetUserID(c)
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	// Parse event ID from path
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Invalid event ID", err.Error())
+		return
+	}
+
+	// Call usecase
+	status, err := h.waitlistUsecase.GetWaitlistStatus(c.Request.Context(), eventID, userID)
+	if err != nil {
+		if err == waitlistUsecase.ErrNotOnWaitlist {
+			response.NotFound(c, "Not on the waitlist for this event")
+			return
+		}
+		response.InternalError(c, "Failed to get waitlist status", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Waitlist status retrieved successfully", status)
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"strings"
+	"time"
 
 	"github.com/anigmaa/backend/internal/domain/user"
 	"github.com/anigmaa/backend/pkg/jwt"
@@ -11,7 +12,8 @@ import (
 )
 
 // JWTAuth middleware validates JWT token
-func JWTAuth(jwtManager *jwt.JWTManager) gin.HandlerFunc {
+// Access tokens outlive a suspension or deletion, so the account is checked on every request
+func JWTAuth(jwtManager *jwt.JWTManager, userRepo user.Repository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -38,14 +40,60 @@ func JWTAuth(jwtManager *jwt.JWTManager) gin.HandlerFunc {
 			return
 		}
 
+		// Check the account is still active
+		currentUser, err := userRepo.GetByID(c.Request.Context(), claims.UserID)
+		if err != nil {
+			response.Unauthorized(c, "User not found")
+			c.Abort()
+			return
+		}
+		if currentUser.IsSuspended(time.Now()) {
+			response.Forbidden(c, "Account is suspended")
+			c.Abort()
+			return
+		}
+
 		// Set user ID in context (convert UUID to string)
 		c.Set("user_id", claims.UserID.String())
 		c.Set("email", claims.Email)
+		// Role and permissions come from the database, so role changes apply on the next request
+		c.Set("role", string(currentUser.Role))
+		c.Set("permissions", currentUser.PermissionNames())
 
 		c.Next()
 	}
 }
 
+// OptionalJWTAuth sets the user in context when a valid token is sent and lets the request through either way
+// Used on public routes that behave differently for logged-in users
+// Suspended and deleted accounts are treated as anonymous
+func OptionalJWTAuth(jwtManager *jwt.JWTManager, userRepo user.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := jwtManager.Verify(parts[1]); err == nil {
+				if currentUser, ok := activeUser(c, userRepo, claims.UserID); ok {
+					c.Set("user_id", claims.UserID.String())
+					c.Set("email", claims.Email)
+					c.Set("role", string(currentUser.Role))
+					c.Set("permissions", currentUser.PermissionNames())
+				}
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// activeUser gets the user from the database, reporting false when they are deleted or suspended
+func activeUser(c *gin.Context, userRepo user.Repository, userID uuid.UUID) (*user.User, bool) {
+	currentUser, err := userRepo.GetByID(c.Request.Context(), userID)
+	if err != nil || currentUser.IsSuspended(time.Now()) {
+		return nil, false
+	}
+	return currentUser, true
+}
+
 // GetUserID gets the user ID from context
 func GetUserID(c *gin.Context) (string, bool) {
 	userID, exists := c.Get("user_id")
@@ -64,6 +112,29 @@ func GetEmail(c *gin.Context) (string, bool) {
 	return email.(string), true
 }
 
+// GetRole gets the platform role from context
+func GetRole(c *gin.Context) (string, bool) {
+	role, exists := c.Get("role")
+	if !exists {
+		return "", false
+	}
+	return role.(string), true
+}
+
+// HasPermission reports whether the user's current role grants the permission
+func HasPermission(c *gin.Context, perm user.Permission) bool {
+	perms, exists := c.Get("permissions")
+	if !exists {
+		return false
+	}
+	for _, p := range perms.([]string) {
+		if p == string(perm) {
+			return true
+		}
+	}
+	return false
+}
+
 // RequireEmailVerification middleware checks if user's email is verified
 // This should be used AFTER JWTAuth middleware
 func RequireEmailVerification(userRepo user.Repository) gin.HandlerFunc {
@@ -102,3 +173,23 @@ func RequireEmailVerification(userRepo user.Repository) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequirePermission middleware only lets users whose current role grants the permission through
+// This should be used AFTER JWTAuth middleware
+func RequirePermission(perm user.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := GetUserID(c); !exists {
+			response.Unauthorized(c, "User not authenticated")
+			c.Abort()
+			return
+		}
+
+		if !HasPermission(c, perm) {
+			response.Forbidden(c, "Missing permission: "+string(perm))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
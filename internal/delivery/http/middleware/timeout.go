@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WriteTimeout replaces the server write timeout for the routes it is applied to,
+// for downloads that take longer to send than a normal JSON response
+func WriteTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Not supported by every writer, the server timeout then stays in place
+		_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(timeout))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package account
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeletedUserName replaces the name of an anonymized account
+const DeletedUserName = "Deleted user"
+
+// Deletion describes a scheduled account deletion
+type Deletion struct {
+	ScheduledAt time.Time `json:"scheduled_at"` // The account is anonymized at this time unless cancelled
+}
+
+// ExportStatus is the state of a data export job
+type ExportStatus string
+
+const (
+	ExportPending    ExportStatus = "pending"    // Waiting for the export job
+	ExportProcessing ExportStatus = "processing" // Archive is being built
+	ExportReady      ExportStatus = "ready"      // Archive can be downloaded until it expires
+	ExportFailed     ExportStatus = "failed"     // Archive could not be built, a new export can be requested
+)
+
+// DataExport is an archive of everything the platform stores about a user
+type DataExport struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	UserID      uuid.UUID    `json:"-" db:"user_id"`
+	Status      ExportStatus `json:"status" db:"status"`
+	Error       *string      `json:"error,omitempty" db:"error"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty" db:"expires_at"`
+	DownloadURL *string      `json:"download_url,omitempty" db:"-"` // Set while the archive is ready
+}
+
+// IsActive reports whether the export is still usable, so no new one needs to be requested
+func (e *DataExport) IsActive(now time.Time) bool {
+	switch e.Status {
+	case ExportPending, ExportProcessing:
+		return true
+	case ExportReady:
+		return e.ExpiresAt == nil || e.ExpiresAt.After(now)
+	}
+	return false
+}
+
+// Section is one file of a data export, holding a JSON array of the user's rows
+type Section struct {
+	Name string
+	Data json.RawMessage
+}
@@ -0,0 +1,27 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for account deletion and data export access
+type Repository interface {
+	// Deletion
+	ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) error
+	CancelDeletion(ctx context.Context, userID uuid.UUID) error
+	GetDueDeletions(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+	Anonymize(ctx context.Context, userID uuid.UUID) error // Removes personal data and content, keeps financial records
+
+	// Data exports
+	CreateExport(ctx context.Context, export *DataExport) error
+	GetLatestExport(ctx context.Context, userID uuid.UUID) (*DataExport, error)
+	ClaimPendingExports(ctx context.Context, limit int) ([]DataExport, error) // Moves the claimed exports to processing
+	CompleteExport(ctx context.Context, exportID uuid.UUID, archive []byte, expiresAt time.Time) error
+	FailExport(ctx context.Context, exportID uuid.UUID, reason string) error
+	GetExportArchive(ctx context.Context, exportID uuid.UUID) ([]byte, error)
+	DeleteExpiredExports(ctx context.Context, now time.Time) error
+	GetUserData(ctx context.Context, userID uuid.UUID) ([]Section, error)
+}
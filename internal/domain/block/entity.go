@@ -0,0 +1,33 @@
+package block
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Block is a block of one user by another
+// A block applies in both directions: neither user sees the other's posts, comments,
+// Q&A or profile, and they cannot follow, mention, invite or transfer tickets to each other
+type Block struct {
+	BlockerID uuid.UUID `json:"blocker_id" db:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id" db:"blocked_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Mute hides a user's posts from the muter's feed and drops their notifications to the muter
+// The muted user is not told and can still interact normally
+type Mute struct {
+	MuterID   uuid.UUID `json:"muter_id" db:"muter_id"`
+	MutedID   uuid.UUID `json:"muted_id" db:"muted_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ListedUser is a user in the caller's block or mute list
+type ListedUser struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	Name       string    `json:"name" db:"name"`
+	AvatarURL  *string   `json:"avatar_url,omitempty" db:"avatar_url"`
+	IsVerified bool      `json:"is_verified" db:"is_verified"`
+	Since      time.Time `json:"since" db:"since"` // When the block or mute was created
+}
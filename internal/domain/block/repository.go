@@ -0,0 +1,26 @@
+package block
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for block and mute data access
+type Repository interface {
+	// Blocks
+	Block(ctx context.Context, b *Block) error
+	Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error
+	HasBlocked(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error)
+	IsBlocked(ctx context.Context, userID, otherID uuid.UUID) (bool, error)   // Either direction
+	GetBlockedIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) // Users blocked by or blocking userID
+	GetBlocked(ctx context.Context, blockerID uuid.UUID, limit, offset int) ([]ListedUser, error)
+	CountBlocked(ctx context.Context, blockerID uuid.UUID) (int, error)
+
+	// Mutes
+	Mute(ctx context.Context, m *Mute) error
+	Unmute(ctx context.Context, muterID, mutedID uuid.UUID) error
+	IsMuted(ctx context.Context, muterID, mutedID uuid.UUID) (bool, error)
+	GetMuted(ctx context.Context, muterID uuid.UUID, limit, offset int) ([]ListedUser, error)
+	CountMuted(ctx context.Context, muterID uuid.UUID) (int, error)
+}
@@ -0,0 +1,22 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedToken is the secret that identifies a user's calendar subscription feed
+// Anyone holding it can read the feed, so it is only shown to its owner and can be rotated
+type FeedToken struct {
+	UserID    uuid.UUID `json:"-" db:"user_id"`
+	Token     string    `json:"-" db:"token"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Feed represents the subscription URLs of a user's calendar feed
+type Feed struct {
+	URL       string    `json:"url"`        // https URL, for "subscribe by URL"
+	WebcalURL string    `json:"webcal_url"` // webcal URL, opens the calendar app directly
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for calendar feed token data access
+type Repository interface {
+	GetFeedToken(ctx context.Context, userID uuid.UUID) (*FeedToken, error)
+	GetFeedTokenByValue(ctx context.Context, token string) (*FeedToken, error)
+	SaveFeedToken(ctx context.Context, token *FeedToken) error
+}
@@ -12,12 +12,30 @@ type Comment struct {
 	PostID          uuid.UUID  `json:"post_id" db:"post_id"`
 	AuthorID        uuid.UUID  `json:"author_id" db:"author_id"`
 	ParentCommentID *uuid.UUID `json:"parent_comment_id,omitempty" db:"parent_comment_id"`
+	Depth           int        `json:"depth" db:"depth"` // 0 for top-level comments
 	Content         string     `json:"content" db:"content"`
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	Edited          bool       `json:"edited" db:"edited"`
+	EditedAt        *time.Time `json:"edited_at,omitempty" db:"edited_at"` // Last content edit
+	DeletedAt       *time.Time `json:"-" db:"deleted_at"`                  // Set on tombstones
 	LikesCount      int        `json:"likes_count" db:"likes_count"`
 }
 
+// IsTombstone reports whether the comment was deleted but kept for its replies
+func (c *Comment) IsTombstone() bool {
+	return c.DeletedAt != nil
+}
+
+// Revision is the content of a comment before one of its edits
+type Revision struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	CommentID  uuid.UUID `json:"comment_id" db:"comment_id"`
+	Content    string    `json:"content" db:"content"`
+	WrittenAt  time.Time `json:"written_at" db:"written_at"`   // When this content was posted or last edited
+	ReplacedAt time.Time `json:"replaced_at" db:"replaced_at"` // When the edit replaced it
+}
+
 // CommentWithDetails includes additional comment information
 type CommentWithDetails struct {
 	Comment
@@ -26,9 +44,25 @@ type CommentWithDetails struct {
 	AuthorIsVerified bool                 `json:"author_is_verified" db:"author_is_verified"`
 	IsLikedByUser    bool                 `json:"is_liked_by_user" db:"is_liked_by_user"`
 	RepliesCount     int                  `json:"replies_count" db:"replies_count"`
+	IsDeleted        bool                 `json:"is_deleted" db:"-"` // Tombstone: content and author are hidden
 	Replies          []CommentWithDetails `json:"replies,omitempty" db:"-"`
 }
 
+// Redact hides the content and author of a tombstone
+func (c *CommentWithDetails) Redact() {
+	if !c.IsTombstone() {
+		return
+	}
+	c.IsDeleted = true
+	c.AuthorID = uuid.Nil
+	c.Content = ""
+	c.AuthorName = ""
+	c.AuthorAvatarURL = nil
+	c.AuthorIsVerified = false
+	c.Edited = false
+	c.EditedAt = nil
+}
+
 // CreateCommentRequest represents comment creation data
 type CreateCommentRequest struct {
 	PostID          uuid.UUID  `json:"post_id" binding:"required"`
@@ -14,11 +14,17 @@ type Repository interface {
 	GetWithDetails(ctx context.Context, commentID, userID uuid.UUID) (*CommentWithDetails, error)
 	Update(ctx context.Context, comment *Comment) error
 	Delete(ctx context.Context, commentID uuid.UUID) error
+	Tombstone(ctx context.Context, commentID uuid.UUID) error
 
 	// Comment queries
-	GetByPost(ctx context.Context, postID, userID uuid.UUID, limit, offset int) ([]CommentWithDetails, error)
-	GetReplies(ctx context.Context, parentCommentID, userID uuid.UUID, limit, offset int) ([]CommentWithDetails, error)
+	GetByPost(ctx context.Context, postID, userID uuid.UUID, sort Sort, limit, offset int) ([]CommentWithDetails, error)
+	GetReplies(ctx context.Context, parentCommentID, userID uuid.UUID, after *Cursor, limit int) ([]CommentWithDetails, error)
 	GetCount(ctx context.Context, postID uuid.UUID) (int, error)
+	HasReplies(ctx context.Context, commentID uuid.UUID) (bool, error)
+
+	// Edit history
+	Edit(ctx context.Context, comment *Comment, revision *Revision) error
+	GetRevisions(ctx context.Context, commentID uuid.UUID) ([]Revision, error)
 
 	// Engagement
 	IncrementLikes(ctx context.Context, commentID uuid.UUID) error
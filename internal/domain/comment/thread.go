@@ -0,0 +1,69 @@
+package comment
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxDepth is the deepest a reply can be nested; top-level comments are at depth 0
+const MaxDepth = 3
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Sort is the order top-level comments are listed in
+type Sort string
+
+const (
+	SortTop    Sort = "top"    // Most liked first
+	SortNewest Sort = "newest" // Most recent first
+	SortOldest Sort = "oldest" // Oldest first
+)
+
+// IsValid reports whether the sort is one of the supported orders
+func (s Sort) IsValid() bool {
+	return s == SortTop || s == SortNewest || s == SortOldest
+}
+
+// Cursor points at the last reply of a page; the next page starts after it
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// NewCursor creates a cursor after a comment
+func NewCursor(c *Comment) *Cursor {
+	return &Cursor{CreatedAt: c.CreatedAt, ID: c.ID}
+}
+
+// Encode turns the cursor into an opaque string for clients
+func (c *Cursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor returned by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &Cursor{}
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.ID, err = uuid.Parse(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
@@ -77,6 +77,30 @@ type UpdateCommunityRequest struct {
 	Privacy     *Privacy `json:"privacy,omitempty"`
 }
 
+// ApplyUpdate copies the fields set in the request onto the community
+func (c *Community) ApplyUpdate(req *UpdateCommunityRequest) {
+	if req.Name != nil {
+		c.Name = *req.Name
+	}
+	if req.Description != nil {
+		c.Description = req.Description
+	}
+	if req.AvatarURL != nil {
+		c.AvatarURL = req.AvatarURL
+	}
+	if req.CoverURL != nil {
+		c.CoverURL = req.CoverURL
+	}
+	if req.Privacy != nil {
+		c.Privacy = *req.Privacy
+	}
+}
+
+// TransferCommunityRequest represents an admin handing a community to another owner
+type TransferCommunityRequest struct {
+	NewOwnerID uuid.UUID `json:"new_owner_id" binding:"required"`
+}
+
 // CommunityFilter represents community filtering options
 type CommunityFilter struct {
 	Search  *string  `form:"search"`
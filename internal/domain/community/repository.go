@@ -26,6 +26,8 @@ type Repository interface {
 	GetUserCommunities(ctx context.Context, userID uuid.UUID, limit, offset int) ([]CommunityWithDetails, error)
 	IsMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error)
 	GetMemberRole(ctx context.Context, communityID, userID uuid.UUID) (*Role, error)
+	TransferOwnership(ctx context.Context, communityID, newOwnerID uuid.UUID) error // The previous owner stays on as an admin
+	GetSuccessor(ctx context.Context, communityID uuid.UUID) (uuid.UUID, error)     // sql.ErrNoRows when no member can take over
 
 	// Community details
 	GetWithDetails(ctx context.Context, communityID, userID uuid.UUID) (*CommunityWithDetails, error)
@@ -46,39 +46,46 @@ const (
 
 // Event represents a hangout event
 type Event struct {
-	ID               uuid.UUID     `json:"id" db:"id"`
-	HostID           uuid.UUID     `json:"host_id" db:"host_id"`
-	Title            string        `json:"title" db:"title"`
-	Description      string        `json:"description" db:"description"`
-	Category         EventCategory `json:"category" db:"category"`
-	StartTime        time.Time     `json:"start_time" db:"start_time"`
-	EndTime          time.Time     `json:"end_time" db:"end_time"`
-	LocationName     string        `json:"location_name" db:"location_name"`
-	LocationAddress  string        `json:"location_address" db:"location_address"`
-	LocationLat      float64       `json:"location_lat" db:"location_lat"`
-	LocationLng      float64       `json:"location_lng" db:"location_lng"`
-	MaxAttendees     int           `json:"max_attendees" db:"max_attendees"`
-	Price            *float64      `json:"price,omitempty" db:"price"`
-	IsFree           bool          `json:"is_free" db:"is_free"`
-	Status           EventStatus   `json:"status" db:"status"`
-	Privacy          EventPrivacy  `json:"privacy" db:"privacy"`
-	Requirements     *string       `json:"requirements,omitempty" db:"requirements"`
-	TicketingEnabled bool          `json:"ticketing_enabled" db:"ticketing_enabled"`
-	TicketsSold      int           `json:"tickets_sold" db:"tickets_sold"`
-	CreatedAt        time.Time     `json:"created_at" db:"created_at"`
-	UpdatedAt        time.Time     `json:"updated_at" db:"updated_at"`
+	ID                   uuid.UUID     `json:"id" db:"id"`
+	HostID               uuid.UUID     `json:"host_id" db:"host_id"`
+	Title                string        `json:"title" db:"title"`
+	Description          string        `json:"description" db:"description"`
+	Category             EventCategory `json:"category" db:"category"`
+	StartTime            time.Time     `json:"start_time" db:"start_time"`
+	EndTime              time.Time     `json:"end_time" db:"end_time"`
+	LocationName         string        `json:"location_name" db:"location_name"`
+	LocationAddress      string        `json:"location_address" db:"location_address"`
+	LocationLat          float64       `json:"location_lat" db:"location_lat"`
+	LocationLng          float64       `json:"location_lng" db:"location_lng"`
+	MaxAttendees         int           `json:"max_attendees" db:"max_attendees"`
+	Price                *float64      `json:"price,omitempty" db:"price"`
+	IsFree               bool          `json:"is_free" db:"is_free"`
+	Status               EventStatus   `json:"status" db:"status"`
+	Privacy              EventPrivacy  `json:"privacy" db:"privacy"`
+	Requirements         *string       `json:"requirements,omitempty" db:"requirements"`
+	TicketingEnabled     bool          `json:"ticketing_enabled" db:"ticketing_enabled"`
+	AllowTicketTransfers bool          `json:"allow_ticket_transfers" db:"allow_ticket_transfers"`
+	TicketsSold          int           `json:"tickets_sold" db:"tickets_sold"`
+	SeriesID             *uuid.UUID    `json:"series_id,omitempty" db:"series_id"`
+	OccurrenceStart      *time.Time    `json:"occurrence_start,omitempty" db:"occurrence_start"` // start given by the series rule, fixed when the occurrence is moved
+	Sequence             int           `json:"-" db:"sequence"`                                  // iCalendar revision, bumped by the database on time, place or status changes
+	IsFeatured           bool          `json:"is_featured" db:"is_featured"`
+	UnpublishedAt        *time.Time    `json:"unpublished_at,omitempty" db:"unpublished_at"` // set while an admin keeps the event out of discovery
+	CreatedAt            time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at" db:"updated_at"`
 }
 
 // EventWithDetails includes additional event information
 type EventWithDetails struct {
 	Event
-	HostName        string   `json:"host_name" db:"host_name"`
-	HostAvatarURL   *string  `json:"host_avatar_url" db:"host_avatar_url"`
-	ImageURLs       []string `json:"image_urls" db:"-"`
-	AttendeesCount  int      `json:"attendees_count" db:"attendees_count"`
-	IsUserAttending bool     `json:"is_user_attending" db:"is_user_attending"`
-	IsUserHost      bool     `json:"is_user_host" db:"is_user_host"`
-	Distance        *float64 `json:"distance,omitempty" db:"distance"` // Distance in km from user
+	HostName        string        `json:"host_name" db:"host_name"`
+	HostAvatarURL   *string       `json:"host_avatar_url" db:"host_avatar_url"`
+	ImageURLs       []string      `json:"image_urls" db:"-"`
+	AttendeesCount  int           `json:"attendees_count" db:"attendees_count"`
+	IsUserAttending bool          `json:"is_user_attending" db:"is_user_attending"`
+	IsUserHost      bool          `json:"is_user_host" db:"is_user_host"`
+	Distance        *float64      `json:"distance,omitempty" db:"distance"` // Distance in km from user
+	CoHosts         []StaffMember `json:"co_hosts,omitempty" db:"-"`
 }
 
 // EventAttendee represents an event attendee
@@ -99,6 +106,42 @@ const (
 	AttendeeCancelled AttendeeStatus = "cancelled"
 )
 
+// StaffRole represents the role of a user helping the host run an event
+type StaffRole string
+
+const (
+	RoleCoHost StaffRole = "co_host" // Holds every permission and is shown on the event
+	RoleStaff  StaffRole = "staff"   // Holds only the permissions granted by the host
+)
+
+// Permission represents an event management action the host can delegate
+type Permission string
+
+const (
+	PermissionCheckIn       Permission = "check_in"
+	PermissionModerateQnA   Permission = "moderate_qna"
+	PermissionViewAnalytics Permission = "view_analytics"
+	PermissionEditEvent     Permission = "edit_event"
+)
+
+// EventStaff represents a co-host or staff member the host delegated permissions to
+// Deleting, cancelling the event and managing staff always stay with the host
+type EventStaff struct {
+	EventID     uuid.UUID    `json:"event_id" db:"event_id"`
+	UserID      uuid.UUID    `json:"user_id" db:"user_id"`
+	Role        StaffRole    `json:"role" db:"role"`
+	Permissions []Permission `json:"permissions" db:"-"`
+	AddedBy     uuid.UUID    `json:"added_by" db:"added_by"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+}
+
+// StaffMember includes the user information of an event staff member
+type StaffMember struct {
+	EventStaff
+	Name      string  `json:"name" db:"name"`
+	AvatarURL *string `json:"avatar_url,omitempty" db:"avatar_url"`
+}
+
 // EventImage represents an event image
 type EventImage struct {
 	ID       uuid.UUID `json:"id" db:"id"`
@@ -107,43 +150,154 @@ type EventImage struct {
 	Order    int       `json:"order" db:"order_index"`
 }
 
+// Series represents a recurring event. Occurrences are generated ahead of time
+// as regular events from the recurrence rule and the template fields below.
+type Series struct {
+	ID                   uuid.UUID     `json:"id" db:"id"`
+	HostID               uuid.UUID     `json:"host_id" db:"host_id"`
+	Recurrence           string        `json:"recurrence" db:"recurrence"` // RRULE, e.g. FREQ=WEEKLY;BYDAY=TU
+	Timezone             string        `json:"timezone" db:"timezone"`
+	FirstStart           time.Time     `json:"first_start" db:"first_start"`
+	DurationMinutes      int           `json:"duration_minutes" db:"duration_minutes"`
+	Title                string        `json:"title" db:"title"`
+	Description          string        `json:"description" db:"description"`
+	Category             EventCategory `json:"category" db:"category"`
+	LocationName         string        `json:"location_name" db:"location_name"`
+	LocationAddress      string        `json:"location_address" db:"location_address"`
+	LocationLat          float64       `json:"location_lat" db:"location_lat"`
+	LocationLng          float64       `json:"location_lng" db:"location_lng"`
+	MaxAttendees         int           `json:"max_attendees" db:"max_attendees"`
+	Price                *float64      `json:"price,omitempty" db:"price"`
+	IsFree               bool          `json:"is_free" db:"is_free"`
+	Privacy              EventPrivacy  `json:"privacy" db:"privacy"`
+	Requirements         *string       `json:"requirements,omitempty" db:"requirements"`
+	TicketingEnabled     bool          `json:"ticketing_enabled" db:"ticketing_enabled"`
+	AllowTicketTransfers bool          `json:"allow_ticket_transfers" db:"allow_ticket_transfers"`
+	ImageURLs            []string      `json:"image_urls" db:"-"` // PostgreSQL text array, copied to every occurrence
+	GeneratedUntil       time.Time     `json:"-" db:"generated_until"`
+	EndedAt              *time.Time    `json:"ended_at,omitempty" db:"ended_at"`
+	CreatedAt            time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// SeriesWithDetails includes the host, follower and upcoming occurrence information of a series
+type SeriesWithDetails struct {
+	Series
+	HostName       string             `json:"host_name" db:"host_name"`
+	HostAvatarURL  *string            `json:"host_avatar_url,omitempty" db:"host_avatar_url"`
+	FollowersCount int                `json:"followers_count" db:"followers_count"`
+	IsFollowing    bool               `json:"is_following" db:"is_following"`
+	NextEventID    *uuid.UUID         `json:"next_event_id,omitempty" db:"next_event_id"`
+	NextStartTime  *time.Time         `json:"next_start_time,omitempty" db:"next_start_time"`
+	Occurrences    []EventWithDetails `json:"occurrences,omitempty" db:"-"`
+}
+
+// FunnelStage represents a page of the event that is counted in the conversion funnel
+type FunnelStage string
+
+const (
+	FunnelView       FunnelStage = "view"        // Event detail page opened
+	FunnelTicketPage FunnelStage = "ticket_page" // Ticket tiers opened
+)
+
+// PageView represents a visit to an event page
+type PageView struct {
+	ID       uuid.UUID   `json:"id" db:"id"`
+	EventID  uuid.UUID   `json:"event_id" db:"event_id"`
+	UserID   *uuid.UUID  `json:"user_id,omitempty" db:"user_id"` // nil for visitors who are not logged in
+	Stage    FunnelStage `json:"stage" db:"stage"`
+	ViewedAt time.Time   `json:"viewed_at" db:"viewed_at"`
+}
+
+// Funnel counts distinct people at each step from viewing an event to checking in
+type Funnel struct {
+	Views           int `json:"views" db:"views"`
+	TicketPageViews int `json:"ticket_page_views" db:"ticket_page_views"`
+	Purchasers      int `json:"purchasers" db:"purchasers"`
+	CheckedIn       int `json:"checked_in" db:"checked_in"`
+}
+
 // CreateEventRequest represents event creation data
 type CreateEventRequest struct {
-	Title            string        `json:"title" binding:"required,min=3,max=100"`
-	Description      string        `json:"description" binding:"required,min=10"`
-	Category         EventCategory `json:"category" binding:"required"`
-	StartTime        time.Time     `json:"start_time" binding:"required"`
-	EndTime          time.Time     `json:"end_time" binding:"required"`
-	LocationName     string        `json:"location_name" binding:"required"`
-	LocationAddress  string        `json:"location_address" binding:"required"`
-	LocationLat      float64       `json:"location_lat" binding:"required,min=-90,max=90"`
-	LocationLng      float64       `json:"location_lng" binding:"required,min=-180,max=180"`
-	MaxAttendees     int           `json:"max_attendees" binding:"required,min=3,max=100"`
-	Price            *float64      `json:"price,omitempty" binding:"omitempty,min=0"`
-	IsFree           bool          `json:"is_free"`
-	Privacy          EventPrivacy  `json:"privacy" binding:"required"`
-	Requirements     *string       `json:"requirements,omitempty"`
-	TicketingEnabled bool          `json:"ticketing_enabled"`
-	ImageURLs        []string      `json:"image_urls,omitempty"`
+	Title                string        `json:"title" binding:"required,min=3,max=100"`
+	Description          string        `json:"description" binding:"required,min=10"`
+	Category             EventCategory `json:"category" binding:"required"`
+	StartTime            time.Time     `json:"start_time" binding:"required"`
+	EndTime              time.Time     `json:"end_time" binding:"required"`
+	LocationName         string        `json:"location_name" binding:"required"`
+	LocationAddress      string        `json:"location_address" binding:"required"`
+	LocationLat          float64       `json:"location_lat" binding:"required,min=-90,max=90"`
+	LocationLng          float64       `json:"location_lng" binding:"required,min=-180,max=180"`
+	MaxAttendees         int           `json:"max_attendees" binding:"required,min=3,max=100"`
+	Price                *float64      `json:"price,omitempty" binding:"omitempty,min=0"`
+	IsFree               bool          `json:"is_free"`
+	Privacy              EventPrivacy  `json:"privacy" binding:"required"`
+	Requirements         *string       `json:"requirements,omitempty"`
+	TicketingEnabled     bool          `json:"ticketing_enabled"`
+	AllowTicketTransfers *bool         `json:"allow_ticket_transfers,omitempty"` // defaults to true
+	ImageURLs            []string      `json:"image_urls,omitempty"`
+}
+
+// CreateSeriesRequest represents recurring event creation data
+// StartTime and EndTime are those of the first occurrence
+type CreateSeriesRequest struct {
+	CreateEventRequest
+	Recurrence string `json:"recurrence" binding:"required,max=255"` // RRULE, e.g. FREQ=WEEKLY;BYDAY=TU or FREQ=MONTHLY;BYDAY=1SA
+	Timezone   string `json:"timezone,omitempty"`                    // IANA name, defaults to Asia/Jakarta
+}
+
+// UpdateSeriesRequest represents a change to an occurrence and every later occurrence of its series
+// StartTime and EndTime are the new times of the chosen occurrence; later occurrences
+// move by the same amount, on the same day
+type UpdateSeriesRequest struct {
+	Title                *string        `json:"title,omitempty" binding:"omitempty,min=3,max=100"`
+	Description          *string        `json:"description,omitempty" binding:"omitempty,min=10"`
+	Category             *EventCategory `json:"category,omitempty"`
+	StartTime            *time.Time     `json:"start_time,omitempty"`
+	EndTime              *time.Time     `json:"end_time,omitempty"`
+	LocationName         *string        `json:"location_name,omitempty"`
+	LocationAddress      *string        `json:"location_address,omitempty"`
+	LocationLat          *float64       `json:"location_lat,omitempty" binding:"omitempty,min=-90,max=90"`
+	LocationLng          *float64       `json:"location_lng,omitempty" binding:"omitempty,min=-180,max=180"`
+	MaxAttendees         *int           `json:"max_attendees,omitempty" binding:"omitempty,min=3,max=100"`
+	Price                *float64       `json:"price,omitempty" binding:"omitempty,min=0"`
+	Privacy              *EventPrivacy  `json:"privacy,omitempty"`
+	Requirements         *string        `json:"requirements,omitempty"`
+	AllowTicketTransfers *bool          `json:"allow_ticket_transfers,omitempty"`
+	ImageURLs            *[]string      `json:"image_urls,omitempty"` // If provided, replaces all images
+}
+
+// AddStaffRequest represents a request to add a co-host or staff member to an event
+type AddStaffRequest struct {
+	UserID      uuid.UUID    `json:"user_id" binding:"required"`
+	Role        StaffRole    `json:"role,omitempty" binding:"omitempty,oneof=co_host staff"` // defaults to staff
+	Permissions []Permission `json:"permissions,omitempty"`                                  // staff only, defaults to check_in
+}
+
+// UpdateStaffRequest represents a request to change the role or permissions of a staff member
+type UpdateStaffRequest struct {
+	Role        *StaffRole    `json:"role,omitempty" binding:"omitempty,oneof=co_host staff"`
+	Permissions *[]Permission `json:"permissions,omitempty"`
 }
 
 // UpdateEventRequest represents event update data
 type UpdateEventRequest struct {
-	Title           *string        `json:"title,omitempty" binding:"omitempty,min=3,max=100"`
-	Description     *string        `json:"description,omitempty" binding:"omitempty,min=10"`
-	Category        *EventCategory `json:"category,omitempty"`
-	StartTime       *time.Time     `json:"start_time,omitempty"`
-	EndTime         *time.Time     `json:"end_time,omitempty"`
-	LocationName    *string        `json:"location_name,omitempty"`
-	LocationAddress *string        `json:"location_address,omitempty"`
-	LocationLat     *float64       `json:"location_lat,omitempty" binding:"omitempty,min=-90,max=90"`
-	LocationLng     *float64       `json:"location_lng,omitempty" binding:"omitempty,min=-180,max=180"`
-	MaxAttendees    *int           `json:"max_attendees,omitempty" binding:"omitempty,min=3,max=100"`
-	Price           *float64       `json:"price,omitempty" binding:"omitempty,min=0"`
-	Privacy         *EventPrivacy  `json:"privacy,omitempty"`
-	Requirements    *string        `json:"requirements,omitempty"`
-	Status          *EventStatus   `json:"status,omitempty"`
-	ImageURLs       *[]string      `json:"image_urls,omitempty"` // If provided, replaces all existing images
+	Title                *string        `json:"title,omitempty" binding:"omitempty,min=3,max=100"`
+	Description          *string        `json:"description,omitempty" binding:"omitempty,min=10"`
+	Category             *EventCategory `json:"category,omitempty"`
+	StartTime            *time.Time     `json:"start_time,omitempty"`
+	EndTime              *time.Time     `json:"end_time,omitempty"`
+	LocationName         *string        `json:"location_name,omitempty"`
+	LocationAddress      *string        `json:"location_address,omitempty"`
+	LocationLat          *float64       `json:"location_lat,omitempty" binding:"omitempty,min=-90,max=90"`
+	LocationLng          *float64       `json:"location_lng,omitempty" binding:"omitempty,min=-180,max=180"`
+	MaxAttendees         *int           `json:"max_attendees,omitempty" binding:"omitempty,min=3,max=100"`
+	Price                *float64       `json:"price,omitempty" binding:"omitempty,min=0"`
+	Privacy              *EventPrivacy  `json:"privacy,omitempty"`
+	Requirements         *string        `json:"requirements,omitempty"`
+	Status               *EventStatus   `json:"status,omitempty"`
+	AllowTicketTransfers *bool          `json:"allow_ticket_transfers,omitempty"`
+	ImageURLs            *[]string      `json:"image_urls,omitempty"` // If provided, replaces all existing images
 }
 
 // EventFilter represents event filtering options
@@ -152,6 +306,7 @@ type EventFilter struct {
 	StartDate *time.Time     `form:"start_date"`
 	EndDate   *time.Time     `form:"end_date"`
 	IsFree    *bool          `form:"is_free"`
+	Featured  *bool          `form:"featured"`
 	Status    *EventStatus   `form:"status"`
 	Lat       *float64       `form:"lat"`
 	Lng       *float64       `form:"lng"`
@@ -159,9 +314,72 @@ type EventFilter struct {
 	Mode      string         `form:"mode"`   // Discovery mode: "trending", "for_you", "chill"
 	Limit     int            `form:"limit"`
 	Offset    int            `form:"offset"`
+
+	// Map viewport (GET /events/map)
+	MinLat *float64 `form:"min_lat" binding:"omitempty,min=-90,max=90"`
+	MinLng *float64 `form:"min_lng" binding:"omitempty,min=-180,max=180"`
+	MaxLat *float64 `form:"max_lat" binding:"omitempty,min=-90,max=90"`
+	MaxLng *float64 `form:"max_lng" binding:"omitempty,min=-180,max=180"`
+	Zoom   *int     `form:"zoom" binding:"omitempty,min=0,max=22"`
+}
+
+// MapEventPin is the event shown for a map cluster
+type MapEventPin struct {
+	ID             uuid.UUID     `json:"id" db:"id"`
+	Title          string        `json:"title" db:"title"`
+	Category       EventCategory `json:"category" db:"category"`
+	StartTime      time.Time     `json:"start_time" db:"start_time"`
+	LocationLat    float64       `json:"location_lat" db:"location_lat"`
+	LocationLng    float64       `json:"location_lng" db:"location_lng"`
+	IsFree         bool          `json:"is_free" db:"is_free"`
+	Price          *float64      `json:"price,omitempty" db:"price"`
+	ImageURL       *string       `json:"image_url,omitempty" db:"image_url"`
+	AttendeesCount int           `json:"attendees_count" db:"attendees_count"`
+}
+
+// MapCluster groups the events that fall into one grid cell of the viewport.
+// Count is 1 for a single pin; Event is the most popular event in the cell.
+type MapCluster struct {
+	Lat    float64     `json:"lat" db:"lat"` // centroid of the events in the cell
+	Lng    float64     `json:"lng" db:"lng"`
+	Count  int         `json:"count" db:"count"`
+	MinLat float64     `json:"min_lat" db:"min_lat"` // extent, for zooming into the cluster
+	MinLng float64     `json:"min_lng" db:"min_lng"`
+	MaxLat float64     `json:"max_lat" db:"max_lat"`
+	MaxLng float64     `json:"max_lng" db:"max_lng"`
+	Event  MapEventPin `json:"event" db:"event"`
+}
+
+// MapView represents the events inside a map viewport
+type MapView struct {
+	Zoom        int          `json:"zoom"`
+	Clustered   bool         `json:"clustered"`
+	TotalEvents int          `json:"total_events"` // events in the returned clusters
+	Truncated   bool         `json:"truncated"`    // cluster limit reached, smaller cells were dropped
+	Clusters    []MapCluster `json:"clusters"`
 }
 
 // Business logic methods
+func (s *EventStaff) Can(permission Permission) bool {
+	if s.Role == RoleCoHost {
+		return true
+	}
+	for _, p := range s.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Permission) IsValid() bool {
+	switch p {
+	case PermissionCheckIn, PermissionModerateQnA, PermissionViewAnalytics, PermissionEditEvent:
+		return true
+	}
+	return false
+}
+
 func (e *Event) IsFull() bool {
 	return e.TicketsSold >= e.MaxAttendees
 }
@@ -182,3 +400,41 @@ func (e *Event) IsCompleted() bool {
 func (e *Event) SpotsLeft() int {
 	return e.MaxAttendees - e.TicketsSold
 }
+
+// IsActive reports whether the series still generates occurrences
+func (s *Series) IsActive() bool {
+	return s.EndedAt == nil
+}
+
+// NewOccurrence builds the event for the occurrence of the series starting at start
+func (s *Series) NewOccurrence(start time.Time) *Event {
+	now := time.Now()
+	seriesID := s.ID
+	occurrenceStart := start
+
+	return &Event{
+		ID:                   uuid.New(),
+		HostID:               s.HostID,
+		Title:                s.Title,
+		Description:          s.Description,
+		Category:             s.Category,
+		StartTime:            start,
+		EndTime:              start.Add(time.Duration(s.DurationMinutes) * time.Minute),
+		LocationName:         s.LocationName,
+		LocationAddress:      s.LocationAddress,
+		LocationLat:          s.LocationLat,
+		LocationLng:          s.LocationLng,
+		MaxAttendees:         s.MaxAttendees,
+		Price:                s.Price,
+		IsFree:               s.IsFree,
+		Status:               StatusUpcoming,
+		Privacy:              s.Privacy,
+		Requirements:         s.Requirements,
+		TicketingEnabled:     s.TicketingEnabled,
+		AllowTicketTransfers: s.AllowTicketTransfers,
+		SeriesID:             &seriesID,
+		OccurrenceStart:      &occurrenceStart,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+}
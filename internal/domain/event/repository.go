@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -20,20 +21,43 @@ type Repository interface {
 	GetByHost(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]EventWithDetails, error)
 	GetJoinedEvents(ctx context.Context, userID uuid.UUID, limit, offset int) ([]EventWithDetails, error)
 	GetNearby(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]EventWithDetails, error)
+	GetMapClusters(ctx context.Context, filter *EventFilter, gridSize float64, limit int) ([]MapCluster, error)
 
 	// Counting for pagination
 	CountEvents(ctx context.Context, filter *EventFilter) (int, error)
 	CountHostedEvents(ctx context.Context, hostID uuid.UUID) (int, error)
 	CountJoinedEvents(ctx context.Context, userID uuid.UUID) (int, error)
-	CountAttendees(ctx context.Context, eventID uuid.UUID) (int, error)
+	CountAttendees(ctx context.Context, eventID, viewerID uuid.UUID) (int, error)
 
 	// Attendee management
 	Join(ctx context.Context, attendee *EventAttendee) error
 	Leave(ctx context.Context, eventID, userID uuid.UUID) error
-	GetAttendees(ctx context.Context, eventID uuid.UUID, limit, offset int) ([]EventAttendee, error)
+	GetAttendees(ctx context.Context, eventID, viewerID uuid.UUID, limit, offset int) ([]EventAttendee, error)
 	IsAttending(ctx context.Context, eventID, userID uuid.UUID) (bool, error)
 	GetAttendeesCount(ctx context.Context, eventID uuid.UUID) (int, error)
 
+	// Staff management
+	AddStaff(ctx context.Context, staff *EventStaff) error
+	UpdateStaff(ctx context.Context, staff *EventStaff) error
+	RemoveStaff(ctx context.Context, eventID, userID uuid.UUID) error
+	GetStaff(ctx context.Context, eventID uuid.UUID) ([]StaffMember, error)
+	GetStaffMember(ctx context.Context, eventID, userID uuid.UUID) (*EventStaff, error)
+
+	// Series management
+	CreateSeries(ctx context.Context, series *Series) error
+	GetSeries(ctx context.Context, seriesID uuid.UUID) (*Series, error)
+	GetSeriesWithDetails(ctx context.Context, seriesID, userID uuid.UUID) (*SeriesWithDetails, error)
+	UpdateSeries(ctx context.Context, series *Series) error
+	AddOccurrences(ctx context.Context, series *Series, occurrences []*Event) error
+	GetSeriesOccurrences(ctx context.Context, seriesID uuid.UUID, from time.Time, limit int) ([]EventWithDetails, error)
+	GetSeriesToExtend(ctx context.Context, horizon time.Time) ([]Series, error)
+
+	// Series followers
+	FollowSeries(ctx context.Context, seriesID, userID uuid.UUID) error
+	UnfollowSeries(ctx context.Context, seriesID, userID uuid.UUID) error
+	GetFollowedSeries(ctx context.Context, userID uuid.UUID, limit, offset int) ([]SeriesWithDetails, error)
+	CountFollowedSeries(ctx context.Context, userID uuid.UUID) (int, error)
+
 	// Image management
 	AddImages(ctx context.Context, images []EventImage) error
 	GetImages(ctx context.Context, eventID uuid.UUID) ([]string, error)
@@ -45,6 +69,12 @@ type Repository interface {
 	GetUpcomingEvents(ctx context.Context, limit int) ([]EventWithDetails, error)
 	GetLiveEvents(ctx context.Context, limit int) ([]EventWithDetails, error)
 
+	// Admin curation
+	SetFeatured(ctx context.Context, eventID uuid.UUID, featured bool) error
+	SetUnpublished(ctx context.Context, eventID uuid.UUID, unpublishedAt *time.Time) error // nil republishes
+
 	// Analytics - get all events by host for revenue calculation
 	GetByHostID(ctx context.Context, hostID uuid.UUID) ([]Event, error)
+	RecordPageView(ctx context.Context, view *PageView) error
+	GetFunnel(ctx context.Context, eventID uuid.UUID) (*Funnel, error)
 }
@@ -14,6 +14,22 @@ const (
 	LikeableComment LikeableType = "comment"
 )
 
+// Share platforms
+const (
+	PlatformWhatsApp  = "whatsapp"
+	PlatformInstagram = "instagram"
+	PlatformFacebook  = "facebook"
+	PlatformX         = "x"
+	PlatformTelegram  = "telegram"
+	PlatformLine      = "line"
+	PlatformTikTok    = "tiktok"
+	PlatformCopyLink  = "copy_link"
+	PlatformOther     = "other" // Also counts shares recorded without a platform
+)
+
+// ShareWindow is how long repeated shares of a post by a user to the same platform count once
+const ShareWindow = time.Hour
+
 // Like represents a like on a post or comment
 type Like struct {
 	ID           uuid.UUID    `json:"id" db:"id"`
@@ -48,3 +64,34 @@ type Share struct {
 	Platform  *string   `json:"platform,omitempty" db:"platform"` // whatsapp, instagram, etc
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
+
+// Interactor is a user who liked or reposted something, with the viewer's follow state
+type Interactor struct {
+	UserID            uuid.UUID `json:"user_id" db:"user_id"`
+	Name              string    `json:"name" db:"name"`
+	Username          string    `json:"username" db:"username"`
+	AvatarURL         *string   `json:"avatar_url,omitempty" db:"avatar_url"`
+	IsVerified        bool      `json:"is_verified" db:"is_verified"`
+	QuoteContent      *string   `json:"quote_content,omitempty" db:"quote_content"`   // Reposts only
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`                   // When they liked or reposted
+	IsFollowing       bool      `json:"is_following" db:"is_following"`               // The viewer follows them
+	IsFollowedBy      bool      `json:"is_followed_by" db:"is_followed_by"`           // They follow the viewer
+	IsFollowRequested bool      `json:"is_follow_requested" db:"is_follow_requested"` // The viewer asked to follow them
+}
+
+// PlatformShareCount is the number of shares on one platform
+type PlatformShareCount struct {
+	Platform string `json:"platform" db:"platform"`
+	Count    int    `json:"count" db:"count"`
+}
+
+// ShareSummary is how often a post was shared, per platform
+type ShareSummary struct {
+	Total     int                  `json:"total"`
+	Platforms []PlatformShareCount `json:"platforms"` // Most shared first
+}
+
+// ShareRequest represents sharing a post outside the app
+type ShareRequest struct {
+	Platform string `json:"platform" binding:"required,oneof=whatsapp instagram facebook x telegram line tiktok copy_link other"`
+}
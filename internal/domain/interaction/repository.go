@@ -13,12 +13,14 @@ type Repository interface {
 	Unlike(ctx context.Context, userID uuid.UUID, likeableType LikeableType, likeableID uuid.UUID) error
 	IsLiked(ctx context.Context, userID uuid.UUID, likeableType LikeableType, likeableID uuid.UUID) (bool, error)
 	GetLikes(ctx context.Context, likeableType LikeableType, likeableID uuid.UUID, limit, offset int) ([]Like, error)
+	GetLikers(ctx context.Context, likeableType LikeableType, likeableID, viewerID uuid.UUID, limit, offset int) ([]Interactor, error)
 
 	// Repost management
 	Repost(ctx context.Context, repost *Repost) error
 	UndoRepost(ctx context.Context, userID, postID uuid.UUID) error
 	IsReposted(ctx context.Context, userID, postID uuid.UUID) (bool, error)
 	GetReposts(ctx context.Context, postID uuid.UUID, limit, offset int) ([]Repost, error)
+	GetReposters(ctx context.Context, postID, viewerID uuid.UUID, limit, offset int) ([]Interactor, error)
 
 	// Bookmark management
 	Bookmark(ctx context.Context, bookmark *Bookmark) error
@@ -29,7 +31,11 @@ type Repository interface {
 	// Share tracking
 	Share(ctx context.Context, share *Share) error
 	GetShareCount(ctx context.Context, postID uuid.UUID) (int, error)
+	GetShareCountsByPost(ctx context.Context, postID uuid.UUID) ([]PlatformShareCount, error)
+	GetShareCountsByEvent(ctx context.Context, eventID uuid.UUID) ([]PlatformShareCount, error)
 
 	// Counting for pagination
 	CountBookmarks(ctx context.Context, userID uuid.UUID) (int, error)
+	CountLikers(ctx context.Context, likeableType LikeableType, likeableID, viewerID uuid.UUID) (int, error)
+	CountReposters(ctx context.Context, postID, viewerID uuid.UUID) (int, error)
 }
@@ -0,0 +1,177 @@
+package moderation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TargetType is the kind of content a report is about
+type TargetType string
+
+const (
+	TargetPost    TargetType = "post"
+	TargetComment TargetType = "comment"
+	TargetEvent   TargetType = "event"
+	TargetQnA     TargetType = "qna"
+	TargetUser    TargetType = "user" // A profile; never auto-hidden, admins suspend the user instead
+
+	// Only used by the audit trail of admin actions, these cannot be reported
+	TargetTransaction TargetType = "transaction"
+	TargetCommunity   TargetType = "community"
+)
+
+// IsContent reports whether the target is content that can be hidden or removed
+func (t TargetType) IsContent() bool {
+	switch t {
+	case TargetPost, TargetComment, TargetEvent, TargetQnA:
+		return true
+	}
+	return false
+}
+
+// Reason is why a user reported something
+type Reason string
+
+const (
+	ReasonSpam           Reason = "spam"
+	ReasonHarassment     Reason = "harassment"
+	ReasonHateSpeech     Reason = "hate_speech"
+	ReasonViolence       Reason = "violence"
+	ReasonNudity         Reason = "nudity"
+	ReasonScam           Reason = "scam"
+	ReasonMisinformation Reason = "misinformation"
+	ReasonImpersonation  Reason = "impersonation"
+	ReasonOther          Reason = "other"
+)
+
+// ReportStatus represents where a report is in the moderation queue
+type ReportStatus string
+
+const (
+	StatusOpen      ReportStatus = "open"      // Waiting for review
+	StatusDismissed ReportStatus = "dismissed" // Reviewed, nothing wrong
+	StatusActioned  ReportStatus = "actioned"  // Content removed or user suspended
+)
+
+// ActionType is a moderation decision recorded in the audit trail
+type ActionType string
+
+const (
+	ActionAutoHide      ActionType = "auto_hide" // Content hidden after reaching the report threshold
+	ActionDismiss       ActionType = "dismiss"
+	ActionRemoveContent ActionType = "remove_content"
+	ActionSuspendUser   ActionType = "suspend_user"
+	ActionUnsuspendUser ActionType = "unsuspend_user"
+
+	// Admin actions outside the report queue
+	ActionVerifyUser        ActionType = "verify_user"
+	ActionUnverifyUser      ActionType = "unverify_user"
+	ActionSetRole           ActionType = "set_role"
+	ActionFeatureEvent      ActionType = "feature_event"
+	ActionUnfeatureEvent    ActionType = "unfeature_event"
+	ActionUnpublishEvent    ActionType = "unpublish_event"
+	ActionRepublishEvent    ActionType = "republish_event"
+	ActionForceRefund       ActionType = "force_refund"
+	ActionUpdateCommunity   ActionType = "update_community"
+	ActionDeleteCommunity   ActionType = "delete_community"
+	ActionTransferCommunity ActionType = "transfer_community"
+)
+
+// Report collects every report filed against one target while it is open
+// Filing on a target that already has an open report adds to it instead of opening a new one
+type Report struct {
+	ID              uuid.UUID    `json:"id" db:"id"`
+	TargetType      TargetType   `json:"target_type" db:"target_type"`
+	TargetID        uuid.UUID    `json:"target_id" db:"target_id"`
+	Status          ReportStatus `json:"status" db:"status"`
+	ReportsCount    int          `json:"reports_count" db:"reports_count"`
+	IsHidden        bool         `json:"is_hidden" db:"is_hidden"` // Content is hidden from everyone but its author
+	FirstReportedAt time.Time    `json:"first_reported_at" db:"first_reported_at"`
+	LastReportedAt  time.Time    `json:"last_reported_at" db:"last_reported_at"`
+	ResolvedBy      *uuid.UUID   `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt      *time.Time   `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// Filing is a single user's report
+type Filing struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	ReportID   uuid.UUID `json:"report_id" db:"report_id"`
+	ReporterID uuid.UUID `json:"reporter_id" db:"reporter_id"`
+	Reason     Reason    `json:"reason" db:"reason"`
+	Details    *string   `json:"details,omitempty" db:"details"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// FilingWithReporter includes the reporter's public info
+type FilingWithReporter struct {
+	Filing
+	ReporterName      string  `json:"reporter_name" db:"reporter_name"`
+	ReporterAvatarURL *string `json:"reporter_avatar_url,omitempty" db:"reporter_avatar_url"`
+}
+
+// ReportWithDetails is a report as shown to admins reviewing it
+type ReportWithDetails struct {
+	Report
+	OwnerID *uuid.UUID           `json:"owner_id,omitempty"` // Author, host or profile owner; nil if the target is gone
+	Filings []FilingWithReporter `json:"filings"`
+}
+
+// Action is an entry in the moderation audit trail
+type Action struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	ActorID    *uuid.UUID `json:"actor_id,omitempty" db:"actor_id"` // nil for automatic actions
+	Action     ActionType `json:"action" db:"action"`
+	TargetType TargetType `json:"target_type" db:"target_type"`
+	TargetID   uuid.UUID  `json:"target_id" db:"target_id"`
+	ReportID   *uuid.UUID `json:"report_id,omitempty" db:"report_id"`
+	Note       *string    `json:"note,omitempty" db:"note"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateReportRequest represents a user reporting content or a profile
+type CreateReportRequest struct {
+	TargetType TargetType `json:"target_type" binding:"required,oneof=post comment event qna user"`
+	TargetID   uuid.UUID  `json:"target_id" binding:"required"`
+	Reason     Reason     `json:"reason" binding:"required,oneof=spam harassment hate_speech violence nudity scam misinformation impersonation other"`
+	Details    *string    `json:"details,omitempty" binding:"omitempty,max=1000"`
+}
+
+// ResolveReportRequest represents an admin dismissing a report or removing the reported content
+type ResolveReportRequest struct {
+	Note *string `json:"note,omitempty" binding:"omitempty,max=1000"`
+}
+
+// SuspendUserRequest represents an admin suspending a user
+type SuspendUserRequest struct {
+	Reason       string     `json:"reason" binding:"required,max=1000"`
+	DurationDays *int       `json:"duration_days,omitempty" binding:"omitempty,min=1"` // nil suspends indefinitely
+	ReportID     *uuid.UUID `json:"report_id,omitempty"`                               // Report resolved by the suspension
+}
+
+// UnsuspendUserRequest represents an admin lifting a suspension
+type UnsuspendUserRequest struct {
+	Note *string `json:"note,omitempty" binding:"omitempty,max=1000"`
+}
+
+// AdminActionRequest carries the optional note recorded with an admin action
+type AdminActionRequest struct {
+	Note *string `json:"note,omitempty" binding:"omitempty,max=1000"`
+}
+
+// ReportFilter represents filters for the moderation queue
+type ReportFilter struct {
+	Status     ReportStatus
+	TargetType *TargetType
+	Limit      int
+	Offset     int
+}
+
+// ActionFilter represents filters for the audit trail
+type ActionFilter struct {
+	TargetType *TargetType
+	TargetID   *uuid.UUID
+	ActorID    *uuid.UUID
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,31 @@
+package moderation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for report and moderation data access
+type Repository interface {
+	// Reports
+	FileReport(ctx context.Context, targetType TargetType, targetID uuid.UUID, filing *Filing) (*Report, error) // Adds to the open report on the target, opening one if needed
+	HasReported(ctx context.Context, reporterID uuid.UUID, targetType TargetType, targetID uuid.UUID) (bool, error)
+	GetReport(ctx context.Context, reportID uuid.UUID) (*Report, error)
+	GetReports(ctx context.Context, filter *ReportFilter) ([]Report, error)
+	CountReports(ctx context.Context, filter *ReportFilter) (int, error)
+	GetFilings(ctx context.Context, reportID uuid.UUID) ([]FilingWithReporter, error)
+	GetTargetOwner(ctx context.Context, targetType TargetType, targetID uuid.UUID) (uuid.UUID, error) // sql.ErrNoRows if the target does not exist
+
+	// Moderation
+	HideReport(ctx context.Context, reportID uuid.UUID, action *Action) error
+	ResolveReport(ctx context.Context, reportID uuid.UUID, status ReportStatus, hidden bool, resolvedBy uuid.UUID, action *Action) error
+	SuspendUser(ctx context.Context, userID uuid.UUID, until *time.Time, reason string, action *Action) error
+	UnsuspendUser(ctx context.Context, userID uuid.UUID, action *Action) error
+
+	// Audit trail
+	RecordAction(ctx context.Context, action *Action) error
+	GetActions(ctx context.Context, filter *ActionFilter) ([]Action, error)
+	CountActions(ctx context.Context, filter *ActionFilter) (int, error)
+}
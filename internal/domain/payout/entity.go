@@ -0,0 +1,215 @@
+package payout
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned by the repository when posting to the ledger
+var (
+	ErrAlreadyPosted       = errors.New("ledger transaction already posted")
+	ErrUnbalanced          = errors.New("ledger transaction is not balanced")
+	ErrInsufficientBalance = errors.New("insufficient available balance")
+)
+
+// Account represents a ledger account
+type Account string
+
+const (
+	AccountGatewayCash    Account = "gateway_cash"    // Money held by the payment gateway or platform bank
+	AccountHostPayable    Account = "host_payable"    // What the platform owes a host
+	AccountPlatformFees   Account = "platform_fees"   // Platform fee income
+	AccountPayoutClearing Account = "payout_clearing" // Payouts requested but not yet sent
+)
+
+// Direction represents the side of a ledger entry
+type Direction string
+
+const (
+	Debit  Direction = "debit"
+	Credit Direction = "credit"
+)
+
+// TransactionKind represents the business event behind a ledger transaction
+type TransactionKind string
+
+const (
+	KindTicketSale     TransactionKind = "ticket_sale"     // Paid ticket, credits the host minus the platform fee
+	KindTicketRefund   TransactionKind = "ticket_refund"   // Refunded ticket, reverses its sale
+	KindPayoutRequest  TransactionKind = "payout_request"  // Host asked to withdraw
+	KindPayoutPaid     TransactionKind = "payout_paid"     // Admin sent the money
+	KindPayoutReversal TransactionKind = "payout_reversal" // Payout rejected or cancelled, money back to the host
+)
+
+// PayoutStatus represents the status of a payout
+type PayoutStatus string
+
+const (
+	StatusRequested PayoutStatus = "requested" // Waiting for an admin
+	StatusPaid      PayoutStatus = "paid"      // Transferred to the host's bank account
+	StatusRejected  PayoutStatus = "rejected"  // Refused by an admin
+	StatusCancelled PayoutStatus = "cancelled" // Withdrawn by the host
+)
+
+// Transaction groups the balanced entries of one business event
+// Each (kind, reference) pair is posted at most once, which keeps webhook retries from double counting
+type Transaction struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	Kind        TransactionKind `json:"kind" db:"kind"`
+	ReferenceID uuid.UUID       `json:"reference_id" db:"reference_id"` // Ticket for sales and refunds, payout for payouts
+	EventID     *uuid.UUID      `json:"event_id,omitempty" db:"event_id"`
+	Description *string         `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	Entries     []Entry         `json:"entries" db:"-"`
+}
+
+// Entry represents one side of a ledger transaction
+type Entry struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	TransactionID uuid.UUID  `json:"transaction_id" db:"transaction_id"`
+	Account       Account    `json:"account" db:"account"`
+	HostID        *uuid.UUID `json:"host_id,omitempty" db:"host_id"`
+	EventID       *uuid.UUID `json:"event_id,omitempty" db:"event_id"`
+	Direction     Direction  `json:"direction" db:"direction"`
+	Amount        float64    `json:"amount" db:"amount"`
+	AvailableAt   *time.Time `json:"available_at,omitempty" db:"available_at"` // host_payable only, nil = immediately
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// StatementEntry is a host_payable entry shown on a host's statement
+type StatementEntry struct {
+	Entry
+	Kind        TransactionKind `json:"kind" db:"kind"`
+	ReferenceID uuid.UUID       `json:"reference_id" db:"reference_id"`
+	Description *string         `json:"description,omitempty" db:"description"`
+	EventTitle  *string         `json:"event_title,omitempty" db:"event_title"`
+}
+
+// Balance represents what the platform owes a host
+type Balance struct {
+	HostID    uuid.UUID `json:"host_id" db:"host_id"`
+	Total     float64   `json:"total" db:"total"`           // Everything owed, including held funds
+	Held      float64   `json:"held" db:"held"`             // Sales of events still within the hold period
+	Available float64   `json:"available" db:"-"`           // Can be requested now
+	InTransit float64   `json:"in_transit" db:"in_transit"` // Requested payouts not yet paid
+}
+
+// BankAccount represents a bank account a host receives payouts on
+type BankAccount struct {
+	ID                uuid.UUID `json:"id" db:"id"`
+	UserID            uuid.UUID `json:"user_id" db:"user_id"`
+	BankName          string    `json:"bank_name" db:"bank_name"`
+	AccountNumber     string    `json:"account_number" db:"account_number"`
+	AccountHolderName string    `json:"account_holder_name" db:"account_holder_name"`
+	IsActive          bool      `json:"is_active" db:"is_active"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// Payout represents a host's request to withdraw their balance
+type Payout struct {
+	ID            uuid.UUID    `json:"id" db:"id"`
+	HostID        uuid.UUID    `json:"host_id" db:"host_id"`
+	BankAccountID uuid.UUID    `json:"bank_account_id" db:"bank_account_id"`
+	Amount        float64      `json:"amount" db:"amount"`
+	Status        PayoutStatus `json:"status" db:"status"`
+	Reference     *string      `json:"reference,omitempty" db:"reference"` // Bank transfer reference
+	Note          *string      `json:"note,omitempty" db:"note"`           // Rejection reason
+	RequestedAt   time.Time    `json:"requested_at" db:"requested_at"`
+	ProcessedAt   *time.Time   `json:"processed_at,omitempty" db:"processed_at"`
+	ProcessedBy   *uuid.UUID   `json:"processed_by,omitempty" db:"processed_by"`
+}
+
+// PayoutWithDetails includes host and bank account information of a payout
+type PayoutWithDetails struct {
+	Payout
+	HostName          string `json:"host_name" db:"host_name"`
+	BankName          string `json:"bank_name" db:"bank_name"`
+	AccountNumber     string `json:"account_number" db:"account_number"`
+	AccountHolderName string `json:"account_holder_name" db:"account_holder_name"`
+}
+
+// ReconciliationLine compares one ticket's payments with its ledger postings
+type ReconciliationLine struct {
+	TicketID        uuid.UUID  `json:"ticket_id" db:"ticket_id"`
+	EventID         *uuid.UUID `json:"event_id,omitempty" db:"event_id"`
+	TransactionsNet float64    `json:"transactions_net" db:"transactions_net"` // Net collected according to ticket_transactions
+	LedgerNet       float64    `json:"ledger_net" db:"ledger_net"`             // Net cash according to the ledger
+	Difference      float64    `json:"difference" db:"-"`
+}
+
+// ReconciliationReport compares the ledger with ticket_transactions
+type ReconciliationReport struct {
+	StartDate              *time.Time           `json:"start_date,omitempty"`
+	EndDate                *time.Time           `json:"end_date,omitempty"`
+	TicketsCompared        int                  `json:"tickets_compared"`
+	TransactionsNet        float64              `json:"transactions_net"`
+	LedgerNet              float64              `json:"ledger_net"`
+	Difference             float64              `json:"difference"`
+	PlatformFees           float64              `json:"platform_fees"`
+	HostPayable            float64              `json:"host_payable"`
+	PayoutsInTransit       float64              `json:"payouts_in_transit"`
+	PayoutsPaid            float64              `json:"payouts_paid"`
+	UnbalancedTransactions int                  `json:"unbalanced_transactions"` // Ledger transactions whose entries do not sum to zero
+	Mismatches             []ReconciliationLine `json:"mismatches"`
+}
+
+// AccountTotals holds the net balance of each ledger account (debits minus credits)
+type AccountTotals struct {
+	GatewayCash    float64 `db:"gateway_cash"`
+	HostPayable    float64 `db:"host_payable"`
+	PlatformFees   float64 `db:"platform_fees"`
+	PayoutClearing float64 `db:"payout_clearing"`
+}
+
+// CreateBankAccountRequest represents bank account registration data
+type CreateBankAccountRequest struct {
+	BankName          string `json:"bank_name" binding:"required,min=2,max=100"`
+	AccountNumber     string `json:"account_number" binding:"required,numeric,min=5,max=50"`
+	AccountHolderName string `json:"account_holder_name" binding:"required,min=2,max=100"`
+}
+
+// RequestPayoutRequest represents a payout request from a host
+type RequestPayoutRequest struct {
+	BankAccountID uuid.UUID `json:"bank_account_id" binding:"required"`
+	Amount        float64   `json:"amount" binding:"required,gt=0"`
+}
+
+// MarkPaidRequest represents an admin confirming a payout was transferred
+type MarkPaidRequest struct {
+	Reference string `json:"reference" binding:"required,max=100"`
+}
+
+// RejectPayoutRequest represents an admin refusing a payout
+type RejectPayoutRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// Business logic methods
+func (t *Transaction) IsBalanced() bool {
+	var debits, credits int64
+	for _, e := range t.Entries {
+		if e.Direction == Debit {
+			debits += Cents(e.Amount)
+		} else {
+			credits += Cents(e.Amount)
+		}
+	}
+	return len(t.Entries) > 0 && debits == credits
+}
+
+func (p *Payout) IsOpen() bool {
+	return p.Status == StatusRequested
+}
+
+// Cents converts an amount to whole cents so ledger sums are compared exactly
+func Cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// RoundAmount rounds an amount to cents
+func RoundAmount(amount float64) float64 {
+	return float64(Cents(amount)) / 100
+}
@@ -0,0 +1,37 @@
+package payout
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for ledger and payout data access
+type Repository interface {
+	// Ledger
+	PostTransaction(ctx context.Context, txn *Transaction) error
+	GetTransactionByReference(ctx context.Context, kind TransactionKind, referenceID uuid.UUID) (*Transaction, error)
+	GetHostStatement(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]StatementEntry, error)
+	CountHostStatement(ctx context.Context, hostID uuid.UUID) (int, error)
+	GetHostBalance(ctx context.Context, hostID uuid.UUID, now time.Time) (*Balance, error)
+
+	// Bank accounts
+	CreateBankAccount(ctx context.Context, account *BankAccount) error
+	GetBankAccountByID(ctx context.Context, accountID uuid.UUID) (*BankAccount, error)
+	GetBankAccountsByUser(ctx context.Context, userID uuid.UUID) ([]BankAccount, error)
+	DeactivateBankAccount(ctx context.Context, accountID uuid.UUID) error
+
+	// Payouts
+	CreatePayout(ctx context.Context, payout *Payout, txn *Transaction, now time.Time) error
+	GetPayoutByID(ctx context.Context, payoutID uuid.UUID) (*Payout, error)
+	GetPayoutsByHost(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]PayoutWithDetails, error)
+	GetPayoutsByStatus(ctx context.Context, status PayoutStatus, limit, offset int) ([]PayoutWithDetails, error)
+	ResolvePayout(ctx context.Context, payout *Payout, txn *Transaction) error
+
+	// Reconciliation
+	GetReconciliationLines(ctx context.Context, startDate, endDate *time.Time) ([]ReconciliationLine, error)
+	GetAccountTotals(ctx context.Context, startDate, endDate *time.Time) (*AccountTotals, error)
+	CountUnbalancedTransactions(ctx context.Context, startDate, endDate *time.Time) (int, error)
+	SumPayouts(ctx context.Context, status PayoutStatus, startDate, endDate *time.Time) (float64, error)
+}
@@ -26,6 +26,15 @@ const (
 	VisibilityPrivate   PostVisibility = "private"
 )
 
+// PostStatus represents the publication state of a post
+type PostStatus string
+
+const (
+	StatusPublished PostStatus = "published"
+	StatusDraft     PostStatus = "draft"     // Only visible to the author
+	StatusScheduled PostStatus = "scheduled" // Published at ScheduledAt by the background publisher
+)
+
 // Post represents a social media post
 type Post struct {
 	ID              uuid.UUID      `json:"id" db:"id"`
@@ -35,18 +44,41 @@ type Post struct {
 	AttachedEventID uuid.UUID      `json:"attached_event_id" db:"attached_event_id"`
 	OriginalPostID  *uuid.UUID     `json:"original_post_id,omitempty" db:"original_post_id"`
 	Visibility      PostVisibility `json:"visibility" db:"visibility"`
-	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+	Status          PostStatus     `json:"status" db:"status"`
+	ScheduledAt     *time.Time     `json:"scheduled_at,omitempty" db:"scheduled_at"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"` // Publish time once published
 	UpdatedAt       time.Time      `json:"updated_at" db:"updated_at"`
+	EditedAt        *time.Time     `json:"edited_at,omitempty" db:"edited_at"` // Last content edit
 	LikesCount      int            `json:"likes_count" db:"likes_count"`
 	CommentsCount   int            `json:"comments_count" db:"comments_count"`
 	RepostsCount    int            `json:"reposts_count" db:"reposts_count"`
 	SharesCount     int            `json:"shares_count" db:"shares_count"`
 }
 
+// IsPublished reports whether the post is visible to others
+func (p *Post) IsPublished() bool {
+	return p.Status == StatusPublished
+}
+
+// Engagement sums the interactions with the post
+func (p *Post) Engagement() int {
+	return p.LikesCount + p.CommentsCount + p.RepostsCount + p.SharesCount
+}
+
+// Revision is the content of a post before one of its edits
+type Revision struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	PostID     uuid.UUID `json:"post_id" db:"post_id"`
+	Content    string    `json:"content" db:"content"`
+	WrittenAt  time.Time `json:"written_at" db:"written_at"`   // When this content was posted or last edited
+	ReplacedAt time.Time `json:"replaced_at" db:"replaced_at"` // When the edit replaced it
+}
+
 // PostWithDetails includes additional post information
 type PostWithDetails struct {
 	Post
 	AuthorName         string         `json:"author_name"`
+	AuthorUsername     string         `json:"author_username"`
 	AuthorAvatarURL    *string        `json:"author_avatar_url"`
 	AuthorIsVerified   bool           `json:"author_is_verified"`
 	ImageURLs          []string       `json:"image_urls,omitempty"`
@@ -57,13 +89,14 @@ type PostWithDetails struct {
 	IsRepostedByUser   bool           `json:"is_reposted_by_user"`
 	IsBookmarkedByUser bool           `json:"is_bookmarked_by_user"`
 	Hashtags           []string       `json:"hashtags,omitempty"`
-	Mentions           []string       `json:"mentions,omitempty"`
+	Mentions           []string       `json:"mentions,omitempty"` // Current usernames of the mentioned users
 }
 
 // AuthorSummary represents basic author information
 type AuthorSummary struct {
 	ID         uuid.UUID `json:"id"`
 	Name       string    `json:"name"`
+	Username   string    `json:"username"`
 	AvatarURL  *string   `json:"avatar_url"`
 	IsVerified bool      `json:"is_verified"`
 }
@@ -113,13 +146,17 @@ type CreatePostRequest struct {
 	AttachedEventID *uuid.UUID     `json:"attached_event_id,omitempty"` // Only required for TypeTextWithEvent
 	Visibility      PostVisibility `json:"visibility" binding:"required"`
 	Hashtags        []string       `json:"hashtags,omitempty"`
-	Mentions        []string       `json:"mentions,omitempty"`
+	Mentions        []string       `json:"mentions,omitempty"` // Usernames, added to the @mentions found in the content
+	// Defaults to published, or scheduled when ScheduledAt is set
+	Status      PostStatus `json:"status,omitempty" binding:"omitempty,oneof=published draft scheduled"`
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"` // Publish time of a scheduled post
 }
 
 // UpdatePostRequest represents post update data
 type UpdatePostRequest struct {
-	Content    *string         `json:"content,omitempty" binding:"omitempty,max=5000"`
-	Visibility *PostVisibility `json:"visibility,omitempty"`
+	Content     *string         `json:"content,omitempty" binding:"omitempty,max=5000"`
+	Visibility  *PostVisibility `json:"visibility,omitempty"`
+	ScheduledAt *time.Time      `json:"scheduled_at,omitempty"` // Schedules or reschedules an unpublished post
 }
 
 // RepostRequest represents repost data
@@ -148,8 +185,12 @@ type PostResponse struct {
 	OriginalPost       *Post          `json:"original_post,omitempty"`
 	OriginalPostAuthor *AuthorSummary `json:"original_post_author,omitempty"`
 	Visibility         PostVisibility `json:"visibility"`
+	Status             PostStatus     `json:"status"`
+	ScheduledAt        *time.Time     `json:"scheduled_at,omitempty"`
 	CreatedAt          time.Time      `json:"created_at"`
 	UpdatedAt          time.Time      `json:"updated_at"`
+	Edited             bool           `json:"edited"`
+	EditedAt           *time.Time     `json:"edited_at,omitempty"`
 	LikesCount         int            `json:"likes_count"`
 	CommentsCount      int            `json:"comments_count"`
 	RepostsCount       int            `json:"reposts_count"`
@@ -168,6 +209,7 @@ func (p *PostWithDetails) ToResponse() PostResponse {
 		Author: AuthorSummary{
 			ID:         p.AuthorID,
 			Name:       p.AuthorName,
+			Username:   p.AuthorUsername,
 			AvatarURL:  p.AuthorAvatarURL,
 			IsVerified: p.AuthorIsVerified,
 		},
@@ -178,8 +220,12 @@ func (p *PostWithDetails) ToResponse() PostResponse {
 		OriginalPost:       p.OriginalPost,
 		OriginalPostAuthor: p.OriginalPostAuthor,
 		Visibility:         p.Visibility,
+		Status:             p.Status,
+		ScheduledAt:        p.ScheduledAt,
 		CreatedAt:          p.CreatedAt,
 		UpdatedAt:          p.UpdatedAt,
+		Edited:             p.EditedAt != nil,
+		EditedAt:           p.EditedAt,
 		LikesCount:         p.LikesCount,
 		CommentsCount:      p.CommentsCount,
 		RepostsCount:       p.RepostsCount,
@@ -2,6 +2,7 @@ package post
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,7 +13,7 @@ type Repository interface {
 	Create(ctx context.Context, post *Post) error
 	GetByID(ctx context.Context, postID uuid.UUID) (*Post, error)
 	GetWithDetails(ctx context.Context, postID, userID uuid.UUID) (*PostWithDetails, error)
-	Update(ctx context.Context, post *Post) error
+	Update(ctx context.Context, post *Post, readStatus PostStatus) error
 	Delete(ctx context.Context, postID uuid.UUID) error
 
 	// Post queries
@@ -24,10 +25,23 @@ type Repository interface {
 	CountFeed(ctx context.Context, userID uuid.UUID) (int, error)
 	CountUserPosts(ctx context.Context, authorID uuid.UUID) (int, error)
 
+	// Drafts and scheduling
+	GetUnpublished(ctx context.Context, authorID uuid.UUID, limit, offset int) ([]Post, error)
+	CountUnpublished(ctx context.Context, authorID uuid.UUID) (int, error)
+	Publish(ctx context.Context, postID uuid.UUID, publishedAt time.Time) error
+	PublishDue(ctx context.Context, now time.Time) (int, error)
+
 	// Image management
 	AddImages(ctx context.Context, images []PostImage) error
 	GetImages(ctx context.Context, postID uuid.UUID) ([]string, error)
 
+	// Edit history
+	Edit(ctx context.Context, post *Post, revision *Revision) error
+	GetRevisions(ctx context.Context, postID uuid.UUID) ([]Revision, error)
+
+	// Mentions
+	SetMentions(ctx context.Context, postID uuid.UUID, userIDs []uuid.UUID) error
+
 	// Engagement
 	IncrementLikes(ctx context.Context, postID uuid.UUID) error
 	DecrementLikes(ctx context.Context, postID uuid.UUID) error
@@ -0,0 +1,140 @@
+package promo
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned by Repository.Redeem and RestoreRedemption when a limit is hit at redemption time
+var (
+	ErrCodeExhausted    = errors.New("promo code redemption limit reached")
+	ErrUserLimitReached = errors.New("promo code already used the maximum number of times")
+)
+
+// DiscountType represents how a promo code discount is calculated
+type DiscountType string
+
+const (
+	DiscountPercentage DiscountType = "percentage" // Percent off the ticket price
+	DiscountFixed      DiscountType = "fixed"      // Fixed amount off the ticket price
+)
+
+// Code represents a host-managed promo code
+type Code struct {
+	ID              uuid.UUID    `json:"id" db:"id"`
+	HostID          uuid.UUID    `json:"host_id" db:"host_id"`
+	Code            string       `json:"code" db:"code"`
+	Description     *string      `json:"description,omitempty" db:"description"`
+	DiscountType    DiscountType `json:"discount_type" db:"discount_type"`
+	DiscountValue   float64      `json:"discount_value" db:"discount_value"`
+	MaxRedemptions  *int         `json:"max_redemptions,omitempty" db:"max_redemptions"` // Discounted tickets, nil = unlimited
+	MaxPerUser      int          `json:"max_per_user" db:"max_per_user"`                 // Discounted tickets per user
+	RedemptionCount int          `json:"redemption_count" db:"redemption_count"`         // Discounted tickets so far
+	ValidFrom       *time.Time   `json:"valid_from,omitempty" db:"valid_from"`
+	ValidUntil      *time.Time   `json:"valid_until,omitempty" db:"valid_until"`
+	IsActive        bool         `json:"is_active" db:"is_active"`
+	EventIDs        []uuid.UUID  `json:"event_ids" db:"-"` // Empty = all events of the host
+	CreatedAt       time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Redemption represents a promo code applied to the tickets of an order
+type Redemption struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	PromoCodeID    uuid.UUID `json:"promo_code_id" db:"promo_code_id"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	EventID        uuid.UUID `json:"event_id" db:"event_id"`
+	TicketID       uuid.UUID `json:"ticket_id" db:"ticket_id"` // Buyer's ticket of the order
+	Quantity       int       `json:"quantity" db:"quantity"`   // Tickets discounted, each counts as one use of the code
+	OriginalAmount float64   `json:"original_amount" db:"original_amount"`
+	DiscountAmount float64   `json:"discount_amount" db:"discount_amount"`
+	RedeemedAt     time.Time `json:"redeemed_at" db:"redeemed_at"`
+}
+
+// RedemptionWithCode includes the code text of a redemption
+type RedemptionWithCode struct {
+	Redemption
+	Code string `json:"code" db:"code"`
+}
+
+// RedemptionStats is the number of paid tickets a code was used on and the discount they got
+type RedemptionStats struct {
+	PromoCodeID   uuid.UUID `json:"promo_code_id" db:"promo_code_id"`
+	Code          string    `json:"code" db:"code"`
+	Redemptions   int       `json:"redemptions" db:"redemptions"` // Discounted tickets
+	TotalDiscount float64   `json:"total_discount" db:"total_discount"`
+}
+
+// CreateCodeRequest represents promo code creation data
+type CreateCodeRequest struct {
+	Code           string       `json:"code" binding:"required,alphanum,min=3,max=32"`
+	Description    *string      `json:"description,omitempty"`
+	DiscountType   DiscountType `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	DiscountValue  float64      `json:"discount_value" binding:"required,gt=0"`
+	MaxRedemptions *int         `json:"max_redemptions,omitempty" binding:"omitempty,min=1"`
+	MaxPerUser     *int         `json:"max_per_user,omitempty" binding:"omitempty,min=1"` // Defaults to 1
+	ValidFrom      *time.Time   `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time   `json:"valid_until,omitempty"`
+	EventIDs       []uuid.UUID  `json:"event_ids,omitempty"`
+}
+
+// UpdateCodeRequest represents promo code update data
+type UpdateCodeRequest struct {
+	Description    *string      `json:"description,omitempty"`
+	MaxRedemptions *int         `json:"max_redemptions,omitempty" binding:"omitempty,min=1"`
+	MaxPerUser     *int         `json:"max_per_user,omitempty" binding:"omitempty,min=1"`
+	ValidFrom      *time.Time   `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time   `json:"valid_until,omitempty"`
+	IsActive       *bool        `json:"is_active,omitempty"`
+	EventIDs       *[]uuid.UUID `json:"event_ids,omitempty"` // Empty list removes the restriction
+}
+
+// Business logic methods
+func (c *Code) IsValidAt(now time.Time) bool {
+	if !c.IsActive {
+		return false
+	}
+	if c.ValidFrom != nil && now.Before(*c.ValidFrom) {
+		return false
+	}
+	if c.ValidUntil != nil && !now.Before(*c.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+func (c *Code) IsExhausted() bool {
+	return c.MaxRedemptions != nil && c.RedemptionCount >= *c.MaxRedemptions
+}
+
+func (c *Code) AppliesTo(eventID uuid.UUID) bool {
+	if len(c.EventIDs) == 0 {
+		return true
+	}
+	for _, id := range c.EventIDs {
+		if id == eventID {
+			return true
+		}
+	}
+	return false
+}
+
+// Discount calculates the discount for a price, rounded to whole rupiah and
+// never more than the price itself
+func (c *Code) Discount(price float64) float64 {
+	var discount float64
+	switch c.DiscountType {
+	case DiscountPercentage:
+		discount = math.Round(price * c.DiscountValue / 100)
+	case DiscountFixed:
+		discount = c.DiscountValue
+	}
+
+	if discount > price {
+		discount = price
+	}
+	return discount
+}
@@ -0,0 +1,26 @@
+package promo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for promo code data access
+type Repository interface {
+	// Code management
+	Create(ctx context.Context, code *Code) error
+	GetByID(ctx context.Context, codeID uuid.UUID) (*Code, error)
+	GetByHostAndCode(ctx context.Context, hostID uuid.UUID, code string) (*Code, error)
+	GetByHost(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]Code, error)
+	Update(ctx context.Context, code *Code) error
+	SetEvents(ctx context.Context, codeID uuid.UUID, eventIDs []uuid.UUID) error
+
+	// Redemptions
+	Redeem(ctx context.Context, redemption *Redemption) error
+	ReleaseRedemption(ctx context.Context, ticketID uuid.UUID) error
+	RestoreRedemption(ctx context.Context, ticketID uuid.UUID) (bool, error)
+	GetRedemptionsByEvent(ctx context.Context, eventID uuid.UUID) ([]RedemptionWithCode, error)
+	GetHostRedemptionStats(ctx context.Context, hostID uuid.UUID, startDate, endDate *time.Time) ([]RedemptionStats, error)
+}
@@ -36,11 +36,10 @@ type QnAWithDetails struct {
 	IsUpvotedByUser bool           `json:"isUpvotedByCurrentUser"`
 }
 
-// UserBasicInfo represents basic user information
+// UserBasicInfo represents the public information of an asker or answerer
 type UserBasicInfo struct {
 	ID        uuid.UUID `json:"id"`
 	Name      string    `json:"name"`
-	Email     string    `json:"email"`
 	Avatar    *string   `json:"avatar,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 }
@@ -0,0 +1,154 @@
+package search
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type represents which kind of result a search is restricted to
+type Type string
+
+const (
+	TypeAll         Type = "all"
+	TypeEvents      Type = "events"
+	TypePosts       Type = "posts"
+	TypeHashtags    Type = "hashtags"
+	TypeUsers       Type = "users"
+	TypeCommunities Type = "communities"
+)
+
+// Params holds a normalized search query
+type Params struct {
+	Query  string
+	Lat    *float64 // optional, enables proximity ranking
+	Lng    *float64
+	Limit  int
+	Offset int
+	Now    time.Time
+	Viewer uuid.UUID // uuid.Nil for anonymous callers; hides posts and users in a block relation with the viewer
+}
+
+// SearchRequest represents the query string of GET /search
+type SearchRequest struct {
+	Query  string   `form:"q" binding:"required,min=2,max=100"`
+	Type   Type     `form:"type"`
+	Lat    *float64 `form:"lat" binding:"omitempty,min=-90,max=90"`
+	Lng    *float64 `form:"lng" binding:"omitempty,min=-180,max=180"`
+	Limit  int      `form:"limit"`
+	Offset int      `form:"offset"`
+}
+
+// EventResult represents an event matching a search
+type EventResult struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	Title        string    `json:"title" db:"title"`
+	Category     string    `json:"category" db:"category"`
+	StartTime    time.Time `json:"start_time" db:"start_time"`
+	LocationName string    `json:"location_name" db:"location_name"`
+	IsFree       bool      `json:"is_free" db:"is_free"`
+	Price        *float64  `json:"price,omitempty" db:"price"`
+	Status       string    `json:"status" db:"status"`
+	ImageURL     *string   `json:"image_url,omitempty" db:"image_url"`
+	HostID       uuid.UUID `json:"host_id" db:"host_id"`
+	HostName     string    `json:"host_name" db:"host_name"`
+	DistanceKm   *float64  `json:"distance_km,omitempty" db:"distance_km"`
+	Score        float64   `json:"score" db:"score"`
+}
+
+// PostResult represents a public post matching a search
+type PostResult struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	Content         string     `json:"content" db:"content"`
+	AuthorID        uuid.UUID  `json:"author_id" db:"author_id"`
+	AuthorName      string     `json:"author_name" db:"author_name"`
+	AuthorAvatarURL *string    `json:"author_avatar_url,omitempty" db:"author_avatar_url"`
+	AttachedEventID *uuid.UUID `json:"attached_event_id,omitempty" db:"attached_event_id"`
+	LikesCount      int        `json:"likes_count" db:"likes_count"`
+	CommentsCount   int        `json:"comments_count" db:"comments_count"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	Score           float64    `json:"score" db:"score"`
+}
+
+// HashtagResult represents a hashtag used in public posts
+type HashtagResult struct {
+	Tag        string `json:"tag" db:"tag"`
+	PostsCount int    `json:"posts_count" db:"posts_count"`
+}
+
+// UserResult represents a user matching a search
+type UserResult struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	Name       string    `json:"name" db:"name"`
+	Username   string    `json:"username" db:"username"`
+	Bio        *string   `json:"bio,omitempty" db:"bio"`
+	AvatarURL  *string   `json:"avatar_url,omitempty" db:"avatar_url"`
+	IsVerified bool      `json:"is_verified" db:"is_verified"`
+	Score      float64   `json:"score" db:"score"`
+}
+
+// CommunityResult represents a community matching a search
+type CommunityResult struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	Slug         string    `json:"slug" db:"slug"`
+	Description  *string   `json:"description,omitempty" db:"description"`
+	AvatarURL    *string   `json:"avatar_url,omitempty" db:"avatar_url"`
+	Privacy      string    `json:"privacy" db:"privacy"`
+	MembersCount int       `json:"members_count" db:"members_count"`
+	Score        float64   `json:"score" db:"score"`
+}
+
+// Facets holds the number of matches per result type
+type Facets struct {
+	Events      int `json:"events" db:"events"`
+	Posts       int `json:"posts" db:"posts"`
+	Hashtags    int `json:"hashtags" db:"hashtags"`
+	Users       int `json:"users" db:"users"`
+	Communities int `json:"communities" db:"communities"`
+}
+
+// Results represents a unified search response
+type Results struct {
+	Query       string            `json:"query"`
+	Type        Type              `json:"type"`
+	Facets      Facets            `json:"facets"`
+	Events      []EventResult     `json:"events"`
+	Posts       []PostResult      `json:"posts"`
+	Hashtags    []HashtagResult   `json:"hashtags"`
+	Users       []UserResult      `json:"users"`
+	Communities []CommunityResult `json:"communities"`
+}
+
+// Business logic methods
+
+// IsValid reports whether t is a known search type
+func (t Type) IsValid() bool {
+	switch t {
+	case TypeAll, TypeEvents, TypePosts, TypeHashtags, TypeUsers, TypeCommunities:
+		return true
+	}
+	return false
+}
+
+// HasLocation reports whether proximity ranking can be applied
+func (p *Params) HasLocation() bool {
+	return p.Lat != nil && p.Lng != nil
+}
+
+// Count returns the facet count for a single result type
+func (f Facets) Count(t Type) int {
+	switch t {
+	case TypeEvents:
+		return f.Events
+	case TypePosts:
+		return f.Posts
+	case TypeHashtags:
+		return f.Hashtags
+	case TypeUsers:
+		return f.Users
+	case TypeCommunities:
+		return f.Communities
+	}
+	return f.Events + f.Posts + f.Hashtags + f.Users + f.Communities
+}
@@ -0,0 +1,20 @@
+package search
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for full-text search
+type Repository interface {
+	// Per-type searches, ordered by score
+	SearchEvents(ctx context.Context, params *Params) ([]EventResult, error)
+	SearchPosts(ctx context.Context, params *Params) ([]PostResult, error)
+	SearchHashtags(ctx context.Context, params *Params) ([]HashtagResult, error)
+	SearchUsers(ctx context.Context, params *Params) ([]UserResult, error)
+	SearchCommunities(ctx context.Context, params *Params) ([]CommunityResult, error)
+
+	// Facets
+	CountResults(ctx context.Context, query string, viewerID uuid.UUID) (*Facets, error)
+}
@@ -1,11 +1,19 @@
 package ticket
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Errors returned by the Repository when tickets cannot get a seat
+var (
+	ErrTierSoldOut    = errors.New("ticket tier is sold out") // Tier quota reached
+	ErrEventFull      = errors.New("event is full")           // Event capacity reached, counting seats offered to the waitlist
+	ErrEventCancelled = errors.New("event is cancelled")      // No seats are handed out for cancelled events
+)
+
 // TicketStatus represents the status of a ticket
 type TicketStatus string
 
@@ -19,26 +27,118 @@ const (
 
 // Ticket represents an event ticket
 type Ticket struct {
-	ID             uuid.UUID    `json:"id" db:"id"`
-	UserID         uuid.UUID    `json:"user_id" db:"user_id"`
-	EventID        uuid.UUID    `json:"event_id" db:"event_id"`
-	AttendanceCode string       `json:"attendance_code" db:"attendance_code"`
-	PricePaid      float64      `json:"price_paid" db:"price_paid"`
-	PurchasedAt    time.Time    `json:"purchased_at" db:"purchased_at"`
-	IsCheckedIn    bool         `json:"is_checked_in" db:"is_checked_in"`
-	CheckedInAt    *time.Time   `json:"checked_in_at,omitempty" db:"checked_in_at"`
-	Status         TicketStatus `json:"status" db:"status"`
+	ID                uuid.UUID    `json:"id" db:"id"`
+	UserID            uuid.UUID    `json:"user_id" db:"user_id"`
+	EventID           uuid.UUID    `json:"event_id" db:"event_id"`
+	TierID            *uuid.UUID   `json:"tier_id,omitempty" db:"tier_id"`
+	OrderID           *uuid.UUID   `json:"order_id,omitempty" db:"order_id"`
+	IsAssigned        bool         `json:"is_assigned" db:"is_assigned"` // false while the buyer still holds it for someone else
+	InviteToken       *string      `json:"-" db:"invite_token"`
+	AttendanceCode    string       `json:"attendance_code" db:"attendance_code"`
+	PricePaid         float64      `json:"price_paid" db:"price_paid"`
+	PurchasedAt       time.Time    `json:"purchased_at" db:"purchased_at"`
+	IsCheckedIn       bool         `json:"is_checked_in" db:"is_checked_in"`
+	CheckedInAt       *time.Time   `json:"checked_in_at,omitempty" db:"checked_in_at"`
+	CheckedInDeviceID *string      `json:"checked_in_device_id,omitempty" db:"checked_in_device_id"` // scanner device, nil when checked in online
+	Status            TicketStatus `json:"status" db:"status"`
 }
 
 // TicketWithDetails includes additional ticket information
 type TicketWithDetails struct {
 	Ticket
-	UserName       string    `json:"user_name"`
-	UserEmail      string    `json:"user_email"`
-	EventTitle     string    `json:"event_title"`
-	EventStartTime time.Time `json:"event_start_time"`
-	EventLocation  string    `json:"event_location"`
-	QRCode         *string   `json:"qr_code,omitempty"` // Base64-encoded QR code PNG
+	UserName       string                `json:"user_name" db:"user_name"`
+	UserEmail      string                `json:"user_email" db:"user_email"`
+	UserAvatarURL  *string               `json:"user_avatar_url,omitempty" db:"user_avatar_url"`
+	EventTitle     string                `json:"event_title" db:"event_title"`
+	EventStartTime time.Time             `json:"event_start_time" db:"event_start_time"`
+	EventLocation  string                `json:"event_location" db:"event_location"`
+	TierName       *string               `json:"tier_name,omitempty" db:"tier_name"`
+	QRCode         *string               `json:"qr_code,omitempty" db:"-"`   // Base64-encoded QR code PNG
+	Transfers      []TransferWithDetails `json:"transfers,omitempty" db:"-"` // Transfer history, only shown to the host
+}
+
+// Order represents a checkout holding one or more tickets paid together
+type Order struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	BuyerID        uuid.UUID  `json:"buyer_id" db:"buyer_id"`
+	EventID        uuid.UUID  `json:"event_id" db:"event_id"`
+	TierID         *uuid.UUID `json:"tier_id,omitempty" db:"tier_id"`
+	Quantity       int        `json:"quantity" db:"quantity"`
+	UnitPrice      float64    `json:"unit_price" db:"unit_price"`
+	DiscountAmount float64    `json:"discount_amount" db:"discount_amount"`
+	TotalAmount    float64    `json:"total_amount" db:"total_amount"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// OrderWithTickets includes the tickets of an order
+type OrderWithTickets struct {
+	Order
+	Tickets []TicketWithDetails `json:"tickets"`
+}
+
+// TicketInvite represents a shareable link for handing over an unassigned ticket
+type TicketInvite struct {
+	TicketID uuid.UUID `json:"ticket_id"`
+	Token    string    `json:"token"`
+	ClaimURL string    `json:"claim_url"` // API path the recipient calls to claim the ticket
+}
+
+// TransferStatus represents the status of a ticket transfer
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"   // Waiting for the recipient
+	TransferAccepted  TransferStatus = "accepted"  // Ticket now belongs to the recipient
+	TransferDeclined  TransferStatus = "declined"  // Rejected by the recipient
+	TransferCancelled TransferStatus = "cancelled" // Withdrawn by the sender
+	TransferExpired   TransferStatus = "expired"   // Ticket or event no longer allowed it
+)
+
+// Transfer represents a ticket holder handing a ticket to another user
+// Transfers are never deleted and form the audit trail of a ticket's holders
+type Transfer struct {
+	ID                uuid.UUID      `json:"id" db:"id"`
+	TicketID          uuid.UUID      `json:"ticket_id" db:"ticket_id"`
+	EventID           uuid.UUID      `json:"event_id" db:"event_id"`
+	FromUserID        uuid.UUID      `json:"from_user_id" db:"from_user_id"`
+	ToUserID          uuid.UUID      `json:"to_user_id" db:"to_user_id"`
+	Status            TransferStatus `json:"status" db:"status"`
+	OldAttendanceCode string         `json:"-" db:"old_attendance_code"`
+	NewAttendanceCode *string        `json:"-" db:"new_attendance_code"`
+	CreatedAt         time.Time      `json:"created_at" db:"created_at"`
+	RespondedAt       *time.Time     `json:"responded_at,omitempty" db:"responded_at"`
+}
+
+// TransferWithDetails includes user and event information of a transfer
+type TransferWithDetails struct {
+	Transfer
+	FromUserName   string    `json:"from_user_name" db:"from_user_name"`
+	ToUserName     string    `json:"to_user_name" db:"to_user_name"`
+	EventTitle     string    `json:"event_title" db:"event_title"`
+	EventStartTime time.Time `json:"event_start_time" db:"event_start_time"`
+}
+
+// Tier represents a ticket tier of an event (e.g. early bird, VIP)
+type Tier struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	EventID      uuid.UUID  `json:"event_id" db:"event_id"`
+	Name         string     `json:"name" db:"name"`
+	Description  *string    `json:"description,omitempty" db:"description"`
+	Price        float64    `json:"price" db:"price"`
+	Quota        int        `json:"quota" db:"quota"`
+	SaleStartsAt *time.Time `json:"sale_starts_at,omitempty" db:"sale_starts_at"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at,omitempty" db:"sale_ends_at"`
+	SortOrder    int        `json:"sort_order" db:"sort_order"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// TierWithAvailability includes live sales information for a tier
+type TierWithAvailability struct {
+	Tier
+	Sold      int  `json:"sold" db:"sold"`
+	Remaining int  `json:"remaining" db:"-"`
+	IsOnSale  bool `json:"is_on_sale" db:"-"`
 }
 
 // TransactionStatus represents the payment transaction status
@@ -63,23 +163,252 @@ type TicketTransaction struct {
 	CompletedAt   *time.Time        `json:"completed_at,omitempty" db:"completed_at"`
 }
 
+// TransactionWithDetails is a transaction with its ticket, event and buyer, as shown to platform staff
+type TransactionWithDetails struct {
+	TicketTransaction
+	TicketStatus TicketStatus `json:"ticket_status" db:"ticket_status"`
+	EventID      uuid.UUID    `json:"event_id" db:"event_id"`
+	EventTitle   string       `json:"event_title" db:"event_title"`
+	UserID       uuid.UUID    `json:"user_id" db:"user_id"`
+	UserName     string       `json:"user_name" db:"user_name"`
+	UserEmail    string       `json:"user_email" db:"user_email"`
+}
+
+// TransactionFilter represents filters for the platform-wide transaction list
+type TransactionFilter struct {
+	Status  *TransactionStatus
+	EventID *uuid.UUID
+	UserID  *uuid.UUID
+	Limit   int
+	Offset  int
+}
+
 // PurchaseTicketRequest represents ticket purchase data
 type PurchaseTicketRequest struct {
-	EventID       uuid.UUID `json:"event_id" binding:"required"`
-	PaymentMethod *string   `json:"payment_method,omitempty"` // null for free events
+	EventID       uuid.UUID  `json:"event_id" binding:"required"`
+	TierID        *uuid.UUID `json:"tier_id,omitempty"`        // required when the event has tiers
+	PromoCode     *string    `json:"promo_code,omitempty"`     // optional discount code from the host
+	PaymentMethod *string    `json:"payment_method,omitempty"` // null for free events
+	// Number of tickets in the order, defaults to 1. The buyer gets the first
+	// ticket, the rest stay unassigned until handed to other users.
+	Quantity int `json:"quantity,omitempty" binding:"omitempty,min=1,max=10"`
+}
+
+// CreateTierRequest represents ticket tier creation data
+type CreateTierRequest struct {
+	Name         string     `json:"name" binding:"required,min=1,max=100"`
+	Description  *string    `json:"description,omitempty"`
+	Price        float64    `json:"price" binding:"min=0"`
+	Quota        int        `json:"quota" binding:"required,min=1"`
+	SaleStartsAt *time.Time `json:"sale_starts_at,omitempty"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at,omitempty"`
+	SortOrder    int        `json:"sort_order"`
+}
+
+// UpdateTierRequest represents ticket tier update data
+type UpdateTierRequest struct {
+	Name         *string    `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Description  *string    `json:"description,omitempty"`
+	Price        *float64   `json:"price,omitempty" binding:"omitempty,min=0"`
+	Quota        *int       `json:"quota,omitempty" binding:"omitempty,min=1"`
+	SaleStartsAt *time.Time `json:"sale_starts_at,omitempty"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at,omitempty"`
+	SortOrder    *int       `json:"sort_order,omitempty"`
+}
+
+// AssignTicketRequest represents handing an unassigned ticket to another user
+type AssignTicketRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+}
+
+// TransferTicketRequest represents a request to transfer a ticket to another user
+type TransferTicketRequest struct {
+	ToUserID uuid.UUID `json:"to_user_id" binding:"required"`
 }
 
 // CheckInRequest represents check-in data
+// Either the scanned QR content or the attendance code must be provided
 type CheckInRequest struct {
-	AttendanceCode string `json:"attendance_code" binding:"required,len=4"`
+	EventID        *uuid.UUID `json:"event_id,omitempty"` // only read when the route has no event in its path
+	QRCode         string     `json:"qr_code,omitempty"`
+	AttendanceCode string     `json:"attendance_code,omitempty" binding:"omitempty,len=4"`
+}
+
+// CheckInResult is returned to the scanner after a successful check-in
+type CheckInResult struct {
+	TicketID          uuid.UUID  `json:"ticket_id"`
+	EventID           uuid.UUID  `json:"event_id"`
+	AttendeeID        uuid.UUID  `json:"attendee_id"`
+	AttendeeName      string     `json:"attendee_name"`
+	AttendeeAvatarURL *string    `json:"attendee_avatar_url,omitempty"`
+	TierName          *string    `json:"tier_name,omitempty"`
+	CheckedInAt       *time.Time `json:"checked_in_at,omitempty"`
+	AlreadyCheckedIn  bool       `json:"already_checked_in"` // true when the ticket was scanned before
+}
+
+// CheckInSnapshot is the signed list of valid tickets a scanner device keeps for offline check-in
+// A device looks scanned QR codes up by ticket ID; attendance codes are not shipped and are
+// verified when the scans are synced. Signature is the base64url Ed25519 signature of the
+// snapshot JSON without the signature field, checked against the check-in public key.
+type CheckInSnapshot struct {
+	EventID     uuid.UUID        `json:"event_id"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	Tickets     []SnapshotTicket `json:"tickets"`
+	Signature   string           `json:"signature,omitempty"`
+}
+
+// SnapshotTicket is a compact ticket entry of a check-in snapshot
+type SnapshotTicket struct {
+	TicketID     uuid.UUID `json:"id"`
+	AttendeeName string    `json:"name"`
+	TierName     *string   `json:"tier,omitempty"`
+	CheckedIn    bool      `json:"checked_in"`
+}
+
+// OfflineCheckInStatus represents how an uploaded offline scan was reconciled
+type OfflineCheckInStatus string
+
+const (
+	OfflineCheckInAccepted  OfflineCheckInStatus = "accepted"  // This scan is the ticket's check-in
+	OfflineCheckInDuplicate OfflineCheckInStatus = "duplicate" // An earlier scan already checked the ticket in
+	OfflineCheckInRejected  OfflineCheckInStatus = "rejected"  // The ticket is not valid for entry
+)
+
+// OfflineCheckIn is the log entry of a scan uploaded by a scanner device
+type OfflineCheckIn struct {
+	ID         uuid.UUID            `json:"id" db:"id"`
+	EventID    uuid.UUID            `json:"event_id" db:"event_id"`
+	TicketID   uuid.UUID            `json:"ticket_id" db:"ticket_id"`
+	DeviceID   string               `json:"device_id" db:"device_id"`
+	ScannedAt  time.Time            `json:"scanned_at" db:"scanned_at"`
+	UploadedBy uuid.UUID            `json:"uploaded_by" db:"uploaded_by"`
+	Status     OfflineCheckInStatus `json:"status" db:"status"`
+	Reason     *string              `json:"reason,omitempty" db:"reason"`
+	UploadedAt time.Time            `json:"uploaded_at" db:"uploaded_at"`
+}
+
+// OfflineScan is a single check-in recorded by a scanner device while offline
+type OfflineScan struct {
+	TicketID       uuid.UUID `json:"ticket_id" binding:"required"`
+	AttendanceCode string    `json:"attendance_code" binding:"required,len=4"`
+	ScannedAt      time.Time `json:"scanned_at" binding:"required"`
+}
+
+// SyncCheckInsRequest represents a batch of offline check-ins uploaded by a scanner device
+type SyncCheckInsRequest struct {
+	DeviceID string        `json:"device_id" binding:"required,max=100"`
+	CheckIns []OfflineScan `json:"check_ins" binding:"required,min=1,max=1000,dive"`
+}
+
+// OfflineScanResult reports the outcome of one uploaded scan
+// For duplicates CheckedInAt and CheckedInDeviceID describe the scan that won
+type OfflineScanResult struct {
+	TicketID          uuid.UUID            `json:"ticket_id"`
+	ScannedAt         time.Time            `json:"scanned_at"`
+	Status            OfflineCheckInStatus `json:"status"`
+	Reason            string               `json:"reason,omitempty"`
+	CheckedInAt       *time.Time           `json:"checked_in_at,omitempty"`
+	CheckedInDeviceID *string              `json:"checked_in_device_id,omitempty"` // nil when checked in online
+}
+
+// SyncCheckInsResult is the reconciliation report of an offline check-in batch
+type SyncCheckInsResult struct {
+	EventID    uuid.UUID           `json:"event_id"`
+	DeviceID   string              `json:"device_id"`
+	Accepted   int                 `json:"accepted"`
+	Duplicates int                 `json:"duplicates"`
+	Rejected   int                 `json:"rejected"`
+	Results    []OfflineScanResult `json:"results"`
 }
 
 // PurchaseTicketResponse represents the response after purchasing a ticket
 type PurchaseTicketResponse struct {
-	Ticket       *Ticket `json:"ticket"`
-	PaymentToken *string `json:"payment_token,omitempty"` // Snap token for paid events
-	PaymentURL   *string `json:"payment_url,omitempty"`   // Redirect URL for payment
-	QRCode       *string `json:"qr_code,omitempty"`       // Base64-encoded QR code PNG
+	Ticket       *Ticket           `json:"ticket"`                  // The buyer's own ticket
+	Order        *OrderWithTickets `json:"order"`                   // All tickets of the order, each with its own QR code
+	PaymentToken *string           `json:"payment_token,omitempty"` // Snap token for paid events
+	PaymentURL   *string           `json:"payment_url,omitempty"`   // Redirect URL for payment
+	QRCode       *string           `json:"qr_code,omitempty"`       // Base64-encoded QR code PNG
+}
+
+// AttendeeExportRow is one attendee in an attendee list export
+// ContactShared is true when the attendee agreed to share their contact details with hosts
+type AttendeeExportRow struct {
+	TicketID          uuid.UUID    `db:"ticket_id"`
+	AttendanceCode    string       `db:"attendance_code"`
+	UserName          string       `db:"user_name"`
+	UserEmail         string       `db:"user_email"`
+	ContactShared     bool         `db:"contact_shared"`
+	TierName          *string      `db:"tier_name"`
+	PricePaid         float64      `db:"price_paid"`
+	Status            TicketStatus `db:"status"`
+	PurchasedAt       time.Time    `db:"purchased_at"`
+	IsCheckedIn       bool         `db:"is_checked_in"`
+	CheckedInAt       *time.Time   `db:"checked_in_at"`
+	CheckedInDeviceID *string      `db:"checked_in_device_id"`
+}
+
+// TransactionExportRow is one payment transaction in a transaction export
+type TransactionExportRow struct {
+	TransactionID  string            `db:"transaction_id"`
+	TicketID       uuid.UUID         `db:"ticket_id"`
+	UserName       string            `db:"user_name"`
+	UserEmail      string            `db:"user_email"`
+	ContactShared  bool              `db:"contact_shared"`
+	TierName       *string           `db:"tier_name"`
+	Amount         float64           `db:"amount"`
+	PromoCode      *string           `db:"promo_code"`
+	DiscountAmount float64           `db:"discount_amount"`
+	PaymentMethod  string            `db:"payment_method"`
+	Status         TransactionStatus `db:"status"`
+	CreatedAt      time.Time         `db:"created_at"`
+	CompletedAt    *time.Time        `db:"completed_at"`
+	IsCheckedIn    bool              `db:"is_checked_in"`
+}
+
+// EventRevenueRow is the revenue of one event, used by host revenue reports and exports
+type EventRevenueRow struct {
+	EventID          uuid.UUID `db:"event_id"`
+	Title            string    `db:"title"`
+	Category         string    `db:"category"`
+	Status           string    `db:"status"`
+	StartTime        time.Time `db:"start_time"`
+	Price            *float64  `db:"price"`
+	IsFree           bool      `db:"is_free"`
+	MaxAttendees     int       `db:"max_attendees"`
+	TicketsSold      int       `db:"tickets_sold"`
+	TicketsCheckedIn int       `db:"tickets_checked_in"`
+	Revenue          float64   `db:"revenue"`
+	RefundedAmount   float64   `db:"refunded_amount"`
+	Discounts        float64   `db:"discounts"`
+}
+
+// TransactionTotal is the number and sum of an event's transactions in one status
+type TransactionTotal struct {
+	Status TransactionStatus `db:"status"`
+	Count  int               `db:"count"`
+	Amount float64           `db:"amount"`
+}
+
+// PaymentMethodTotal is the number and sum of an event's successful payments with one method
+type PaymentMethodTotal struct {
+	Method string  `db:"method"`
+	Count  int     `db:"count"`
+	Amount float64 `db:"amount"`
+}
+
+// TierRevenue is the paid and refunded amount of one ticket tier
+type TierRevenue struct {
+	TierID          uuid.UUID `db:"tier_id"`
+	Revenue         float64   `db:"revenue"`
+	RefundedRevenue float64   `db:"refunded_revenue"`
+}
+
+// SalesBucket is the successful sales completed within one hour, day or week
+// BucketStart is wall-clock time in the timezone the buckets were computed in
+type SalesBucket struct {
+	BucketStart  time.Time `db:"bucket_start"`
+	Transactions int       `db:"transactions"`
+	Revenue      float64   `db:"revenue"`
 }
 
 // Business logic methods
@@ -94,3 +423,21 @@ func (t *Ticket) IsValid() bool {
 func (t *Ticket) CanBeRefunded() bool {
 	return t.Status == StatusActive && !t.IsCheckedIn
 }
+
+func (t *Ticket) HoldsSeat() bool {
+	return t.Status == StatusActive || t.Status == StatusPending
+}
+
+func (t *Ticket) CanBeTransferred() bool {
+	return t.Status == StatusActive && t.IsAssigned && !t.IsCheckedIn
+}
+
+func (t *Tier) IsOnSale(now time.Time) bool {
+	if t.SaleStartsAt != nil && now.Before(*t.SaleStartsAt) {
+		return false
+	}
+	if t.SaleEndsAt != nil && !now.Before(*t.SaleEndsAt) {
+		return false
+	}
+	return true
+}
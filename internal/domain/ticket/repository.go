@@ -2,6 +2,7 @@ package ticket
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -18,7 +19,7 @@ type Repository interface {
 	// Ticket queries
 	GetByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]TicketWithDetails, error)
 	GetByEvent(ctx context.Context, eventID uuid.UUID, limit, offset int) ([]TicketWithDetails, error)
-	GetByAttendanceCode(ctx context.Context, code string) (*Ticket, error)
+	GetByAttendanceCode(ctx context.Context, eventID uuid.UUID, code string) (*Ticket, error)
 	GetUserTicketForEvent(ctx context.Context, userID, eventID uuid.UUID) (*Ticket, error)
 
 	// Counting for pagination
@@ -29,12 +30,67 @@ type Repository interface {
 	CheckIn(ctx context.Context, ticketID uuid.UUID) error
 	GetCheckedInCount(ctx context.Context, eventID uuid.UUID) (int, error)
 
+	// Offline check-in
+	GetCheckInRoster(ctx context.Context, eventID uuid.UUID) ([]TicketWithDetails, error)
+	CheckInOffline(ctx context.Context, ticketID uuid.UUID, deviceID string, scannedAt time.Time) error
+	CreateOfflineCheckIns(ctx context.Context, checkIns []OfflineCheckIn) error
+
 	// Transaction
 	CreateTransaction(ctx context.Context, transaction *TicketTransaction) error
 	GetTransaction(ctx context.Context, transactionID string) (*TicketTransaction, error)
 	UpdateTransactionStatus(ctx context.Context, transactionID string, status TransactionStatus) error
 
+	// Platform-wide transactions for staff
+	GetTransactionByID(ctx context.Context, id uuid.UUID) (*TransactionWithDetails, error)
+	GetTransactions(ctx context.Context, filter *TransactionFilter) ([]TransactionWithDetails, error)
+	CountTransactions(ctx context.Context, filter *TransactionFilter) (int, error)
+
+	// Orders
+	CreateOrder(ctx context.Context, order *Order, tickets []*Ticket) error
+	GetOrderByID(ctx context.Context, orderID uuid.UUID) (*Order, error)
+	GetTicketsByOrder(ctx context.Context, orderID uuid.UUID) ([]TicketWithDetails, error)
+	DeleteOrder(ctx context.Context, orderID uuid.UUID) error
+	GetTransactionsByTransactionID(ctx context.Context, transactionID string) ([]TicketTransaction, error)
+
+	// Assignment of unassigned order tickets
+	AssignTicket(ctx context.Context, ticketID, userID uuid.UUID) error
+	SetInviteToken(ctx context.Context, ticketID uuid.UUID, token string) error
+	GetByInviteToken(ctx context.Context, token string) (*Ticket, error)
+
+	// Transfers between users
+	CreateTransfer(ctx context.Context, transfer *Transfer) error
+	GetTransferByID(ctx context.Context, transferID uuid.UUID) (*Transfer, error)
+	GetPendingTransferByTicket(ctx context.Context, ticketID uuid.UUID) (*Transfer, error)
+	GetIncomingTransfers(ctx context.Context, userID uuid.UUID) ([]TransferWithDetails, error)
+	GetTransfersByEvent(ctx context.Context, eventID uuid.UUID) ([]TransferWithDetails, error)
+	UpdateTransferStatus(ctx context.Context, transferID uuid.UUID, status TransferStatus) error
+	CompleteTransfer(ctx context.Context, transfer *Transfer) error
+
+	// Ticket tiers
+	CreateTier(ctx context.Context, tier *Tier) error
+	GetTierByID(ctx context.Context, tierID uuid.UUID) (*Tier, error)
+	GetTiersByEvent(ctx context.Context, eventID uuid.UUID) ([]TierWithAvailability, error)
+	UpdateTier(ctx context.Context, tier *Tier) error
+	DeleteTier(ctx context.Context, tierID uuid.UUID) error
+	CountTierSeatsTaken(ctx context.Context, tierID uuid.UUID) (int, error)
+	CountTierTickets(ctx context.Context, tierID uuid.UUID) (int, error)
+
+	// Capacity
+	CountSeatsTaken(ctx context.Context, eventID uuid.UUID) (int, error)
+	ActivatePaidTicket(ctx context.Context, ticketID uuid.UUID) (*Ticket, error)
+	ExpirePendingTickets(ctx context.Context, cutoff time.Time) ([]Ticket, error)
+
 	// Analytics - get tickets and transactions for analytics
 	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]Ticket, error)
 	GetTransactionsByTicketID(ctx context.Context, ticketID uuid.UUID) ([]TicketTransaction, error)
+	GetTransactionTotals(ctx context.Context, eventID uuid.UUID) ([]TransactionTotal, error)
+	GetPaymentMethodTotals(ctx context.Context, eventID uuid.UUID) ([]PaymentMethodTotal, error)
+	GetTierRevenue(ctx context.Context, eventID uuid.UUID) ([]TierRevenue, error)
+	GetSalesTimeline(ctx context.Context, eventID uuid.UUID, bucket, timezone string) ([]SalesBucket, error)
+	GetHostEventRevenue(ctx context.Context, hostID uuid.UUID, startDate, endDate *time.Time) ([]EventRevenueRow, error)
+
+	// Exports - rows are passed to fn one at a time as they are read from the database
+	StreamAttendees(ctx context.Context, eventID uuid.UUID, fn func(*AttendeeExportRow) error) error
+	StreamTransactions(ctx context.Context, eventID uuid.UUID, status string, fn func(*TransactionExportRow) error) error
+	StreamHostEventRevenue(ctx context.Context, hostID uuid.UUID, status string, fn func(*EventRevenueRow) error) error
 }
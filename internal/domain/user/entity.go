@@ -7,23 +7,53 @@ import (
 	"github.com/google/uuid"
 )
 
+// Role is a user's platform role
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator" // Platform staff handling reports and suspensions
+	RoleAdmin     Role = "admin"     // Platform staff holding every permission
+)
+
 // User represents a user in the system (Google Auth only)
 type User struct {
-	ID              uuid.UUID  `json:"id" db:"id"`
-	Email           string     `json:"email" db:"email"`
-	Name            string     `json:"name" db:"name"`
-	Bio             *string    `json:"bio,omitempty" db:"bio"`
-	AvatarURL       *string    `json:"avatar_url,omitempty" db:"avatar_url"`
-	Phone           *string    `json:"phone,omitempty" db:"phone"`
-	DateOfBirth     *time.Time `json:"date_of_birth,omitempty" db:"date_of_birth"`
-	Gender          *string    `json:"gender,omitempty" db:"gender"`
-	Location        *string    `json:"location,omitempty" db:"location"`
-	Interests       []string   `json:"interests" db:"interests"` // PostgreSQL text array
-	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
-	LastLoginAt     *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
-	IsVerified      bool       `json:"is_verified" db:"is_verified"`
-	IsEmailVerified bool       `json:"is_email_verified" db:"is_email_verified"`
+	ID                  uuid.UUID  `json:"id" db:"id"`
+	Email               string     `json:"email" db:"email"`
+	Username            string     `json:"username" db:"username"` // Unique regardless of case
+	Name                string     `json:"name" db:"name"`
+	Bio                 *string    `json:"bio,omitempty" db:"bio"`
+	AvatarURL           *string    `json:"avatar_url,omitempty" db:"avatar_url"`
+	Phone               *string    `json:"phone,omitempty" db:"phone"`
+	DateOfBirth         *time.Time `json:"date_of_birth,omitempty" db:"date_of_birth"`
+	Gender              *string    `json:"gender,omitempty" db:"gender"`
+	Location            *string    `json:"location,omitempty" db:"location"`
+	Interests           []string   `json:"interests" db:"interests"` // PostgreSQL text array
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+	LastLoginAt         *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+	IsVerified          bool       `json:"is_verified" db:"is_verified"`
+	IsEmailVerified     bool       `json:"is_email_verified" db:"is_email_verified"`
+	Role                Role       `json:"role,omitempty" db:"role"`
+	SuspendedAt         *time.Time `json:"suspended_at,omitempty" db:"suspended_at"`
+	SuspendedUntil      *time.Time `json:"suspended_until,omitempty" db:"suspended_until"` // nil while suspended means indefinitely
+	SuspensionReason    *string    `json:"suspension_reason,omitempty" db:"suspension_reason"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" db:"deletion_scheduled_at"` // Account is deleted at this time unless cancelled
+}
+
+// IsAdmin reports whether the user is a platform admin
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// IsStaff reports whether the user holds any platform staff role
+func (u *User) IsStaff() bool {
+	return u.Role == RoleModerator || u.Role == RoleAdmin
+}
+
+// IsSuspended reports whether the user is suspended at the given time
+func (u *User) IsSuspended(now time.Time) bool {
+	return u.SuspendedAt != nil && (u.SuspendedUntil == nil || u.SuspendedUntil.After(now))
 }
 
 // UserSettings contains user preferences
@@ -52,12 +82,13 @@ type UserStats struct {
 
 // UserPrivacy contains privacy settings
 type UserPrivacy struct {
-	UserID         uuid.UUID `json:"user_id" db:"user_id"`
-	ProfileVisible bool      `json:"profile_visible" db:"profile_visible"`
-	EventsVisible  bool      `json:"events_visible" db:"events_visible"`
-	AllowFollowers bool      `json:"allow_followers" db:"allow_followers"`
-	ShowEmail      bool      `json:"show_email" db:"show_email"`
-	ShowLocation   bool      `json:"show_location" db:"show_location"`
+	UserID                uuid.UUID `json:"user_id" db:"user_id"`
+	ProfileVisible        bool      `json:"profile_visible" db:"profile_visible"`
+	EventsVisible         bool      `json:"events_visible" db:"events_visible"`
+	AllowFollowers        bool      `json:"allow_followers" db:"allow_followers"`
+	ShowEmail             bool      `json:"show_email" db:"show_email"`
+	ShowLocation          bool      `json:"show_location" db:"show_location"`
+	ShareContactWithHosts bool      `json:"share_contact_with_hosts" db:"share_contact_with_hosts"` // Hosts see full name and email in attendee exports
 }
 
 // Follow represents a follow relationship
@@ -68,13 +99,43 @@ type Follow struct {
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
+// FollowRequest is a pending follow of a user who does not allow open following
+type FollowRequest struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	RequesterID uuid.UUID `json:"requester_id" db:"requester_id"`
+	TargetID    uuid.UUID `json:"target_id" db:"target_id"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// FollowRequestWithUser is an incoming follow request with the requester's public info
+type FollowRequestWithUser struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	Requester User      `json:"requester" db:"-"`
+}
+
+// FollowStatus is the outcome of a follow attempt
+type FollowStatus string
+
+const (
+	FollowStatusFollowing FollowStatus = "following"
+	FollowStatusRequested FollowStatus = "requested"
+)
+
+// FollowResult is returned when following a user
+type FollowResult struct {
+	Status FollowStatus `json:"status"`
+}
+
 // UserProfile is a complete user profile with stats and settings
 type UserProfile struct {
-	User        User         `json:"user"`
-	Settings    UserSettings `json:"settings"`
-	Stats       UserStats    `json:"stats"`
-	Privacy     UserPrivacy  `json:"privacy"`
-	IsFollowing *bool        `json:"is_following,omitempty"` // nil if not applicable (e.g., own profile), true/false otherwise
+	User              User          `json:"user"`
+	Settings          *UserSettings `json:"settings,omitempty"` // Own profile only
+	Stats             UserStats     `json:"stats"`
+	Privacy           *UserPrivacy  `json:"privacy,omitempty"`             // Own profile only
+	IsFollowing       *bool         `json:"is_following,omitempty"`        // nil if not applicable (e.g., own profile), true/false otherwise
+	IsFollowRequested *bool         `json:"is_follow_requested,omitempty"` // nil if not applicable, true while a follow request is pending
+	IsRestricted      bool          `json:"is_restricted"`                 // true when privacy settings hide the profile from the viewer
 }
 
 // FlexibleTime can unmarshal from multiple date/datetime formats
@@ -130,6 +191,22 @@ type UpdateSettingsRequest struct {
 	ShowOnlineStatus   *bool   `json:"show_online_status,omitempty"`
 }
 
+// UpdatePrivacyRequest represents privacy settings update data
+type UpdatePrivacyRequest struct {
+	ProfileVisible        *bool `json:"profile_visible,omitempty"`
+	EventsVisible         *bool `json:"events_visible,omitempty"`
+	AllowFollowers        *bool `json:"allow_followers,omitempty"`
+	ShowEmail             *bool `json:"show_email,omitempty"`
+	ShowLocation          *bool `json:"show_location,omitempty"`
+	ShareContactWithHosts *bool `json:"share_contact_with_hosts,omitempty"`
+}
+
+// SetRoleRequest represents an admin changing a user's platform role
+type SetRoleRequest struct {
+	Role Role    `json:"role" binding:"required,oneof=user moderator admin"`
+	Note *string `json:"note,omitempty" binding:"omitempty,max=1000"`
+}
+
 // GoogleAuthRequest represents Google authentication data
 type GoogleAuthRequest struct {
 	IDToken string `json:"idToken" binding:"required"`
@@ -138,6 +215,7 @@ type GoogleAuthRequest struct {
 // ProfileResponse represents public profile data for API response
 type ProfileResponse struct {
 	ID                     uuid.UUID  `json:"id"`
+	Username               string     `json:"username"`
 	Name                   string     `json:"name"`
 	Bio                    *string    `json:"bio,omitempty"`
 	AvatarURL              *string    `json:"avatar_url,omitempty"`
@@ -155,12 +233,13 @@ type ProfileResponse struct {
 	PostsCount             int        `json:"posts_count"`
 	InvitesSuccessfulCount int        `json:"invites_successful_count"`
 	ShareLink              string     `json:"share_link"`
+	IsRestricted           bool       `json:"is_restricted"`
 }
 
 // ToProfileResponse converts UserProfile to ProfileResponse
 func (up *UserProfile) ToProfileResponse(baseURL string) ProfileResponse {
-	// Generate share link using user ID (no username in Google-only auth)
-	shareLink := baseURL + "/user/" + up.User.ID.String()
+	// Share links use the handle; old handles keep redirecting after a change
+	shareLink := baseURL + "/user/" + up.User.Username
 
 	// Initialize empty interests array if nil
 	interests := up.User.Interests
@@ -170,6 +249,7 @@ func (up *UserProfile) ToProfileResponse(baseURL string) ProfileResponse {
 
 	return ProfileResponse{
 		ID:                     up.User.ID,
+		Username:               up.User.Username,
 		Name:                   up.User.Name,
 		Bio:                    up.User.Bio,
 		AvatarURL:              up.User.AvatarURL,
@@ -187,5 +267,6 @@ func (up *UserProfile) ToProfileResponse(baseURL string) ProfileResponse {
 		PostsCount:             up.Stats.PostsCount,
 		InvitesSuccessfulCount: up.Stats.InvitesSuccessfulCount,
 		ShareLink:              shareLink,
+		IsRestricted:           up.IsRestricted,
 	}
 }
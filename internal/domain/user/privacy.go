@@ -0,0 +1,82 @@
+package user
+
+import "github.com/google/uuid"
+
+// DefaultPrivacy returns the privacy settings of a user who never changed them.
+// Mirrors the column defaults of the user_privacy table.
+func DefaultPrivacy(userID uuid.UUID) UserPrivacy {
+	return UserPrivacy{
+		UserID:         userID,
+		ProfileVisible: true,
+		EventsVisible:  true,
+		AllowFollowers: true,
+		ShowEmail:      false,
+		ShowLocation:   true,
+	}
+}
+
+// Audience describes how a viewer relates to the owner of a profile
+type Audience struct {
+	IsSelf     bool // Viewer is the owner
+	IsFollower bool // Viewer follows the owner
+}
+
+// CanViewProfile reports whether the audience sees the full profile.
+// Hidden profiles stay visible to the owner and their followers.
+func (p *UserPrivacy) CanViewProfile(a Audience) bool {
+	return a.IsSelf || a.IsFollower || p.ProfileVisible
+}
+
+// CanViewEvents reports whether the audience sees the events a user hosts and joins
+func (p *UserPrivacy) CanViewEvents(a Audience) bool {
+	return a.IsSelf || (p.EventsVisible && p.CanViewProfile(a))
+}
+
+// ProjectUser returns a copy of u with the fields the audience may not see cleared.
+// Phone, date of birth, last login, suspension reason and scheduled deletion are personal data only the owner sees.
+func (p *UserPrivacy) ProjectUser(u User, a Audience) User {
+	if a.IsSelf {
+		return u
+	}
+
+	u.Phone = nil
+	u.DateOfBirth = nil
+	u.LastLoginAt = nil
+	u.SuspensionReason = nil
+	u.DeletionScheduledAt = nil
+
+	if !p.ShowEmail || !p.CanViewProfile(a) {
+		u.Email = ""
+	}
+	if !p.ShowLocation || !p.CanViewProfile(a) {
+		u.Location = nil
+	}
+	if !p.CanViewProfile(a) {
+		u.Bio = nil
+		u.Gender = nil
+		u.Interests = []string{}
+	}
+
+	return u
+}
+
+// ProjectFor returns a copy of the profile as seen by the audience.
+// Settings and privacy are only returned to the owner.
+func (up *UserProfile) ProjectFor(a Audience) *UserProfile {
+	if a.IsSelf {
+		return up
+	}
+
+	privacy := DefaultPrivacy(up.User.ID)
+	if up.Privacy != nil {
+		privacy = *up.Privacy
+	}
+
+	projected := *up
+	projected.User = privacy.ProjectUser(up.User, a)
+	projected.Settings = nil
+	projected.Privacy = nil
+	projected.IsRestricted = !privacy.CanViewProfile(a)
+
+	return &projected
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -22,6 +23,21 @@ type Repository interface {
 	UpdateSettings(ctx context.Context, settings *UserSettings) error
 	UpdatePrivacy(ctx context.Context, privacy *UserPrivacy) error
 
+	// Usernames
+	GetByPreviousUsername(ctx context.Context, username string) (*User, error)
+	GetIDsByUsernames(ctx context.Context, usernames []string) ([]uuid.UUID, error)
+	IsUsernameAvailable(ctx context.Context, username string, userID uuid.UUID, heldSince time.Time) (bool, error)
+	ChangeUsername(ctx context.Context, userID uuid.UUID, username string, cooldownSince time.Time) error
+	GetUsernameHistory(ctx context.Context, userID uuid.UUID) ([]UsernameChange, error)
+
+	// Platform role
+	SetRole(ctx context.Context, userID uuid.UUID, role Role) error
+	CountByRole(ctx context.Context, role Role) (int, error)
+
+	// Privacy
+	GetPrivacy(ctx context.Context, userID uuid.UUID) (*UserPrivacy, error)
+	GetPrivacyByUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]UserPrivacy, error)
+
 	// Follow system
 	Follow(ctx context.Context, followerID, followingID uuid.UUID) error
 	Unfollow(ctx context.Context, followerID, followingID uuid.UUID) error
@@ -29,10 +45,19 @@ type Repository interface {
 	GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]User, error)
 	IsFollowing(ctx context.Context, followerID, followingID uuid.UUID) (bool, error)
 
+	// Follow requests
+	CreateFollowRequest(ctx context.Context, req *FollowRequest) error
+	HasFollowRequest(ctx context.Context, requesterID, targetID uuid.UUID) (bool, error)
+	DeleteFollowRequest(ctx context.Context, requesterID, targetID uuid.UUID) error
+	GetFollowRequests(ctx context.Context, targetID uuid.UUID, limit, offset int) ([]FollowRequestWithUser, error)
+	CountFollowRequests(ctx context.Context, targetID uuid.UUID) (int, error)
+	ApproveFollowRequest(ctx context.Context, requesterID, targetID uuid.UUID) error
+	ApproveAllFollowRequests(ctx context.Context, targetID uuid.UUID) error
+
 	// Counting for pagination
 	CountFollowers(ctx context.Context, userID uuid.UUID) (int, error)
 	CountFollowing(ctx context.Context, userID uuid.UUID) (int, error)
-	CountSearchResults(ctx context.Context, query string) (int, error)
+	CountSearchResults(ctx context.Context, query string, viewerID uuid.UUID) (int, error)
 
 	// Stats
 	GetStats(ctx context.Context, userID uuid.UUID) (*UserStats, error)
@@ -41,5 +66,5 @@ type Repository interface {
 	UpdateAverageRating(ctx context.Context, userID uuid.UUID, rating float64) error
 
 	// Search
-	SearchUsers(ctx context.Context, query string, limit, offset int) ([]User, error)
+	SearchUsers(ctx context.Context, query string, viewerID uuid.UUID, limit, offset int) ([]User, error)
 }
@@ -0,0 +1,68 @@
+package user
+
+// Permission is a platform action granted to users through their role
+type Permission string
+
+const (
+	PermModerateContent    Permission = "content:moderate"    // Review reports, hide and remove content
+	PermSuspendUsers       Permission = "users:suspend"       // Suspend and unsuspend users
+	PermVerifyUsers        Permission = "users:verify"        // Grant and revoke the verified badge
+	PermManageRoles        Permission = "users:manage_roles"  // Change other users' roles
+	PermManageEvents       Permission = "events:manage"       // Feature and unpublish any event
+	PermViewTransactions   Permission = "transactions:view"   // View any ticket transaction
+	PermRefundTransactions Permission = "transactions:refund" // Refund tickets regardless of owner or event timing
+	PermManagePayouts      Permission = "payouts:manage"      // Review and settle host payouts
+	PermManageCommunities  Permission = "communities:manage"  // Edit, delete and transfer any community
+)
+
+// rolePermissions lists the permissions each role grants
+var rolePermissions = map[Role][]Permission{
+	RoleUser: {},
+	RoleModerator: {
+		PermModerateContent,
+		PermSuspendUsers,
+		PermManageEvents,
+	},
+	RoleAdmin: {
+		PermModerateContent,
+		PermSuspendUsers,
+		PermVerifyUsers,
+		PermManageRoles,
+		PermManageEvents,
+		PermViewTransactions,
+		PermRefundTransactions,
+		PermManagePayouts,
+		PermManageCommunities,
+	},
+}
+
+// IsValid reports whether the role is a known platform role
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Permissions returns the permissions the role grants
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// HasPermission reports whether the role grants the permission
+func (r Role) HasPermission(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionNames returns the user's permissions as strings, as carried in access tokens
+func (u *User) PermissionNames() []string {
+	perms := u.Role.Permissions()
+	names := make([]string, len(perms))
+	for i, p := range perms {
+		names[i] = string(p)
+	}
+	return names
+}
@@ -0,0 +1,170 @@
+package user
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Username limits
+const (
+	UsernameMinLength = 3
+	UsernameMaxLength = 30
+)
+
+var (
+	ErrUsernameInvalid  = errors.New("username must be 3 to 30 letters, digits or underscores")
+	ErrUsernameReserved = errors.New("username is reserved")
+	ErrUsernameTaken    = errors.New("username is already taken")
+	ErrUsernameCooldown = errors.New("username was changed too recently, try again later")
+)
+
+var (
+	usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{3,30}$`)
+
+	// mentionPattern matches @username not preceded by a word character, so emails are skipped
+	mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@])@([A-Za-z0-9_]{3,30})\b`)
+)
+
+// reservedUsernames clash with app routes, staff roles or the brand.
+// Keep in sync with the backfill in migrations/consolidated/33_user_handles.up.sql.
+var reservedUsernames = map[string]bool{
+	"about": true, "account": true, "admin": true, "administrator": true, "analytics": true,
+	"anigmaa": true, "api": true, "app": true, "auth": true, "blog": true,
+	"calendar": true, "communities": true, "community": true, "deleted": true, "dev": true,
+	"edit": true, "event": true, "events": true, "explore": true, "feed": true,
+	"help": true, "home": true, "login": true, "logout": true, "me": true,
+	"moderator": true, "new": true, "notifications": true, "null": true, "official": true,
+	"payments": true, "post": true, "posts": true, "privacy": true, "profile": true,
+	"qna": true, "root": true, "search": true, "security": true, "settings": true,
+	"signup": true, "staff": true, "support": true, "system": true, "team": true,
+	"terms": true, "tickets": true, "undefined": true, "user": true, "users": true,
+	"www": true,
+}
+
+// UsernameChange is a username a user gave up
+type UsernameChange struct {
+	Username  string    `json:"username" db:"username"`
+	ChangedAt time.Time `json:"changed_at" db:"changed_at"`
+}
+
+// UsernameAvailability is the result of checking a username
+type UsernameAvailability struct {
+	Username    string   `json:"username"`
+	Available   bool     `json:"available"`
+	Reason      string   `json:"reason,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"` // Available alternatives when taken
+}
+
+// UpdateUsernameRequest represents a username change
+type UpdateUsernameRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// NormalizeUsername trims whitespace and a leading @
+func NormalizeUsername(username string) string {
+	return strings.TrimPrefix(strings.TrimSpace(username), "@")
+}
+
+// ValidateUsername checks a username's format and that it is not reserved.
+// Usernames keep their casing but are compared case-insensitively.
+func ValidateUsername(username string) error {
+	if !usernamePattern.MatchString(username) {
+		return ErrUsernameInvalid
+	}
+	if reservedUsernames[strings.ToLower(username)] {
+		return ErrUsernameReserved
+	}
+	return nil
+}
+
+// SuggestUsernames derives candidate usernames from a display name, most natural first.
+// Accents are dropped and other characters become separators. Reserved words are skipped.
+func SuggestUsernames(name string) []string {
+	words := usernameWords(name)
+	if len(words) == 0 {
+		return nil
+	}
+
+	candidates := []string{
+		strings.Join(words, ""),
+		strings.Join(words, "_"),
+	}
+	if len(words) > 1 {
+		candidates = append(candidates,
+			words[0]+words[len(words)-1],
+			words[0]+"_"+words[len(words)-1],
+			words[0],
+		)
+	}
+
+	seen := make(map[string]bool, len(candidates))
+	suggestions := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if len(c) > UsernameMaxLength {
+			c = strings.TrimRight(c[:UsernameMaxLength], "_")
+		}
+		if seen[c] || ValidateUsername(c) != nil {
+			continue
+		}
+		seen[c] = true
+		suggestions = append(suggestions, c)
+	}
+	return suggestions
+}
+
+// UsernameWithSuffix appends a suffix to a username, shortening it to stay within the limit
+func UsernameWithSuffix(username, suffix string) string {
+	if max := UsernameMaxLength - len(suffix); len(username) > max {
+		username = username[:max]
+	}
+	return username + suffix
+}
+
+// ExtractMentions returns the distinct usernames mentioned with @ in a text, in order of appearance
+func ExtractMentions(text string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(text, -1)
+
+	seen := make(map[string]bool, len(matches))
+	mentions := make([]string, 0, len(matches))
+	for _, m := range matches {
+		key := strings.ToLower(m[1])
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		mentions = append(mentions, m[1])
+	}
+	return mentions
+}
+
+// usernameWords splits a name into lowercase ASCII words
+func usernameWords(name string) []string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+
+	// Decompose so accented letters become a base letter and a combining mark
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Drop combining marks
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return words
+}
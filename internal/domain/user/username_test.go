@@ -0,0 +1,59 @@
+package user
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		want     error
+	}{
+		{"budi", nil},
+		{"Budi_Santoso99", nil},
+		{"ab", ErrUsernameInvalid},
+		{"budi.santoso", ErrUsernameInvalid},
+		{"budi santoso", ErrUsernameInvalid},
+		{"abcdefghijklmnopqrstuvwxyz12345", ErrUsernameInvalid},
+		{"Admin", ErrUsernameReserved},
+		{"profile", ErrUsernameReserved},
+	}
+
+	for _, tt := range tests {
+		if got := ValidateUsername(tt.username); got != tt.want {
+			t.Errorf("ValidateUsername(%q) = %v, want %v", tt.username, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestUsernames(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Budi Santoso", []string{"budisantoso", "budi_santoso", "budi"}},
+		{"Siti Nur Aisyah", []string{"sitinuraisyah", "siti_nur_aisyah", "sitiaisyah", "siti_aisyah", "siti"}},
+		{"José Ñúñez", []string{"josenunez", "jose_nunez", "jose"}},
+		{"Admin", nil},
+		{"李", nil},
+	}
+
+	for _, tt := range tests {
+		got := SuggestUsernames(tt.name)
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SuggestUsernames(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExtractMentions(t *testing.T) {
+	got := ExtractMentions("Thanks @budi and @Siti_N! Mail me at me@example.com, cc @budi @ab")
+	want := []string{"budi", "Siti_N"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractMentions() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,47 @@
+package waitlist
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EntryStatus represents the status of a waitlist entry
+type EntryStatus string
+
+const (
+	StatusWaiting EntryStatus = "waiting" // Queued for a seat
+	StatusOffered EntryStatus = "offered" // Seat offered, awaiting claim
+	StatusClaimed EntryStatus = "claimed" // Offer used to purchase a ticket
+	StatusExpired EntryStatus = "expired" // Offer not claimed in time
+	StatusLeft    EntryStatus = "left"    // User left the waitlist
+)
+
+// Entry represents a user's place on an event waitlist
+type Entry struct {
+	ID             uuid.UUID   `json:"id" db:"id"`
+	EventID        uuid.UUID   `json:"event_id" db:"event_id"`
+	UserID         uuid.UUID   `json:"user_id" db:"user_id"`
+	Status         EntryStatus `json:"status" db:"status"`
+	OfferedAt      *time.Time  `json:"offered_at,omitempty" db:"offered_at"`
+	OfferExpiresAt *time.Time  `json:"offer_expires_at,omitempty" db:"offer_expires_at"`
+	TicketID       *uuid.UUID  `json:"ticket_id,omitempty" db:"ticket_id"`
+	JoinedAt       time.Time   `json:"joined_at" db:"joined_at"`
+	UpdatedAt      time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// WaitlistStatus represents a user's current standing on an event waitlist
+type WaitlistStatus struct {
+	Entry        *Entry `json:"entry"`
+	Position     int    `json:"position"`      // 1-based queue position, 0 when not waiting
+	WaitlistSize int    `json:"waitlist_size"` // Users currently waiting
+}
+
+// Business logic methods
+func (e *Entry) HasActiveOffer() bool {
+	return e.Status == StatusOffered && e.OfferExpiresAt != nil && time.Now().Before(*e.OfferExpiresAt)
+}
+
+func (e *Entry) IsQueued() bool {
+	return e.Status == StatusWaiting || e.HasActiveOffer()
+}
@@ -0,0 +1,26 @@
+package waitlist
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for waitlist data access
+type Repository interface {
+	// Entry management
+	Upsert(ctx context.Context, entry *Entry) error
+	GetByEventAndUser(ctx context.Context, eventID, userID uuid.UUID) (*Entry, error)
+	UpdateStatus(ctx context.Context, entryID uuid.UUID, status EntryStatus) error
+	MarkClaimed(ctx context.Context, entryID uuid.UUID, ticketID *uuid.UUID) error
+
+	// Promotion
+	OfferFreeSeats(ctx context.Context, eventID uuid.UUID, expiresAt time.Time) ([]Entry, error)
+	ExpireOffers(ctx context.Context, now time.Time) ([]Entry, error)
+
+	// Counting
+	CountWaiting(ctx context.Context, eventID uuid.UUID) (int, error)
+	CountActiveOffers(ctx context.Context, eventID uuid.UUID) (int, error)
+	GetPosition(ctx context.Context, entry *Entry) (int, error)
+}
@@ -48,6 +48,7 @@ type SnapRequest struct {
 	CustomerDetails    CustomerDetails    `json:"customer_details"`
 	ItemDetails        []ItemDetail       `json:"item_details"`
 	Callbacks          *Callbacks         `json:"callbacks,omitempty"`
+	Expiry             *Expiry            `json:"expiry,omitempty"`
 }
 
 // TransactionDetails contains transaction information
@@ -77,6 +78,12 @@ type Callbacks struct {
 	Finish string `json:"finish,omitempty"`
 }
 
+// Expiry limits how long the payment page accepts payment
+type Expiry struct {
+	Unit     string `json:"unit"` // "minute", "hour" or "day"
+	Duration int    `json:"duration"`
+}
+
 // SnapResponse represents the Snap API response
 type SnapResponse struct {
 	Token       string `json:"token"`
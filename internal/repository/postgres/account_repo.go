@@ -0,0 +1,382 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/account"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type accountRepository struct {
+	db *sqlx.DB
+}
+
+// NewAccountRepository creates a new account repository
+func NewAccountRepository(db *sqlx.DB) account.Repository {
+	return &accountRepository{db: db}
+}
+
+// ScheduleDeletion schedules the deletion of an account
+func (r *accountRepository) ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	query := `
+		UPDATE users SET deletion_scheduled_at = $2, updated_at = $3
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, at, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CancelDeletion cancels a scheduled account deletion, sql.ErrNoRows when none is scheduled
+func (r *accountRepository) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE users SET deletion_scheduled_at = NULL, updated_at = $2
+		WHERE id = $1 AND deletion_scheduled_at IS NOT NULL AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetDueDeletions gets the accounts whose grace period ended, oldest first
+func (r *accountRepository) GetDueDeletions(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT id FROM users
+		WHERE deletion_scheduled_at <= $1 AND deleted_at IS NULL
+		ORDER BY deletion_scheduled_at ASC
+		LIMIT $2
+	`
+
+	ids := []uuid.UUID{}
+	if err := r.db.SelectContext(ctx, &ids, query, now, limit); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// anonymizeStatements remove a user's content, social graph and access.
+// Tickets, transactions, ledger entries, payouts and bank accounts are kept for the financial records,
+// as are hosted events and attendance of events that already started.
+var anonymizeStatements = []string{
+	// Q&A, keeping the upvote counts of other questions right
+	`UPDATE event_qna SET upvotes = GREATEST(upvotes - 1, 0)
+	 WHERE id IN (SELECT qna_id FROM qna_upvotes WHERE user_id = $1)`,
+	`DELETE FROM qna_upvotes WHERE user_id = $1`,
+	`DELETE FROM event_qna WHERE user_id = $1`,
+
+	// Posts and interactions; likes have no foreign key to what they like
+	`DELETE FROM likes
+	 WHERE user_id = $1
+	    OR (likeable_type = 'post' AND likeable_id IN (SELECT id FROM posts WHERE author_id = $1))
+	    OR (likeable_type = 'comment' AND likeable_id IN (
+	        SELECT id FROM comments
+	        WHERE author_id = $1 OR post_id IN (SELECT id FROM posts WHERE author_id = $1)
+	    ))`,
+	`DELETE FROM comment_revisions WHERE comment_id IN (SELECT id FROM comments WHERE author_id = $1)`,
+	// Comments others replied to stay as tombstones so their replies survive
+	`UPDATE comments SET content = '', deleted_at = NOW()
+	 WHERE author_id = $1 AND deleted_at IS NULL
+	   AND EXISTS (SELECT 1 FROM comments r WHERE r.parent_comment_id = comments.id AND r.author_id != $1)`,
+	`DELETE FROM comments WHERE author_id = $1 AND deleted_at IS NULL`,
+	`DELETE FROM reposts WHERE user_id = $1`,
+	`DELETE FROM bookmarks WHERE user_id = $1`,
+	`DELETE FROM shares WHERE user_id = $1`,
+	`DELETE FROM posts WHERE author_id = $1`,
+	`DELETE FROM reviews WHERE reviewer_id = $1`,
+	`DELETE FROM notifications WHERE user_id = $1 OR actor_id = $1`,
+	`DELETE FROM post_mentions WHERE user_id = $1`,
+
+	// Social graph
+	`DELETE FROM follow_requests WHERE requester_id = $1 OR target_id = $1`,
+	`DELETE FROM user_blocks WHERE blocker_id = $1 OR blocked_id = $1`,
+	`DELETE FROM user_mutes WHERE muter_id = $1 OR muted_id = $1`,
+	`DELETE FROM invitations WHERE inviter_id = $1 OR invitee_id = $1`,
+
+	// Access
+	`DELETE FROM auth_tokens WHERE user_id = $1`,
+	`DELETE FROM calendar_feed_tokens WHERE user_id = $1`,
+	`DELETE FROM username_history WHERE user_id = $1`,
+	`DELETE FROM data_exports WHERE user_id = $1`,
+
+	// Events and communities
+	`DELETE FROM event_waitlist WHERE user_id = $1`,
+	`DELETE FROM event_series_followers WHERE user_id = $1`,
+	`DELETE FROM event_staff WHERE user_id = $1`,
+	`DELETE FROM community_members WHERE user_id = $1`,
+	`DELETE FROM event_attendees
+	 WHERE user_id = $1 AND event_id IN (SELECT id FROM events WHERE start_time > NOW())`,
+	`UPDATE event_page_views SET user_id = NULL WHERE user_id = $1`,
+	`UPDATE event_series SET ended_at = NOW(), updated_at = NOW() WHERE host_id = $1 AND ended_at IS NULL`,
+}
+
+// Anonymize removes a user's personal data and content in one transaction.
+// The users row is kept, scrubbed, so the records referencing it stay intact.
+func (r *accountRepository) Anonymize(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Remove follows, remembering who needs their counts fixed
+	var followed []uuid.UUID
+	err = tx.SelectContext(ctx, &followed, `
+		DELETE FROM follows WHERE follower_id = $1 OR following_id = $1
+		RETURNING CASE WHEN follower_id = $1 THEN following_id ELSE follower_id END
+	`, userID)
+	if err != nil {
+		return err
+	}
+	followed = append(followed, userID)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE user_stats s SET
+			followers_count = (SELECT COUNT(*) FROM follows WHERE following_id = s.user_id),
+			following_count = (SELECT COUNT(*) FROM follows WHERE follower_id = s.user_id)
+		WHERE s.user_id = ANY($1)
+	`, pq.Array(followed))
+	if err != nil {
+		return err
+	}
+
+	for _, query := range anonymizeStatements {
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users SET
+			email = 'deleted+' || id || '@users.invalid',
+			username = 'deleted_' || REPLACE(id::text, '-', ''),
+			name = $2,
+			bio = NULL, avatar_url = NULL, phone = NULL, date_of_birth = NULL,
+			gender = NULL, location = NULL, interests = '{}',
+			last_login_at = NULL, is_verified = FALSE, is_email_verified = FALSE,
+			role = 'user', suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL,
+			deletion_scheduled_at = NULL, deleted_at = $3, updated_at = $3
+		WHERE id = $1 AND deleted_at IS NULL
+	`, userID, account.DeletedUserName, now)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+const exportColumns = `id, user_id, status, error, created_at, completed_at, expires_at`
+
+// CreateExport creates a data export job
+func (r *accountRepository) CreateExport(ctx context.Context, export *account.DataExport) error {
+	query := `
+		INSERT INTO data_exports (id, user_id, status, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, export.ID, export.UserID, export.Status, export.CreatedAt)
+	return err
+}
+
+// GetLatestExport gets the most recent data export of a user
+func (r *accountRepository) GetLatestExport(ctx context.Context, userID uuid.UUID) (*account.DataExport, error) {
+	var export account.DataExport
+	query := `
+		SELECT ` + exportColumns + `
+		FROM data_exports
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	if err := r.db.GetContext(ctx, &export, query, userID); err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ClaimPendingExports moves the oldest pending exports to processing and returns them.
+// Exports left processing for an hour, by a server that stopped, are claimed again.
+func (r *accountRepository) ClaimPendingExports(ctx context.Context, limit int) ([]account.DataExport, error) {
+	query := `
+		UPDATE data_exports SET status = 'processing', started_at = NOW()
+		WHERE id IN (
+			SELECT id FROM data_exports
+			WHERE status = 'pending'
+			   OR (status = 'processing' AND started_at < NOW() - INTERVAL '1 hour')
+			ORDER BY created_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + exportColumns
+
+	exports := []account.DataExport{}
+	if err := r.db.SelectContext(ctx, &exports, query, limit); err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// CompleteExport stores the archive of an export and makes it downloadable until expiresAt
+func (r *accountRepository) CompleteExport(ctx context.Context, exportID uuid.UUID, archive []byte, expiresAt time.Time) error {
+	query := `
+		UPDATE data_exports
+		SET status = 'ready', archive = $2, error = NULL, completed_at = $3, expires_at = $4
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, exportID, archive, time.Now(), expiresAt)
+	return err
+}
+
+// FailExport marks an export as failed
+func (r *accountRepository) FailExport(ctx context.Context, exportID uuid.UUID, reason string) error {
+	query := `
+		UPDATE data_exports SET status = 'failed', error = $2, completed_at = $3
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, exportID, reason, time.Now())
+	return err
+}
+
+// GetExportArchive gets the archive of a ready export
+func (r *accountRepository) GetExportArchive(ctx context.Context, exportID uuid.UUID) ([]byte, error) {
+	query := `SELECT archive FROM data_exports WHERE id = $1 AND status = 'ready' AND archive IS NOT NULL`
+
+	var archive []byte
+	if err := r.db.QueryRowContext(ctx, query, exportID).Scan(&archive); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// DeleteExpiredExports deletes exports whose archive expired
+func (r *accountRepository) DeleteExpiredExports(ctx context.Context, now time.Time) error {
+	query := `DELETE FROM data_exports WHERE expires_at < $1`
+	_, err := r.db.ExecContext(ctx, query, now)
+	return err
+}
+
+// exportSections are the queries behind each file of a data export, all taking the user ID as $1
+var exportSections = []struct {
+	name  string
+	query string
+}{
+	{"profile", `
+		SELECT id, email, username, name, bio, avatar_url, phone, date_of_birth, gender, location, interests,
+		       created_at, updated_at, last_login_at, is_verified, is_email_verified, role,
+		       suspended_at, suspended_until, suspension_reason, deletion_scheduled_at
+		FROM users WHERE id = $1`},
+	{"username_history", `SELECT username, changed_at FROM username_history WHERE user_id = $1 ORDER BY changed_at`},
+	{"settings", `SELECT * FROM user_settings WHERE user_id = $1`},
+	{"privacy", `SELECT * FROM user_privacy WHERE user_id = $1`},
+	{"stats", `SELECT * FROM user_stats WHERE user_id = $1`},
+	{"followers", `SELECT follower_id AS user_id, created_at FROM follows WHERE following_id = $1 ORDER BY created_at`},
+	{"following", `SELECT following_id AS user_id, created_at FROM follows WHERE follower_id = $1 ORDER BY created_at`},
+	{"follow_requests", `SELECT * FROM follow_requests WHERE requester_id = $1 OR target_id = $1 ORDER BY created_at`},
+	{"blocked_users", `SELECT blocked_id AS user_id, created_at FROM user_blocks WHERE blocker_id = $1 ORDER BY created_at`},
+	{"muted_users", `SELECT muted_id AS user_id, created_at FROM user_mutes WHERE muter_id = $1 ORDER BY created_at`},
+	{"posts", `SELECT * FROM posts WHERE author_id = $1 ORDER BY created_at`},
+	{"comments", `SELECT * FROM comments WHERE author_id = $1 ORDER BY created_at`},
+	{"post_revisions", `
+		SELECT pr.* FROM post_revisions pr
+		JOIN posts p ON p.id = pr.post_id
+		WHERE p.author_id = $1
+		ORDER BY pr.replaced_at`},
+	{"comment_revisions", `
+		SELECT cr.* FROM comment_revisions cr
+		JOIN comments c ON c.id = cr.comment_id
+		WHERE c.author_id = $1
+		ORDER BY cr.replaced_at`},
+	{"likes", `SELECT * FROM likes WHERE user_id = $1 ORDER BY created_at`},
+	{"reposts", `SELECT * FROM reposts WHERE user_id = $1 ORDER BY created_at`},
+	{"bookmarks", `SELECT * FROM bookmarks WHERE user_id = $1 ORDER BY created_at`},
+	{"shares", `SELECT * FROM shares WHERE user_id = $1 ORDER BY created_at`},
+	{"hosted_events", `SELECT * FROM events WHERE host_id = $1 ORDER BY start_time`},
+	{"hosted_series", `SELECT * FROM event_series WHERE host_id = $1 ORDER BY created_at`},
+	{"joined_events", `
+		SELECT ea.*, e.title AS event_title, e.start_time AS event_start_time
+		FROM event_attendees ea
+		JOIN events e ON e.id = ea.event_id
+		WHERE ea.user_id = $1
+		ORDER BY e.start_time`},
+	{"followed_series", `SELECT * FROM event_series_followers WHERE user_id = $1`},
+	{"event_staff", `SELECT * FROM event_staff WHERE user_id = $1`},
+	{"waitlist", `SELECT * FROM event_waitlist WHERE user_id = $1`},
+	{"questions", `SELECT * FROM event_qna WHERE user_id = $1 ORDER BY created_at`},
+	{"reviews", `SELECT * FROM reviews WHERE reviewer_id = $1 ORDER BY created_at`},
+	{"orders", `SELECT * FROM ticket_orders WHERE buyer_id = $1 ORDER BY created_at`},
+	{"tickets", `SELECT * FROM tickets WHERE user_id = $1 ORDER BY purchased_at`},
+	{"ticket_transactions", `
+		SELECT tt.*
+		FROM ticket_transactions tt
+		JOIN tickets t ON t.id = tt.ticket_id
+		WHERE t.user_id = $1
+		ORDER BY tt.created_at`},
+	{"ticket_transfers", `SELECT * FROM ticket_transfers WHERE from_user_id = $1 OR to_user_id = $1 ORDER BY created_at`},
+	{"promo_redemptions", `SELECT * FROM promo_redemptions WHERE user_id = $1`},
+	{"bank_accounts", `SELECT * FROM host_bank_accounts WHERE user_id = $1`},
+	{"payouts", `SELECT * FROM payouts WHERE host_id = $1 ORDER BY requested_at`},
+	{"communities", `
+		SELECT cm.community_id, c.name AS community_name, cm.role, cm.joined_at
+		FROM community_members cm
+		JOIN communities c ON c.id = cm.community_id
+		WHERE cm.user_id = $1
+		ORDER BY cm.joined_at`},
+	{"invitations", `SELECT * FROM invitations WHERE inviter_id = $1 OR invitee_id = $1`},
+	{"notifications", `SELECT * FROM notifications WHERE user_id = $1 ORDER BY created_at`},
+	{"reports_filed", `SELECT * FROM report_filings WHERE reporter_id = $1 ORDER BY created_at`},
+}
+
+// GetUserData gets everything stored about a user, one JSON array per section
+func (r *accountRepository) GetUserData(ctx context.Context, userID uuid.UUID) ([]account.Section, error) {
+	sections := make([]account.Section, 0, len(exportSections))
+	for _, s := range exportSections {
+		query := `SELECT COALESCE(json_agg(row_to_json(t)), '[]'::json) FROM (` + s.query + `) t`
+
+		var data []byte
+		if err := r.db.QueryRowContext(ctx, query, userID).Scan(&data); err != nil {
+			return nil, err
+		}
+		sections = append(sections, account.Section{Name: s.name, Data: json.RawMessage(data)})
+	}
+	return sections, nil
+}
@@ -0,0 +1,259 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/block"
+	"github.com/anigmaa/backend/internal/domain/comment"
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/post"
+	"github.com/anigmaa/backend/internal/domain/search"
+	"github.com/anigmaa/backend/internal/domain/user"
+	"github.com/anigmaa/backend/internal/infrastructure/database"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// openTestDB connects to TEST_DATABASE_URL and migrates it, or skips the test when it is not set
+func openTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := sqlx.Connect("postgres", url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.RunMigrations(db, "../../../migrations/consolidated"); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// blockFixture is a viewer, a user they blocked, a user who blocked them and an unrelated user.
+// All names share a random token so searches only find users of this run.
+type blockFixture struct {
+	token     string
+	viewer    *user.User
+	blocked   *user.User
+	blockedBy *user.User
+	stranger  *user.User
+}
+
+func newBlockFixture(t *testing.T, ctx context.Context, db *sqlx.DB) *blockFixture {
+	t.Helper()
+
+	userRepo := NewUserRepository(db)
+	blockRepo := NewBlockRepository(db)
+
+	token := "bf" + strings.ReplaceAll(uuid.NewString(), "-", "")[:10]
+	newUser := func(role string) *user.User {
+		u := &user.User{
+			Email:     token + "_" + role + "@example.com",
+			Username:  token + "_" + role,
+			Name:      "Blockfilter " + token + " " + role,
+			Interests: []string{},
+		}
+		if err := userRepo.Create(ctx, u); err != nil {
+			t.Fatalf("create user %s: %v", role, err)
+		}
+		return u
+	}
+
+	f := &blockFixture{
+		token:     token,
+		viewer:    newUser("viewer"),
+		blocked:   newUser("blocked"),
+		blockedBy: newUser("blockedby"),
+		stranger:  newUser("stranger"),
+	}
+
+	blocks := []block.Block{
+		{BlockerID: f.viewer.ID, BlockedID: f.blocked.ID, CreatedAt: time.Now()},
+		{BlockerID: f.blockedBy.ID, BlockedID: f.viewer.ID, CreatedAt: time.Now()},
+	}
+	for i := range blocks {
+		if err := blockRepo.Block(ctx, &blocks[i]); err != nil {
+			t.Fatalf("block: %v", err)
+		}
+	}
+	return f
+}
+
+// others are the users the viewer may or may not see, with whether they should be visible
+func (f *blockFixture) others() map[uuid.UUID]bool {
+	return map[uuid.UUID]bool{
+		f.blocked.ID:   false,
+		f.blockedBy.ID: false,
+		f.stranger.ID:  true,
+	}
+}
+
+// assertVisible checks that exactly the expected fixture users are among the found IDs
+func (f *blockFixture) assertVisible(t *testing.T, what string, found []uuid.UUID) {
+	t.Helper()
+
+	seen := make(map[uuid.UUID]bool, len(found))
+	for _, id := range found {
+		seen[id] = true
+	}
+	for id, visible := range f.others() {
+		if seen[id] != visible {
+			t.Errorf("%s: user %s visible = %v, want %v", what, id, seen[id], visible)
+		}
+	}
+}
+
+func TestGetFeedHidesBlockedAuthors(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	f := newBlockFixture(t, ctx, db)
+	postRepo := NewPostRepository(db)
+
+	for authorID := range f.others() {
+		p := &post.Post{
+			AuthorID:   authorID,
+			Content:    "Post by " + authorID.String(),
+			Type:       post.TypeText,
+			Visibility: post.VisibilityPublic,
+			Status:     post.StatusPublished,
+		}
+		if err := postRepo.Create(ctx, p); err != nil {
+			t.Fatalf("create post: %v", err)
+		}
+	}
+
+	posts, err := postRepo.GetFeed(ctx, f.viewer.ID, 100, 0)
+	if err != nil {
+		t.Fatalf("GetFeed: %v", err)
+	}
+
+	authors := make([]uuid.UUID, len(posts))
+	for i, p := range posts {
+		authors[i] = p.AuthorID
+	}
+	f.assertVisible(t, "GetFeed", authors)
+}
+
+func TestGetCommentsByPostHidesBlockedAuthors(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	f := newBlockFixture(t, ctx, db)
+	postRepo := NewPostRepository(db)
+	commentRepo := NewCommentRepository(db)
+
+	p := &post.Post{
+		AuthorID:   f.viewer.ID,
+		Content:    "Post with comments",
+		Type:       post.TypeText,
+		Visibility: post.VisibilityPublic,
+		Status:     post.StatusPublished,
+	}
+	if err := postRepo.Create(ctx, p); err != nil {
+		t.Fatalf("create post: %v", err)
+	}
+	for authorID := range f.others() {
+		c := &comment.Comment{
+			PostID:   p.ID,
+			AuthorID: authorID,
+			Content:  "Comment by " + authorID.String(),
+		}
+		if err := commentRepo.Create(ctx, c); err != nil {
+			t.Fatalf("create comment: %v", err)
+		}
+	}
+
+	comments, err := commentRepo.GetByPost(ctx, p.ID, f.viewer.ID, comment.SortNewest, 100, 0)
+	if err != nil {
+		t.Fatalf("GetByPost: %v", err)
+	}
+
+	authors := make([]uuid.UUID, len(comments))
+	for i, c := range comments {
+		authors[i] = c.AuthorID
+	}
+	f.assertVisible(t, "GetByPost", authors)
+}
+
+func TestSearchUsersHidesBlockedUsers(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	f := newBlockFixture(t, ctx, db)
+
+	users, err := NewUserRepository(db).SearchUsers(ctx, f.token, f.viewer.ID, 100, 0)
+	if err != nil {
+		t.Fatalf("user SearchUsers: %v", err)
+	}
+	ids := make([]uuid.UUID, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	f.assertVisible(t, "user SearchUsers", ids)
+
+	results, err := NewSearchRepository(db).SearchUsers(ctx, &search.Params{
+		Query:  f.token,
+		Limit:  100,
+		Now:    time.Now(),
+		Viewer: f.viewer.ID,
+	})
+	if err != nil {
+		t.Fatalf("search SearchUsers: %v", err)
+	}
+	ids = make([]uuid.UUID, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	f.assertVisible(t, "search SearchUsers", ids)
+}
+
+func TestGetAttendeesHidesBlockedUsers(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	f := newBlockFixture(t, ctx, db)
+	eventRepo := NewEventRepository(db)
+
+	start := time.Now().Add(24 * time.Hour)
+	evt := &event.Event{
+		HostID:          f.viewer.ID,
+		Title:           "Blockfilter meetup",
+		Description:     "Attendee list filtering",
+		Category:        event.CategoryMeetup,
+		StartTime:       start,
+		EndTime:         start.Add(2 * time.Hour),
+		LocationName:    "Hall",
+		LocationAddress: "Main street 1",
+		LocationLat:     -6.2,
+		LocationLng:     106.8,
+		MaxAttendees:    10,
+		IsFree:          true,
+		Privacy:         event.PrivacyPublic,
+	}
+	if err := eventRepo.Create(ctx, evt); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	for userID := range f.others() {
+		if err := eventRepo.Join(ctx, &event.EventAttendee{EventID: evt.ID, UserID: userID}); err != nil {
+			t.Fatalf("join event: %v", err)
+		}
+	}
+
+	attendees, err := eventRepo.GetAttendees(ctx, evt.ID, f.viewer.ID, 100, 0)
+	if err != nil {
+		t.Fatalf("GetAttendees: %v", err)
+	}
+
+	ids := make([]uuid.UUID, len(attendees))
+	for i, a := range attendees {
+		ids[i] = a.UserID
+	}
+	f.assertVisible(t, "GetAttendees", ids)
+}
@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/anigmaa/backend/internal/domain/block"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type blockRepository struct {
+	db *sqlx.DB
+}
+
+// NewBlockRepository creates a new block repository
+func NewBlockRepository(db *sqlx.DB) block.Repository {
+	return &blockRepository{db: db}
+}
+
+// Block blocks a user and drops pending follow requests and invitations between both users
+func (r *blockRepository) Block(ctx context.Context, b *block.Block) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`, b.BlockerID, b.BlockedID, b.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM follow_requests
+		WHERE (requester_id = $1 AND target_id = $2) OR (requester_id = $2 AND target_id = $1)
+	`, b.BlockerID, b.BlockedID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM invitations
+		WHERE status = 'pending'
+			AND ((inviter_id = $1 AND invitee_id = $2) OR (inviter_id = $2 AND invitee_id = $1))
+	`, b.BlockerID, b.BlockedID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Unblock removes a block
+func (r *blockRepository) Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	query := `DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// HasBlocked checks if blockerID blocked blockedID
+func (r *blockRepository) HasBlocked(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2)`
+
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, query, blockerID, blockedID)
+	return exists, err
+}
+
+// IsBlocked checks if either user blocked the other
+func (r *blockRepository) IsBlocked(ctx context.Context, userID, otherID uuid.UUID) (bool, error) {
+	query := `SELECT is_blocked_between($1, $2)`
+
+	var blocked bool
+	err := r.db.GetContext(ctx, &blocked, query, userID, otherID)
+	return blocked, err
+}
+
+// GetBlockedIDs gets the users blocked by or blocking a user
+func (r *blockRepository) GetBlockedIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT blocked_id FROM user_blocks WHERE blocker_id = $1
+		UNION
+		SELECT blocker_id FROM user_blocks WHERE blocked_id = $1
+	`
+
+	ids := []uuid.UUID{}
+	err := r.db.SelectContext(ctx, &ids, query, userID)
+	return ids, err
+}
+
+// GetBlocked gets the users a user blocked, most recent first
+func (r *blockRepository) GetBlocked(ctx context.Context, blockerID uuid.UUID, limit, offset int) ([]block.ListedUser, error) {
+	query := `
+		SELECT u.id, u.name, u.avatar_url, COALESCE(u.is_verified, false) as is_verified, b.created_at as since
+		FROM user_blocks b
+		INNER JOIN users u ON u.id = b.blocked_id
+		WHERE b.blocker_id = $1
+		ORDER BY b.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	users := []block.ListedUser{}
+	err := r.db.SelectContext(ctx, &users, query, blockerID, limit, offset)
+	return users, err
+}
+
+// CountBlocked counts the users a user blocked
+func (r *blockRepository) CountBlocked(ctx context.Context, blockerID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM user_blocks WHERE blocker_id = $1`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, blockerID).Scan(&count)
+	return count, err
+}
+
+// Mute mutes a user
+func (r *blockRepository) Mute(ctx context.Context, m *block.Mute) error {
+	query := `
+		INSERT INTO user_mutes (muter_id, muted_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (muter_id, muted_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, m.MuterID, m.MutedID, m.CreatedAt)
+	return err
+}
+
+// Unmute removes a mute
+func (r *blockRepository) Unmute(ctx context.Context, muterID, mutedID uuid.UUID) error {
+	query := `DELETE FROM user_mutes WHERE muter_id = $1 AND muted_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, muterID, mutedID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// IsMuted checks if muterID muted mutedID
+func (r *blockRepository) IsMuted(ctx context.Context, muterID, mutedID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM user_mutes WHERE muter_id = $1 AND muted_id = $2)`
+
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, query, muterID, mutedID)
+	return exists, err
+}
+
+// GetMuted gets the users a user muted, most recent first
+func (r *blockRepository) GetMuted(ctx context.Context, muterID uuid.UUID, limit, offset int) ([]block.ListedUser, error) {
+	query := `
+		SELECT u.id, u.name, u.avatar_url, COALESCE(u.is_verified, false) as is_verified, m.created_at as since
+		FROM user_mutes m
+		INNER JOIN users u ON u.id = m.muted_id
+		WHERE m.muter_id = $1
+		ORDER BY m.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	users := []block.ListedUser{}
+	err := r.db.SelectContext(ctx, &users, query, muterID, limit, offset)
+	return users, err
+}
+
+// CountMuted counts the users a user muted
+func (r *blockRepository) CountMuted(ctx context.Context, muterID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM user_mutes WHERE muter_id = $1`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, muterID).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/anigmaa/backend/internal/domain/calendar"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type calendarRepository struct {
+	db *sqlx.DB
+}
+
+// NewCalendarRepository creates a new calendar repository
+func NewCalendarRepository(db *sqlx.DB) calendar.Repository {
+	return &calendarRepository{db: db}
+}
+
+// GetFeedToken gets the calendar feed token of a user
+func (r *calendarRepository) GetFeedToken(ctx context.Context, userID uuid.UUID) (*calendar.FeedToken, error) {
+	var token calendar.FeedToken
+	query := `SELECT user_id, token, created_at FROM calendar_feed_tokens WHERE user_id = $1`
+
+	if err := r.db.GetContext(ctx, &token, query, userID); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetFeedTokenByValue gets a calendar feed token by its secret value
+func (r *calendarRepository) GetFeedTokenByValue(ctx context.Context, value string) (*calendar.FeedToken, error) {
+	var token calendar.FeedToken
+	query := `SELECT user_id, token, created_at FROM calendar_feed_tokens WHERE token = $1`
+
+	if err := r.db.GetContext(ctx, &token, query, value); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// SaveFeedToken creates or replaces the calendar feed token of a user
+func (r *calendarRepository) SaveFeedToken(ctx context.Context, token *calendar.FeedToken) error {
+	query := `
+		INSERT INTO calendar_feed_tokens (user_id, token, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = EXCLUDED.created_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, token.UserID, token.Token, token.CreatedAt)
+	return err
+}
@@ -33,12 +33,12 @@ func (r *commentRepository) Create(ctx context.Context, c *comment.Comment) erro
 	c.UpdatedAt = now
 
 	query := `
-		INSERT INTO comments (id, post_id, author_id, parent_comment_id, content, created_at, updated_at, likes_count)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, 0)
+		INSERT INTO comments (id, post_id, author_id, parent_comment_id, depth, content, created_at, updated_at, likes_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 0)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
-		c.ID, c.PostID, c.AuthorID, c.ParentCommentID, c.Content, c.CreatedAt, c.UpdatedAt,
+		c.ID, c.PostID, c.AuthorID, c.ParentCommentID, c.Depth, c.Content, c.CreatedAt, c.UpdatedAt,
 	)
 
 	return err
@@ -47,7 +47,8 @@ func (r *commentRepository) Create(ctx context.Context, c *comment.Comment) erro
 // GetByID gets a comment by ID
 func (r *commentRepository) GetByID(ctx context.Context, commentID uuid.UUID) (*comment.Comment, error) {
 	query := `
-		SELECT id, post_id, author_id, parent_comment_id, content, created_at, updated_at, likes_count
+		SELECT id, post_id, author_id, parent_comment_id, depth, content, created_at, updated_at,
+		       edited_at IS NOT NULL as edited, edited_at, deleted_at, likes_count
 		FROM comments
 		WHERE id = $1
 	`
@@ -69,10 +70,14 @@ func (r *commentRepository) GetWithDetails(ctx context.Context, commentID, userI
 			c.post_id,
 			c.author_id,
 			c.parent_comment_id,
+			c.depth,
 			c.content,
 			c.likes_count,
 			c.created_at,
 			c.updated_at,
+			c.edited_at IS NOT NULL as edited,
+			c.edited_at,
+			c.deleted_at,
 			u.name as author_name,
 			u.avatar_url as author_avatar_url,
 			u.is_verified as author_is_verified,
@@ -97,6 +102,7 @@ func (r *commentRepository) GetWithDetails(ctx context.Context, commentID, userI
 	if err != nil {
 		return nil, err
 	}
+	c.Redact()
 
 	return &c, nil
 }
@@ -108,7 +114,7 @@ func (r *commentRepository) Update(ctx context.Context, c *comment.Comment) erro
 	query := `
 		UPDATE comments
 		SET content = $1, updated_at = $2
-		WHERE id = $3
+		WHERE id = $3 AND deleted_at IS NULL
 	`
 
 	result, err := r.db.ExecContext(ctx, query, c.Content, c.UpdatedAt, c.ID)
@@ -150,89 +156,155 @@ func (r *commentRepository) Delete(ctx context.Context, commentID uuid.UUID) err
 	return nil
 }
 
-// GetByPost gets comments for a post
-func (r *commentRepository) GetByPost(ctx context.Context, postID, userID uuid.UUID, limit, offset int) ([]comment.CommentWithDetails, error) {
+// Edit saves an edited comment along with the revision holding its previous content
+func (r *commentRepository) Edit(ctx context.Context, c *comment.Comment, revision *comment.Revision) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if revision.ID == uuid.Nil {
+		revision.ID = uuid.New()
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO comment_revisions (id, comment_id, content, written_at, replaced_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, revision.ID, c.ID, revision.Content, revision.WrittenAt, revision.ReplacedAt)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE comments
+		SET content = $1, updated_at = $2, edited_at = $3
+		WHERE id = $4 AND deleted_at IS NULL
+	`, c.Content, c.UpdatedAt, c.EditedAt, c.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+// GetRevisions gets the previous contents of a comment, most recent first
+func (r *commentRepository) GetRevisions(ctx context.Context, commentID uuid.UUID) ([]comment.Revision, error) {
 	query := `
-		SELECT
-			c.id,
-			c.post_id,
-			c.author_id,
-			c.parent_comment_id,
-			c.content,
-			c.likes_count,
-			c.created_at,
-			c.updated_at,
-			u.name as author_name,
-			u.avatar_url as author_avatar_url,
-			u.is_verified as author_is_verified,
-			COALESCE(
-				(SELECT COUNT(*) FROM likes
-				 WHERE likeable_id = c.id
-				 AND likeable_type = 'comment'
-				 AND user_id = $1),
-				0
-			) > 0 as is_liked_by_user
-		FROM comments c
-		INNER JOIN users u ON c.author_id = u.id
-		WHERE c.post_id = $2
-		ORDER BY c.likes_count DESC, c.created_at DESC
-		LIMIT $3 OFFSET $4
+		SELECT id, comment_id, content, written_at, replaced_at
+		FROM comment_revisions
+		WHERE comment_id = $1
+		ORDER BY replaced_at DESC
 	`
 
-	rows, err := r.db.QueryxContext(ctx, query, userID, postID, limit, offset)
-	if err != nil {
+	revisions := []comment.Revision{}
+	if err := r.db.SelectContext(ctx, &revisions, query, commentID); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var comments []comment.CommentWithDetails
-	for rows.Next() {
-		var c comment.CommentWithDetails
+	return revisions, nil
+}
 
-		err := rows.Scan(
-			&c.ID,
-			&c.PostID,
-			&c.AuthorID,
-			&c.ParentCommentID,
-			&c.Content,
-			&c.LikesCount,
-			&c.CreatedAt,
-			&c.UpdatedAt,
-			&c.AuthorName,
-			&c.AuthorAvatarURL,
-			&c.AuthorIsVerified,
-			&c.IsLikedByUser,
+// Tombstone marks a comment deleted and clears its content, keeping it in place for its replies
+// Note: comments_count is automatically decremented via database trigger (update_comments_count_trigger)
+func (r *commentRepository) Tombstone(ctx context.Context, commentID uuid.UUID) error {
+	// Earlier revisions go with the content
+	query := `
+		WITH purged AS (
+			DELETE FROM comment_revisions WHERE comment_id = $1
 		)
-		if err != nil {
-			return nil, err
-		}
+		UPDATE comments
+		SET content = '', deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
 
-		comments = append(comments, c)
+	result, err := r.db.ExecContext(ctx, query, commentID)
+	if err != nil {
+		return err
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
 	}
 
-	if comments == nil {
-		comments = []comment.CommentWithDetails{}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
 	}
 
-	return comments, nil
+	return nil
 }
 
-// GetReplies gets replies to a comment
-func (r *commentRepository) GetReplies(ctx context.Context, parentCommentID, userID uuid.UUID, limit, offset int) ([]comment.CommentWithDetails, error) {
-	query := `
+// commentOrders maps each sort to its ORDER BY clause
+var commentOrders = map[comment.Sort]string{
+	comment.SortTop:    "c.likes_count DESC, c.created_at DESC, c.id DESC",
+	comment.SortNewest: "c.created_at DESC, c.id DESC",
+	comment.SortOldest: "c.created_at ASC, c.id ASC",
+}
+
+// GetByPost gets the top-level comments of a post in the given order,
+// leaving out authors in a block relation with the user
+func (r *commentRepository) GetByPost(ctx context.Context, postID, userID uuid.UUID, sort comment.Sort, limit, offset int) ([]comment.CommentWithDetails, error) {
+	orderBy, ok := commentOrders[sort]
+	if !ok {
+		orderBy = commentOrders[comment.SortTop]
+	}
+
+	query := commentDetailsSelect + `
+		WHERE c.post_id = $2 AND c.parent_comment_id IS NULL
+			AND NOT is_blocked_between($1, c.author_id)
+			AND (c.author_id = $1 OR NOT is_content_hidden('comment', c.id))
+		ORDER BY ` + orderBy + `
+		LIMIT $3 OFFSET $4
+	`
+
+	return r.queryDetails(ctx, query, userID, postID, limit, offset)
+}
+
+// GetReplies gets the direct replies to a comment, oldest first, starting after the cursor
+func (r *commentRepository) GetReplies(ctx context.Context, parentCommentID, userID uuid.UUID, after *comment.Cursor, limit int) ([]comment.CommentWithDetails, error) {
+	args := []interface{}{userID, parentCommentID, limit}
+	cursorFilter := ""
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		cursorFilter = "AND (c.created_at, c.id) > ($4, $5)"
+	}
+
+	query := commentDetailsSelect + `
+		WHERE c.parent_comment_id = $2
+			AND NOT is_blocked_between($1, c.author_id)
+			AND (c.author_id = $1 OR NOT is_content_hidden('comment', c.id))
+			` + cursorFilter + `
+		ORDER BY c.created_at ASC, c.id ASC
+		LIMIT $3
+	`
+
+	return r.queryDetails(ctx, query, args...)
+}
+
+// commentDetailsSelect selects comments with their details for the user in $1
+const commentDetailsSelect = `
 		SELECT
 			c.id,
 			c.post_id,
 			c.author_id,
 			c.parent_comment_id,
+			c.depth,
 			c.content,
 			c.likes_count,
 			c.created_at,
 			c.updated_at,
+			c.edited_at IS NOT NULL as edited,
+			c.edited_at,
+			c.deleted_at,
 			u.name as author_name,
 			u.avatar_url as author_avatar_url,
 			u.is_verified as author_is_verified,
@@ -242,21 +314,20 @@ func (r *commentRepository) GetReplies(ctx context.Context, parentCommentID, use
 				 AND likeable_type = 'comment'
 				 AND user_id = $1),
 				0
-			) > 0 as is_liked_by_user
+			) > 0 as is_liked_by_user,
+			(SELECT COUNT(*) FROM comments r WHERE r.parent_comment_id = c.id) as replies_count
 		FROM comments c
-		INNER JOIN users u ON c.author_id = u.id
-		WHERE c.parent_comment_id = $2
-		ORDER BY c.created_at ASC
-		LIMIT $3 OFFSET $4
-	`
+		INNER JOIN users u ON c.author_id = u.id`
 
-	rows, err := r.db.QueryxContext(ctx, query, userID, parentCommentID, limit, offset)
+// queryDetails runs a commentDetailsSelect query, redacting tombstones
+func (r *commentRepository) queryDetails(ctx context.Context, query string, args ...interface{}) ([]comment.CommentWithDetails, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var replies []comment.CommentWithDetails
+	comments := []comment.CommentWithDetails{}
 	for rows.Next() {
 		var c comment.CommentWithDetails
 
@@ -265,36 +336,38 @@ func (r *commentRepository) GetReplies(ctx context.Context, parentCommentID, use
 			&c.PostID,
 			&c.AuthorID,
 			&c.ParentCommentID,
+			&c.Depth,
 			&c.Content,
 			&c.LikesCount,
 			&c.CreatedAt,
 			&c.UpdatedAt,
+			&c.Edited,
+			&c.EditedAt,
+			&c.DeletedAt,
 			&c.AuthorName,
 			&c.AuthorAvatarURL,
 			&c.AuthorIsVerified,
 			&c.IsLikedByUser,
+			&c.RepliesCount,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		replies = append(replies, c)
+		c.Redact()
+		comments = append(comments, c)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	if replies == nil {
-		replies = []comment.CommentWithDetails{}
-	}
-
-	return replies, nil
+	return comments, nil
 }
 
-// GetCount gets the count of comments for a post
+// GetCount gets the count of comments for a post, leaving out tombstones
 func (r *commentRepository) GetCount(ctx context.Context, postID uuid.UUID) (int, error) {
-	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1`
+	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1 AND deleted_at IS NULL`
 
 	var count int
 	err := r.db.GetContext(ctx, &count, query, postID)
@@ -305,6 +378,15 @@ func (r *commentRepository) GetCount(ctx context.Context, postID uuid.UUID) (int
 	return count, nil
 }
 
+// HasReplies checks whether any comment replies to a comment
+func (r *commentRepository) HasReplies(ctx context.Context, commentID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM comments WHERE parent_comment_id = $1)`
+
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, query, commentID)
+	return exists, err
+}
+
 // IncrementLikes increments the likes count
 // Note: This is also handled by database trigger, but provided for manual use if needed
 func (r *commentRepository) IncrementLikes(ctx context.Context, commentID uuid.UUID) error {
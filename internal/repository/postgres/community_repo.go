@@ -239,6 +239,76 @@ func (r *communityRepository) GetMemberRole(ctx context.Context, communityID, us
 	return &role, err
 }
 
+// TransferOwnership makes another user the owner of a community, joining them if needed
+// The previous owner stays on as an admin
+func (r *communityRepository) TransferOwnership(ctx context.Context, communityID, newOwnerID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE communities SET creator_id = $2, updated_at = $3 WHERE id = $1
+	`, communityID, newOwnerID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE community_members SET role = 'admin'
+		WHERE community_id = $1 AND role = 'owner' AND user_id <> $2
+	`, communityID, newOwnerID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO community_members (id, community_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, 'owner', $4)
+		ON CONFLICT (community_id, user_id) DO UPDATE SET role = 'owner'
+	`, uuid.New(), communityID, newOwnerID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetSuccessor picks the member to take over a community from its owner:
+// the longest-standing admin, then moderator, then member, skipping suspended and leaving users
+func (r *communityRepository) GetSuccessor(ctx context.Context, communityID uuid.UUID) (uuid.UUID, error) {
+	query := `
+		SELECT cm.user_id
+		FROM community_members cm
+		JOIN communities c ON c.id = cm.community_id
+		JOIN users u ON u.id = cm.user_id
+		WHERE cm.community_id = $1
+			AND cm.user_id <> c.creator_id
+			AND u.deleted_at IS NULL
+			AND u.deletion_scheduled_at IS NULL
+			AND (u.suspended_at IS NULL OR u.suspended_until <= NOW())
+		ORDER BY
+			CASE cm.role WHEN 'admin' THEN 0 WHEN 'moderator' THEN 1 ELSE 2 END,
+			cm.joined_at ASC
+		LIMIT 1
+	`
+
+	var userID uuid.UUID
+	if err := r.db.GetContext(ctx, &userID, query, communityID); err != nil {
+		return uuid.Nil, err
+	}
+	return userID, nil
+}
+
 // GetWithDetails gets a community with details
 func (r *communityRepository) GetWithDetails(ctx context.Context, communityID, userID uuid.UUID) (*community.CommunityWithDetails, error) {
 	var comm community.CommunityWithDetails
@@ -9,6 +9,7 @@ import (
 	"github.com/anigmaa/backend/internal/domain/event"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type eventRepository struct {
@@ -24,9 +25,9 @@ func (r *eventRepository) Create(ctx context.Context, e *event.Event) error {
 		INSERT INTO events (id, host_id, title, description, category, start_time, end_time,
 			location_name, location_address, location_lat, location_lng, location_geom,
 			max_attendees, price, is_free, status, privacy, requirements, ticketing_enabled,
-			tickets_sold, created_at, updated_at)
+			allow_ticket_transfers, tickets_sold, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, ST_SetSRID(ST_MakePoint($12, $11), 4326),
-			$13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+			$13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
 	`
 
 	e.ID = uuid.New()
@@ -39,7 +40,7 @@ func (r *eventRepository) Create(ctx context.Context, e *event.Event) error {
 		e.ID, e.HostID, e.Title, e.Description, e.Category, e.StartTime, e.EndTime,
 		e.LocationName, e.LocationAddress, e.LocationLat, e.LocationLng,
 		e.MaxAttendees, e.Price, e.IsFree, e.Status, e.Privacy, e.Requirements,
-		e.TicketingEnabled, e.TicketsSold, e.CreatedAt, e.UpdatedAt,
+		e.TicketingEnabled, e.AllowTicketTransfers, e.TicketsSold, e.CreatedAt, e.UpdatedAt,
 	)
 
 	return err
@@ -49,7 +50,8 @@ func (r *eventRepository) GetByID(ctx context.Context, id uuid.UUID) (*event.Eve
 	var e event.Event
 	query := `SELECT id, host_id, title, description, category, start_time, end_time,
 		location_name, location_address, location_lat, location_lng, max_attendees,
-		price, is_free, status, privacy, requirements, ticketing_enabled, tickets_sold,
+		price, is_free, status, privacy, requirements, ticketing_enabled, allow_ticket_transfers,
+		tickets_sold, series_id, occurrence_start, sequence, is_featured, unpublished_at,
 		created_at, updated_at FROM events WHERE id = $1`
 
 	err := r.db.GetContext(ctx, &e, query, id)
@@ -65,7 +67,8 @@ func (r *eventRepository) GetWithDetails(ctx context.Context, eventID, userID uu
 		SELECT e.id, e.host_id, e.title, e.description, e.category, e.start_time, e.end_time,
 			e.location_name, e.location_address, e.location_lat, e.location_lng,
 			e.max_attendees, e.price, e.is_free, e.status, e.privacy, e.requirements,
-			e.ticketing_enabled, e.tickets_sold, e.created_at, e.updated_at,
+			e.ticketing_enabled, e.allow_ticket_transfers, e.tickets_sold,
+			e.series_id, e.occurrence_start, e.sequence, e.is_featured, e.unpublished_at, e.created_at, e.updated_at,
 			u.name as host_name, u.avatar_url as host_avatar_url,
 			(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id AND status = 'confirmed') as attendees_count,
 			EXISTS(SELECT 1 FROM event_attendees WHERE event_id = e.id AND user_id = $2 AND status = 'confirmed') as is_user_attending,
@@ -93,8 +96,8 @@ func (r *eventRepository) Update(ctx context.Context, e *event.Event) error {
 			end_time = $5, location_name = $6, location_address = $7, location_lat = $8,
 			location_lng = $9, location_geom = ST_SetSRID(ST_MakePoint($9, $8), 4326),
 			max_attendees = $10, price = $11, privacy = $12, requirements = $13,
-			status = $14, updated_at = $15
-		WHERE id = $16
+			status = $14, allow_ticket_transfers = $15, updated_at = $16
+		WHERE id = $17
 	`
 
 	e.UpdatedAt = time.Now()
@@ -102,7 +105,7 @@ func (r *eventRepository) Update(ctx context.Context, e *event.Event) error {
 		e.Title, e.Description, e.Category, e.StartTime, e.EndTime,
 		e.LocationName, e.LocationAddress, e.LocationLat, e.LocationLng,
 		e.MaxAttendees, e.Price, e.Privacy, e.Requirements, e.Status,
-		e.UpdatedAt, e.ID,
+		e.AllowTicketTransfers, e.UpdatedAt, e.ID,
 	)
 
 	return err
@@ -125,12 +128,12 @@ func (r *eventRepository) List(ctx context.Context, filter *event.EventFilter) (
 		SELECT e.id, e.host_id, e.title, e.description, e.category, e.start_time, e.end_time,
 			e.location_name, e.location_address, e.location_lat, e.location_lng,
 			e.max_attendees, e.price, e.is_free, e.status, e.privacy, e.requirements,
-			e.ticketing_enabled, e.tickets_sold, e.created_at, e.updated_at,
+			e.ticketing_enabled, e.allow_ticket_transfers, e.tickets_sold, e.is_featured, e.created_at, e.updated_at,
 			u.name as host_name, u.avatar_url as host_avatar_url,
 			(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id AND status = 'confirmed') as attendees_count
 		FROM events e
 		INNER JOIN users u ON e.host_id = u.id
-		WHERE 1=1
+		WHERE NOT is_content_hidden('event', e.id)
 	`
 
 	args := []interface{}{}
@@ -154,6 +157,12 @@ func (r *eventRepository) List(ctx context.Context, filter *event.EventFilter) (
 		argCount++
 	}
 
+	if filter.Featured != nil {
+		query += fmt.Sprintf(" AND e.is_featured = $%d", argCount)
+		args = append(args, *filter.Featured)
+		argCount++
+	}
+
 	if filter.Status != nil {
 		query += fmt.Sprintf(" AND e.status = $%d", argCount)
 		args = append(args, *filter.Status)
@@ -235,7 +244,8 @@ func (r *eventRepository) GetByHost(ctx context.Context, hostID uuid.UUID, limit
 		SELECT e.id, e.host_id, e.title, e.description, e.category, e.start_time, e.end_time,
 			e.location_name, e.location_address, e.location_lat, e.location_lng,
 			e.max_attendees, e.price, e.is_free, e.status, e.privacy, e.requirements,
-			e.ticketing_enabled, e.tickets_sold, e.created_at, e.updated_at,
+			e.ticketing_enabled, e.allow_ticket_transfers, e.tickets_sold,
+			e.series_id, e.occurrence_start, e.sequence, e.created_at, e.updated_at,
 			u.name as host_name, u.avatar_url as host_avatar_url,
 			(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id AND status = 'confirmed') as attendees_count
 		FROM events e
@@ -265,7 +275,8 @@ func (r *eventRepository) GetJoinedEvents(ctx context.Context, userID uuid.UUID,
 		SELECT e.id, e.host_id, e.title, e.description, e.category, e.start_time, e.end_time,
 			e.location_name, e.location_address, e.location_lat, e.location_lng,
 			e.max_attendees, e.price, e.is_free, e.status, e.privacy, e.requirements,
-			e.ticketing_enabled, e.tickets_sold, e.created_at, e.updated_at,
+			e.ticketing_enabled, e.allow_ticket_transfers, e.tickets_sold,
+			e.series_id, e.occurrence_start, e.sequence, e.created_at, e.updated_at,
 			u.name as host_name, u.avatar_url as host_avatar_url,
 			(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id AND status = 'confirmed') as attendees_count,
 			true as is_user_attending
@@ -287,7 +298,7 @@ func (r *eventRepository) GetNearby(ctx context.Context, lat, lng, radiusKm floa
 		SELECT e.id, e.host_id, e.title, e.description, e.category, e.start_time, e.end_time,
 			e.location_name, e.location_address, e.location_lat, e.location_lng,
 			e.max_attendees, e.price, e.is_free, e.status, e.privacy, e.requirements,
-			e.ticketing_enabled, e.tickets_sold, e.created_at, e.updated_at,
+			e.ticketing_enabled, e.allow_ticket_transfers, e.tickets_sold, e.created_at, e.updated_at,
 			u.name as host_name, u.avatar_url as host_avatar_url,
 			(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id AND status = 'confirmed') as attendees_count,
 			ST_Distance(e.location_geom::geography, ST_SetSRID(ST_MakePoint($2, $1), 4326)::geography) / 1000 as distance
@@ -295,6 +306,7 @@ func (r *eventRepository) GetNearby(ctx context.Context, lat, lng, radiusKm floa
 		INNER JOIN users u ON e.host_id = u.id
 		WHERE ST_DWithin(e.location_geom::geography, ST_SetSRID(ST_MakePoint($2, $1), 4326)::geography, $3 * 1000)
 			AND e.status = 'upcoming'
+			AND NOT is_content_hidden('event', e.id)
 		ORDER BY (
 			(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id AND status = 'confirmed') + 1
 		) * random() DESC
@@ -306,6 +318,99 @@ func (r *eventRepository) GetNearby(ctx context.Context, lat, lng, radiusKm floa
 	return events, err
 }
 
+// GetMapClusters snaps the events inside the filter's viewport to a grid of
+// gridSize degrees and returns one cluster per occupied cell, largest first
+func (r *eventRepository) GetMapClusters(ctx context.Context, filter *event.EventFilter, gridSize float64, limit int) ([]event.MapCluster, error) {
+	conditions := `
+		WHERE e.location_geom && ST_MakeEnvelope($1, $2, $3, $4, 4326)::geography
+			AND e.privacy = 'public'
+			AND NOT is_content_hidden('event', e.id)
+	`
+	args := []interface{}{*filter.MinLng, *filter.MinLat, *filter.MaxLng, *filter.MaxLat, gridSize, limit}
+	argCount := 7
+
+	// Same defaults as List: hide completed events unless a status is requested
+	if filter.Status == nil {
+		conditions += " AND e.status IN ('upcoming', 'ongoing')"
+	} else {
+		conditions += fmt.Sprintf(" AND e.status = $%d", argCount)
+		args = append(args, *filter.Status)
+		argCount++
+	}
+
+	if filter.Category != nil {
+		conditions += fmt.Sprintf(" AND e.category = $%d", argCount)
+		args = append(args, *filter.Category)
+		argCount++
+	}
+
+	if filter.IsFree != nil {
+		conditions += fmt.Sprintf(" AND e.is_free = $%d", argCount)
+		args = append(args, *filter.IsFree)
+		argCount++
+	}
+
+	if filter.Featured != nil {
+		conditions += fmt.Sprintf(" AND e.is_featured = $%d", argCount)
+		args = append(args, *filter.Featured)
+		argCount++
+	}
+
+	if filter.StartDate != nil {
+		conditions += fmt.Sprintf(" AND e.start_time >= $%d", argCount)
+		args = append(args, *filter.StartDate)
+		argCount++
+	}
+
+	if filter.EndDate != nil {
+		conditions += fmt.Sprintf(" AND e.start_time <= $%d", argCount)
+		args = append(args, *filter.EndDate)
+	}
+
+	query := `
+		WITH filtered AS (
+			SELECT e.id, e.title, e.category, e.start_time, e.location_lat, e.location_lng,
+				COALESCE(e.is_free, true) as is_free, e.price,
+				(SELECT image_url FROM event_images WHERE event_id = e.id ORDER BY order_index LIMIT 1) as image_url,
+				(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id AND status = 'confirmed') as attendees_count,
+				ST_SnapToGrid(e.location_geom::geometry, $5) as cell
+			FROM events e
+		` + conditions + `
+		),
+		ranked AS (
+			SELECT f.*,
+				ROW_NUMBER() OVER w_rank as rn,
+				COUNT(*) OVER w as cell_count,
+				AVG(f.location_lat) OVER w as center_lat, AVG(f.location_lng) OVER w as center_lng,
+				MIN(f.location_lat) OVER w as cell_min_lat, MIN(f.location_lng) OVER w as cell_min_lng,
+				MAX(f.location_lat) OVER w as cell_max_lat, MAX(f.location_lng) OVER w as cell_max_lng
+			FROM filtered f
+			WINDOW w AS (PARTITION BY f.cell),
+				w_rank AS (PARTITION BY f.cell ORDER BY f.attendees_count DESC, f.start_time ASC)
+		)
+		SELECT center_lat as lat, center_lng as lng, cell_count as count,
+			cell_min_lat as min_lat, cell_min_lng as min_lng, cell_max_lat as max_lat, cell_max_lng as max_lng,
+			id as "event.id", title as "event.title", category as "event.category",
+			start_time as "event.start_time", location_lat as "event.location_lat",
+			location_lng as "event.location_lng", is_free as "event.is_free", price as "event.price",
+			image_url as "event.image_url", attendees_count as "event.attendees_count"
+		FROM ranked
+		WHERE rn = 1
+		ORDER BY cell_count DESC, start_time ASC
+		LIMIT $6
+	`
+
+	var clusters []event.MapCluster
+	if err := r.db.SelectContext(ctx, &clusters, query, args...); err != nil {
+		return nil, err
+	}
+
+	if clusters == nil {
+		clusters = []event.MapCluster{}
+	}
+	return clusters, nil
+}
+
 func (r *eventRepository) Join(ctx context.Context, attendee *event.EventAttendee) error {
 	query := `
 		INSERT INTO event_attendees (id, event_id, user_id, joined_at, status)
@@ -326,16 +431,27 @@ func (r *eventRepository) Leave(ctx context.Context, eventID, userID uuid.UUID)
 	return err
 }
 
-func (r *eventRepository) GetAttendees(ctx context.Context, eventID uuid.UUID, limit, offset int) ([]event.EventAttendee, error) {
+// attendeeVisibleCondition hides attendees whose privacy settings hide their events,
+// except from the attendee themself and the event host, and attendees in a block relation with the viewer.
+// Expects ea, ev and up aliases and the viewer ID as $2.
+const attendeeVisibleCondition = `(ea.user_id = $2 OR ev.host_id = $2 OR COALESCE(up.events_visible, TRUE))
+			AND NOT is_blocked_between($2, ea.user_id)`
+
+// GetAttendees gets confirmed attendees visible to the viewer (uuid.Nil for anonymous viewers)
+func (r *eventRepository) GetAttendees(ctx context.Context, eventID, viewerID uuid.UUID, limit, offset int) ([]event.EventAttendee, error) {
 	query := `
-		SELECT * FROM event_attendees
-		WHERE event_id = $1 AND status = 'confirmed'
-		ORDER BY joined_at DESC
-		LIMIT $2 OFFSET $3
+		SELECT ea.id, ea.event_id, ea.user_id, ea.joined_at, ea.status
+		FROM event_attendees ea
+		INNER JOIN events ev ON ev.id = ea.event_id
+		LEFT JOIN user_privacy up ON up.user_id = ea.user_id
+		WHERE ea.event_id = $1 AND ea.status = 'confirmed'
+			AND ` + attendeeVisibleCondition + `
+		ORDER BY ea.joined_at DESC
+		LIMIT $3 OFFSET $4
 	`
 
-	var attendees []event.EventAttendee
-	err := r.db.SelectContext(ctx, &attendees, query, eventID, limit, offset)
+	attendees := []event.EventAttendee{}
+	err := r.db.SelectContext(ctx, &attendees, query, eventID, viewerID, limit, offset)
 	return attendees, err
 }
 
@@ -353,6 +469,137 @@ func (r *eventRepository) GetAttendeesCount(ctx context.Context, eventID uuid.UU
 	return count, err
 }
 
+func (r *eventRepository) AddStaff(ctx context.Context, staff *event.EventStaff) error {
+	query := `
+		INSERT INTO event_staff (event_id, user_id, role, permissions, added_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (event_id, user_id) DO UPDATE SET role = EXCLUDED.role, permissions = EXCLUDED.permissions
+	`
+
+	staff.CreatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, query,
+		staff.EventID, staff.UserID, staff.Role, pq.Array(permissionStrings(staff.Permissions)),
+		staff.AddedBy, staff.CreatedAt,
+	)
+	return err
+}
+
+func (r *eventRepository) UpdateStaff(ctx context.Context, staff *event.EventStaff) error {
+	query := `UPDATE event_staff SET role = $1, permissions = $2 WHERE event_id = $3 AND user_id = $4`
+	result, err := r.db.ExecContext(ctx, query,
+		staff.Role, pq.Array(permissionStrings(staff.Permissions)), staff.EventID, staff.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *eventRepository) RemoveStaff(ctx context.Context, eventID, userID uuid.UUID) error {
+	query := `DELETE FROM event_staff WHERE event_id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *eventRepository) GetStaff(ctx context.Context, eventID uuid.UUID) ([]event.StaffMember, error) {
+	query := `
+		SELECT s.event_id, s.user_id, s.role, s.permissions, s.added_by, s.created_at, u.name, u.avatar_url
+		FROM event_staff s
+		INNER JOIN users u ON s.user_id = u.id
+		WHERE s.event_id = $1
+		ORDER BY s.role ASC, s.created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	staff := []event.StaffMember{}
+	for rows.Next() {
+		var m event.StaffMember
+		var permissions []string
+		err := rows.Scan(
+			&m.EventID, &m.UserID, &m.Role, pq.Array(&permissions), &m.AddedBy, &m.CreatedAt,
+			&m.Name, &m.AvatarURL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		m.Permissions = toPermissions(permissions)
+		staff = append(staff, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return staff, nil
+}
+
+func (r *eventRepository) GetStaffMember(ctx context.Context, eventID, userID uuid.UUID) (*event.EventStaff, error) {
+	query := `
+		SELECT event_id, user_id, role, permissions, added_by, created_at
+		FROM event_staff
+		WHERE event_id = $1 AND user_id = $2
+	`
+
+	var s event.EventStaff
+	var permissions []string
+	err := r.db.QueryRowContext(ctx, query, eventID, userID).Scan(
+		&s.EventID, &s.UserID, &s.Role, pq.Array(&permissions), &s.AddedBy, &s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.Permissions = toPermissions(permissions)
+
+	return &s, nil
+}
+
+// permissionStrings converts staff permissions for storage in a TEXT[] column
+func permissionStrings(permissions []event.Permission) []string {
+	result := make([]string, len(permissions))
+	for i, p := range permissions {
+		result[i] = string(p)
+	}
+	return result
+}
+
+// toPermissions converts a TEXT[] column back into staff permissions
+func toPermissions(values []string) []event.Permission {
+	result := make([]event.Permission, len(values))
+	for i, v := range values {
+		result[i] = event.Permission(v)
+	}
+	return result
+}
+
 func (r *eventRepository) AddImages(ctx context.Context, images []event.EventImage) error {
 	query := `INSERT INTO event_images (id, event_id, image_url, order_index) VALUES ($1, $2, $3, $4)`
 
@@ -393,6 +640,46 @@ func (r *eventRepository) UpdateStatus(ctx context.Context, eventID uuid.UUID, s
 	return err
 }
 
+// SetFeatured features an event or removes it from the featured events
+func (r *eventRepository) SetFeatured(ctx context.Context, eventID uuid.UUID, featured bool) error {
+	query := `UPDATE events SET is_featured = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, featured, time.Now(), eventID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetUnpublished unpublishes an event at the given time, or republishes it when unpublishedAt is nil
+func (r *eventRepository) SetUnpublished(ctx context.Context, eventID uuid.UUID, unpublishedAt *time.Time) error {
+	query := `UPDATE events SET unpublished_at = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, unpublishedAt, time.Now(), eventID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
 func (r *eventRepository) GetUpcomingEvents(ctx context.Context, limit int) ([]event.EventWithDetails, error) {
 	query := `
 		SELECT e.*, u.name as host_name, u.avatar_url as host_avatar_url,
@@ -400,6 +687,7 @@ func (r *eventRepository) GetUpcomingEvents(ctx context.Context, limit int) ([]e
 		FROM events e
 		INNER JOIN users u ON e.host_id = u.id
 		WHERE e.status = 'upcoming' AND e.start_time > NOW()
+			AND NOT is_content_hidden('event', e.id)
 		ORDER BY (
 			(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id) + 1
 		) * random() DESC
@@ -418,6 +706,7 @@ func (r *eventRepository) GetLiveEvents(ctx context.Context, limit int) ([]event
 		FROM events e
 		INNER JOIN users u ON e.host_id = u.id
 		WHERE e.status = 'ongoing' AND e.start_time <= NOW() AND e.end_time >= NOW()
+			AND NOT is_content_hidden('event', e.id)
 		ORDER BY (
 			(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id) + 1
 		) * random() DESC
@@ -435,7 +724,7 @@ func (r *eventRepository) GetByHostID(ctx context.Context, hostID uuid.UUID) ([]
 		SELECT id, host_id, title, description, category, start_time, end_time,
 			location_name, location_address, location_lat, location_lng,
 			max_attendees, price, is_free, status, privacy, requirements,
-			ticketing_enabled, tickets_sold, created_at, updated_at
+			ticketing_enabled, allow_ticket_transfers, tickets_sold, created_at, updated_at
 		FROM events
 		WHERE host_id = $1
 		ORDER BY start_time DESC
@@ -450,9 +739,46 @@ func (r *eventRepository) GetByHostID(ctx context.Context, hostID uuid.UUID) ([]
 	return events, nil
 }
 
+// RecordPageView records a visit to an event page for the conversion funnel
+func (r *eventRepository) RecordPageView(ctx context.Context, view *event.PageView) error {
+	query := `
+		INSERT INTO event_page_views (id, event_id, user_id, stage, viewed_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, view.ID, view.EventID, view.UserID, view.Stage, view.ViewedAt)
+	return err
+}
+
+// GetFunnel counts distinct people at each funnel step of an event
+// Logged-in visitors count once per step; anonymous visits count individually
+// Purchasers are order buyers, so a group order counts as one purchase
+func (r *eventRepository) GetFunnel(ctx context.Context, eventID uuid.UUID) (*event.Funnel, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(DISTINCT COALESCE(user_id::text, id::text))
+			 FROM event_page_views WHERE event_id = $1 AND stage = 'view') as views,
+			(SELECT COUNT(DISTINCT COALESCE(user_id::text, id::text))
+			 FROM event_page_views WHERE event_id = $1 AND stage = 'ticket_page') as ticket_page_views,
+			(SELECT COUNT(DISTINCT COALESCE(o.buyer_id, t.user_id))
+			 FROM tickets t
+			 LEFT JOIN ticket_orders o ON t.order_id = o.id
+			 WHERE t.event_id = $1 AND t.status = 'active') as purchasers,
+			(SELECT COUNT(DISTINCT user_id)
+			 FROM tickets WHERE event_id = $1 AND is_checked_in = TRUE) as checked_in
+	`
+
+	var funnel event.Funnel
+	if err := r.db.GetContext(ctx, &funnel, query, eventID); err != nil {
+		return nil, err
+	}
+
+	return &funnel, nil
+}
+
 // CountEvents counts total events matching filter
 func (r *eventRepository) CountEvents(ctx context.Context, filter *event.EventFilter) (int, error) {
-	query := `SELECT COUNT(*) FROM events WHERE 1=1`
+	query := `SELECT COUNT(*) FROM events WHERE NOT is_content_hidden('event', id)`
 	args := []interface{}{}
 	argCount := 1
 
@@ -477,6 +803,11 @@ func (r *eventRepository) CountEvents(ctx context.Context, filter *event.EventFi
 		args = append(args, *filter.IsFree)
 		argCount++
 	}
+	if filter.Featured != nil {
+		query += fmt.Sprintf(" AND is_featured = $%d", argCount)
+		args = append(args, *filter.Featured)
+		argCount++
+	}
 	if filter.StartDate != nil {
 		query += fmt.Sprintf(" AND start_time >= $%d", argCount)
 		args = append(args, *filter.StartDate)
@@ -514,13 +845,16 @@ func (r *eventRepository) CountJoinedEvents(ctx context.Context, userID uuid.UUI
 }
 
 // CountAttendees counts total attendees for an event
-func (r *eventRepository) CountAttendees(ctx context.Context, eventID uuid.UUID) (int, error) {
+func (r *eventRepository) CountAttendees(ctx context.Context, eventID, viewerID uuid.UUID) (int, error) {
 	query := `
 		SELECT COUNT(*)
-		FROM event_attendees
-		WHERE event_id = $1 AND status = 'confirmed'
+		FROM event_attendees ea
+		INNER JOIN events ev ON ev.id = ea.event_id
+		LEFT JOIN user_privacy up ON up.user_id = ea.user_id
+		WHERE ea.event_id = $1 AND ea.status = 'confirmed'
+			AND ` + attendeeVisibleCondition + `
 	`
 	var count int
-	err := r.db.QueryRowContext(ctx, query, eventID).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, eventID, viewerID).Scan(&count)
 	return count, err
 }
@@ -0,0 +1,300 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const seriesColumns = `
+	s.id, s.host_id, s.recurrence, s.timezone, s.first_start, s.duration_minutes,
+	s.title, s.description, s.category, s.location_name, s.location_address,
+	s.location_lat, s.location_lng, s.max_attendees, s.price, s.is_free, s.privacy,
+	s.requirements, s.ticketing_enabled, s.allow_ticket_transfers, s.image_urls,
+	s.generated_until, s.ended_at, s.created_at, s.updated_at
+`
+
+// seriesRow scans the image_urls text array that event.Series leaves to the repository
+type seriesRow struct {
+	event.Series
+	ImageURLs pq.StringArray `db:"image_urls"`
+}
+
+type seriesDetailsRow struct {
+	event.SeriesWithDetails
+	ImageURLs pq.StringArray `db:"image_urls"`
+}
+
+// CreateSeries creates a new event series
+func (r *eventRepository) CreateSeries(ctx context.Context, s *event.Series) error {
+	query := `
+		INSERT INTO event_series (id, host_id, recurrence, timezone, first_start, duration_minutes,
+			title, description, category, location_name, location_address, location_lat, location_lng,
+			max_attendees, price, is_free, privacy, requirements, ticketing_enabled, allow_ticket_transfers,
+			image_urls, generated_until, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+			$21, $22, $23, $24)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		s.ID, s.HostID, s.Recurrence, s.Timezone, s.FirstStart, s.DurationMinutes,
+		s.Title, s.Description, s.Category, s.LocationName, s.LocationAddress, s.LocationLat, s.LocationLng,
+		s.MaxAttendees, s.Price, s.IsFree, s.Privacy, s.Requirements, s.TicketingEnabled, s.AllowTicketTransfers,
+		pq.Array(s.ImageURLs), s.GeneratedUntil, s.CreatedAt, s.UpdatedAt,
+	)
+	return err
+}
+
+// GetSeries gets an event series by ID
+func (r *eventRepository) GetSeries(ctx context.Context, seriesID uuid.UUID) (*event.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM event_series s WHERE s.id = $1`
+
+	var row seriesRow
+	if err := r.db.GetContext(ctx, &row, query, seriesID); err != nil {
+		return nil, err
+	}
+
+	row.Series.ImageURLs = normalizeStrings(row.ImageURLs)
+	return &row.Series, nil
+}
+
+// GetSeriesWithDetails gets an event series with host, follower and next occurrence information
+func (r *eventRepository) GetSeriesWithDetails(ctx context.Context, seriesID, userID uuid.UUID) (*event.SeriesWithDetails, error) {
+	query := `
+		SELECT ` + seriesColumns + `,
+			u.name as host_name, u.avatar_url as host_avatar_url,
+			(SELECT COUNT(*) FROM event_series_followers WHERE series_id = s.id) as followers_count,
+			EXISTS(SELECT 1 FROM event_series_followers WHERE series_id = s.id AND user_id = $2) as is_following,
+			next.id as next_event_id, next.start_time as next_start_time
+		FROM event_series s
+		INNER JOIN users u ON s.host_id = u.id
+		LEFT JOIN LATERAL (
+			SELECT e.id, e.start_time FROM events e
+			WHERE e.series_id = s.id AND e.status = 'upcoming' AND e.start_time > NOW()
+			ORDER BY e.start_time ASC
+			LIMIT 1
+		) next ON true
+		WHERE s.id = $1
+	`
+
+	var row seriesDetailsRow
+	if err := r.db.GetContext(ctx, &row, query, seriesID, userID); err != nil {
+		return nil, err
+	}
+
+	row.SeriesWithDetails.ImageURLs = normalizeStrings(row.ImageURLs)
+	return &row.SeriesWithDetails, nil
+}
+
+// UpdateSeries updates the template, generation horizon and end of a series
+func (r *eventRepository) UpdateSeries(ctx context.Context, s *event.Series) error {
+	query := `
+		UPDATE event_series
+		SET first_start = $1, duration_minutes = $2, title = $3, description = $4, category = $5,
+			location_name = $6, location_address = $7, location_lat = $8, location_lng = $9,
+			max_attendees = $10, price = $11, is_free = $12, privacy = $13, requirements = $14,
+			allow_ticket_transfers = $15, image_urls = $16, generated_until = $17, ended_at = $18,
+			updated_at = $19
+		WHERE id = $20
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		s.FirstStart, s.DurationMinutes, s.Title, s.Description, s.Category,
+		s.LocationName, s.LocationAddress, s.LocationLat, s.LocationLng,
+		s.MaxAttendees, s.Price, s.IsFree, s.Privacy, s.Requirements,
+		s.AllowTicketTransfers, pq.Array(s.ImageURLs), s.GeneratedUntil, s.EndedAt,
+		s.UpdatedAt, s.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// AddOccurrences inserts generated occurrences with the series images and moves
+// the series generation horizon, in one transaction. Occurrences that already
+// exist for the same rule start time are skipped.
+func (r *eventRepository) AddOccurrences(ctx context.Context, s *event.Series, occurrences []*event.Event) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	eventQuery := `
+		INSERT INTO events (id, host_id, title, description, category, start_time, end_time,
+			location_name, location_address, location_lat, location_lng, location_geom,
+			max_attendees, price, is_free, status, privacy, requirements, ticketing_enabled,
+			allow_ticket_transfers, tickets_sold, series_id, occurrence_start, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, ST_SetSRID(ST_MakePoint($11, $10), 4326),
+			$12, $13, $14, $15, $16, $17, $18, $19, 0, $20, $21, $22, $23)
+		ON CONFLICT (series_id, occurrence_start) WHERE series_id IS NOT NULL DO NOTHING
+	`
+	imageQuery := `INSERT INTO event_images (id, event_id, image_url, order_index) VALUES ($1, $2, $3, $4)`
+
+	for _, e := range occurrences {
+		result, err := tx.ExecContext(ctx, eventQuery,
+			e.ID, e.HostID, e.Title, e.Description, e.Category, e.StartTime, e.EndTime,
+			e.LocationName, e.LocationAddress, e.LocationLat, e.LocationLng,
+			e.MaxAttendees, e.Price, e.IsFree, e.Status, e.Privacy, e.Requirements,
+			e.TicketingEnabled, e.AllowTicketTransfers, e.SeriesID, e.OccurrenceStart,
+			e.CreatedAt, e.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		if inserted, _ := result.RowsAffected(); inserted == 0 {
+			continue
+		}
+
+		for i, url := range s.ImageURLs {
+			if _, err := tx.ExecContext(ctx, imageQuery, uuid.New(), e.ID, url, i); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE event_series SET generated_until = $1, updated_at = $2 WHERE id = $3`,
+		s.GeneratedUntil, time.Now(), s.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetSeriesOccurrences gets the occurrences of a series starting at or after from, soonest first
+func (r *eventRepository) GetSeriesOccurrences(ctx context.Context, seriesID uuid.UUID, from time.Time, limit int) ([]event.EventWithDetails, error) {
+	query := `
+		SELECT e.id, e.host_id, e.title, e.description, e.category, e.start_time, e.end_time,
+			e.location_name, e.location_address, e.location_lat, e.location_lng,
+			e.max_attendees, e.price, e.is_free, e.status, e.privacy, e.requirements,
+			e.ticketing_enabled, e.allow_ticket_transfers, e.tickets_sold,
+			e.series_id, e.occurrence_start, e.sequence, e.created_at, e.updated_at,
+			u.name as host_name, u.avatar_url as host_avatar_url,
+			(SELECT COUNT(*) FROM event_attendees WHERE event_id = e.id AND status = 'confirmed') as attendees_count
+		FROM events e
+		INNER JOIN users u ON e.host_id = u.id
+		WHERE e.series_id = $1 AND e.start_time >= $2
+		ORDER BY e.start_time ASC
+		LIMIT $3
+	`
+
+	var events []event.EventWithDetails
+	if err := r.db.SelectContext(ctx, &events, query, seriesID, from, limit); err != nil {
+		return nil, err
+	}
+
+	if events == nil {
+		events = []event.EventWithDetails{}
+	}
+	return events, nil
+}
+
+// GetSeriesToExtend gets the active series whose occurrences do not reach horizon yet
+func (r *eventRepository) GetSeriesToExtend(ctx context.Context, horizon time.Time) ([]event.Series, error) {
+	query := `
+		SELECT ` + seriesColumns + `
+		FROM event_series s
+		WHERE s.ended_at IS NULL AND s.generated_until < $1
+		ORDER BY s.generated_until ASC
+	`
+
+	var rows []seriesRow
+	if err := r.db.SelectContext(ctx, &rows, query, horizon); err != nil {
+		return nil, err
+	}
+
+	series := make([]event.Series, len(rows))
+	for i, row := range rows {
+		series[i] = row.Series
+		series[i].ImageURLs = normalizeStrings(row.ImageURLs)
+	}
+	return series, nil
+}
+
+// FollowSeries adds a follower to a series, following twice is a no-op
+func (r *eventRepository) FollowSeries(ctx context.Context, seriesID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO event_series_followers (series_id, user_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (series_id, user_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, seriesID, userID, time.Now())
+	return err
+}
+
+// UnfollowSeries removes a follower from a series
+func (r *eventRepository) UnfollowSeries(ctx context.Context, seriesID, userID uuid.UUID) error {
+	query := `DELETE FROM event_series_followers WHERE series_id = $1 AND user_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, seriesID, userID)
+	return err
+}
+
+// GetFollowedSeries gets the series a user follows, soonest next occurrence first
+func (r *eventRepository) GetFollowedSeries(ctx context.Context, userID uuid.UUID, limit, offset int) ([]event.SeriesWithDetails, error) {
+	query := `
+		SELECT ` + seriesColumns + `,
+			u.name as host_name, u.avatar_url as host_avatar_url,
+			(SELECT COUNT(*) FROM event_series_followers WHERE series_id = s.id) as followers_count,
+			true as is_following,
+			next.id as next_event_id, next.start_time as next_start_time
+		FROM event_series_followers f
+		INNER JOIN event_series s ON f.series_id = s.id
+		INNER JOIN users u ON s.host_id = u.id
+		LEFT JOIN LATERAL (
+			SELECT e.id, e.start_time FROM events e
+			WHERE e.series_id = s.id AND e.status = 'upcoming' AND e.start_time > NOW()
+			ORDER BY e.start_time ASC
+			LIMIT 1
+		) next ON true
+		WHERE f.user_id = $1
+		ORDER BY next.start_time ASC NULLS LAST, f.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var rows []seriesDetailsRow
+	if err := r.db.SelectContext(ctx, &rows, query, userID, limit, offset); err != nil {
+		return nil, err
+	}
+
+	series := make([]event.SeriesWithDetails, len(rows))
+	for i, row := range rows {
+		series[i] = row.SeriesWithDetails
+		series[i].ImageURLs = normalizeStrings(row.ImageURLs)
+	}
+	return series, nil
+}
+
+// CountFollowedSeries counts the series a user follows
+func (r *eventRepository) CountFollowedSeries(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM event_series_followers WHERE user_id = $1`
+	err := r.db.GetContext(ctx, &count, query, userID)
+	return count, err
+}
+
+// normalizeStrings turns a scanned NULL or empty array into an empty slice
+func normalizeStrings(values pq.StringArray) []string {
+	if values == nil {
+		return []string{}
+	}
+	return []string(values)
+}
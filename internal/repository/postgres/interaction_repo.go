@@ -119,6 +119,33 @@ func (r *interactionRepository) GetLikes(ctx context.Context, likeableType inter
 	return likes, nil
 }
 
+// GetLikers gets the users who liked something, newest first, with the viewer's follow state.
+// Deleted users and users in a block relation with the viewer are left out.
+func (r *interactionRepository) GetLikers(ctx context.Context, likeableType interaction.LikeableType, likeableID, viewerID uuid.UUID, limit, offset int) ([]interaction.Interactor, error) {
+	query := `
+		SELECT u.id as user_id, u.name, u.username, u.avatar_url, COALESCE(u.is_verified, false) as is_verified,
+			l.created_at,
+			EXISTS(SELECT 1 FROM follows WHERE follower_id = $3 AND following_id = u.id) as is_following,
+			EXISTS(SELECT 1 FROM follows WHERE follower_id = u.id AND following_id = $3) as is_followed_by,
+			EXISTS(SELECT 1 FROM follow_requests WHERE requester_id = $3 AND target_id = u.id) as is_follow_requested
+		FROM likes l
+		INNER JOIN users u ON u.id = l.user_id
+		WHERE l.likeable_type = $1 AND l.likeable_id = $2
+			AND u.deleted_at IS NULL
+			AND NOT is_blocked_between($3, u.id)
+		ORDER BY l.created_at DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	likers := []interaction.Interactor{}
+	err := r.db.SelectContext(ctx, &likers, query, likeableType, likeableID, viewerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return likers, nil
+}
+
 // Repost creates a new repost
 // Note: reposts_count is automatically updated via database trigger (update_reposts_count_trigger)
 func (r *interactionRepository) Repost(ctx context.Context, repost *interaction.Repost) error {
@@ -218,6 +245,33 @@ func (r *interactionRepository) GetReposts(ctx context.Context, postID uuid.UUID
 	return reposts, nil
 }
 
+// GetReposters gets the users who reposted a post, newest first, with the viewer's follow state.
+// Deleted users and users in a block relation with the viewer are left out.
+func (r *interactionRepository) GetReposters(ctx context.Context, postID, viewerID uuid.UUID, limit, offset int) ([]interaction.Interactor, error) {
+	query := `
+		SELECT u.id as user_id, u.name, u.username, u.avatar_url, COALESCE(u.is_verified, false) as is_verified,
+			rp.quote_content, rp.created_at,
+			EXISTS(SELECT 1 FROM follows WHERE follower_id = $2 AND following_id = u.id) as is_following,
+			EXISTS(SELECT 1 FROM follows WHERE follower_id = u.id AND following_id = $2) as is_followed_by,
+			EXISTS(SELECT 1 FROM follow_requests WHERE requester_id = $2 AND target_id = u.id) as is_follow_requested
+		FROM reposts rp
+		INNER JOIN users u ON u.id = rp.user_id
+		WHERE rp.post_id = $1
+			AND u.deleted_at IS NULL
+			AND NOT is_blocked_between($2, u.id)
+		ORDER BY rp.created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	reposters := []interaction.Interactor{}
+	err := r.db.SelectContext(ctx, &reposters, query, postID, viewerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return reposters, nil
+}
+
 // Bookmark creates a new bookmark
 func (r *interactionRepository) Bookmark(ctx context.Context, bookmark *interaction.Bookmark) error {
 	// Generate UUID if not provided
@@ -316,6 +370,7 @@ func (r *interactionRepository) GetBookmarks(ctx context.Context, userID uuid.UU
 }
 
 // Share creates a new share
+// A repeated share to the same platform within the same interaction.ShareWindow is ignored
 // Note: shares_count is automatically updated via database trigger (update_shares_count_trigger)
 func (r *interactionRepository) Share(ctx context.Context, share *interaction.Share) error {
 	// Generate UUID if not provided
@@ -327,17 +382,56 @@ func (r *interactionRepository) Share(ctx context.Context, share *interaction.Sh
 	share.CreatedAt = time.Now()
 
 	query := `
-		INSERT INTO shares (id, user_id, post_id, platform, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO shares (id, user_id, post_id, platform, created_at, window_start)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, post_id, COALESCE(platform, ''), window_start) DO NOTHING
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
 		share.ID, share.UserID, share.PostID, share.Platform, share.CreatedAt,
+		share.CreatedAt.Truncate(interaction.ShareWindow),
 	)
 
 	return err
 }
 
+// GetShareCountsByPost counts the shares of a post per platform, most shared first
+func (r *interactionRepository) GetShareCountsByPost(ctx context.Context, postID uuid.UUID) ([]interaction.PlatformShareCount, error) {
+	query := `
+		SELECT COALESCE(platform, $2) as platform, COUNT(*) as count
+		FROM shares
+		WHERE post_id = $1
+		GROUP BY 1
+		ORDER BY count DESC, platform ASC
+	`
+
+	counts := []interaction.PlatformShareCount{}
+	if err := r.db.SelectContext(ctx, &counts, query, postID, interaction.PlatformOther); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GetShareCountsByEvent counts the shares of posts attached to an event per platform, most shared first
+func (r *interactionRepository) GetShareCountsByEvent(ctx context.Context, eventID uuid.UUID) ([]interaction.PlatformShareCount, error) {
+	query := `
+		SELECT COALESCE(s.platform, $2) as platform, COUNT(*) as count
+		FROM shares s
+		INNER JOIN posts p ON p.id = s.post_id
+		WHERE p.attached_event_id = $1
+		GROUP BY 1
+		ORDER BY count DESC, platform ASC
+	`
+
+	counts := []interaction.PlatformShareCount{}
+	if err := r.db.SelectContext(ctx, &counts, query, eventID, interaction.PlatformOther); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 // GetShareCount gets the count of shares for a post
 func (r *interactionRepository) GetShareCount(ctx context.Context, postID uuid.UUID) (int, error) {
 	query := `SELECT COUNT(*) FROM shares WHERE post_id = $1`
@@ -359,3 +453,35 @@ func (r *interactionRepository) CountBookmarks(ctx context.Context, userID uuid.
 	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
 	return count, err
 }
+
+// CountLikers counts the users GetLikers lists
+func (r *interactionRepository) CountLikers(ctx context.Context, likeableType interaction.LikeableType, likeableID, viewerID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM likes l
+		INNER JOIN users u ON u.id = l.user_id
+		WHERE l.likeable_type = $1 AND l.likeable_id = $2
+			AND u.deleted_at IS NULL
+			AND NOT is_blocked_between($3, u.id)
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, likeableType, likeableID, viewerID).Scan(&count)
+	return count, err
+}
+
+// CountReposters counts the users GetReposters lists
+func (r *interactionRepository) CountReposters(ctx context.Context, postID, viewerID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM reposts rp
+		INNER JOIN users u ON u.id = rp.user_id
+		WHERE rp.post_id = $1
+			AND u.deleted_at IS NULL
+			AND NOT is_blocked_between($2, u.id)
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, postID, viewerID).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,372 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/moderation"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type moderationRepository struct {
+	db *sqlx.DB
+}
+
+// NewModerationRepository creates a new moderation repository
+func NewModerationRepository(db *sqlx.DB) moderation.Repository {
+	return &moderationRepository{db: db}
+}
+
+const reportColumns = `id, target_type, target_id, status, reports_count, is_hidden,
+	first_reported_at, last_reported_at, resolved_by, resolved_at`
+
+// FileReport adds a filing to the open report on a target, opening the report if there is none.
+// A reporter who already filed on the open report is counted once.
+func (r *moderationRepository) FileReport(ctx context.Context, targetType moderation.TargetType, targetID uuid.UUID, filing *moderation.Filing) (*moderation.Report, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var reportID uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO reports (target_type, target_id, status, reports_count, first_reported_at, last_reported_at)
+		VALUES ($1, $2, 'open', 0, $3, $3)
+		ON CONFLICT (target_type, target_id) WHERE status = 'open'
+		DO UPDATE SET last_reported_at = EXCLUDED.last_reported_at
+		RETURNING id
+	`, targetType, targetID, filing.CreatedAt).Scan(&reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	filing.ReportID = reportID
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO report_filings (id, report_id, reporter_id, reason, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (report_id, reporter_id) DO NOTHING
+	`, filing.ID, filing.ReportID, filing.ReporterID, filing.Reason, filing.Details, filing.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var report moderation.Report
+	err = tx.GetContext(ctx, &report, `
+		UPDATE reports
+		SET reports_count = (SELECT COUNT(*) FROM report_filings WHERE report_id = $1)
+		WHERE id = $1
+		RETURNING `+reportColumns, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// HasReported checks if a user already filed on the open report on a target
+func (r *moderationRepository) HasReported(ctx context.Context, reporterID uuid.UUID, targetType moderation.TargetType, targetID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM report_filings f
+			INNER JOIN reports rp ON rp.id = f.report_id
+			WHERE f.reporter_id = $1 AND rp.target_type = $2 AND rp.target_id = $3 AND rp.status = 'open'
+		)
+	`
+
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, query, reporterID, targetType, targetID)
+	return exists, err
+}
+
+// GetReport gets a report by ID
+func (r *moderationRepository) GetReport(ctx context.Context, reportID uuid.UUID) (*moderation.Report, error) {
+	query := `SELECT ` + reportColumns + ` FROM reports WHERE id = $1`
+
+	var report moderation.Report
+	if err := r.db.GetContext(ctx, &report, query, reportID); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetReports gets reports matching the filter, most reported first
+func (r *moderationRepository) GetReports(ctx context.Context, filter *moderation.ReportFilter) ([]moderation.Report, error) {
+	conditions, args := reportConditions(filter)
+	query := fmt.Sprintf(`
+		SELECT %s FROM reports
+		%s
+		ORDER BY reports_count DESC, last_reported_at DESC
+		LIMIT $%d OFFSET $%d
+	`, reportColumns, conditions, len(args)+1, len(args)+2)
+	args = append(args, filter.Limit, filter.Offset)
+
+	reports := []moderation.Report{}
+	err := r.db.SelectContext(ctx, &reports, query, args...)
+	return reports, err
+}
+
+// CountReports counts reports matching the filter
+func (r *moderationRepository) CountReports(ctx context.Context, filter *moderation.ReportFilter) (int, error) {
+	conditions, args := reportConditions(filter)
+	query := `SELECT COUNT(*) FROM reports ` + conditions
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// reportConditions builds the WHERE clause for a report filter
+func reportConditions(filter *moderation.ReportFilter) (string, []interface{}) {
+	conditions := "WHERE status = $1"
+	args := []interface{}{filter.Status}
+
+	if filter.TargetType != nil {
+		args = append(args, *filter.TargetType)
+		conditions += fmt.Sprintf(" AND target_type = $%d", len(args))
+	}
+
+	return conditions, args
+}
+
+// GetFilings gets the individual reports behind a report, oldest first
+func (r *moderationRepository) GetFilings(ctx context.Context, reportID uuid.UUID) ([]moderation.FilingWithReporter, error) {
+	query := `
+		SELECT f.id, f.report_id, f.reporter_id, f.reason, f.details, f.created_at,
+			u.name as reporter_name, u.avatar_url as reporter_avatar_url
+		FROM report_filings f
+		INNER JOIN users u ON u.id = f.reporter_id
+		WHERE f.report_id = $1
+		ORDER BY f.created_at ASC
+	`
+
+	filings := []moderation.FilingWithReporter{}
+	err := r.db.SelectContext(ctx, &filings, query, reportID)
+	return filings, err
+}
+
+// GetTargetOwner gets the user responsible for a target: the author, host or the profile owner
+func (r *moderationRepository) GetTargetOwner(ctx context.Context, targetType moderation.TargetType, targetID uuid.UUID) (uuid.UUID, error) {
+	var query string
+	switch targetType {
+	case moderation.TargetPost:
+		query = `SELECT author_id FROM posts WHERE id = $1`
+	case moderation.TargetComment:
+		query = `SELECT author_id FROM comments WHERE id = $1`
+	case moderation.TargetEvent:
+		query = `SELECT host_id FROM events WHERE id = $1`
+	case moderation.TargetQnA:
+		query = `SELECT user_id FROM event_qna WHERE id = $1`
+	case moderation.TargetUser:
+		query = `SELECT id FROM users WHERE id = $1`
+	default:
+		return uuid.Nil, fmt.Errorf("unknown report target type: %s", targetType)
+	}
+
+	var ownerID uuid.UUID
+	err := r.db.GetContext(ctx, &ownerID, query, targetID)
+	return ownerID, err
+}
+
+// HideReport hides the content of an open report and records the action.
+// Does nothing if the report is already hidden or no longer open.
+func (r *moderationRepository) HideReport(ctx context.Context, reportID uuid.UUID, action *moderation.Action) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE reports SET is_hidden = TRUE
+		WHERE id = $1 AND status = 'open' AND is_hidden = FALSE
+	`, reportID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return nil
+	}
+
+	if err := insertAction(ctx, tx, action); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResolveReport closes an open report and records the action if one is given
+func (r *moderationRepository) ResolveReport(ctx context.Context, reportID uuid.UUID, status moderation.ReportStatus, hidden bool, resolvedBy uuid.UUID, action *moderation.Action) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE reports
+		SET status = $2, is_hidden = $3, resolved_by = $4, resolved_at = $5
+		WHERE id = $1 AND status = 'open'
+	`, reportID, status, hidden, resolvedBy, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if action != nil {
+		if err := insertAction(ctx, tx, action); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SuspendUser suspends a user until the given time, or indefinitely when until is nil, and records the action
+func (r *moderationRepository) SuspendUser(ctx context.Context, userID uuid.UUID, until *time.Time, reason string, action *moderation.Action) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET suspended_at = $2, suspended_until = $3, suspension_reason = $4
+		WHERE id = $1
+	`, userID, action.CreatedAt, until, reason)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertAction(ctx, tx, action); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UnsuspendUser lifts a user's suspension and records the action
+func (r *moderationRepository) UnsuspendUser(ctx context.Context, userID uuid.UUID, action *moderation.Action) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL
+		WHERE id = $1 AND suspended_at IS NOT NULL
+	`, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertAction(ctx, tx, action); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RecordAction writes an audit trail entry for an action taken outside the report queue
+func (r *moderationRepository) RecordAction(ctx context.Context, a *moderation.Action) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO moderation_actions (id, actor_id, action, target_type, target_id, report_id, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, a.ID, a.ActorID, a.Action, a.TargetType, a.TargetID, a.ReportID, a.Note, a.CreatedAt)
+	return err
+}
+
+// GetActions gets audit trail entries matching the filter, most recent first
+func (r *moderationRepository) GetActions(ctx context.Context, filter *moderation.ActionFilter) ([]moderation.Action, error) {
+	conditions, args := actionConditions(filter)
+	query := fmt.Sprintf(`
+		SELECT id, actor_id, action, target_type, target_id, report_id, note, created_at
+		FROM moderation_actions
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, conditions, len(args)+1, len(args)+2)
+	args = append(args, filter.Limit, filter.Offset)
+
+	actions := []moderation.Action{}
+	err := r.db.SelectContext(ctx, &actions, query, args...)
+	return actions, err
+}
+
+// CountActions counts audit trail entries matching the filter
+func (r *moderationRepository) CountActions(ctx context.Context, filter *moderation.ActionFilter) (int, error) {
+	conditions, args := actionConditions(filter)
+	query := `SELECT COUNT(*) FROM moderation_actions ` + conditions
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// actionConditions builds the WHERE clause for an audit trail filter
+func actionConditions(filter *moderation.ActionFilter) (string, []interface{}) {
+	conditions := "WHERE 1=1"
+	args := []interface{}{}
+
+	if filter.TargetType != nil {
+		args = append(args, *filter.TargetType)
+		conditions += fmt.Sprintf(" AND target_type = $%d", len(args))
+	}
+	if filter.TargetID != nil {
+		args = append(args, *filter.TargetID)
+		conditions += fmt.Sprintf(" AND target_id = $%d", len(args))
+	}
+	if filter.ActorID != nil {
+		args = append(args, *filter.ActorID)
+		conditions += fmt.Sprintf(" AND actor_id = $%d", len(args))
+	}
+
+	return conditions, args
+}
+
+// insertAction writes an audit trail entry inside a transaction
+func insertAction(ctx context.Context, tx *sqlx.Tx, a *moderation.Action) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO moderation_actions (id, actor_id, action, target_type, target_id, report_id, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, a.ID, a.ActorID, a.Action, a.TargetType, a.TargetID, a.ReportID, a.Note, a.CreatedAt)
+	return err
+}
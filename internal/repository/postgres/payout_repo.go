@@ -0,0 +1,519 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/payout"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type payoutRepository struct {
+	db *sqlx.DB
+}
+
+// NewPayoutRepository creates a new ledger and payout repository
+func NewPayoutRepository(db *sqlx.DB) payout.Repository {
+	return &payoutRepository{db: db}
+}
+
+// hostBalanceQuery sums a host's host_payable entries
+// Credits not yet available are held; debits (refunds, payouts) count immediately
+const hostBalanceQuery = `
+	SELECT
+		$1::uuid AS host_id,
+		COALESCE(SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END), 0) AS total,
+		COALESCE(SUM(CASE WHEN direction = 'credit' THEN amount ELSE -amount END) FILTER (WHERE available_at > $2), 0) AS held,
+		(SELECT COALESCE(SUM(amount), 0) FROM payouts WHERE host_id = $1 AND status = 'requested') AS in_transit
+	FROM ledger_entries
+	WHERE account = 'host_payable' AND host_id = $1
+`
+
+// insertLedgerTransaction writes a transaction and its entries
+// Returns payout.ErrAlreadyPosted if the (kind, reference) pair was posted before
+func insertLedgerTransaction(ctx context.Context, tx *sqlx.Tx, txn *payout.Transaction) error {
+	if !txn.IsBalanced() {
+		return payout.ErrUnbalanced
+	}
+
+	if txn.ID == uuid.Nil {
+		txn.ID = uuid.New()
+	}
+	txn.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO ledger_transactions (id, kind, reference_id, event_id, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (kind, reference_id) DO NOTHING
+	`
+
+	result, err := tx.ExecContext(ctx, query, txn.ID, txn.Kind, txn.ReferenceID, txn.EventID, txn.Description, txn.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return payout.ErrAlreadyPosted
+	}
+
+	entryQuery := `
+		INSERT INTO ledger_entries (id, transaction_id, account, host_id, event_id, direction, amount, available_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	for i := range txn.Entries {
+		e := &txn.Entries[i]
+		if e.ID == uuid.Nil {
+			e.ID = uuid.New()
+		}
+		e.TransactionID = txn.ID
+		e.CreatedAt = txn.CreatedAt
+
+		if _, err := tx.ExecContext(ctx, entryQuery,
+			e.ID, e.TransactionID, e.Account, e.HostID, e.EventID, e.Direction, e.Amount, e.AvailableAt, e.CreatedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PostTransaction posts a balanced ledger transaction
+func (r *payoutRepository) PostTransaction(ctx context.Context, txn *payout.Transaction) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertLedgerTransaction(ctx, tx, txn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetTransactionByReference gets a posted ledger transaction with its entries
+func (r *payoutRepository) GetTransactionByReference(ctx context.Context, kind payout.TransactionKind, referenceID uuid.UUID) (*payout.Transaction, error) {
+	query := `
+		SELECT id, kind, reference_id, event_id, description, created_at
+		FROM ledger_transactions
+		WHERE kind = $1 AND reference_id = $2
+	`
+
+	var txn payout.Transaction
+	if err := r.db.GetContext(ctx, &txn, query, kind, referenceID); err != nil {
+		return nil, err
+	}
+
+	entryQuery := `
+		SELECT id, transaction_id, account, host_id, event_id, direction, amount, available_at, created_at
+		FROM ledger_entries
+		WHERE transaction_id = $1
+	`
+
+	if err := r.db.SelectContext(ctx, &txn.Entries, entryQuery, txn.ID); err != nil {
+		return nil, err
+	}
+
+	return &txn, nil
+}
+
+// GetHostStatement gets the host_payable entries of a host, newest first
+func (r *payoutRepository) GetHostStatement(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]payout.StatementEntry, error) {
+	query := `
+		SELECT
+			le.id, le.transaction_id, le.account, le.host_id, le.event_id, le.direction, le.amount,
+			le.available_at, le.created_at,
+			lt.kind, lt.reference_id, lt.description,
+			e.title as event_title
+		FROM ledger_entries le
+		INNER JOIN ledger_transactions lt ON le.transaction_id = lt.id
+		LEFT JOIN events e ON le.event_id = e.id
+		WHERE le.account = 'host_payable' AND le.host_id = $1
+		ORDER BY le.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var entries []payout.StatementEntry
+	if err := r.db.SelectContext(ctx, &entries, query, hostID, limit, offset); err != nil {
+		return nil, err
+	}
+
+	if entries == nil {
+		entries = []payout.StatementEntry{}
+	}
+
+	return entries, nil
+}
+
+// CountHostStatement counts the host_payable entries of a host
+func (r *payoutRepository) CountHostStatement(ctx context.Context, hostID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM ledger_entries WHERE account = 'host_payable' AND host_id = $1`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, hostID); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetHostBalance gets what the platform owes a host as of now
+func (r *payoutRepository) GetHostBalance(ctx context.Context, hostID uuid.UUID, now time.Time) (*payout.Balance, error) {
+	var balance payout.Balance
+	if err := r.db.GetContext(ctx, &balance, hostBalanceQuery, hostID, now); err != nil {
+		return nil, err
+	}
+
+	balance.Available = balance.Total - balance.Held
+	return &balance, nil
+}
+
+// CreateBankAccount registers a bank account for a host
+func (r *payoutRepository) CreateBankAccount(ctx context.Context, a *payout.BankAccount) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	a.IsActive = true
+	a.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO host_bank_accounts (id, user_id, bank_name, account_number, account_holder_name, is_active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		a.ID, a.UserID, a.BankName, a.AccountNumber, a.AccountHolderName, a.IsActive, a.CreatedAt,
+	)
+	return err
+}
+
+// GetBankAccountByID gets a bank account by ID, including deactivated ones
+func (r *payoutRepository) GetBankAccountByID(ctx context.Context, accountID uuid.UUID) (*payout.BankAccount, error) {
+	query := `
+		SELECT id, user_id, bank_name, account_number, account_holder_name, is_active, created_at
+		FROM host_bank_accounts
+		WHERE id = $1
+	`
+
+	var a payout.BankAccount
+	if err := r.db.GetContext(ctx, &a, query, accountID); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// GetBankAccountsByUser gets the active bank accounts of a host
+func (r *payoutRepository) GetBankAccountsByUser(ctx context.Context, userID uuid.UUID) ([]payout.BankAccount, error) {
+	query := `
+		SELECT id, user_id, bank_name, account_number, account_holder_name, is_active, created_at
+		FROM host_bank_accounts
+		WHERE user_id = $1 AND is_active = TRUE
+		ORDER BY created_at DESC
+	`
+
+	var accounts []payout.BankAccount
+	if err := r.db.SelectContext(ctx, &accounts, query, userID); err != nil {
+		return nil, err
+	}
+
+	if accounts == nil {
+		accounts = []payout.BankAccount{}
+	}
+
+	return accounts, nil
+}
+
+// DeactivateBankAccount removes a bank account from use
+// Accounts are kept for the payouts already sent to them
+func (r *payoutRepository) DeactivateBankAccount(ctx context.Context, accountID uuid.UUID) error {
+	query := `UPDATE host_bank_accounts SET is_active = FALSE WHERE id = $1 AND is_active = TRUE`
+
+	result, err := r.db.ExecContext(ctx, query, accountID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CreatePayout creates a payout request and moves its amount out of the host's balance
+// Requests of the same host are serialized so two requests cannot spend the same balance
+// Returns payout.ErrInsufficientBalance if the available balance is too low
+func (r *payoutRepository) CreatePayout(ctx context.Context, p *payout.Payout, txn *payout.Transaction, now time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, p.HostID.String()); err != nil {
+		return err
+	}
+
+	var balance payout.Balance
+	if err := tx.GetContext(ctx, &balance, hostBalanceQuery, p.HostID, now); err != nil {
+		return err
+	}
+	if payout.Cents(balance.Total-balance.Held) < payout.Cents(p.Amount) {
+		return payout.ErrInsufficientBalance
+	}
+
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	p.Status = payout.StatusRequested
+	p.RequestedAt = now
+
+	query := `
+		INSERT INTO payouts (id, host_id, bank_account_id, amount, status, requested_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	if _, err := tx.ExecContext(ctx, query, p.ID, p.HostID, p.BankAccountID, p.Amount, p.Status, p.RequestedAt); err != nil {
+		return err
+	}
+
+	txn.ReferenceID = p.ID
+	if err := insertLedgerTransaction(ctx, tx, txn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetPayoutByID gets a payout by ID
+func (r *payoutRepository) GetPayoutByID(ctx context.Context, payoutID uuid.UUID) (*payout.Payout, error) {
+	query := `
+		SELECT id, host_id, bank_account_id, amount, status, reference, note, requested_at, processed_at, processed_by
+		FROM payouts
+		WHERE id = $1
+	`
+
+	var p payout.Payout
+	if err := r.db.GetContext(ctx, &p, query, payoutID); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// GetPayoutsByHost gets the payouts of a host, newest first
+func (r *payoutRepository) GetPayoutsByHost(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]payout.PayoutWithDetails, error) {
+	query := `
+		SELECT
+			p.id, p.host_id, p.bank_account_id, p.amount, p.status, p.reference, p.note,
+			p.requested_at, p.processed_at, p.processed_by,
+			u.name as host_name,
+			b.bank_name, b.account_number, b.account_holder_name
+		FROM payouts p
+		INNER JOIN users u ON p.host_id = u.id
+		INNER JOIN host_bank_accounts b ON p.bank_account_id = b.id
+		WHERE p.host_id = $1
+		ORDER BY p.requested_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var payouts []payout.PayoutWithDetails
+	if err := r.db.SelectContext(ctx, &payouts, query, hostID, limit, offset); err != nil {
+		return nil, err
+	}
+
+	if payouts == nil {
+		payouts = []payout.PayoutWithDetails{}
+	}
+
+	return payouts, nil
+}
+
+// GetPayoutsByStatus gets payouts in a status, oldest first so admins work through the queue in order
+func (r *payoutRepository) GetPayoutsByStatus(ctx context.Context, status payout.PayoutStatus, limit, offset int) ([]payout.PayoutWithDetails, error) {
+	query := `
+		SELECT
+			p.id, p.host_id, p.bank_account_id, p.amount, p.status, p.reference, p.note,
+			p.requested_at, p.processed_at, p.processed_by,
+			u.name as host_name,
+			b.bank_name, b.account_number, b.account_holder_name
+		FROM payouts p
+		INNER JOIN users u ON p.host_id = u.id
+		INNER JOIN host_bank_accounts b ON p.bank_account_id = b.id
+		WHERE p.status = $1
+		ORDER BY p.requested_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	var payouts []payout.PayoutWithDetails
+	if err := r.db.SelectContext(ctx, &payouts, query, status, limit, offset); err != nil {
+		return nil, err
+	}
+
+	if payouts == nil {
+		payouts = []payout.PayoutWithDetails{}
+	}
+
+	return payouts, nil
+}
+
+// ResolvePayout moves a requested payout to its final status and posts the matching ledger transaction
+// Returns sql.ErrNoRows if the payout was no longer requested
+func (r *payoutRepository) ResolvePayout(ctx context.Context, p *payout.Payout, txn *payout.Transaction) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE payouts
+		SET status = $1, reference = $2, note = $3, processed_at = $4, processed_by = $5
+		WHERE id = $6 AND status = 'requested'
+	`
+
+	result, err := tx.ExecContext(ctx, query, p.Status, p.Reference, p.Note, p.ProcessedAt, p.ProcessedBy, p.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertLedgerTransaction(ctx, tx, txn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetReconciliationLines compares, per ticket purchased in the period, the net amount collected
+// according to ticket_transactions with the net gateway cash posted to the ledger
+// Refunds always return the full price, so a ticket with a refunded transaction should net to zero
+func (r *payoutRepository) GetReconciliationLines(ctx context.Context, startDate, endDate *time.Time) ([]payout.ReconciliationLine, error) {
+	query := `
+		WITH txn AS (
+			SELECT
+				ticket_id,
+				CASE WHEN BOOL_OR(status = 'refunded') THEN 0
+				     ELSE COALESCE(SUM(amount) FILTER (WHERE status = 'success'), 0)
+				END AS net
+			FROM ticket_transactions
+			GROUP BY ticket_id
+		),
+		led AS (
+			SELECT
+				lt.reference_id AS ticket_id,
+				SUM(CASE WHEN le.direction = 'debit' THEN le.amount ELSE -le.amount END) AS net
+			FROM ledger_transactions lt
+			INNER JOIN ledger_entries le ON le.transaction_id = lt.id
+			WHERE lt.kind IN ('ticket_sale', 'ticket_refund') AND le.account = 'gateway_cash'
+			GROUP BY lt.reference_id
+		)
+		SELECT
+			t.id as ticket_id, t.event_id,
+			COALESCE(txn.net, 0) as transactions_net,
+			COALESCE(led.net, 0) as ledger_net
+		FROM tickets t
+		LEFT JOIN txn ON txn.ticket_id = t.id
+		LEFT JOIN led ON led.ticket_id = t.id
+		WHERE (txn.ticket_id IS NOT NULL OR led.ticket_id IS NOT NULL)
+		  AND ($1::timestamptz IS NULL OR t.purchased_at >= $1)
+		  AND ($2::timestamptz IS NULL OR t.purchased_at <= $2)
+		ORDER BY t.purchased_at ASC
+	`
+
+	var lines []payout.ReconciliationLine
+	if err := r.db.SelectContext(ctx, &lines, query, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	if lines == nil {
+		lines = []payout.ReconciliationLine{}
+	}
+
+	return lines, nil
+}
+
+// GetAccountTotals gets the net balance (debits minus credits) of each account for ledger transactions in the period
+func (r *payoutRepository) GetAccountTotals(ctx context.Context, startDate, endDate *time.Time) (*payout.AccountTotals, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(CASE WHEN le.direction = 'debit' THEN le.amount ELSE -le.amount END) FILTER (WHERE le.account = 'gateway_cash'), 0) as gateway_cash,
+			COALESCE(SUM(CASE WHEN le.direction = 'debit' THEN le.amount ELSE -le.amount END) FILTER (WHERE le.account = 'host_payable'), 0) as host_payable,
+			COALESCE(SUM(CASE WHEN le.direction = 'debit' THEN le.amount ELSE -le.amount END) FILTER (WHERE le.account = 'platform_fees'), 0) as platform_fees,
+			COALESCE(SUM(CASE WHEN le.direction = 'debit' THEN le.amount ELSE -le.amount END) FILTER (WHERE le.account = 'payout_clearing'), 0) as payout_clearing
+		FROM ledger_entries le
+		INNER JOIN ledger_transactions lt ON le.transaction_id = lt.id
+		WHERE ($1::timestamptz IS NULL OR lt.created_at >= $1)
+		  AND ($2::timestamptz IS NULL OR lt.created_at <= $2)
+	`
+
+	var totals payout.AccountTotals
+	if err := r.db.GetContext(ctx, &totals, query, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	return &totals, nil
+}
+
+// CountUnbalancedTransactions counts ledger transactions in the period whose entries do not balance
+func (r *payoutRepository) CountUnbalancedTransactions(ctx context.Context, startDate, endDate *time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM (
+			SELECT lt.id
+			FROM ledger_transactions lt
+			LEFT JOIN ledger_entries le ON le.transaction_id = lt.id
+			WHERE ($1::timestamptz IS NULL OR lt.created_at >= $1)
+			  AND ($2::timestamptz IS NULL OR lt.created_at <= $2)
+			GROUP BY lt.id
+			HAVING COUNT(le.id) = 0
+			    OR SUM(CASE WHEN le.direction = 'debit' THEN le.amount ELSE -le.amount END) <> 0
+		) unbalanced
+	`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, startDate, endDate); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SumPayouts sums payouts in a status processed (or requested, for open payouts) in the period
+func (r *payoutRepository) SumPayouts(ctx context.Context, status payout.PayoutStatus, startDate, endDate *time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM payouts
+		WHERE status = $1
+		  AND ($2::timestamptz IS NULL OR COALESCE(processed_at, requested_at) >= $2)
+		  AND ($3::timestamptz IS NULL OR COALESCE(processed_at, requested_at) <= $3)
+	`
+
+	var total float64
+	if err := r.db.GetContext(ctx, &total, query, status, startDate, endDate); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
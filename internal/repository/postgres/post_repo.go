@@ -9,6 +9,7 @@ import (
 	"github.com/anigmaa/backend/internal/domain/post"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type postRepository struct {
@@ -35,14 +36,14 @@ func (r *postRepository) Create(ctx context.Context, p *post.Post) error {
 	query := `
 		INSERT INTO posts (
 			id, author_id, content, type, attached_event_id, original_post_id,
-			visibility, created_at, updated_at, likes_count, comments_count,
+			visibility, status, scheduled_at, created_at, updated_at, likes_count, comments_count,
 			reposts_count, shares_count
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 0, 0, 0, 0)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 0, 0, 0, 0)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
 		p.ID, p.AuthorID, p.Content, p.Type, p.AttachedEventID, p.OriginalPostID,
-		p.Visibility, p.CreatedAt, p.UpdatedAt,
+		p.Visibility, p.Status, p.ScheduledAt, p.CreatedAt, p.UpdatedAt,
 	)
 
 	return err
@@ -52,7 +53,7 @@ func (r *postRepository) Create(ctx context.Context, p *post.Post) error {
 func (r *postRepository) GetByID(ctx context.Context, postID uuid.UUID) (*post.Post, error) {
 	query := `
 		SELECT id, author_id, content, type, attached_event_id, original_post_id,
-		       visibility, created_at, updated_at, likes_count, comments_count,
+		       visibility, status, scheduled_at, created_at, updated_at, edited_at, likes_count, comments_count,
 		       reposts_count, shares_count
 		FROM posts
 		WHERE id = $1
@@ -72,9 +73,9 @@ func (r *postRepository) GetWithDetails(ctx context.Context, postID, userID uuid
 	query := `
 		SELECT
 			p.id, p.author_id, p.content, p.type, p.attached_event_id,
-			p.original_post_id, p.visibility, p.created_at, p.updated_at,
+			p.original_post_id, p.visibility, p.status, p.scheduled_at, p.created_at, p.updated_at, p.edited_at,
 			p.likes_count, p.comments_count, p.reposts_count, p.shares_count,
-			u.name as author_name, u.avatar_url as author_avatar_url, u.is_verified as author_is_verified,
+			u.name as author_name, u.username as author_username, u.avatar_url as author_avatar_url, u.is_verified as author_is_verified,
 			EXISTS(SELECT 1 FROM likes WHERE user_id = $2 AND likeable_type = 'post' AND likeable_id = p.id) as is_liked_by_user,
 			EXISTS(SELECT 1 FROM bookmarks WHERE user_id = $2 AND post_id = p.id) as is_bookmarked_by_user,
 			EXISTS(SELECT 1 FROM reposts WHERE user_id = $2 AND post_id = p.id) as is_reposted_by_user,
@@ -82,6 +83,11 @@ func (r *postRepository) GetWithDetails(ctx context.Context, postID, userID uuid
 				(SELECT json_agg(image_url ORDER BY order_index)
 				 FROM post_images WHERE post_id = p.id), '[]'::json
 			) as image_urls,
+			COALESCE(
+				(SELECT array_agg(mu.username ORDER BY mu.username)
+				 FROM post_mentions pm INNER JOIN users mu ON mu.id = pm.user_id
+				 WHERE pm.post_id = p.id AND mu.deleted_at IS NULL), '{}'
+			) as mentions,
 			e.id as event_id, e.title as event_title, e.description as event_description,
 			e.category as event_category, e.start_time as event_start_time, e.end_time as event_end_time,
 			e.location_name as event_location_name, e.location_address as event_location_address,
@@ -100,6 +106,8 @@ func (r *postRepository) GetWithDetails(ctx context.Context, postID, userID uuid
 		LEFT JOIN events e ON p.attached_event_id = e.id
 		LEFT JOIN users eh ON e.host_id = eh.id
 		WHERE p.id = $1
+			-- Moderated and unpublished posts stay visible to their author only
+			AND (p.author_id = $2 OR (p.status = 'published' AND NOT is_content_hidden('post', p.id)))
 	`
 
 	var p post.PostWithDetails
@@ -121,11 +129,12 @@ func (r *postRepository) GetWithDetails(ctx context.Context, postID, userID uuid
 
 	err := r.db.QueryRowxContext(ctx, query, postID, userID).Scan(
 		&p.ID, &p.AuthorID, &p.Content, &p.Type, &p.AttachedEventID,
-		&p.OriginalPostID, &p.Visibility, &p.CreatedAt, &p.UpdatedAt,
+		&p.OriginalPostID, &p.Visibility, &p.Status, &p.ScheduledAt, &p.CreatedAt, &p.UpdatedAt, &p.EditedAt,
 		&p.LikesCount, &p.CommentsCount, &p.RepostsCount, &p.SharesCount,
-		&p.AuthorName, &p.AuthorAvatarURL, &p.AuthorIsVerified,
+		&p.AuthorName, &p.AuthorUsername, &p.AuthorAvatarURL, &p.AuthorIsVerified,
 		&p.IsLikedByUser, &p.IsBookmarkedByUser, &p.IsRepostedByUser,
 		&imageURLs,
+		pq.Array(&p.Mentions),
 		&eventID, &eventTitle, &eventDescription, &eventCategory,
 		&eventStartTime, &eventEndTime,
 		&eventLocationName, &eventLocationAddress, &eventLocationLat, &eventLocationLng,
@@ -229,17 +238,19 @@ func (r *postRepository) GetWithDetails(ctx context.Context, postID, userID uuid
 	return &p, nil
 }
 
-// Update updates a post
-func (r *postRepository) Update(ctx context.Context, p *post.Post) error {
+// Update updates a post that still has the status it was read with
+// Returns sql.ErrNoRows when the status changed in the meantime, e.g. the post was published
+// by the background publisher, so its publication is not reverted
+func (r *postRepository) Update(ctx context.Context, p *post.Post, readStatus post.PostStatus) error {
 	p.UpdatedAt = time.Now()
 
 	query := `
 		UPDATE posts
-		SET content = $1, visibility = $2, updated_at = $3
-		WHERE id = $4
+		SET content = $1, visibility = $2, scheduled_at = $3, status = $4, updated_at = $5
+		WHERE id = $6 AND status = $7
 	`
 
-	result, err := r.db.ExecContext(ctx, query, p.Content, p.Visibility, p.UpdatedAt, p.ID)
+	result, err := r.db.ExecContext(ctx, query, p.Content, p.Visibility, p.ScheduledAt, p.Status, p.UpdatedAt, p.ID, readStatus)
 	if err != nil {
 		return err
 	}
@@ -256,6 +267,63 @@ func (r *postRepository) Update(ctx context.Context, p *post.Post) error {
 	return nil
 }
 
+// Edit saves an edited post along with the revision holding its previous content
+func (r *postRepository) Edit(ctx context.Context, p *post.Post, revision *post.Revision) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if revision.ID == uuid.Nil {
+		revision.ID = uuid.New()
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO post_revisions (id, post_id, content, written_at, replaced_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, revision.ID, p.ID, revision.Content, revision.WrittenAt, revision.ReplacedAt)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE posts
+		SET content = $1, visibility = $2, updated_at = $3, edited_at = $4
+		WHERE id = $5
+	`, p.Content, p.Visibility, p.UpdatedAt, p.EditedAt, p.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+// GetRevisions gets the previous contents of a post, most recent first
+func (r *postRepository) GetRevisions(ctx context.Context, postID uuid.UUID) ([]post.Revision, error) {
+	query := `
+		SELECT id, post_id, content, written_at, replaced_at
+		FROM post_revisions
+		WHERE post_id = $1
+		ORDER BY replaced_at DESC
+	`
+
+	revisions := []post.Revision{}
+	if err := r.db.SelectContext(ctx, &revisions, query, postID); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
 // Delete deletes a post (hard delete - cascades to related tables)
 func (r *postRepository) Delete(ctx context.Context, postID uuid.UUID) error {
 	query := `DELETE FROM posts WHERE id = $1`
@@ -285,6 +353,7 @@ func (r *postRepository) List(ctx context.Context, filter *post.PostFilter, user
 
 // GetFeed gets the feed for a user with random + engagement bias algorithm
 // Algorithm: Random selection from top N recent posts, weighted by engagement score
+// Posts by users in a block relation with the user or muted by the user are left out
 // Engagement score = likes + (comments * 2) + (reposts * 3)
 //
 // CTO REVIEW: PERFORMANCE - Missing database index
@@ -299,9 +368,9 @@ func (r *postRepository) GetFeed(ctx context.Context, userID uuid.UUID, limit, o
 			-- Get top 100 most recent public posts as candidate pool
 			SELECT
 				p.id, p.author_id, p.content, p.type, p.attached_event_id,
-				p.original_post_id, p.visibility, p.created_at, p.updated_at,
+				p.original_post_id, p.visibility, p.status, p.scheduled_at, p.created_at, p.updated_at, p.edited_at,
 				p.likes_count, p.comments_count, p.reposts_count, p.shares_count,
-				u.name as author_name, u.avatar_url as author_avatar_url, u.is_verified as author_is_verified,
+				u.name as author_name, u.username as author_username, u.avatar_url as author_avatar_url, u.is_verified as author_is_verified,
 				EXISTS(SELECT 1 FROM likes WHERE user_id = $1 AND likeable_type = 'post' AND likeable_id = p.id) as is_liked_by_user,
 				EXISTS(SELECT 1 FROM bookmarks WHERE user_id = $1 AND post_id = p.id) as is_bookmarked_by_user,
 				EXISTS(SELECT 1 FROM reposts WHERE user_id = $1 AND post_id = p.id) as is_reposted_by_user,
@@ -309,6 +378,11 @@ func (r *postRepository) GetFeed(ctx context.Context, userID uuid.UUID, limit, o
 					(SELECT json_agg(image_url ORDER BY order_index)
 					 FROM post_images WHERE post_id = p.id), '[]'::json
 				) as image_urls,
+				COALESCE(
+					(SELECT array_agg(mu.username ORDER BY mu.username)
+					 FROM post_mentions pm INNER JOIN users mu ON mu.id = pm.user_id
+					 WHERE pm.post_id = p.id AND mu.deleted_at IS NULL), '{}'
+				) as mentions,
 				e.id as event_id, e.title as event_title, e.description as event_description,
 				e.category as event_category, e.start_time as event_start_time, e.end_time as event_end_time,
 				e.location_name as event_location_name, e.location_address as event_location_address,
@@ -326,7 +400,11 @@ func (r *postRepository) GetFeed(ctx context.Context, userID uuid.UUID, limit, o
 			INNER JOIN users u ON p.author_id = u.id
 			LEFT JOIN events e ON p.attached_event_id = e.id
 			LEFT JOIN users eh ON e.host_id = eh.id
-			WHERE p.visibility = 'public'
+			WHERE p.visibility = 'public' AND p.status = 'published'
+				AND NOT is_blocked_between($1, p.author_id)
+				AND NOT is_content_hidden('post', p.id)
+				-- Muted authors only disappear from the muter's feed
+				AND NOT EXISTS(SELECT 1 FROM user_mutes WHERE muter_id = $1 AND muted_id = p.author_id)
 			ORDER BY p.created_at DESC
 			LIMIT 100
 		)
@@ -366,11 +444,12 @@ func (r *postRepository) GetFeed(ctx context.Context, userID uuid.UUID, limit, o
 
 		err := rows.Scan(
 			&p.ID, &p.AuthorID, &p.Content, &p.Type, &p.AttachedEventID,
-			&p.OriginalPostID, &p.Visibility, &p.CreatedAt, &p.UpdatedAt,
+			&p.OriginalPostID, &p.Visibility, &p.Status, &p.ScheduledAt, &p.CreatedAt, &p.UpdatedAt, &p.EditedAt,
 			&p.LikesCount, &p.CommentsCount, &p.RepostsCount, &p.SharesCount,
-			&p.AuthorName, &p.AuthorAvatarURL, &p.AuthorIsVerified,
+			&p.AuthorName, &p.AuthorUsername, &p.AuthorAvatarURL, &p.AuthorIsVerified,
 			&p.IsLikedByUser, &p.IsBookmarkedByUser, &p.IsRepostedByUser,
 			&imageURLs,
+			pq.Array(&p.Mentions),
 			&eventID, &eventTitle, &eventDescription, &eventCategory,
 			&eventStartTime, &eventEndTime,
 			&eventLocationName, &eventLocationAddress, &eventLocationLat, &eventLocationLng,
@@ -478,9 +557,9 @@ func (r *postRepository) GetUserPosts(ctx context.Context, authorID, viewerID uu
 	query := `
 		SELECT
 			p.id, p.author_id, p.content, p.type, p.attached_event_id,
-			p.original_post_id, p.visibility, p.created_at, p.updated_at,
+			p.original_post_id, p.visibility, p.status, p.scheduled_at, p.created_at, p.updated_at, p.edited_at,
 			p.likes_count, p.comments_count, p.reposts_count, p.shares_count,
-			u.name as author_name, u.avatar_url as author_avatar_url, u.is_verified as author_is_verified,
+			u.name as author_name, u.username as author_username, u.avatar_url as author_avatar_url, u.is_verified as author_is_verified,
 			EXISTS(SELECT 1 FROM likes WHERE user_id = $2 AND likeable_type = 'post' AND likeable_id = p.id) as is_liked_by_user,
 			EXISTS(SELECT 1 FROM bookmarks WHERE user_id = $2 AND post_id = p.id) as is_bookmarked_by_user,
 			EXISTS(SELECT 1 FROM reposts WHERE user_id = $2 AND post_id = p.id) as is_reposted_by_user,
@@ -488,6 +567,11 @@ func (r *postRepository) GetUserPosts(ctx context.Context, authorID, viewerID uu
 				(SELECT json_agg(image_url ORDER BY order_index)
 				 FROM post_images WHERE post_id = p.id), '[]'::json
 			) as image_urls,
+			COALESCE(
+				(SELECT array_agg(mu.username ORDER BY mu.username)
+				 FROM post_mentions pm INNER JOIN users mu ON mu.id = pm.user_id
+				 WHERE pm.post_id = p.id AND mu.deleted_at IS NULL), '{}'
+			) as mentions,
 			e.id as event_id, e.title as event_title, e.description as event_description,
 			e.category as event_category, e.start_time as event_start_time, e.end_time as event_end_time,
 			e.location_name as event_location_name, e.location_address as event_location_address,
@@ -505,7 +589,8 @@ func (r *postRepository) GetUserPosts(ctx context.Context, authorID, viewerID uu
 		INNER JOIN users u ON p.author_id = u.id
 		LEFT JOIN events e ON p.attached_event_id = e.id
 		LEFT JOIN users eh ON e.host_id = eh.id
-		WHERE p.author_id = $1 AND p.visibility IN ('public', 'followers')
+		WHERE p.author_id = $1 AND p.visibility IN ('public', 'followers') AND p.status = 'published'
+			AND (p.author_id = $2 OR NOT is_content_hidden('post', p.id))
 		ORDER BY p.created_at DESC
 		LIMIT $3 OFFSET $4
 	`
@@ -537,11 +622,12 @@ func (r *postRepository) GetUserPosts(ctx context.Context, authorID, viewerID uu
 
 		err := rows.Scan(
 			&p.ID, &p.AuthorID, &p.Content, &p.Type, &p.AttachedEventID,
-			&p.OriginalPostID, &p.Visibility, &p.CreatedAt, &p.UpdatedAt,
+			&p.OriginalPostID, &p.Visibility, &p.Status, &p.ScheduledAt, &p.CreatedAt, &p.UpdatedAt, &p.EditedAt,
 			&p.LikesCount, &p.CommentsCount, &p.RepostsCount, &p.SharesCount,
-			&p.AuthorName, &p.AuthorAvatarURL, &p.AuthorIsVerified,
+			&p.AuthorName, &p.AuthorUsername, &p.AuthorAvatarURL, &p.AuthorIsVerified,
 			&p.IsLikedByUser, &p.IsBookmarkedByUser, &p.IsRepostedByUser,
 			&imageURLs,
+			pq.Array(&p.Mentions),
 			&eventID, &eventTitle, &eventDescription, &eventCategory,
 			&eventStartTime, &eventEndTime,
 			&eventLocationName, &eventLocationAddress, &eventLocationLat, &eventLocationLng,
@@ -700,6 +786,32 @@ func (r *postRepository) GetImages(ctx context.Context, postID uuid.UUID) ([]str
 	return imageURLs, nil
 }
 
+// SetMentions replaces the users mentioned in a post
+func (r *postRepository) SetMentions(ctx context.Context, postID uuid.UUID, userIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM post_mentions WHERE post_id = $1`, postID); err != nil {
+		return err
+	}
+
+	if len(userIDs) > 0 {
+		query := `
+			INSERT INTO post_mentions (post_id, user_id, created_at)
+			SELECT $1, unnest($2::uuid[]), $3
+			ON CONFLICT (post_id, user_id) DO NOTHING
+		`
+		if _, err := tx.ExecContext(ctx, query, postID, pq.Array(userIDs), time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 // IncrementLikes increments the likes count
 func (r *postRepository) IncrementLikes(ctx context.Context, postID uuid.UUID) error {
 	query := `UPDATE posts SET likes_count = likes_count + 1 WHERE id = $1`
@@ -754,21 +866,101 @@ func (r *postRepository) CountFeed(ctx context.Context, userID uuid.UUID) (int,
 	query := `
 		SELECT COUNT(*)
 		FROM posts p
-		WHERE p.visibility = 'public'
+		WHERE p.visibility = 'public' AND p.status = 'published'
+			AND NOT is_blocked_between($1, p.author_id)
+			AND NOT is_content_hidden('post', p.id)
+			AND NOT EXISTS(SELECT 1 FROM user_mutes WHERE muter_id = $1 AND muted_id = p.author_id)
 	`
 	var count int
-	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
 	return count, err
 }
 
-// CountUserPosts counts the total number of posts by a specific author
+// CountUserPosts counts the total number of published posts by a specific author
 func (r *postRepository) CountUserPosts(ctx context.Context, authorID uuid.UUID) (int, error) {
 	query := `
 		SELECT COUNT(*)
 		FROM posts p
-		WHERE p.author_id = $1
+		WHERE p.author_id = $1 AND p.status = 'published'
 	`
 	var count int
 	err := r.db.QueryRowContext(ctx, query, authorID).Scan(&count)
 	return count, err
 }
+
+// GetUnpublished gets an author's scheduled posts, next to be published first, then their drafts, last edited first
+func (r *postRepository) GetUnpublished(ctx context.Context, authorID uuid.UUID, limit, offset int) ([]post.Post, error) {
+	query := `
+		SELECT id, author_id, content, type, attached_event_id, original_post_id,
+		       visibility, status, scheduled_at, created_at, updated_at, edited_at, likes_count, comments_count,
+		       reposts_count, shares_count
+		FROM posts
+		WHERE author_id = $1 AND status != 'published'
+		ORDER BY scheduled_at ASC NULLS LAST, updated_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	posts := []post.Post{}
+	if err := r.db.SelectContext(ctx, &posts, query, authorID, limit, offset); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// CountUnpublished counts an author's drafts and scheduled posts
+func (r *postRepository) CountUnpublished(ctx context.Context, authorID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM posts WHERE author_id = $1 AND status != 'published'`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, authorID).Scan(&count)
+	return count, err
+}
+
+// Publish publishes a draft or scheduled post; its creation time becomes the publish time
+// so it shows up as new in feeds
+func (r *postRepository) Publish(ctx context.Context, postID uuid.UUID, publishedAt time.Time) error {
+	query := `
+		UPDATE posts
+		SET status = 'published', scheduled_at = NULL, created_at = $2, updated_at = $2
+		WHERE id = $1 AND status != 'published'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, postID, publishedAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// PublishDue publishes the scheduled posts whose time has come, dated at their scheduled time
+// Returns the number of posts published
+func (r *postRepository) PublishDue(ctx context.Context, now time.Time) (int, error) {
+	query := `
+		UPDATE posts
+		SET status = 'published', created_at = scheduled_at, scheduled_at = NULL, updated_at = $1
+		WHERE status = 'scheduled' AND scheduled_at <= $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,423 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/promo"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type promoRepository struct {
+	db *sqlx.DB
+}
+
+// NewPromoRepository creates a new promo code repository
+func NewPromoRepository(db *sqlx.DB) promo.Repository {
+	return &promoRepository{db: db}
+}
+
+// Create creates a new promo code with its event restriction
+func (r *promoRepository) Create(ctx context.Context, c *promo.Code) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO promo_codes (id, host_id, code, description, discount_type, discount_value,
+		                         max_redemptions, max_per_user, redemption_count,
+		                         valid_from, valid_until, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 0, $9, $10, $11, $12, $13)
+	`
+
+	_, err = tx.ExecContext(ctx, query,
+		c.ID, c.HostID, c.Code, c.Description, c.DiscountType, c.DiscountValue,
+		c.MaxRedemptions, c.MaxPerUser, c.ValidFrom, c.ValidUntil, c.IsActive, c.CreatedAt, c.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := insertPromoEvents(ctx, tx, c.ID, c.EventIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID gets a promo code by ID
+func (r *promoRepository) GetByID(ctx context.Context, codeID uuid.UUID) (*promo.Code, error) {
+	query := `
+		SELECT id, host_id, code, description, discount_type, discount_value,
+		       max_redemptions, max_per_user, redemption_count,
+		       valid_from, valid_until, is_active, created_at, updated_at
+		FROM promo_codes
+		WHERE id = $1
+	`
+
+	var c promo.Code
+	if err := r.db.GetContext(ctx, &c, query, codeID); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadEvents(ctx, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// GetByHostAndCode gets a host's promo code by its code text (case-insensitive)
+func (r *promoRepository) GetByHostAndCode(ctx context.Context, hostID uuid.UUID, code string) (*promo.Code, error) {
+	query := `
+		SELECT id, host_id, code, description, discount_type, discount_value,
+		       max_redemptions, max_per_user, redemption_count,
+		       valid_from, valid_until, is_active, created_at, updated_at
+		FROM promo_codes
+		WHERE host_id = $1 AND code = UPPER($2)
+	`
+
+	var c promo.Code
+	if err := r.db.GetContext(ctx, &c, query, hostID, code); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadEvents(ctx, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// GetByHost gets all promo codes of a host
+func (r *promoRepository) GetByHost(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]promo.Code, error) {
+	query := `
+		SELECT id, host_id, code, description, discount_type, discount_value,
+		       max_redemptions, max_per_user, redemption_count,
+		       valid_from, valid_until, is_active, created_at, updated_at
+		FROM promo_codes
+		WHERE host_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var codes []promo.Code
+	if err := r.db.SelectContext(ctx, &codes, query, hostID, limit, offset); err != nil {
+		return nil, err
+	}
+
+	if codes == nil {
+		codes = []promo.Code{}
+	}
+
+	for i := range codes {
+		if err := r.loadEvents(ctx, &codes[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return codes, nil
+}
+
+// Update updates a promo code's limits, window and status
+func (r *promoRepository) Update(ctx context.Context, c *promo.Code) error {
+	c.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE promo_codes
+		SET description = $1, max_redemptions = $2, max_per_user = $3,
+		    valid_from = $4, valid_until = $5, is_active = $6, updated_at = $7
+		WHERE id = $8
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		c.Description, c.MaxRedemptions, c.MaxPerUser,
+		c.ValidFrom, c.ValidUntil, c.IsActive, c.UpdatedAt, c.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetEvents replaces the events a promo code is restricted to
+func (r *promoRepository) SetEvents(ctx context.Context, codeID uuid.UUID, eventIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM promo_code_events WHERE promo_code_id = $1`, codeID); err != nil {
+		return err
+	}
+
+	if err := insertPromoEvents(ctx, tx, codeID, eventIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// userRedemptionsQuery sums the tickets discounted for user $2 by code $1, leaving out released redemptions
+const userRedemptionsQuery = `
+	SELECT COALESCE(SUM(quantity), 0) FROM promo_redemptions
+	WHERE promo_code_id = $1 AND user_id = $2 AND released_at IS NULL
+`
+
+// Redeem records a redemption and bumps the code's redemption count
+// The code row is locked so overall and per-user limits hold under concurrent checkouts
+func (r *promoRepository) Redeem(ctx context.Context, red *promo.Redemption) error {
+	if red.ID == uuid.Nil {
+		red.ID = uuid.New()
+	}
+	if red.Quantity <= 0 {
+		red.Quantity = 1
+	}
+	red.RedeemedAt = time.Now()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var c promo.Code
+	lockQuery := `
+		SELECT id, max_redemptions, max_per_user, redemption_count
+		FROM promo_codes
+		WHERE id = $1
+		FOR UPDATE
+	`
+	if err := tx.GetContext(ctx, &c, lockQuery, red.PromoCodeID); err != nil {
+		return err
+	}
+
+	// Every discounted ticket uses the code once
+	if c.MaxRedemptions != nil && c.RedemptionCount+red.Quantity > *c.MaxRedemptions {
+		return promo.ErrCodeExhausted
+	}
+
+	var userRedemptions int
+	if err := tx.GetContext(ctx, &userRedemptions, userRedemptionsQuery, red.PromoCodeID, red.UserID); err != nil {
+		return err
+	}
+	if userRedemptions+red.Quantity > c.MaxPerUser {
+		return promo.ErrUserLimitReached
+	}
+
+	insertQuery := `
+		INSERT INTO promo_redemptions (id, promo_code_id, user_id, event_id, ticket_id, quantity,
+		                               original_amount, discount_amount, redeemed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = tx.ExecContext(ctx, insertQuery,
+		red.ID, red.PromoCodeID, red.UserID, red.EventID, red.TicketID, red.Quantity,
+		red.OriginalAmount, red.DiscountAmount, red.RedeemedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	updateQuery := `UPDATE promo_codes SET redemption_count = redemption_count + $2 WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, updateQuery, red.PromoCodeID, red.Quantity); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReleaseRedemption gives the uses of a ticket's redemption back to the code while the
+// ticket is unpaid. The redemption is kept so RestoreRedemption can take it again.
+func (r *promoRepository) ReleaseRedemption(ctx context.Context, ticketID uuid.UUID) error {
+	query := `
+		WITH released AS (
+			UPDATE promo_redemptions SET released_at = $2
+			WHERE ticket_id = $1 AND released_at IS NULL
+			RETURNING promo_code_id, quantity
+		)
+		UPDATE promo_codes pc
+		SET redemption_count = pc.redemption_count - released.quantity
+		FROM released
+		WHERE pc.id = released.promo_code_id
+	`
+
+	_, err := r.db.ExecContext(ctx, query, ticketID, time.Now())
+	return err
+}
+
+// RestoreRedemption takes the uses of a released redemption again, for a ticket paid after
+// it expired. The code row is locked and its limits checked like in Redeem. Returns false
+// when the ticket has no released redemption.
+func (r *promoRepository) RestoreRedemption(ctx context.Context, ticketID uuid.UUID) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var red promo.Redemption
+	redemptionQuery := `
+		SELECT id, promo_code_id, user_id, quantity
+		FROM promo_redemptions
+		WHERE ticket_id = $1 AND released_at IS NOT NULL
+		FOR UPDATE
+	`
+	if err := tx.GetContext(ctx, &red, redemptionQuery, ticketID); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var c promo.Code
+	lockQuery := `
+		SELECT id, max_redemptions, max_per_user, redemption_count
+		FROM promo_codes
+		WHERE id = $1
+		FOR UPDATE
+	`
+	if err := tx.GetContext(ctx, &c, lockQuery, red.PromoCodeID); err != nil {
+		return false, err
+	}
+
+	if c.MaxRedemptions != nil && c.RedemptionCount+red.Quantity > *c.MaxRedemptions {
+		return false, promo.ErrCodeExhausted
+	}
+
+	var userRedemptions int
+	if err := tx.GetContext(ctx, &userRedemptions, userRedemptionsQuery, red.PromoCodeID, red.UserID); err != nil {
+		return false, err
+	}
+	if userRedemptions+red.Quantity > c.MaxPerUser {
+		return false, promo.ErrUserLimitReached
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE promo_redemptions SET released_at = NULL WHERE id = $1`, red.ID); err != nil {
+		return false, err
+	}
+
+	updateQuery := `UPDATE promo_codes SET redemption_count = redemption_count + $2 WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, updateQuery, red.PromoCodeID, red.Quantity); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetRedemptionsByEvent gets all redemptions for an event's tickets
+func (r *promoRepository) GetRedemptionsByEvent(ctx context.Context, eventID uuid.UUID) ([]promo.RedemptionWithCode, error) {
+	query := `
+		SELECT
+			pr.id, pr.promo_code_id, pr.user_id, pr.event_id, pr.ticket_id, pr.quantity,
+			pr.original_amount, pr.discount_amount, pr.redeemed_at,
+			pc.code
+		FROM promo_redemptions pr
+		INNER JOIN promo_codes pc ON pr.promo_code_id = pc.id
+		WHERE pr.event_id = $1 AND pr.released_at IS NULL
+		ORDER BY pr.redeemed_at DESC
+	`
+
+	var redemptions []promo.RedemptionWithCode
+	if err := r.db.SelectContext(ctx, &redemptions, query, eventID); err != nil {
+		return nil, err
+	}
+
+	return redemptions, nil
+}
+
+// loadEvents loads the event restriction of a promo code
+func (r *promoRepository) loadEvents(ctx context.Context, c *promo.Code) error {
+	query := `SELECT event_id FROM promo_code_events WHERE promo_code_id = $1`
+
+	var eventIDs []uuid.UUID
+	if err := r.db.SelectContext(ctx, &eventIDs, query, c.ID); err != nil {
+		return err
+	}
+
+	if eventIDs == nil {
+		eventIDs = []uuid.UUID{}
+	}
+	c.EventIDs = eventIDs
+
+	return nil
+}
+
+// insertPromoEvents inserts the event restriction rows of a promo code
+func insertPromoEvents(ctx context.Context, tx *sqlx.Tx, codeID uuid.UUID, eventIDs []uuid.UUID) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(eventIDs))
+	for i, id := range eventIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		INSERT INTO promo_code_events (promo_code_id, event_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`
+	_, err := tx.ExecContext(ctx, query, codeID, pq.Array(ids))
+	return err
+}
+
+// GetHostRedemptionStats sums redemptions on paid tickets per promo code across a host's events
+// startDate and endDate filter by event start time and are both optional
+func (r *promoRepository) GetHostRedemptionStats(ctx context.Context, hostID uuid.UUID, startDate, endDate *time.Time) ([]promo.RedemptionStats, error) {
+	query := `
+		SELECT
+			pc.id as promo_code_id, pc.code,
+			SUM(pr.quantity) as redemptions,
+			COALESCE(SUM(pr.discount_amount), 0) as total_discount
+		FROM promo_redemptions pr
+		INNER JOIN promo_codes pc ON pr.promo_code_id = pc.id
+		INNER JOIN tickets t ON pr.ticket_id = t.id
+		INNER JOIN events e ON pr.event_id = e.id
+		WHERE e.host_id = $1
+		  AND t.status = 'active'
+		  AND ($2::timestamptz IS NULL OR e.start_time >= $2)
+		  AND ($3::timestamptz IS NULL OR e.start_time <= $3)
+		GROUP BY pc.id, pc.code
+		ORDER BY total_discount DESC
+	`
+
+	var stats []promo.RedemptionStats
+	if err := r.db.SelectContext(ctx, &stats, query, hostID, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	if stats == nil {
+		stats = []promo.RedemptionStats{}
+	}
+
+	return stats, nil
+}
@@ -69,7 +69,7 @@ func (r *QnARepository) GetByID(ctx context.Context, id uuid.UUID) (*qna.QnA, er
 	return q, err
 }
 
-// GetByEvent retrieves all Q&A for an event
+// GetByEvent retrieves all Q&A for an event, leaving out askers in a block relation with the user
 func (r *QnARepository) GetByEvent(ctx context.Context, eventID, userID uuid.UUID, limit, offset int) ([]qna.QnAWithDetails, error) {
 	query := `
 		SELECT
@@ -78,13 +78,11 @@ func (r *QnARepository) GetByEvent(ctx context.Context, eventID, userID uuid.UUI
 			-- Asked by user
 			u1.id as asked_by_id,
 			u1.name as asked_by_name,
-			u1.email as asked_by_email,
 			u1.avatar_url as asked_by_avatar,
 			u1.created_at as asked_by_created_at,
 			-- Answered by user (nullable)
 			u2.id as answered_by_id,
 			u2.name as answered_by_name,
-			u2.email as answered_by_email,
 			u2.avatar_url as answered_by_avatar,
 			u2.created_at as answered_by_created_at,
 			-- Check if current user upvoted
@@ -96,6 +94,8 @@ func (r *QnARepository) GetByEvent(ctx context.Context, eventID, userID uuid.UUI
 		INNER JOIN users u1 ON q.user_id = u1.id
 		LEFT JOIN users u2 ON q.answered_by = u2.id
 		WHERE q.event_id = $1
+			AND NOT is_blocked_between($2, q.user_id)
+			AND (q.user_id = $2 OR NOT is_content_hidden('qna', q.id))
 		ORDER BY q.upvotes DESC, q.asked_at DESC
 		LIMIT $3 OFFSET $4
 	`
@@ -110,10 +110,10 @@ func (r *QnARepository) GetByEvent(ctx context.Context, eventID, userID uuid.UUI
 
 	for rows.Next() {
 		var q qna.QnAWithDetails
-		var askedByID, askedByName, askedByEmail string
+		var askedByID, askedByName string
 		var askedByAvatar *string
 		var askedByCreatedAt string
-		var answeredByID, answeredByName, answeredByEmail *string
+		var answeredByID, answeredByName *string
 		var answeredByAvatar *string
 		var answeredByCreatedAt *string
 
@@ -127,12 +127,10 @@ func (r *QnARepository) GetByEvent(ctx context.Context, eventID, userID uuid.UUI
 			&q.Upvotes,
 			&askedByID,
 			&askedByName,
-			&askedByEmail,
 			&askedByAvatar,
 			&askedByCreatedAt,
 			&answeredByID,
 			&answeredByName,
-			&answeredByEmail,
 			&answeredByAvatar,
 			&answeredByCreatedAt,
 			&q.IsUpvotedByUser,
@@ -147,7 +145,6 @@ func (r *QnARepository) GetByEvent(ctx context.Context, eventID, userID uuid.UUI
 		q.AskedBy = qna.UserBasicInfo{
 			ID:     askedByUUID,
 			Name:   askedByName,
-			Email:  askedByEmail,
 			Avatar: askedByAvatar,
 		}
 
@@ -157,7 +154,6 @@ func (r *QnARepository) GetByEvent(ctx context.Context, eventID, userID uuid.UUI
 			q.AnsweredBy = &qna.UserBasicInfo{
 				ID:     answeredByUUID,
 				Name:   *answeredByName,
-				Email:  *answeredByEmail,
 				Avatar: answeredByAvatar,
 			}
 		}
@@ -294,7 +290,7 @@ func (r *QnARepository) GetUpvoteCount(ctx context.Context, qnaID uuid.UUID) (in
 
 // CountEventQnA counts total questions for an event
 func (r *QnARepository) CountEventQnA(ctx context.Context, eventID uuid.UUID) (int, error) {
-	query := `SELECT COUNT(*) FROM event_qna WHERE event_id = $1`
+	query := `SELECT COUNT(*) FROM event_qna WHERE event_id = $1 AND NOT is_content_hidden('qna', id)`
 	var count int
 	err := r.db.QueryRowContext(ctx, query, eventID).Scan(&count)
 	return count, err
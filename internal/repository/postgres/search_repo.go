@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/anigmaa/backend/internal/domain/search"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// searchQueryCTE turns $1 into a tsquery that matches either Indonesian or
+// English stems. websearch_to_tsquery never fails on user input.
+const searchQueryCTE = `
+	WITH q AS (
+		SELECT websearch_to_tsquery('indonesian', $1) || websearch_to_tsquery('english', $1) AS query
+	)
+`
+
+// proximityFactor decays the score with distance from ($3, $4); 1 at the
+// point, 0.5 at 25 km. Rows without a location (or queries without one) are
+// left unchanged.
+const proximityFactor = `
+	CASE
+		WHEN $3::float8 IS NULL OR $4::float8 IS NULL OR e.location_geom IS NULL THEN 1
+		ELSE 1 / (1 + ST_Distance(e.location_geom::geography, ST_SetSRID(ST_MakePoint($4::float8, $3::float8), 4326)::geography) / 1000 / 25)
+	END
+`
+
+type searchRepository struct {
+	db *sqlx.DB
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(db *sqlx.DB) search.Repository {
+	return &searchRepository{db: db}
+}
+
+// SearchEvents searches public, non-cancelled events by title, description and location
+func (r *searchRepository) SearchEvents(ctx context.Context, params *search.Params) ([]search.EventResult, error) {
+	query := searchQueryCTE + `
+		SELECT e.id, e.title, e.category, e.start_time, e.location_name,
+			COALESCE(e.is_free, true) as is_free, e.price, e.status, e.host_id,
+			u.name as host_name,
+			(SELECT image_url FROM event_images WHERE event_id = e.id ORDER BY order_index LIMIT 1) as image_url,
+			CASE
+				WHEN $3::float8 IS NULL OR $4::float8 IS NULL OR e.location_geom IS NULL THEN NULL
+				ELSE ST_Distance(e.location_geom::geography, ST_SetSRID(ST_MakePoint($4::float8, $3::float8), 4326)::geography) / 1000
+			END as distance_km,
+			(ts_rank_cd(event_search_vector(e.title, e.description, e.location_name), q.query) + word_similarity($1, e.title))
+				-- Recency: events starting soon rank higher, past events are halved
+				* (1 / (1 + ABS(EXTRACT(EPOCH FROM (e.start_time - $2))) / 86400 / 30))
+				* CASE WHEN e.start_time < $2 THEN 0.5 ELSE 1 END
+				* ` + proximityFactor + ` as score
+		FROM events e
+		CROSS JOIN q
+		INNER JOIN users u ON e.host_id = u.id
+		WHERE e.privacy = 'public'
+			AND e.status != 'cancelled'
+			AND NOT is_content_hidden('event', e.id)
+			AND (event_search_vector(e.title, e.description, e.location_name) @@ q.query OR $1 <% e.title)
+		ORDER BY score DESC, e.start_time ASC
+		LIMIT $5 OFFSET $6
+	`
+
+	var results []search.EventResult
+	err := r.db.SelectContext(ctx, &results, query,
+		params.Query, params.Now, params.Lat, params.Lng, params.Limit, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if results == nil {
+		results = []search.EventResult{}
+	}
+	return results, nil
+}
+
+// SearchPosts searches public posts by content; posts attached to an event
+// near the caller rank higher
+func (r *searchRepository) SearchPosts(ctx context.Context, params *search.Params) ([]search.PostResult, error) {
+	query := searchQueryCTE + `
+		SELECT p.id, p.content, p.author_id, u.name as author_name, u.avatar_url as author_avatar_url,
+			p.attached_event_id, COALESCE(p.likes_count, 0) as likes_count,
+			COALESCE(p.comments_count, 0) as comments_count, p.created_at,
+			ts_rank_cd(post_search_vector(p.content), q.query)
+				-- Recency: halves after a week
+				* (1 / (1 + EXTRACT(EPOCH FROM ($2 - p.created_at)) / 86400 / 7))
+				* ` + proximityFactor + ` as score
+		FROM posts p
+		CROSS JOIN q
+		INNER JOIN users u ON p.author_id = u.id
+		LEFT JOIN events e ON p.attached_event_id = e.id
+		WHERE p.visibility = 'public' AND p.status = 'published'
+			AND post_search_vector(p.content) @@ q.query
+			AND NOT is_blocked_between($7, p.author_id)
+			AND NOT is_content_hidden('post', p.id)
+		ORDER BY score DESC, p.created_at DESC
+		LIMIT $5 OFFSET $6
+	`
+
+	var results []search.PostResult
+	err := r.db.SelectContext(ctx, &results, query,
+		params.Query, params.Now, params.Lat, params.Lng, params.Limit, params.Offset, params.Viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if results == nil {
+		results = []search.PostResult{}
+	}
+	return results, nil
+}
+
+// SearchHashtags finds hashtags used in public posts by prefix or similarity
+func (r *searchRepository) SearchHashtags(ctx context.Context, params *search.Params) ([]search.HashtagResult, error) {
+	query := `
+		SELECT tag, COUNT(*) as posts_count
+		FROM posts p, unnest(p.hashtags) AS tag
+		WHERE p.visibility = 'public' AND p.status = 'published'
+			AND (tag LIKE $1 || '%' OR tag % $1)
+		GROUP BY tag
+		ORDER BY (tag = $1) DESC, similarity(tag, $1) DESC, posts_count DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var results []search.HashtagResult
+	err := r.db.SelectContext(ctx, &results, query, normalizeHashtag(params.Query), params.Limit, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if results == nil {
+		results = []search.HashtagResult{}
+	}
+	return results, nil
+}
+
+// SearchUsers searches users by name and bio, or by username prefix
+func (r *searchRepository) SearchUsers(ctx context.Context, params *search.Params) ([]search.UserResult, error) {
+	query := searchQueryCTE + `
+		SELECT u.id, u.name, u.username,
+			-- Hidden profiles only show their name and avatar
+			CASE WHEN COALESCE(up.profile_visible, TRUE) THEN u.bio END as bio,
+			u.avatar_url, COALESCE(u.is_verified, false) as is_verified,
+			ts_rank_cd(profile_search_vector(u.name, u.bio), q.query) + word_similarity($1, u.name)
+				-- An exact username is what the user was looking for
+				+ CASE WHEN LOWER(u.username) = LOWER(LTRIM($1, '@')) THEN 1 ELSE 0 END as score
+		FROM users u
+		CROSS JOIN q
+		LEFT JOIN user_privacy up ON up.user_id = u.id
+		WHERE (profile_search_vector(u.name, u.bio) @@ q.query OR $1 <% u.name
+				OR LOWER(u.username) LIKE LOWER(LTRIM($1, '@')) || '%')
+			AND u.deleted_at IS NULL
+			AND NOT is_blocked_between($4, u.id)
+		ORDER BY score DESC, is_verified DESC, u.name ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	var results []search.UserResult
+	err := r.db.SelectContext(ctx, &results, query, params.Query, params.Limit, params.Offset, params.Viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if results == nil {
+		results = []search.UserResult{}
+	}
+	return results, nil
+}
+
+// SearchCommunities searches non-secret communities by name and description
+func (r *searchRepository) SearchCommunities(ctx context.Context, params *search.Params) ([]search.CommunityResult, error) {
+	query := searchQueryCTE + `
+		SELECT c.id, c.name, c.slug, c.description, c.avatar_url, c.privacy, COALESCE(c.members_count, 0) as members_count,
+			(ts_rank_cd(profile_search_vector(c.name, c.description), q.query) + word_similarity($1, c.name))
+				-- Larger communities rank slightly higher
+				* (1 + LN(COALESCE(c.members_count, 0) + 1) / 10) as score
+		FROM communities c
+		CROSS JOIN q
+		WHERE c.privacy != 'secret'
+			AND (profile_search_vector(c.name, c.description) @@ q.query OR $1 <% c.name)
+		ORDER BY score DESC, members_count DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var results []search.CommunityResult
+	err := r.db.SelectContext(ctx, &results, query, params.Query, params.Limit, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if results == nil {
+		results = []search.CommunityResult{}
+	}
+	return results, nil
+}
+
+// CountResults counts matches per result type using the same filters as the searches
+func (r *searchRepository) CountResults(ctx context.Context, query string, viewerID uuid.UUID) (*search.Facets, error) {
+	countQuery := searchQueryCTE + `
+		SELECT
+			(SELECT COUNT(*) FROM events e, q
+			 WHERE e.privacy = 'public' AND e.status != 'cancelled' AND NOT is_content_hidden('event', e.id)
+				AND (event_search_vector(e.title, e.description, e.location_name) @@ q.query OR $1 <% e.title)) as events,
+			(SELECT COUNT(*) FROM posts p, q
+			 WHERE p.visibility = 'public' AND p.status = 'published' AND post_search_vector(p.content) @@ q.query
+				AND NOT is_blocked_between($3, p.author_id) AND NOT is_content_hidden('post', p.id)) as posts,
+			(SELECT COUNT(DISTINCT tag) FROM posts p, unnest(p.hashtags) AS tag
+			 WHERE p.visibility = 'public' AND p.status = 'published' AND (tag LIKE $2 || '%' OR tag % $2)) as hashtags,
+			(SELECT COUNT(*) FROM users u, q
+			 WHERE (profile_search_vector(u.name, u.bio) @@ q.query OR $1 <% u.name
+					OR LOWER(u.username) LIKE LOWER(LTRIM($1, '@')) || '%')
+				AND u.deleted_at IS NULL
+				AND NOT is_blocked_between($3, u.id)) as users,
+			(SELECT COUNT(*) FROM communities c, q
+			 WHERE c.privacy != 'secret'
+				AND (profile_search_vector(c.name, c.description) @@ q.query OR $1 <% c.name)) as communities
+	`
+
+	var facets search.Facets
+	if err := r.db.GetContext(ctx, &facets, countQuery, query, normalizeHashtag(query), viewerID); err != nil {
+		return nil, err
+	}
+	return &facets, nil
+}
+
+// normalizeHashtag matches the form stored in posts.hashtags
+func normalizeHashtag(query string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(query), "#"))
+}
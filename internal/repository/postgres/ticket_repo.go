@@ -11,6 +11,7 @@ import (
 	"github.com/anigmaa/backend/internal/domain/ticket"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type ticketRepository struct {
@@ -36,6 +37,28 @@ func generateAttendanceCode() (string, error) {
 	return string(code), nil
 }
 
+// newAttendanceCode generates an attendance code not yet used within the event
+func newAttendanceCode(ctx context.Context, q sqlx.QueryerContext, eventID uuid.UUID) (string, error) {
+	// Try up to 5 times to generate a unique code
+	for i := 0; i < 5; i++ {
+		code, err := generateAttendanceCode()
+		if err != nil {
+			return "", fmt.Errorf("failed to generate attendance code: %w", err)
+		}
+
+		// Check if code already exists for this event
+		var exists bool
+		checkQuery := `SELECT EXISTS(SELECT 1 FROM tickets WHERE event_id = $1 AND attendance_code = $2)`
+		if err := sqlx.GetContext(ctx, q, &exists, checkQuery, eventID, code); err != nil {
+			return "", err
+		}
+		if !exists {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("failed to generate a unique attendance code for event %s", eventID)
+}
+
 // Create creates a new ticket
 // Note: This should be called within a transaction if paired with CreateTransaction
 func (r *ticketRepository) Create(ctx context.Context, t *ticket.Ticket) error {
@@ -46,36 +69,23 @@ func (r *ticketRepository) Create(ctx context.Context, t *ticket.Ticket) error {
 
 	// Generate attendance code if not provided
 	if t.AttendanceCode == "" {
-		// Try up to 5 times to generate a unique code
-		for i := 0; i < 5; i++ {
-			code, err := generateAttendanceCode()
-			if err != nil {
-				return fmt.Errorf("failed to generate attendance code: %w", err)
-			}
-			t.AttendanceCode = code
-
-			// Check if code already exists
-			var exists bool
-			checkQuery := `SELECT EXISTS(SELECT 1 FROM tickets WHERE attendance_code = $1)`
-			if err := r.db.GetContext(ctx, &exists, checkQuery, code); err != nil {
-				return err
-			}
-			if !exists {
-				break
-			}
+		code, err := newAttendanceCode(ctx, r.db, t.EventID)
+		if err != nil {
+			return err
 		}
+		t.AttendanceCode = code
 	}
 
 	// Set timestamp
 	t.PurchasedAt = time.Now()
 
 	query := `
-		INSERT INTO tickets (id, user_id, event_id, attendance_code, price_paid, purchased_at, is_checked_in, status)
-		VALUES ($1, $2, $3, $4, $5, $6, FALSE, $7)
+		INSERT INTO tickets (id, user_id, event_id, tier_id, order_id, is_assigned, attendance_code, price_paid, purchased_at, is_checked_in, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE, $10)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
-		t.ID, t.UserID, t.EventID, t.AttendanceCode, t.PricePaid, t.PurchasedAt, t.Status,
+		t.ID, t.UserID, t.EventID, t.TierID, t.OrderID, t.IsAssigned, t.AttendanceCode, t.PricePaid, t.PurchasedAt, t.Status,
 	)
 
 	return err
@@ -84,8 +94,8 @@ func (r *ticketRepository) Create(ctx context.Context, t *ticket.Ticket) error {
 // GetByID gets a ticket by ID
 func (r *ticketRepository) GetByID(ctx context.Context, ticketID uuid.UUID) (*ticket.Ticket, error) {
 	query := `
-		SELECT id, user_id, event_id, attendance_code, price_paid, purchased_at,
-		       is_checked_in, checked_in_at, status
+		SELECT id, user_id, event_id, tier_id, order_id, is_assigned, attendance_code, price_paid, purchased_at,
+		       is_checked_in, checked_in_at, checked_in_device_id, status
 		FROM tickets
 		WHERE id = $1
 	`
@@ -103,13 +113,15 @@ func (r *ticketRepository) GetByID(ctx context.Context, ticketID uuid.UUID) (*ti
 func (r *ticketRepository) GetWithDetails(ctx context.Context, ticketID uuid.UUID) (*ticket.TicketWithDetails, error) {
 	query := `
 		SELECT
-			t.id, t.user_id, t.event_id, t.attendance_code, t.price_paid, t.purchased_at,
-			t.is_checked_in, t.checked_in_at, t.status,
-			u.name as user_name, u.email as user_email,
-			e.title as event_title, e.start_time as event_start_time, e.location_name as event_location
+			t.id, t.user_id, t.event_id, t.tier_id, t.order_id, t.is_assigned, t.attendance_code, t.price_paid, t.purchased_at,
+			t.is_checked_in, t.checked_in_at, t.checked_in_device_id, t.status,
+			u.name as user_name, u.email as user_email, u.avatar_url as user_avatar_url,
+			e.title as event_title, e.start_time as event_start_time, e.location_name as event_location,
+			tt.name as tier_name
 		FROM tickets t
 		INNER JOIN users u ON t.user_id = u.id
 		INNER JOIN events e ON t.event_id = e.id
+		LEFT JOIN ticket_tiers tt ON t.tier_id = tt.id
 		WHERE t.id = $1
 	`
 
@@ -172,13 +184,15 @@ func (r *ticketRepository) Delete(ctx context.Context, ticketID uuid.UUID) error
 func (r *ticketRepository) GetByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]ticket.TicketWithDetails, error) {
 	query := `
 		SELECT
-			t.id, t.user_id, t.event_id, t.attendance_code, t.price_paid, t.purchased_at,
-			t.is_checked_in, t.checked_in_at, t.status,
+			t.id, t.user_id, t.event_id, t.tier_id, t.order_id, t.is_assigned, t.attendance_code, t.price_paid, t.purchased_at,
+			t.is_checked_in, t.checked_in_at, t.checked_in_device_id, t.status,
 			u.name as user_name, u.email as user_email,
-			e.title as event_title, e.start_time as event_start_time, e.location_name as event_location
+			e.title as event_title, e.start_time as event_start_time, e.location_name as event_location,
+			tt.name as tier_name
 		FROM tickets t
 		INNER JOIN users u ON t.user_id = u.id
 		INNER JOIN events e ON t.event_id = e.id
+		LEFT JOIN ticket_tiers tt ON t.tier_id = tt.id
 		WHERE t.user_id = $1
 		ORDER BY t.purchased_at DESC
 		LIMIT $2 OFFSET $3
@@ -201,13 +215,15 @@ func (r *ticketRepository) GetByUser(ctx context.Context, userID uuid.UUID, limi
 func (r *ticketRepository) GetByEvent(ctx context.Context, eventID uuid.UUID, limit, offset int) ([]ticket.TicketWithDetails, error) {
 	query := `
 		SELECT
-			t.id, t.user_id, t.event_id, t.attendance_code, t.price_paid, t.purchased_at,
-			t.is_checked_in, t.checked_in_at, t.status,
+			t.id, t.user_id, t.event_id, t.tier_id, t.order_id, t.is_assigned, t.attendance_code, t.price_paid, t.purchased_at,
+			t.is_checked_in, t.checked_in_at, t.checked_in_device_id, t.status,
 			u.name as user_name, u.email as user_email,
-			e.title as event_title, e.start_time as event_start_time, e.location_name as event_location
+			e.title as event_title, e.start_time as event_start_time, e.location_name as event_location,
+			tt.name as tier_name
 		FROM tickets t
 		INNER JOIN users u ON t.user_id = u.id
 		INNER JOIN events e ON t.event_id = e.id
+		LEFT JOIN ticket_tiers tt ON t.tier_id = tt.id
 		WHERE t.event_id = $1
 		ORDER BY t.purchased_at DESC
 		LIMIT $2 OFFSET $3
@@ -226,17 +242,18 @@ func (r *ticketRepository) GetByEvent(ctx context.Context, eventID uuid.UUID, li
 	return tickets, nil
 }
 
-// GetByAttendanceCode gets a ticket by attendance code
-func (r *ticketRepository) GetByAttendanceCode(ctx context.Context, code string) (*ticket.Ticket, error) {
+// GetByAttendanceCode gets a ticket of an event by attendance code
+// Codes are only unique within an event
+func (r *ticketRepository) GetByAttendanceCode(ctx context.Context, eventID uuid.UUID, code string) (*ticket.Ticket, error) {
 	query := `
-		SELECT id, user_id, event_id, attendance_code, price_paid, purchased_at,
-		       is_checked_in, checked_in_at, status
+		SELECT id, user_id, event_id, tier_id, order_id, is_assigned, attendance_code, price_paid, purchased_at,
+		       is_checked_in, checked_in_at, checked_in_device_id, status
 		FROM tickets
-		WHERE attendance_code = $1
+		WHERE event_id = $1 AND attendance_code = $2
 	`
 
 	var t ticket.Ticket
-	err := r.db.GetContext(ctx, &t, query, code)
+	err := r.db.GetContext(ctx, &t, query, eventID, code)
 	if err != nil {
 		return nil, err
 	}
@@ -244,13 +261,16 @@ func (r *ticketRepository) GetByAttendanceCode(ctx context.Context, code string)
 	return &t, nil
 }
 
-// GetUserTicketForEvent gets a user's ticket for a specific event
+// GetUserTicketForEvent gets the ticket a user holds for a specific event: their live ticket if any,
+// otherwise their most recent one. Tickets of one order share purchased_at, so id breaks ties.
 func (r *ticketRepository) GetUserTicketForEvent(ctx context.Context, userID, eventID uuid.UUID) (*ticket.Ticket, error) {
 	query := `
-		SELECT id, user_id, event_id, attendance_code, price_paid, purchased_at,
-		       is_checked_in, checked_in_at, status
+		SELECT id, user_id, event_id, tier_id, order_id, is_assigned, attendance_code, price_paid, purchased_at,
+		       is_checked_in, checked_in_at, checked_in_device_id, status
 		FROM tickets
-		WHERE user_id = $1 AND event_id = $2
+		WHERE user_id = $1 AND event_id = $2 AND is_assigned = TRUE
+		ORDER BY (status NOT IN ('cancelled', 'refunded', 'expired')) DESC, purchased_at DESC, id DESC
+		LIMIT 1
 	`
 
 	var t ticket.Ticket
@@ -301,6 +321,105 @@ func (r *ticketRepository) GetCheckedInCount(ctx context.Context, eventID uuid.U
 	return count, nil
 }
 
+// GetCheckInRoster gets the tickets of an event that can be checked in, with attendee and tier names
+func (r *ticketRepository) GetCheckInRoster(ctx context.Context, eventID uuid.UUID) ([]ticket.TicketWithDetails, error) {
+	query := `
+		SELECT
+			t.id, t.user_id, t.event_id, t.tier_id, t.order_id, t.is_assigned, t.attendance_code, t.price_paid, t.purchased_at,
+			t.is_checked_in, t.checked_in_at, t.checked_in_device_id, t.status,
+			u.name as user_name, u.email as user_email, u.avatar_url as user_avatar_url,
+			e.title as event_title, e.start_time as event_start_time, e.location_name as event_location,
+			tt.name as tier_name
+		FROM tickets t
+		INNER JOIN users u ON t.user_id = u.id
+		INNER JOIN events e ON t.event_id = e.id
+		LEFT JOIN ticket_tiers tt ON t.tier_id = tt.id
+		WHERE t.event_id = $1 AND t.status = 'active' AND t.is_assigned = TRUE
+		ORDER BY u.name ASC
+	`
+
+	var tickets []ticket.TicketWithDetails
+	err := r.db.SelectContext(ctx, &tickets, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tickets == nil {
+		tickets = []ticket.TicketWithDetails{}
+	}
+
+	return tickets, nil
+}
+
+// CheckInOffline checks a ticket in with a scan uploaded by a scanner device
+// The earliest scan wins: a later check-in is replaced, and on equal times the online
+// check-in or the lower device ID wins, so the outcome does not depend on upload order
+// Returns sql.ErrNoRows when the ticket was already checked in by a winning scan
+func (r *ticketRepository) CheckInOffline(ctx context.Context, ticketID uuid.UUID, deviceID string, scannedAt time.Time) error {
+	query := `
+		UPDATE tickets
+		SET is_checked_in = TRUE, checked_in_at = $1, checked_in_device_id = $2
+		WHERE id = $3 AND (
+			is_checked_in = FALSE
+			OR checked_in_at > $1
+			OR (checked_in_at = $1 AND COALESCE(checked_in_device_id, '') > $2)
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, scannedAt, deviceID, ticketID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CreateOfflineCheckIns logs uploaded offline scans
+// Scans uploaded before (same ticket, device and time) are skipped
+func (r *ticketRepository) CreateOfflineCheckIns(ctx context.Context, checkIns []ticket.OfflineCheckIn) error {
+	if len(checkIns) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO offline_check_ins (id, event_id, ticket_id, device_id, scanned_at, uploaded_by, status, reason, uploaded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (ticket_id, device_id, scanned_at) DO NOTHING
+	`
+
+	now := time.Now()
+	for i := range checkIns {
+		c := &checkIns[i]
+		if c.ID == uuid.Nil {
+			c.ID = uuid.New()
+		}
+		c.UploadedAt = now
+
+		if _, err := tx.ExecContext(ctx, query,
+			c.ID, c.EventID, c.TicketID, c.DeviceID, c.ScannedAt, c.UploadedBy, c.Status, c.Reason, c.UploadedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 // CreateTransaction creates a new ticket transaction
 func (r *ticketRepository) CreateTransaction(ctx context.Context, transaction *ticket.TicketTransaction) error {
 	// Generate UUID if not provided
@@ -341,6 +460,81 @@ func (r *ticketRepository) GetTransaction(ctx context.Context, transactionID str
 	return &t, nil
 }
 
+// transactionDetailsQuery selects transactions with their ticket, event and buyer; callers append the WHERE clause
+const transactionDetailsQuery = `
+	SELECT
+		tx.id, tx.ticket_id, tx.transaction_id, tx.amount, tx.payment_method, tx.status,
+		tx.created_at, tx.completed_at,
+		t.status as ticket_status,
+		e.id as event_id, e.title as event_title,
+		u.id as user_id, u.name as user_name, u.email as user_email
+	FROM ticket_transactions tx
+	INNER JOIN tickets t ON tx.ticket_id = t.id
+	INNER JOIN events e ON t.event_id = e.id
+	INNER JOIN users u ON t.user_id = u.id
+`
+
+// GetTransactionByID gets a transaction with its ticket, event and buyer
+func (r *ticketRepository) GetTransactionByID(ctx context.Context, id uuid.UUID) (*ticket.TransactionWithDetails, error) {
+	query := transactionDetailsQuery + ` WHERE tx.id = $1`
+
+	var t ticket.TransactionWithDetails
+	if err := r.db.GetContext(ctx, &t, query, id); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// GetTransactions gets transactions across all events matching the filter, most recent first
+func (r *ticketRepository) GetTransactions(ctx context.Context, filter *ticket.TransactionFilter) ([]ticket.TransactionWithDetails, error) {
+	conditions, args := transactionConditions(filter)
+	query := transactionDetailsQuery + conditions + fmt.Sprintf(`
+		ORDER BY tx.created_at DESC, tx.id DESC
+		LIMIT $%d OFFSET $%d
+	`, len(args)+1, len(args)+2)
+	args = append(args, filter.Limit, filter.Offset)
+
+	transactions := []ticket.TransactionWithDetails{}
+	err := r.db.SelectContext(ctx, &transactions, query, args...)
+	return transactions, err
+}
+
+// CountTransactions counts transactions across all events matching the filter
+func (r *ticketRepository) CountTransactions(ctx context.Context, filter *ticket.TransactionFilter) (int, error) {
+	conditions, args := transactionConditions(filter)
+	query := `
+		SELECT COUNT(*)
+		FROM ticket_transactions tx
+		INNER JOIN tickets t ON tx.ticket_id = t.id
+	` + conditions
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// transactionConditions builds the WHERE clause for a platform transaction filter
+func transactionConditions(filter *ticket.TransactionFilter) (string, []interface{}) {
+	conditions := " WHERE 1=1"
+	args := []interface{}{}
+
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		conditions += fmt.Sprintf(" AND tx.status = $%d", len(args))
+	}
+	if filter.EventID != nil {
+		args = append(args, *filter.EventID)
+		conditions += fmt.Sprintf(" AND t.event_id = $%d", len(args))
+	}
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		conditions += fmt.Sprintf(" AND t.user_id = $%d", len(args))
+	}
+
+	return conditions, args
+}
+
 // UpdateTransactionStatus updates a transaction status
 func (r *ticketRepository) UpdateTransactionStatus(ctx context.Context, transactionID string, status ticket.TransactionStatus) error {
 	now := time.Now()
@@ -375,8 +569,8 @@ func (r *ticketRepository) UpdateTransactionStatus(ctx context.Context, transact
 // GetByEventID gets all tickets for an event (for analytics)
 func (r *ticketRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]ticket.Ticket, error) {
 	query := `
-		SELECT id, user_id, event_id, attendance_code, price_paid, purchased_at,
-		       is_checked_in, checked_in_at, status
+		SELECT id, user_id, event_id, tier_id, order_id, is_assigned, attendance_code, price_paid, purchased_at,
+		       is_checked_in, checked_in_at, checked_in_device_id, status
 		FROM tickets
 		WHERE event_id = $1
 		ORDER BY purchased_at DESC
@@ -425,3 +619,1032 @@ func (r *ticketRepository) CountEventTickets(ctx context.Context, eventID uuid.U
 	err := r.db.QueryRowContext(ctx, query, eventID).Scan(&count)
 	return count, err
 }
+
+// seatsTakenQuery counts seats held for event $1: confirmed attendees, pending tickets
+// still awaiting payment, and active tickets whose holder has not joined the event yet
+// (unassigned order tickets, and tickets activated moments before their attendee row is written)
+const seatsTakenQuery = `
+	SELECT
+		(SELECT COUNT(*) FROM event_attendees WHERE event_id = $1 AND status = 'confirmed') +
+		(SELECT COUNT(*) FROM tickets WHERE event_id = $1 AND status = 'pending') +
+		(SELECT COUNT(*) FROM tickets t
+		 WHERE t.event_id = $1 AND t.status = 'active'
+		   AND (t.is_assigned = FALSE OR NOT EXISTS(
+				SELECT 1 FROM event_attendees ea
+				WHERE ea.event_id = t.event_id AND ea.user_id = t.user_id AND ea.status = 'confirmed')))
+`
+
+// CountSeatsTaken counts seats held for an event
+func (r *ticketRepository) CountSeatsTaken(ctx context.Context, eventID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, seatsTakenQuery, eventID).Scan(&count)
+	return count, err
+}
+
+// lockFreeSeats locks an event row, so seat allocations for the event run one at a time,
+// and counts the seats left for a user: capacity minus seats taken and unexpired offers
+// made to other waitlisted users (the user's own offer covers one of their seats)
+func lockFreeSeats(ctx context.Context, tx *sqlx.Tx, eventID, userID uuid.UUID) (int, error) {
+	var maxAttendees int
+	if err := tx.GetContext(ctx, &maxAttendees, `SELECT max_attendees FROM events WHERE id = $1 FOR UPDATE`, eventID); err != nil {
+		return 0, err
+	}
+
+	var taken int
+	if err := tx.GetContext(ctx, &taken, seatsTakenQuery, eventID); err != nil {
+		return 0, err
+	}
+
+	var offers int
+	offersQuery := `
+		SELECT COUNT(*) FROM event_waitlist
+		WHERE event_id = $1 AND user_id <> $2 AND status = 'offered' AND offer_expires_at > $3
+	`
+	if err := tx.GetContext(ctx, &offers, offersQuery, eventID, userID, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return maxAttendees - taken - offers, nil
+}
+
+// lockFreeTierSeats locks a tier row and counts the tickets left in its quota
+func lockFreeTierSeats(ctx context.Context, tx *sqlx.Tx, tierID uuid.UUID) (int, error) {
+	var quota, sold int
+	if err := tx.GetContext(ctx, &quota, `SELECT quota FROM ticket_tiers WHERE id = $1 FOR UPDATE`, tierID); err != nil {
+		return 0, err
+	}
+	soldQuery := `SELECT COUNT(*) FROM tickets WHERE tier_id = $1 AND status IN ('active', 'pending')`
+	if err := tx.GetContext(ctx, &sold, soldQuery, tierID); err != nil {
+		return 0, err
+	}
+	return quota - sold, nil
+}
+
+// ActivatePaidTicket activates a ticket whose payment went through and returns it
+// A pending ticket already holds its seat. A ticket that expired or was cancelled before
+// the payment arrived gets a seat again only if one is free under the event lock, using
+// the buyer's waitlist offer if they hold one. Returns ticket.ErrEventFull,
+// ticket.ErrTierSoldOut or ticket.ErrEventCancelled when it cannot get a seat, and
+// sql.ErrNoRows when the ticket does not exist or was already settled.
+func (r *ticketRepository) ActivatePaidTicket(ctx context.Context, ticketID uuid.UUID) (*ticket.Ticket, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, user_id, event_id, tier_id, order_id, is_assigned, attendance_code, price_paid, purchased_at,
+		       is_checked_in, checked_in_at, checked_in_device_id, status
+		FROM tickets
+		WHERE id = $1 AND status IN ('pending', 'expired', 'cancelled')
+		FOR UPDATE
+	`
+
+	var t ticket.Ticket
+	if err := tx.GetContext(ctx, &t, query, ticketID); err != nil {
+		return nil, err
+	}
+
+	if t.Status != ticket.StatusPending {
+		// Lock the event so the seat cannot also go to an order or a waitlist offer
+		free, err := lockFreeSeats(ctx, tx, t.EventID, t.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		var eventStatus string
+		if err := tx.GetContext(ctx, &eventStatus, `SELECT status FROM events WHERE id = $1`, t.EventID); err != nil {
+			return nil, err
+		}
+		if eventStatus == "cancelled" {
+			return nil, ticket.ErrEventCancelled
+		}
+		if free < 1 {
+			return nil, ticket.ErrEventFull
+		}
+
+		if t.TierID != nil {
+			left, err := lockFreeTierSeats(ctx, tx, *t.TierID)
+			if err != nil {
+				return nil, err
+			}
+			if left < 1 {
+				return nil, ticket.ErrTierSoldOut
+			}
+		}
+
+		// The buyer's offer covered this seat
+		claimQuery := `
+			UPDATE event_waitlist
+			SET status = 'claimed', ticket_id = $1, updated_at = $2
+			WHERE event_id = $3 AND user_id = $4 AND status = 'offered' AND offer_expires_at > $2
+		`
+		if _, err := tx.ExecContext(ctx, claimQuery, t.ID, time.Now(), t.EventID, t.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE tickets SET status = 'active' WHERE id = $1`, t.ID); err != nil {
+		return nil, err
+	}
+	t.Status = ticket.StatusActive
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// ExpirePendingTickets expires pending tickets purchased before the cutoff and
+// fails their pending transactions
+func (r *ticketRepository) ExpirePendingTickets(ctx context.Context, cutoff time.Time) ([]ticket.Ticket, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE tickets
+		SET status = 'expired'
+		WHERE status = 'pending' AND purchased_at < $1
+		RETURNING id, user_id, event_id, tier_id, order_id, is_assigned, attendance_code, price_paid, purchased_at,
+		          is_checked_in, checked_in_at, checked_in_device_id, status
+	`
+
+	var tickets []ticket.Ticket
+	if err := tx.SelectContext(ctx, &tickets, query, cutoff); err != nil {
+		return nil, err
+	}
+
+	if len(tickets) > 0 {
+		ticketIDs := make([]string, len(tickets))
+		for i, t := range tickets {
+			ticketIDs[i] = t.ID.String()
+		}
+
+		txnQuery := `
+			UPDATE ticket_transactions
+			SET status = 'failed'
+			WHERE ticket_id = ANY($1::uuid[]) AND status = 'pending'
+		`
+		if _, err := tx.ExecContext(ctx, txnQuery, pq.Array(ticketIDs)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// CreateOrder creates an order together with all of its tickets
+// Returns ticket.ErrEventFull or ticket.ErrTierSoldOut when the order does not fit
+func (r *ticketRepository) CreateOrder(ctx context.Context, o *ticket.Order, tickets []*ticket.Ticket) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	o.CreatedAt = time.Now()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the event so concurrent orders cannot both take its last seats
+	free, err := lockFreeSeats(ctx, tx, o.EventID, o.BuyerID)
+	if err != nil {
+		return err
+	}
+	if len(tickets) > free {
+		return ticket.ErrEventFull
+	}
+
+	// Same for the tier quota
+	if o.TierID != nil {
+		left, err := lockFreeTierSeats(ctx, tx, *o.TierID)
+		if err != nil {
+			return err
+		}
+		if len(tickets) > left {
+			return ticket.ErrTierSoldOut
+		}
+	}
+
+	orderQuery := `
+		INSERT INTO ticket_orders (id, buyer_id, event_id, tier_id, quantity, unit_price,
+		                           discount_amount, total_amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = tx.ExecContext(ctx, orderQuery,
+		o.ID, o.BuyerID, o.EventID, o.TierID, o.Quantity, o.UnitPrice,
+		o.DiscountAmount, o.TotalAmount, o.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	ticketQuery := `
+		INSERT INTO tickets (id, user_id, event_id, tier_id, order_id, is_assigned, attendance_code, price_paid, purchased_at, is_checked_in, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE, $10)
+	`
+	for _, t := range tickets {
+		if t.ID == uuid.Nil {
+			t.ID = uuid.New()
+		}
+		t.OrderID = &o.ID
+		t.PurchasedAt = o.CreatedAt
+
+		// Generate attendance code if not provided
+		if t.AttendanceCode == "" {
+			code, err := newAttendanceCode(ctx, tx, t.EventID)
+			if err != nil {
+				return err
+			}
+			t.AttendanceCode = code
+		}
+
+		_, err := tx.ExecContext(ctx, ticketQuery,
+			t.ID, t.UserID, t.EventID, t.TierID, t.OrderID, t.IsAssigned, t.AttendanceCode, t.PricePaid, t.PurchasedAt, t.Status,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetOrderByID gets an order by ID
+func (r *ticketRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (*ticket.Order, error) {
+	query := `
+		SELECT id, buyer_id, event_id, tier_id, quantity, unit_price,
+		       discount_amount, total_amount, created_at
+		FROM ticket_orders
+		WHERE id = $1
+	`
+
+	var o ticket.Order
+	err := r.db.GetContext(ctx, &o, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// GetTicketsByOrder gets all tickets of an order
+func (r *ticketRepository) GetTicketsByOrder(ctx context.Context, orderID uuid.UUID) ([]ticket.TicketWithDetails, error) {
+	query := `
+		SELECT
+			t.id, t.user_id, t.event_id, t.tier_id, t.order_id, t.is_assigned, t.attendance_code, t.price_paid, t.purchased_at,
+			t.is_checked_in, t.checked_in_at, t.checked_in_device_id, t.status,
+			u.name as user_name, u.email as user_email,
+			e.title as event_title, e.start_time as event_start_time, e.location_name as event_location,
+			tt.name as tier_name
+		FROM tickets t
+		INNER JOIN users u ON t.user_id = u.id
+		INNER JOIN events e ON t.event_id = e.id
+		LEFT JOIN ticket_tiers tt ON t.tier_id = tt.id
+		WHERE t.order_id = $1
+		ORDER BY t.is_assigned DESC, t.id
+	`
+
+	var tickets []ticket.TicketWithDetails
+	err := r.db.SelectContext(ctx, &tickets, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tickets == nil {
+		tickets = []ticket.TicketWithDetails{}
+	}
+
+	return tickets, nil
+}
+
+// DeleteOrder deletes an order and its tickets
+// Used to roll back a checkout whose payment could not be created
+func (r *ticketRepository) DeleteOrder(ctx context.Context, orderID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tickets WHERE order_id = $1`, orderID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM ticket_orders WHERE id = $1`, orderID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+// GetTransactionsByTransactionID gets every ticket transaction sharing a payment transaction ID
+// An order paid in one Midtrans transaction has one row per ticket
+func (r *ticketRepository) GetTransactionsByTransactionID(ctx context.Context, transactionID string) ([]ticket.TicketTransaction, error) {
+	query := `
+		SELECT id, ticket_id, transaction_id, amount, payment_method, status, created_at, completed_at
+		FROM ticket_transactions
+		WHERE transaction_id = $1
+	`
+
+	var transactions []ticket.TicketTransaction
+	err := r.db.SelectContext(ctx, &transactions, query, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// AssignTicket hands an unassigned ticket to a user and invalidates its invite link
+// The ticket gets a new attendance code, so the code the buyer received stops working
+func (r *ticketRepository) AssignTicket(ctx context.Context, ticketID, userID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var eventID uuid.UUID
+	err = tx.GetContext(ctx, &eventID,
+		`SELECT event_id FROM tickets WHERE id = $1 AND is_assigned = FALSE FOR UPDATE`, ticketID)
+	if err != nil {
+		return err
+	}
+
+	code, err := newAttendanceCode(ctx, tx, eventID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE tickets
+		SET user_id = $1, is_assigned = TRUE, invite_token = NULL, attendance_code = $2
+		WHERE id = $3
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, code, ticketID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetInviteToken sets the invite token of an unassigned ticket, replacing any previous link
+func (r *ticketRepository) SetInviteToken(ctx context.Context, ticketID uuid.UUID, token string) error {
+	query := `UPDATE tickets SET invite_token = $1 WHERE id = $2 AND is_assigned = FALSE`
+
+	result, err := r.db.ExecContext(ctx, query, token, ticketID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetByInviteToken gets an unassigned ticket by its invite token
+func (r *ticketRepository) GetByInviteToken(ctx context.Context, token string) (*ticket.Ticket, error) {
+	query := `
+		SELECT id, user_id, event_id, tier_id, order_id, is_assigned, attendance_code, price_paid, purchased_at,
+		       is_checked_in, checked_in_at, checked_in_device_id, status
+		FROM tickets
+		WHERE invite_token = $1
+	`
+
+	var t ticket.Ticket
+	err := r.db.GetContext(ctx, &t, query, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// CreateTransfer creates a pending transfer of a ticket to another user
+func (r *ticketRepository) CreateTransfer(ctx context.Context, tr *ticket.Transfer) error {
+	if tr.ID == uuid.Nil {
+		tr.ID = uuid.New()
+	}
+	tr.Status = ticket.TransferPending
+	tr.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO ticket_transfers (id, ticket_id, event_id, from_user_id, to_user_id, status,
+		                              old_attendance_code, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		tr.ID, tr.TicketID, tr.EventID, tr.FromUserID, tr.ToUserID, tr.Status,
+		tr.OldAttendanceCode, tr.CreatedAt,
+	)
+
+	return err
+}
+
+// GetTransferByID gets a ticket transfer by ID
+func (r *ticketRepository) GetTransferByID(ctx context.Context, transferID uuid.UUID) (*ticket.Transfer, error) {
+	query := `
+		SELECT id, ticket_id, event_id, from_user_id, to_user_id, status,
+		       old_attendance_code, new_attendance_code, created_at, responded_at
+		FROM ticket_transfers
+		WHERE id = $1
+	`
+
+	var tr ticket.Transfer
+	err := r.db.GetContext(ctx, &tr, query, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tr, nil
+}
+
+// GetPendingTransferByTicket gets the open transfer of a ticket, if any
+func (r *ticketRepository) GetPendingTransferByTicket(ctx context.Context, ticketID uuid.UUID) (*ticket.Transfer, error) {
+	query := `
+		SELECT id, ticket_id, event_id, from_user_id, to_user_id, status,
+		       old_attendance_code, new_attendance_code, created_at, responded_at
+		FROM ticket_transfers
+		WHERE ticket_id = $1 AND status = 'pending'
+	`
+
+	var tr ticket.Transfer
+	err := r.db.GetContext(ctx, &tr, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tr, nil
+}
+
+// GetIncomingTransfers gets the pending transfers waiting for a user to respond
+func (r *ticketRepository) GetIncomingTransfers(ctx context.Context, userID uuid.UUID) ([]ticket.TransferWithDetails, error) {
+	query := `
+		SELECT
+			tr.id, tr.ticket_id, tr.event_id, tr.from_user_id, tr.to_user_id, tr.status,
+			tr.old_attendance_code, tr.new_attendance_code, tr.created_at, tr.responded_at,
+			fu.name as from_user_name, tu.name as to_user_name,
+			e.title as event_title, e.start_time as event_start_time
+		FROM ticket_transfers tr
+		INNER JOIN users fu ON tr.from_user_id = fu.id
+		INNER JOIN users tu ON tr.to_user_id = tu.id
+		INNER JOIN events e ON tr.event_id = e.id
+		WHERE tr.to_user_id = $1 AND tr.status = 'pending'
+		ORDER BY tr.created_at DESC
+	`
+
+	var transfers []ticket.TransferWithDetails
+	err := r.db.SelectContext(ctx, &transfers, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfers == nil {
+		transfers = []ticket.TransferWithDetails{}
+	}
+
+	return transfers, nil
+}
+
+// GetTransfersByEvent gets the transfer history of all tickets of an event, oldest first
+func (r *ticketRepository) GetTransfersByEvent(ctx context.Context, eventID uuid.UUID) ([]ticket.TransferWithDetails, error) {
+	query := `
+		SELECT
+			tr.id, tr.ticket_id, tr.event_id, tr.from_user_id, tr.to_user_id, tr.status,
+			tr.old_attendance_code, tr.new_attendance_code, tr.created_at, tr.responded_at,
+			fu.name as from_user_name, tu.name as to_user_name,
+			e.title as event_title, e.start_time as event_start_time
+		FROM ticket_transfers tr
+		INNER JOIN users fu ON tr.from_user_id = fu.id
+		INNER JOIN users tu ON tr.to_user_id = tu.id
+		INNER JOIN events e ON tr.event_id = e.id
+		WHERE tr.event_id = $1
+		ORDER BY tr.created_at ASC
+	`
+
+	var transfers []ticket.TransferWithDetails
+	err := r.db.SelectContext(ctx, &transfers, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// UpdateTransferStatus resolves a pending transfer without moving the ticket
+func (r *ticketRepository) UpdateTransferStatus(ctx context.Context, transferID uuid.UUID, status ticket.TransferStatus) error {
+	query := `
+		UPDATE ticket_transfers
+		SET status = $1, responded_at = $2
+		WHERE id = $3 AND status = 'pending'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, time.Now(), transferID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CompleteTransfer moves the ticket to the recipient and accepts the transfer
+// The ticket gets a new attendance code so the sender's code and QR stop working
+func (r *ticketRepository) CompleteTransfer(ctx context.Context, tr *ticket.Transfer) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	code, err := newAttendanceCode(ctx, tx, tr.EventID)
+	if err != nil {
+		return err
+	}
+
+	// Only move the ticket if the sender still holds it unused
+	ticketQuery := `
+		UPDATE tickets
+		SET user_id = $1, attendance_code = $2
+		WHERE id = $3 AND user_id = $4 AND status = 'active' AND is_checked_in = FALSE
+	`
+	result, err := tx.ExecContext(ctx, ticketQuery, tr.ToUserID, code, tr.TicketID, tr.FromUserID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	now := time.Now()
+	transferQuery := `
+		UPDATE ticket_transfers
+		SET status = 'accepted', new_attendance_code = $1, responded_at = $2
+		WHERE id = $3 AND status = 'pending'
+	`
+	result, err = tx.ExecContext(ctx, transferQuery, code, now, tr.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	tr.Status = ticket.TransferAccepted
+	tr.NewAttendanceCode = &code
+	tr.RespondedAt = &now
+
+	return nil
+}
+
+// CreateTier creates a new ticket tier
+func (r *ticketRepository) CreateTier(ctx context.Context, tier *ticket.Tier) error {
+	if tier.ID == uuid.Nil {
+		tier.ID = uuid.New()
+	}
+
+	now := time.Now()
+	tier.CreatedAt = now
+	tier.UpdatedAt = now
+
+	query := `
+		INSERT INTO ticket_tiers (id, event_id, name, description, price, quota,
+		                          sale_starts_at, sale_ends_at, sort_order, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		tier.ID, tier.EventID, tier.Name, tier.Description, tier.Price, tier.Quota,
+		tier.SaleStartsAt, tier.SaleEndsAt, tier.SortOrder, tier.CreatedAt, tier.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetTierByID gets a ticket tier by ID
+func (r *ticketRepository) GetTierByID(ctx context.Context, tierID uuid.UUID) (*ticket.Tier, error) {
+	query := `
+		SELECT id, event_id, name, description, price, quota,
+		       sale_starts_at, sale_ends_at, sort_order, created_at, updated_at
+		FROM ticket_tiers
+		WHERE id = $1
+	`
+
+	var tier ticket.Tier
+	err := r.db.GetContext(ctx, &tier, query, tierID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tier, nil
+}
+
+// GetTiersByEvent gets all tiers of an event with the number of seats taken in each
+func (r *ticketRepository) GetTiersByEvent(ctx context.Context, eventID uuid.UUID) ([]ticket.TierWithAvailability, error) {
+	query := `
+		SELECT
+			tt.id, tt.event_id, tt.name, tt.description, tt.price, tt.quota,
+			tt.sale_starts_at, tt.sale_ends_at, tt.sort_order, tt.created_at, tt.updated_at,
+			COUNT(t.id) as sold
+		FROM ticket_tiers tt
+		LEFT JOIN tickets t ON t.tier_id = tt.id AND t.status IN ('active', 'pending')
+		WHERE tt.event_id = $1
+		GROUP BY tt.id
+		ORDER BY tt.sort_order ASC, tt.price ASC
+	`
+
+	var tiers []ticket.TierWithAvailability
+	err := r.db.SelectContext(ctx, &tiers, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tiers == nil {
+		tiers = []ticket.TierWithAvailability{}
+	}
+
+	return tiers, nil
+}
+
+// UpdateTier updates a ticket tier
+func (r *ticketRepository) UpdateTier(ctx context.Context, tier *ticket.Tier) error {
+	tier.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE ticket_tiers
+		SET name = $1, description = $2, price = $3, quota = $4,
+		    sale_starts_at = $5, sale_ends_at = $6, sort_order = $7, updated_at = $8
+		WHERE id = $9
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		tier.Name, tier.Description, tier.Price, tier.Quota,
+		tier.SaleStartsAt, tier.SaleEndsAt, tier.SortOrder, tier.UpdatedAt, tier.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeleteTier deletes a ticket tier
+func (r *ticketRepository) DeleteTier(ctx context.Context, tierID uuid.UUID) error {
+	query := `DELETE FROM ticket_tiers WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, tierID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CountTierSeatsTaken counts active and pending tickets of a tier against its quota
+func (r *ticketRepository) CountTierSeatsTaken(ctx context.Context, tierID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM tickets WHERE tier_id = $1 AND status IN ('active', 'pending')`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, tierID).Scan(&count)
+	return count, err
+}
+
+// CountTierTickets counts all tickets ever issued for a tier, regardless of status
+func (r *ticketRepository) CountTierTickets(ctx context.Context, tierID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM tickets WHERE tier_id = $1`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, tierID).Scan(&count)
+	return count, err
+}
+
+// StreamAttendees passes every ticket of an event to fn, oldest purchase first
+// Rows are read one at a time so large attendee lists are never held in memory
+func (r *ticketRepository) StreamAttendees(ctx context.Context, eventID uuid.UUID, fn func(*ticket.AttendeeExportRow) error) error {
+	query := `
+		SELECT
+			t.id as ticket_id, t.attendance_code, t.price_paid, t.status, t.purchased_at,
+			t.is_checked_in, t.checked_in_at, t.checked_in_device_id,
+			u.name as user_name, u.email as user_email,
+			COALESCE(up.share_contact_with_hosts, FALSE) as contact_shared,
+			tt.name as tier_name
+		FROM tickets t
+		INNER JOIN users u ON t.user_id = u.id
+		LEFT JOIN user_privacy up ON up.user_id = t.user_id
+		LEFT JOIN ticket_tiers tt ON t.tier_id = tt.id
+		WHERE t.event_id = $1
+		ORDER BY t.purchased_at ASC, t.id ASC
+	`
+
+	rows, err := r.db.QueryxContext(ctx, query, eventID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row ticket.AttendeeExportRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// StreamTransactions passes every payment transaction of an event to fn, oldest first
+// An empty status returns transactions of every status
+func (r *ticketRepository) StreamTransactions(ctx context.Context, eventID uuid.UUID, status string, fn func(*ticket.TransactionExportRow) error) error {
+	query := `
+		SELECT
+			tx.transaction_id, tx.ticket_id, tx.amount, tx.payment_method, tx.status,
+			tx.created_at, tx.completed_at,
+			t.is_checked_in,
+			u.name as user_name, u.email as user_email,
+			COALESCE(up.share_contact_with_hosts, FALSE) as contact_shared,
+			tt.name as tier_name,
+			pc.code as promo_code,
+			COALESCE(pr.discount_amount, 0) as discount_amount
+		FROM ticket_transactions tx
+		INNER JOIN tickets t ON tx.ticket_id = t.id
+		INNER JOIN users u ON t.user_id = u.id
+		LEFT JOIN user_privacy up ON up.user_id = t.user_id
+		LEFT JOIN ticket_tiers tt ON t.tier_id = tt.id
+		LEFT JOIN promo_redemptions pr ON pr.ticket_id = t.id
+		LEFT JOIN promo_codes pc ON pr.promo_code_id = pc.id
+		WHERE t.event_id = $1
+		  AND ($2 = '' OR tx.status::text = $2)
+		ORDER BY tx.created_at ASC, tx.id ASC
+	`
+
+	rows, err := r.db.QueryxContext(ctx, query, eventID, status)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row ticket.TransactionExportRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// hostEventRevenueQuery selects revenue totals per event; callers append the WHERE clause
+// Discounts only count redemptions on tickets that are still paid
+const hostEventRevenueQuery = `
+	SELECT
+		e.id as event_id, e.title, e.category, e.status, e.start_time,
+		e.price, e.is_free, e.max_attendees, e.tickets_sold,
+		COALESCE(c.tickets_checked_in, 0) as tickets_checked_in,
+		COALESCE(s.revenue, 0) as revenue,
+		COALESCE(s.refunded_amount, 0) as refunded_amount,
+		COALESCE(d.discounts, 0) as discounts
+	FROM events e
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) as tickets_checked_in
+		FROM tickets t
+		WHERE t.event_id = e.id AND t.is_checked_in = TRUE
+	) c ON TRUE
+	LEFT JOIN LATERAL (
+		SELECT
+			SUM(tx.amount) FILTER (WHERE tx.status = 'success') as revenue,
+			SUM(tx.amount) FILTER (WHERE tx.status = 'refunded') as refunded_amount
+		FROM ticket_transactions tx
+		INNER JOIN tickets t ON tx.ticket_id = t.id
+		WHERE t.event_id = e.id
+	) s ON TRUE
+	LEFT JOIN LATERAL (
+		SELECT SUM(pr.discount_amount) as discounts
+		FROM promo_redemptions pr
+		INNER JOIN tickets t ON pr.ticket_id = t.id
+		WHERE pr.event_id = e.id AND t.status = 'active'
+	) d ON TRUE
+`
+
+// StreamHostEventRevenue passes revenue totals of each event a host owns to fn, newest event first
+// An empty status returns events of every status
+func (r *ticketRepository) StreamHostEventRevenue(ctx context.Context, hostID uuid.UUID, status string, fn func(*ticket.EventRevenueRow) error) error {
+	query := hostEventRevenueQuery + `
+		WHERE e.host_id = $1
+		  AND ($2 = '' OR e.status::text = $2)
+		ORDER BY e.start_time DESC, e.id ASC
+	`
+
+	rows, err := r.db.QueryxContext(ctx, query, hostID, status)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row ticket.EventRevenueRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetHostEventRevenue gets revenue totals of each event a host owns, newest event first
+// startDate and endDate filter by event start time and are both optional
+func (r *ticketRepository) GetHostEventRevenue(ctx context.Context, hostID uuid.UUID, startDate, endDate *time.Time) ([]ticket.EventRevenueRow, error) {
+	query := hostEventRevenueQuery + `
+		WHERE e.host_id = $1
+		  AND ($2::timestamptz IS NULL OR e.start_time >= $2)
+		  AND ($3::timestamptz IS NULL OR e.start_time <= $3)
+		ORDER BY e.start_time DESC, e.id ASC
+	`
+
+	var rows []ticket.EventRevenueRow
+	if err := r.db.SelectContext(ctx, &rows, query, hostID, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	if rows == nil {
+		rows = []ticket.EventRevenueRow{}
+	}
+
+	return rows, nil
+}
+
+// GetTransactionTotals counts and sums an event's transactions per status
+func (r *ticketRepository) GetTransactionTotals(ctx context.Context, eventID uuid.UUID) ([]ticket.TransactionTotal, error) {
+	query := `
+		SELECT tx.status, COUNT(*) as count, COALESCE(SUM(tx.amount), 0) as amount
+		FROM ticket_transactions tx
+		INNER JOIN tickets t ON tx.ticket_id = t.id
+		WHERE t.event_id = $1
+		GROUP BY tx.status
+	`
+
+	var totals []ticket.TransactionTotal
+	if err := r.db.SelectContext(ctx, &totals, query, eventID); err != nil {
+		return nil, err
+	}
+
+	if totals == nil {
+		totals = []ticket.TransactionTotal{}
+	}
+
+	return totals, nil
+}
+
+// GetPaymentMethodTotals counts and sums an event's successful payments per payment method
+func (r *ticketRepository) GetPaymentMethodTotals(ctx context.Context, eventID uuid.UUID) ([]ticket.PaymentMethodTotal, error) {
+	query := `
+		SELECT tx.payment_method as method, COUNT(*) as count, COALESCE(SUM(tx.amount), 0) as amount
+		FROM ticket_transactions tx
+		INNER JOIN tickets t ON tx.ticket_id = t.id
+		WHERE t.event_id = $1 AND tx.status = 'success'
+		GROUP BY tx.payment_method
+		ORDER BY count DESC
+	`
+
+	var totals []ticket.PaymentMethodTotal
+	if err := r.db.SelectContext(ctx, &totals, query, eventID); err != nil {
+		return nil, err
+	}
+
+	if totals == nil {
+		totals = []ticket.PaymentMethodTotal{}
+	}
+
+	return totals, nil
+}
+
+// GetTierRevenue sums paid and refunded amounts per ticket tier of an event
+func (r *ticketRepository) GetTierRevenue(ctx context.Context, eventID uuid.UUID) ([]ticket.TierRevenue, error) {
+	query := `
+		SELECT
+			t.tier_id,
+			COALESCE(SUM(tx.amount) FILTER (WHERE tx.status = 'success'), 0) as revenue,
+			COALESCE(SUM(tx.amount) FILTER (WHERE tx.status = 'refunded'), 0) as refunded_revenue
+		FROM ticket_transactions tx
+		INNER JOIN tickets t ON tx.ticket_id = t.id
+		WHERE t.event_id = $1 AND t.tier_id IS NOT NULL
+		GROUP BY t.tier_id
+	`
+
+	var revenue []ticket.TierRevenue
+	if err := r.db.SelectContext(ctx, &revenue, query, eventID); err != nil {
+		return nil, err
+	}
+
+	if revenue == nil {
+		revenue = []ticket.TierRevenue{}
+	}
+
+	return revenue, nil
+}
+
+// GetSalesTimeline groups an event's completed successful payments into time buckets, oldest first
+// bucket is a date_trunc field (hour, day or week) and timezone an IANA name the buckets follow
+func (r *ticketRepository) GetSalesTimeline(ctx context.Context, eventID uuid.UUID, bucket, timezone string) ([]ticket.SalesBucket, error) {
+	query := `
+		SELECT
+			date_trunc($2, tx.completed_at AT TIME ZONE $3) as bucket_start,
+			COUNT(*) as transactions,
+			COALESCE(SUM(tx.amount), 0) as revenue
+		FROM ticket_transactions tx
+		INNER JOIN tickets t ON tx.ticket_id = t.id
+		WHERE t.event_id = $1
+		  AND tx.status = 'success'
+		  AND tx.completed_at IS NOT NULL
+		GROUP BY bucket_start
+		ORDER BY bucket_start ASC
+	`
+
+	var buckets []ticket.SalesBucket
+	if err := r.db.SelectContext(ctx, &buckets, query, eventID, bucket, timezone); err != nil {
+		return nil, err
+	}
+
+	if buckets == nil {
+		buckets = []ticket.SalesBucket{}
+	}
+
+	return buckets, nil
+}
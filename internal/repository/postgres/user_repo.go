@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/anigmaa/backend/internal/domain/user"
@@ -25,11 +26,11 @@ func NewUserRepository(db *sqlx.DB) user.Repository {
 func (r *userRepository) Create(ctx context.Context, u *user.User) error {
 	query := `
 		INSERT INTO users (
-			id, email, name, bio, avatar_url,
+			id, email, username, name, bio, avatar_url,
 			phone, date_of_birth, gender, location, interests,
 			created_at, updated_at, is_verified, is_email_verified
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -38,27 +39,30 @@ func (r *userRepository) Create(ctx context.Context, u *user.User) error {
 	u.UpdatedAt = time.Now()
 	u.IsVerified = false
 
-	return r.db.QueryRowContext(ctx, query,
-		u.ID, u.Email, u.Name, u.Bio, u.AvatarURL,
+	err := r.db.QueryRowContext(ctx, query,
+		u.ID, u.Email, u.Username, u.Name, u.Bio, u.AvatarURL,
 		u.Phone, u.DateOfBirth, u.Gender, u.Location, pq.Array(u.Interests),
 		u.CreatedAt, u.UpdatedAt, u.IsVerified, u.IsEmailVerified,
 	).Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+	return usernameError(err)
 }
 
 // GetByID gets a user by ID
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
 	var u user.User
 	query := `
-		SELECT id, email, name, bio, avatar_url,
+		SELECT id, email, username, name, bio, avatar_url,
 		       phone, date_of_birth, gender, location, interests,
-		       created_at, updated_at, last_login_at, is_verified, is_email_verified
-		FROM users WHERE id = $1
+		       created_at, updated_at, last_login_at, is_verified, is_email_verified,
+		       role, suspended_at, suspended_until, suspension_reason, deletion_scheduled_at
+		FROM users WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&u.ID, &u.Email, &u.Name, &u.Bio, &u.AvatarURL,
+		&u.ID, &u.Email, &u.Username, &u.Name, &u.Bio, &u.AvatarURL,
 		&u.Phone, &u.DateOfBirth, &u.Gender, &u.Location, pq.Array(&u.Interests),
 		&u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt, &u.IsVerified, &u.IsEmailVerified,
+		&u.Role, &u.SuspendedAt, &u.SuspendedUntil, &u.SuspensionReason, &u.DeletionScheduledAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -74,16 +78,18 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User,
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
 	var u user.User
 	query := `
-		SELECT id, email, name, bio, avatar_url,
+		SELECT id, email, username, name, bio, avatar_url,
 		       phone, date_of_birth, gender, location, interests,
-		       created_at, updated_at, last_login_at, is_verified, is_email_verified
-		FROM users WHERE email = $1
+		       created_at, updated_at, last_login_at, is_verified, is_email_verified,
+		       role, suspended_at, suspended_until, suspension_reason, deletion_scheduled_at
+		FROM users WHERE email = $1 AND deleted_at IS NULL
 	`
 
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
-		&u.ID, &u.Email, &u.Name, &u.Bio, &u.AvatarURL,
+		&u.ID, &u.Email, &u.Username, &u.Name, &u.Bio, &u.AvatarURL,
 		&u.Phone, &u.DateOfBirth, &u.Gender, &u.Location, pq.Array(&u.Interests),
 		&u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt, &u.IsVerified, &u.IsEmailVerified,
+		&u.Role, &u.SuspendedAt, &u.SuspendedUntil, &u.SuspensionReason, &u.DeletionScheduledAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -95,10 +101,167 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*user.Us
 	return &u, nil
 }
 
-// GetByUsername is deprecated - Google Auth only, no usernames
-// Kept for backwards compatibility but will always return not found
+// GetByUsername gets a user by their current username, ignoring case
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*user.User, error) {
-	return nil, fmt.Errorf("user not found")
+	var u user.User
+	query := `
+		SELECT id, email, username, name, bio, avatar_url,
+		       phone, date_of_birth, gender, location, interests,
+		       created_at, updated_at, last_login_at, is_verified, is_email_verified,
+		       role, suspended_at, suspended_until, suspension_reason, deletion_scheduled_at
+		FROM users WHERE LOWER(username) = LOWER($1) AND deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowContext(ctx, query, username).Scan(
+		&u.ID, &u.Email, &u.Username, &u.Name, &u.Bio, &u.AvatarURL,
+		&u.Phone, &u.DateOfBirth, &u.Gender, &u.Location, pq.Array(&u.Interests),
+		&u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt, &u.IsVerified, &u.IsEmailVerified,
+		&u.Role, &u.SuspendedAt, &u.SuspendedUntil, &u.SuspensionReason, &u.DeletionScheduledAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+// GetByPreviousUsername gets the user who most recently gave up a username, ignoring case
+func (r *userRepository) GetByPreviousUsername(ctx context.Context, username string) (*user.User, error) {
+	query := `
+		SELECT user_id FROM username_history
+		WHERE LOWER(username) = LOWER($1)
+		ORDER BY changed_at DESC
+		LIMIT 1
+	`
+
+	var userID uuid.UUID
+	if err := r.db.GetContext(ctx, &userID, query, username); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, err
+	}
+
+	return r.GetByID(ctx, userID)
+}
+
+// GetIDsByUsernames gets the IDs of the users holding any of the usernames, ignoring case
+func (r *userRepository) GetIDsByUsernames(ctx context.Context, usernames []string) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	if len(usernames) == 0 {
+		return ids, nil
+	}
+
+	lowered := make([]string, len(usernames))
+	for i, name := range usernames {
+		lowered[i] = strings.ToLower(name)
+	}
+
+	query := `SELECT id FROM users WHERE LOWER(username) = ANY($1) AND deleted_at IS NULL`
+	if err := r.db.SelectContext(ctx, &ids, query, pq.Array(lowered)); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// IsUsernameAvailable checks that no other user holds a username, or gave it up after heldSince
+func (r *userRepository) IsUsernameAvailable(ctx context.Context, username string, userID uuid.UUID, heldSince time.Time) (bool, error) {
+	query := `
+		SELECT NOT EXISTS(
+			SELECT 1 FROM users WHERE LOWER(username) = LOWER($1) AND id != $2
+		) AND NOT EXISTS(
+			SELECT 1 FROM username_history
+			WHERE LOWER(username) = LOWER($1) AND user_id != $2 AND changed_at > $3
+		)
+	`
+
+	var available bool
+	err := r.db.GetContext(ctx, &available, query, username, userID, heldSince)
+	return available, err
+}
+
+// ChangeUsername sets a user's username, recording the previous one in the history.
+// Returns user.ErrUsernameTaken when another user holds it, and user.ErrUsernameCooldown
+// when the user already gave up a username after cooldownSince.
+func (r *userRepository) ChangeUsername(ctx context.Context, userID uuid.UUID, username string, cooldownSince time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var previous string
+	err = tx.GetContext(ctx, &previous,
+		`SELECT username FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, userID)
+	if err != nil {
+		return err
+	}
+	if previous == username {
+		return nil
+	}
+
+	// Only a change of casing keeps the old form reachable, nothing to record
+	renamed := !strings.EqualFold(previous, username)
+	if renamed {
+		var recent bool
+		err = tx.GetContext(ctx, &recent,
+			`SELECT EXISTS(SELECT 1 FROM username_history WHERE user_id = $1 AND changed_at > $2)`, userID, cooldownSince)
+		if err != nil {
+			return err
+		}
+		if recent {
+			return user.ErrUsernameCooldown
+		}
+	}
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx,
+		`UPDATE users SET username = $2, updated_at = $3 WHERE id = $1`, userID, username, now)
+	if err != nil {
+		return usernameError(err)
+	}
+
+	if renamed {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO username_history (id, user_id, username, changed_at)
+			VALUES ($1, $2, $3, $4)
+		`, uuid.New(), userID, previous, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetUsernameHistory gets the usernames a user gave up, most recent first
+func (r *userRepository) GetUsernameHistory(ctx context.Context, userID uuid.UUID) ([]user.UsernameChange, error) {
+	query := `
+		SELECT username, changed_at FROM username_history
+		WHERE user_id = $1
+		ORDER BY changed_at DESC
+	`
+
+	changes := []user.UsernameChange{}
+	if err := r.db.SelectContext(ctx, &changes, query, userID); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// usernameError maps a violation of the unique username index to user.ErrUsernameTaken
+func usernameError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		if pqErr.Code == "23505" && pqErr.Constraint == "idx_users_username_lower" { // unique_violation
+			return user.ErrUsernameTaken
+		}
+	}
+	return err
 }
 
 // Update updates a user
@@ -129,6 +292,35 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+// SetRole changes a user's platform role
+func (r *userRepository) SetRole(ctx context.Context, userID uuid.UUID, role user.Role) error {
+	query := `UPDATE users SET role = $2, updated_at = $3 WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, userID, role, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CountByRole counts the active users holding a platform role
+func (r *userRepository) CountByRole(ctx context.Context, role user.Role) (int, error) {
+	query := `SELECT COUNT(*) FROM users WHERE role = $1 AND deleted_at IS NULL`
+
+	var count int
+	err := r.db.GetContext(ctx, &count, query, role)
+	return count, err
+}
+
 // GetProfile gets a complete user profile
 func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*user.UserProfile, error) {
 	var profile user.UserProfile
@@ -147,7 +339,8 @@ func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*use
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
-	profile.Settings = settings
+	settings.UserID = userID
+	profile.Settings = &settings
 
 	// Get stats
 	stats, err := r.GetStats(ctx, userID)
@@ -159,10 +352,8 @@ func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*use
 	}
 
 	// Get privacy
-	var privacy user.UserPrivacy
-	privacyQuery := `SELECT * FROM user_privacy WHERE user_id = $1`
-	err = r.db.GetContext(ctx, &privacy, privacyQuery, userID)
-	if err != nil && err != sql.ErrNoRows {
+	privacy, err := r.GetPrivacy(ctx, userID)
+	if err != nil {
 		return nil, err
 	}
 	profile.Privacy = privacy
@@ -170,10 +361,13 @@ func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*use
 	return &profile, nil
 }
 
-// GetProfileByUsername is deprecated - Google Auth only, no usernames
-// Kept for backwards compatibility but will always return not found
+// GetProfileByUsername gets a complete user profile by current username
 func (r *userRepository) GetProfileByUsername(ctx context.Context, username string) (*user.UserProfile, error) {
-	return nil, fmt.Errorf("user not found")
+	u, err := r.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetProfile(ctx, u.ID)
 }
 
 // UpdateSettings updates user settings
@@ -201,24 +395,76 @@ func (r *userRepository) UpdateSettings(ctx context.Context, settings *user.User
 // UpdatePrivacy updates user privacy settings
 func (r *userRepository) UpdatePrivacy(ctx context.Context, privacy *user.UserPrivacy) error {
 	query := `
-		INSERT INTO user_privacy (user_id, profile_visible, events_visible, allow_followers, show_email, show_location)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO user_privacy (user_id, profile_visible, events_visible, allow_followers, show_email, show_location, share_contact_with_hosts)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		ON CONFLICT (user_id) DO UPDATE SET
 			profile_visible = EXCLUDED.profile_visible,
 			events_visible = EXCLUDED.events_visible,
 			allow_followers = EXCLUDED.allow_followers,
 			show_email = EXCLUDED.show_email,
-			show_location = EXCLUDED.show_location
+			show_location = EXCLUDED.show_location,
+			share_contact_with_hosts = EXCLUDED.share_contact_with_hosts
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
 		privacy.UserID, privacy.ProfileVisible, privacy.EventsVisible,
-		privacy.AllowFollowers, privacy.ShowEmail, privacy.ShowLocation,
+		privacy.AllowFollowers, privacy.ShowEmail, privacy.ShowLocation, privacy.ShareContactWithHosts,
 	)
 
 	return err
 }
 
+// GetPrivacy gets user privacy settings, falling back to the defaults when never saved
+func (r *userRepository) GetPrivacy(ctx context.Context, userID uuid.UUID) (*user.UserPrivacy, error) {
+	query := `
+		SELECT user_id, COALESCE(profile_visible, TRUE) as profile_visible, COALESCE(events_visible, TRUE) as events_visible,
+		       COALESCE(allow_followers, TRUE) as allow_followers, COALESCE(show_email, FALSE) as show_email,
+		       COALESCE(show_location, TRUE) as show_location, share_contact_with_hosts
+		FROM user_privacy WHERE user_id = $1
+	`
+
+	var privacy user.UserPrivacy
+	err := r.db.GetContext(ctx, &privacy, query, userID)
+	if err == sql.ErrNoRows {
+		privacy = user.DefaultPrivacy(userID)
+		return &privacy, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &privacy, nil
+}
+
+// GetPrivacyByUsers gets privacy settings for several users, keyed by user ID.
+// Users without saved settings get the defaults.
+func (r *userRepository) GetPrivacyByUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]user.UserPrivacy, error) {
+	result := make(map[uuid.UUID]user.UserPrivacy, len(userIDs))
+	for _, id := range userIDs {
+		result[id] = user.DefaultPrivacy(id)
+	}
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT user_id, COALESCE(profile_visible, TRUE) as profile_visible, COALESCE(events_visible, TRUE) as events_visible,
+		       COALESCE(allow_followers, TRUE) as allow_followers, COALESCE(show_email, FALSE) as show_email,
+		       COALESCE(show_location, TRUE) as show_location, share_contact_with_hosts
+		FROM user_privacy WHERE user_id = ANY($1)
+	`
+
+	var rows []user.UserPrivacy
+	if err := r.db.SelectContext(ctx, &rows, query, pq.Array(userIDs)); err != nil {
+		return nil, err
+	}
+	for _, p := range rows {
+		result[p.UserID] = p
+	}
+
+	return result, nil
+}
+
 // Follow follows a user
 func (r *userRepository) Follow(ctx context.Context, followerID, followingID uuid.UUID) error {
 	query := `
@@ -256,7 +502,7 @@ func (r *userRepository) Unfollow(ctx context.Context, followerID, followingID u
 // GetFollowers gets users following a specific user
 func (r *userRepository) GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]user.User, error) {
 	query := `
-		SELECT u.id, u.email, u.name, u.bio, u.avatar_url,
+		SELECT u.id, u.email, u.username, u.name, u.bio, u.avatar_url,
 		       u.phone, u.date_of_birth, u.gender, u.location, u.interests,
 		       u.created_at, u.updated_at, u.last_login_at, u.is_verified, u.is_email_verified
 		FROM users u
@@ -276,7 +522,7 @@ func (r *userRepository) GetFollowers(ctx context.Context, userID uuid.UUID, lim
 	for rows.Next() {
 		var u user.User
 		err := rows.Scan(
-			&u.ID, &u.Email, &u.Name, &u.Bio, &u.AvatarURL,
+			&u.ID, &u.Email, &u.Username, &u.Name, &u.Bio, &u.AvatarURL,
 			&u.Phone, &u.DateOfBirth, &u.Gender, &u.Location, pq.Array(&u.Interests),
 			&u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt, &u.IsVerified, &u.IsEmailVerified,
 		)
@@ -296,7 +542,7 @@ func (r *userRepository) GetFollowers(ctx context.Context, userID uuid.UUID, lim
 // GetFollowing gets users that a specific user is following
 func (r *userRepository) GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]user.User, error) {
 	query := `
-		SELECT u.id, u.email, u.name, u.bio, u.avatar_url,
+		SELECT u.id, u.email, u.username, u.name, u.bio, u.avatar_url,
 		       u.phone, u.date_of_birth, u.gender, u.location, u.interests,
 		       u.created_at, u.updated_at, u.last_login_at, u.is_verified, u.is_email_verified
 		FROM users u
@@ -316,7 +562,7 @@ func (r *userRepository) GetFollowing(ctx context.Context, userID uuid.UUID, lim
 	for rows.Next() {
 		var u user.User
 		err := rows.Scan(
-			&u.ID, &u.Email, &u.Name, &u.Bio, &u.AvatarURL,
+			&u.ID, &u.Email, &u.Username, &u.Name, &u.Bio, &u.AvatarURL,
 			&u.Phone, &u.DateOfBirth, &u.Gender, &u.Location, pq.Array(&u.Interests),
 			&u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt, &u.IsVerified, &u.IsEmailVerified,
 		)
@@ -342,6 +588,163 @@ func (r *userRepository) IsFollowing(ctx context.Context, followerID, followingI
 	return exists, err
 }
 
+// CreateFollowRequest creates a pending follow request
+func (r *userRepository) CreateFollowRequest(ctx context.Context, req *user.FollowRequest) error {
+	query := `
+		INSERT INTO follow_requests (id, requester_id, target_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (requester_id, target_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, req.ID, req.RequesterID, req.TargetID, req.CreatedAt)
+	return err
+}
+
+// HasFollowRequest checks if a follow request is pending
+func (r *userRepository) HasFollowRequest(ctx context.Context, requesterID, targetID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM follow_requests WHERE requester_id = $1 AND target_id = $2)`
+
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, query, requesterID, targetID)
+	return exists, err
+}
+
+// DeleteFollowRequest removes a pending follow request
+func (r *userRepository) DeleteFollowRequest(ctx context.Context, requesterID, targetID uuid.UUID) error {
+	query := `DELETE FROM follow_requests WHERE requester_id = $1 AND target_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, requesterID, targetID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetFollowRequests gets pending follow requests sent to a user, newest first
+func (r *userRepository) GetFollowRequests(ctx context.Context, targetID uuid.UUID, limit, offset int) ([]user.FollowRequestWithUser, error) {
+	query := `
+		SELECT fr.id, fr.created_at,
+		       u.id, u.email, u.username, u.name, u.bio, u.avatar_url,
+		       u.phone, u.date_of_birth, u.gender, u.location, u.interests,
+		       u.created_at, u.updated_at, u.last_login_at, u.is_verified, u.is_email_verified
+		FROM follow_requests fr
+		INNER JOIN users u ON u.id = fr.requester_id
+		WHERE fr.target_id = $1
+		ORDER BY fr.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, targetID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := []user.FollowRequestWithUser{}
+	for rows.Next() {
+		var req user.FollowRequestWithUser
+		u := &req.Requester
+		err := rows.Scan(
+			&req.ID, &req.CreatedAt,
+			&u.ID, &u.Email, &u.Username, &u.Name, &u.Bio, &u.AvatarURL,
+			&u.Phone, &u.DateOfBirth, &u.Gender, &u.Location, pq.Array(&u.Interests),
+			&u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt, &u.IsVerified, &u.IsEmailVerified,
+		)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// CountFollowRequests counts pending follow requests sent to a user
+func (r *userRepository) CountFollowRequests(ctx context.Context, targetID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM follow_requests WHERE target_id = $1`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, targetID).Scan(&count)
+	return count, err
+}
+
+// ApproveFollowRequest turns a pending follow request into a follow
+func (r *userRepository) ApproveFollowRequest(ctx context.Context, requesterID, targetID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM follow_requests WHERE requester_id = $1 AND target_id = $2`, requesterID, targetID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO follows (id, follower_id, following_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (follower_id, following_id) DO NOTHING
+	`, uuid.New(), requesterID, targetID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Update follower/following counts
+	_ = r.updateFollowCounts(ctx, requesterID, targetID)
+
+	return nil
+}
+
+// ApproveAllFollowRequests turns every pending follow request sent to a user into a follow
+func (r *userRepository) ApproveAllFollowRequests(ctx context.Context, targetID uuid.UUID) error {
+	query := `
+		WITH approved AS (
+			DELETE FROM follow_requests WHERE target_id = $1
+			RETURNING requester_id
+		)
+		INSERT INTO follows (id, follower_id, following_id, created_at)
+		SELECT uuid_generate_v4(), requester_id, $1, NOW() FROM approved
+		ON CONFLICT (follower_id, following_id) DO NOTHING
+		RETURNING follower_id
+	`
+
+	var followerIDs []uuid.UUID
+	if err := r.db.SelectContext(ctx, &followerIDs, query, targetID); err != nil {
+		return err
+	}
+
+	// Update follower/following counts
+	for _, followerID := range followerIDs {
+		_ = r.updateFollowCounts(ctx, followerID, targetID)
+	}
+
+	return nil
+}
+
 // GetStats gets user statistics
 func (r *userRepository) GetStats(ctx context.Context, userID uuid.UUID) (*user.UserStats, error) {
 	var stats user.UserStats
@@ -405,19 +808,23 @@ func (r *userRepository) UpdateAverageRating(ctx context.Context, userID uuid.UU
 	return err
 }
 
-// SearchUsers searches users by name or email
-func (r *userRepository) SearchUsers(ctx context.Context, query string, limit, offset int) ([]user.User, error) {
+// SearchUsers searches users by name or username, or by email when they chose to show it
+// Deleted users and users in a block relation with the viewer are left out
+func (r *userRepository) SearchUsers(ctx context.Context, query string, viewerID uuid.UUID, limit, offset int) ([]user.User, error) {
 	searchQuery := `
-		SELECT id, email, name, bio, avatar_url,
-		       phone, date_of_birth, gender, location, interests,
-		       created_at, updated_at, last_login_at, is_verified, is_email_verified
-		FROM users
-		WHERE name ILIKE $1 OR email ILIKE $1
-		ORDER BY created_at DESC
+		SELECT u.id, u.email, u.username, u.name, u.bio, u.avatar_url,
+		       u.phone, u.date_of_birth, u.gender, u.location, u.interests,
+		       u.created_at, u.updated_at, u.last_login_at, u.is_verified, u.is_email_verified
+		FROM users u
+		LEFT JOIN user_privacy up ON up.user_id = u.id
+		WHERE (u.name ILIKE $1 OR u.username ILIKE $1 OR (u.email ILIKE $1 AND COALESCE(up.show_email, FALSE)))
+			AND u.deleted_at IS NULL
+			AND NOT is_blocked_between($4, u.id)
+		ORDER BY u.created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, searchQuery, "%"+query+"%", limit, offset)
+	rows, err := r.db.QueryContext(ctx, searchQuery, "%"+query+"%", limit, offset, viewerID)
 	if err != nil {
 		return nil, err
 	}
@@ -427,7 +834,7 @@ func (r *userRepository) SearchUsers(ctx context.Context, query string, limit, o
 	for rows.Next() {
 		var u user.User
 		err := rows.Scan(
-			&u.ID, &u.Email, &u.Name, &u.Bio, &u.AvatarURL,
+			&u.ID, &u.Email, &u.Username, &u.Name, &u.Bio, &u.AvatarURL,
 			&u.Phone, &u.DateOfBirth, &u.Gender, &u.Location, pq.Array(&u.Interests),
 			&u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt, &u.IsVerified, &u.IsEmailVerified,
 		)
@@ -484,14 +891,17 @@ func (r *userRepository) CountFollowing(ctx context.Context, userID uuid.UUID) (
 }
 
 // CountSearchResults counts total users matching search query
-func (r *userRepository) CountSearchResults(ctx context.Context, query string) (int, error) {
+func (r *userRepository) CountSearchResults(ctx context.Context, query string, viewerID uuid.UUID) (int, error) {
 	sql := `
 		SELECT COUNT(*)
-		FROM users
-		WHERE (name ILIKE $1 OR email ILIKE $1)
+		FROM users u
+		LEFT JOIN user_privacy up ON up.user_id = u.id
+		WHERE (u.name ILIKE $1 OR u.username ILIKE $1 OR (u.email ILIKE $1 AND COALESCE(up.show_email, FALSE)))
+			AND u.deleted_at IS NULL
+			AND NOT is_blocked_between($2, u.id)
 	`
 	searchTerm := "%" + query + "%"
 	var count int
-	err := r.db.QueryRowContext(ctx, sql, searchTerm).Scan(&count)
+	err := r.db.QueryRowContext(ctx, sql, searchTerm, viewerID).Scan(&count)
 	return count, err
 }
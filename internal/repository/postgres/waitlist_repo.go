@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/waitlist"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type waitlistRepository struct {
+	db *sqlx.DB
+}
+
+// NewWaitlistRepository creates a new waitlist repository
+func NewWaitlistRepository(db *sqlx.DB) waitlist.Repository {
+	return &waitlistRepository{db: db}
+}
+
+// Upsert adds a user to the back of an event waitlist
+// Re-joining after leaving or an expired offer resets the entry to waiting
+func (r *waitlistRepository) Upsert(ctx context.Context, e *waitlist.Entry) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+
+	now := time.Now()
+	e.Status = waitlist.StatusWaiting
+	e.JoinedAt = now
+	e.UpdatedAt = now
+
+	query := `
+		INSERT INTO event_waitlist (id, event_id, user_id, status, joined_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (event_id, user_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			offered_at = NULL,
+			offer_expires_at = NULL,
+			ticket_id = NULL,
+			joined_at = EXCLUDED.joined_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		e.ID, e.EventID, e.UserID, e.Status, e.JoinedAt, e.UpdatedAt,
+	).Scan(&e.ID)
+}
+
+// GetByEventAndUser gets a user's waitlist entry for an event
+func (r *waitlistRepository) GetByEventAndUser(ctx context.Context, eventID, userID uuid.UUID) (*waitlist.Entry, error) {
+	query := `
+		SELECT id, event_id, user_id, status, offered_at, offer_expires_at, ticket_id, joined_at, updated_at
+		FROM event_waitlist
+		WHERE event_id = $1 AND user_id = $2
+	`
+
+	var e waitlist.Entry
+	err := r.db.GetContext(ctx, &e, query, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// UpdateStatus updates a waitlist entry status
+func (r *waitlistRepository) UpdateStatus(ctx context.Context, entryID uuid.UUID, status waitlist.EntryStatus) error {
+	query := `UPDATE event_waitlist SET status = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, status, time.Now(), entryID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// MarkClaimed marks an offered entry as claimed
+// ticketID is nil when the seat was taken by joining a non-ticketed event
+func (r *waitlistRepository) MarkClaimed(ctx context.Context, entryID uuid.UUID, ticketID *uuid.UUID) error {
+	query := `
+		UPDATE event_waitlist
+		SET status = 'claimed', ticket_id = $1, updated_at = $2
+		WHERE id = $3 AND status = 'offered'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, ticketID, time.Now(), entryID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// OfferFreeSeats offers every free seat of an event to the longest-waiting users
+// The event row stays locked while seats are counted and offered, so an offer never
+// takes a seat that an order or a late payment got at the same time
+func (r *waitlistRepository) OfferFreeSeats(ctx context.Context, eventID uuid.UUID, expiresAt time.Time) ([]waitlist.Entry, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	free, err := lockFreeSeats(ctx, tx, eventID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if free <= 0 {
+		return nil, nil
+	}
+
+	query := `
+		UPDATE event_waitlist
+		SET status = 'offered', offered_at = $2, offer_expires_at = $3, updated_at = $2
+		WHERE id IN (
+			SELECT id FROM event_waitlist
+			WHERE event_id = $1 AND status = 'waiting'
+			ORDER BY joined_at ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_id, user_id, status, offered_at, offer_expires_at, ticket_id, joined_at, updated_at
+	`
+
+	var entries []waitlist.Entry
+	if err := tx.SelectContext(ctx, &entries, query, eventID, time.Now(), expiresAt, free); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// ExpireOffers expires all unclaimed offers past their deadline
+func (r *waitlistRepository) ExpireOffers(ctx context.Context, now time.Time) ([]waitlist.Entry, error) {
+	query := `
+		UPDATE event_waitlist
+		SET status = 'expired', updated_at = $1
+		WHERE status = 'offered' AND offer_expires_at <= $1
+		RETURNING id, event_id, user_id, status, offered_at, offer_expires_at, ticket_id, joined_at, updated_at
+	`
+
+	var entries []waitlist.Entry
+	err := r.db.SelectContext(ctx, &entries, query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CountWaiting counts users waiting for a seat
+func (r *waitlistRepository) CountWaiting(ctx context.Context, eventID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM event_waitlist WHERE event_id = $1 AND status = 'waiting'`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, eventID).Scan(&count)
+	return count, err
+}
+
+// CountActiveOffers counts unexpired seat offers, which hold a seat until claimed
+func (r *waitlistRepository) CountActiveOffers(ctx context.Context, eventID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM event_waitlist
+		WHERE event_id = $1 AND status = 'offered' AND offer_expires_at > $2
+	`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, eventID, time.Now()).Scan(&count)
+	return count, err
+}
+
+// GetPosition gets the 1-based queue position of a waiting entry
+func (r *waitlistRepository) GetPosition(ctx context.Context, e *waitlist.Entry) (int, error) {
+	query := `
+		SELECT COUNT(*) + 1 FROM event_waitlist
+		WHERE event_id = $1 AND status = 'waiting' AND joined_at < $2
+	`
+	var position int
+	err := r.db.QueryRowContext(ctx, query, e.EventID, e.JoinedAt).Scan(&position)
+	return position, err
+}
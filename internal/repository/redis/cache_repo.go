@@ -26,31 +26,27 @@ func NewCacheRepository(client *redis.Client) CacheRepository {
 }
 
 // Get retrieves a value from cache
+// A missing key returns redis.Nil
 func (r *cacheRepository) Get(ctx context.Context, key string) (string, error) {
-	// TODO: implement
-	return "", nil
+	return r.client.Get(ctx, key).Result()
 }
 
 // Set sets a value in cache with expiration
 func (r *cacheRepository) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	// TODO: implement
-	return nil
+	return r.client.Set(ctx, key, value, expiration).Err()
 }
 
 // Delete removes one or more keys from cache
 func (r *cacheRepository) Delete(ctx context.Context, keys ...string) error {
-	// TODO: implement
-	return nil
+	return r.client.Del(ctx, keys...).Err()
 }
 
 // Exists checks if keys exist in cache
 func (r *cacheRepository) Exists(ctx context.Context, keys ...string) (int64, error) {
-	// TODO: implement
-	return 0, nil
+	return r.client.Exists(ctx, keys...).Result()
 }
 
 // SetNX sets a value only if the key doesn't exist
 func (r *cacheRepository) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
-	// TODO: implement
-	return false, nil
+	return r.client.SetNX(ctx, key, value, expiration).Result()
 }
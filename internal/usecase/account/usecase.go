@@ -0,0 +1,308 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/account"
+	"github.com/anigmaa/backend/internal/domain/community"
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/user"
+	ticketUsecase "github.com/anigmaa/backend/internal/usecase/ticket"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound         = errors.New("user not found")
+	ErrDeletionNotScheduled = errors.New("account deletion is not scheduled")
+	ErrExportNotFound       = errors.New("data export not found")
+	ErrExportNotReady       = errors.New("data export is not ready yet")
+)
+
+const (
+	// deletionBatchSize caps the accounts deleted per job run
+	deletionBatchSize = 20
+
+	// exportBatchSize caps the archives built per job run
+	exportBatchSize = 5
+
+	// exportFailedReason is shown to the user instead of the internal error
+	exportFailedReason = "the archive could not be built, request a new export"
+)
+
+// Usecase handles account deletion and personal data export
+type Usecase struct {
+	accountRepo     account.Repository
+	userRepo        user.Repository
+	eventRepo       event.Repository
+	communityRepo   community.Repository
+	ticketUsecase   *ticketUsecase.Usecase
+	gracePeriod     time.Duration
+	exportRetention time.Duration
+	publicURL       string
+}
+
+// NewUsecase creates a new account usecase
+// gracePeriod is how long a deletion can be cancelled, exportRetention how long an export archive can be downloaded
+func NewUsecase(accountRepo account.Repository, userRepo user.Repository, eventRepo event.Repository, communityRepo community.Repository, ticketUsecase *ticketUsecase.Usecase, gracePeriod, exportRetention time.Duration, publicURL string) *Usecase {
+	return &Usecase{
+		accountRepo:     accountRepo,
+		userRepo:        userRepo,
+		eventRepo:       eventRepo,
+		communityRepo:   communityRepo,
+		ticketUsecase:   ticketUsecase,
+		gracePeriod:     gracePeriod,
+		exportRetention: exportRetention,
+		publicURL:       publicURL,
+	}
+}
+
+// ScheduleDeletion schedules the deletion of a user's account at the end of the grace period.
+// Scheduling again keeps the original date.
+func (uc *Usecase) ScheduleDeletion(ctx context.Context, userID uuid.UUID) (*account.Deletion, error) {
+	u, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if u.DeletionScheduledAt != nil {
+		return &account.Deletion{ScheduledAt: *u.DeletionScheduledAt}, nil
+	}
+
+	at := time.Now().Add(uc.gracePeriod)
+	if err := uc.accountRepo.ScheduleDeletion(ctx, userID, at); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &account.Deletion{ScheduledAt: at}, nil
+}
+
+// CancelDeletion cancels a scheduled account deletion during the grace period
+func (uc *Usecase) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	if err := uc.accountRepo.CancelDeletion(ctx, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrDeletionNotScheduled
+		}
+		return err
+	}
+	return nil
+}
+
+// ProcessDeletions deletes the accounts whose grace period ended.
+// An account that fails is left scheduled and retried on the next run.
+func (uc *Usecase) ProcessDeletions(ctx context.Context) error {
+	userIDs, err := uc.accountRepo.GetDueDeletions(ctx, time.Now(), deletionBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if err := uc.deleteAccount(ctx, userID); err != nil {
+			// Log error but don't fail
+			continue
+		}
+	}
+
+	return nil
+}
+
+// deleteAccount winds down everything a user is responsible for, then anonymizes the account.
+// Every step can be repeated, so a deletion that failed halfway is completed by the next run.
+func (uc *Usecase) deleteAccount(ctx context.Context, userID uuid.UUID) error {
+	// Cancel hosted events that did not start yet and refund their attendees
+	events, err := uc.eventRepo.GetByHostID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, evt := range events {
+		if evt.Status == event.StatusCancelled || !evt.StartTime.After(now) {
+			continue
+		}
+		if err := uc.eventRepo.UpdateStatus(ctx, evt.ID, event.StatusCancelled); err != nil {
+			return err
+		}
+		if err := uc.ticketUsecase.RefundEventTickets(ctx, evt.ID); err != nil {
+			return err
+		}
+	}
+
+	// Give back the user's own seats
+	if err := uc.ticketUsecase.CancelUpcomingTickets(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.handOverCommunities(ctx, userID); err != nil {
+		return err
+	}
+
+	return uc.accountRepo.Anonymize(ctx, userID)
+}
+
+// handOverCommunities transfers each community the user owns to a successor,
+// deleting the communities nobody can take over
+func (uc *Usecase) handOverCommunities(ctx context.Context, userID uuid.UUID) error {
+	const pageSize = 100
+
+	// Collect first, transfers would shift the pages
+	var owned []community.Community
+	for offset := 0; ; offset += pageSize {
+		communities, err := uc.communityRepo.GetByCreator(ctx, userID, pageSize, offset)
+		if err != nil {
+			return err
+		}
+		owned = append(owned, communities...)
+		if len(communities) < pageSize {
+			break
+		}
+	}
+
+	for _, comm := range owned {
+		successorID, err := uc.communityRepo.GetSuccessor(ctx, comm.ID)
+		if err == sql.ErrNoRows {
+			if err := uc.communityRepo.Delete(ctx, comm.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := uc.communityRepo.TransferOwnership(ctx, comm.ID, successorID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RequestExport gets the user's current data export, starting a new one unless one is pending or can still be downloaded
+func (uc *Usecase) RequestExport(ctx context.Context, userID uuid.UUID) (*account.DataExport, error) {
+	now := time.Now()
+
+	latest, err := uc.accountRepo.GetLatestExport(ctx, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if latest != nil && latest.IsActive(now) {
+		uc.setDownloadURL(latest)
+		return latest, nil
+	}
+
+	export := &account.DataExport{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    account.ExportPending,
+		CreatedAt: now,
+	}
+	if err := uc.accountRepo.CreateExport(ctx, export); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// GetExportArchive gets the zip archive of the user's ready data export
+func (uc *Usecase) GetExportArchive(ctx context.Context, userID uuid.UUID) (*account.DataExport, []byte, error) {
+	export, err := uc.accountRepo.GetLatestExport(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, ErrExportNotFound
+		}
+		return nil, nil, err
+	}
+
+	switch {
+	case export.Status == account.ExportPending || export.Status == account.ExportProcessing:
+		return nil, nil, ErrExportNotReady
+	case !export.IsActive(time.Now()):
+		return nil, nil, ErrExportNotFound
+	}
+
+	archive, err := uc.accountRepo.GetExportArchive(ctx, export.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, ErrExportNotFound
+		}
+		return nil, nil, err
+	}
+
+	return export, archive, nil
+}
+
+// ProcessExports purges expired archives and builds the archives of pending data exports
+func (uc *Usecase) ProcessExports(ctx context.Context) error {
+	if err := uc.accountRepo.DeleteExpiredExports(ctx, time.Now()); err != nil {
+		return err
+	}
+
+	exports, err := uc.accountRepo.ClaimPendingExports(ctx, exportBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, export := range exports {
+		archive, err := uc.buildArchive(ctx, export.UserID)
+		if err != nil {
+			if err := uc.accountRepo.FailExport(ctx, export.ID, exportFailedReason); err != nil {
+				// Log error but don't fail
+			}
+			continue
+		}
+
+		if err := uc.accountRepo.CompleteExport(ctx, export.ID, archive, time.Now().Add(uc.exportRetention)); err != nil {
+			// Log error but don't fail, the export is claimed again later
+			continue
+		}
+	}
+
+	return nil
+}
+
+// buildArchive zips everything stored about a user, one JSON file per section
+func (uc *Usecase) buildArchive(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	sections, err := uc.accountRepo.GetUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, section := range sections {
+		w, err := zw.Create(section.Name + ".json")
+		if err != nil {
+			return nil, err
+		}
+
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, section.Data, "", "  "); err != nil {
+			return nil, err
+		}
+		if _, err := indented.WriteTo(w); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// setDownloadURL links a ready export to its download endpoint
+func (uc *Usecase) setDownloadURL(export *account.DataExport) {
+	if export.Status != account.ExportReady {
+		return
+	}
+	url := fmt.Sprintf("%s/api/v1/users/me/export/download", uc.publicURL)
+	export.DownloadURL = &url
+}
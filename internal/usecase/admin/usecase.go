@@ -0,0 +1,306 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/community"
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/moderation"
+	"github.com/anigmaa/backend/internal/domain/ticket"
+	"github.com/anigmaa/backend/internal/domain/user"
+	ticketUsecase "github.com/anigmaa/backend/internal/usecase/ticket"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrCannotChangeOwnRole = errors.New("cannot change your own role")
+	ErrEventNotFound       = errors.New("event not found")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrNotRefundable       = errors.New("only successful payments for active tickets can be refunded")
+	ErrCommunityNotFound   = errors.New("community not found")
+	ErrAlreadyOwner        = errors.New("user already owns this community")
+	ErrNewOwnerNotFound    = errors.New("new owner not found")
+	ErrNewOwnerSuspended   = errors.New("new owner is suspended")
+)
+
+// Usecase handles platform administration by staff.
+// Every change is recorded in the moderation audit trail.
+type Usecase struct {
+	userRepo       user.Repository
+	eventRepo      event.Repository
+	ticketRepo     ticket.Repository
+	communityRepo  community.Repository
+	moderationRepo moderation.Repository
+	ticketUsecase  *ticketUsecase.Usecase
+}
+
+// NewUsecase creates a new admin usecase
+func NewUsecase(userRepo user.Repository, eventRepo event.Repository, ticketRepo ticket.Repository, communityRepo community.Repository, moderationRepo moderation.Repository, ticketUsecase *ticketUsecase.Usecase) *Usecase {
+	return &Usecase{
+		userRepo:       userRepo,
+		eventRepo:      eventRepo,
+		ticketRepo:     ticketRepo,
+		communityRepo:  communityRepo,
+		moderationRepo: moderationRepo,
+		ticketUsecase:  ticketUsecase,
+	}
+}
+
+// SetUserVerified grants or revokes a user's verified badge
+func (uc *Usecase) SetUserVerified(ctx context.Context, adminID, userID uuid.UUID, verified bool, note *string) (*user.User, error) {
+	u, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if u.IsVerified != verified {
+		u.IsVerified = verified
+		if err := uc.userRepo.Update(ctx, u); err != nil {
+			return nil, err
+		}
+	}
+
+	action := moderation.ActionVerifyUser
+	if !verified {
+		action = moderation.ActionUnverifyUser
+	}
+	uc.record(ctx, adminID, action, moderation.TargetUser, userID, note)
+
+	return u, nil
+}
+
+// SetUserRole changes a user's platform role.
+// The new permissions apply from the user's next request.
+func (uc *Usecase) SetUserRole(ctx context.Context, adminID, userID uuid.UUID, req *user.SetRoleRequest) (*user.User, error) {
+	if adminID == userID {
+		return nil, ErrCannotChangeOwnRole
+	}
+
+	u, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := uc.userRepo.SetRole(ctx, userID, req.Role); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	u.Role = req.Role
+
+	note := "role: " + string(req.Role)
+	if req.Note != nil {
+		note += "; " + *req.Note
+	}
+	uc.record(ctx, adminID, moderation.ActionSetRole, moderation.TargetUser, userID, &note)
+
+	return u, nil
+}
+
+// SetEventFeatured features an event or removes it from the featured events
+func (uc *Usecase) SetEventFeatured(ctx context.Context, adminID, eventID uuid.UUID, featured bool, note *string) (*event.Event, error) {
+	if err := uc.eventRepo.SetFeatured(ctx, eventID, featured); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	action := moderation.ActionFeatureEvent
+	if !featured {
+		action = moderation.ActionUnfeatureEvent
+	}
+	uc.record(ctx, adminID, action, moderation.TargetEvent, eventID, note)
+
+	return uc.getEvent(ctx, eventID)
+}
+
+// SetEventPublished unpublishes an event, hiding it from discovery and search, or publishes it again.
+// Ticket holders keep access to an unpublished event.
+func (uc *Usecase) SetEventPublished(ctx context.Context, adminID, eventID uuid.UUID, published bool, note *string) (*event.Event, error) {
+	var unpublishedAt *time.Time
+	action := moderation.ActionRepublishEvent
+	if !published {
+		now := time.Now()
+		unpublishedAt = &now
+		action = moderation.ActionUnpublishEvent
+	}
+
+	if err := uc.eventRepo.SetUnpublished(ctx, eventID, unpublishedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	uc.record(ctx, adminID, action, moderation.TargetEvent, eventID, note)
+
+	return uc.getEvent(ctx, eventID)
+}
+
+// GetTransactions gets ticket transactions across all events, most recent first
+func (uc *Usecase) GetTransactions(ctx context.Context, filter *ticket.TransactionFilter) ([]ticket.TransactionWithDetails, error) {
+	filter.Limit = normalizeLimit(filter.Limit)
+	return uc.ticketRepo.GetTransactions(ctx, filter)
+}
+
+// CountTransactions counts ticket transactions across all events
+func (uc *Usecase) CountTransactions(ctx context.Context, filter *ticket.TransactionFilter) (int, error) {
+	return uc.ticketRepo.CountTransactions(ctx, filter)
+}
+
+// GetTransaction gets any ticket transaction with its ticket, event and buyer
+func (uc *Usecase) GetTransaction(ctx context.Context, transactionID uuid.UUID) (*ticket.TransactionWithDetails, error) {
+	t, err := uc.ticketRepo.GetTransactionByID(ctx, transactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// RefundTransaction refunds the ticket paid by a transaction, regardless of who holds it,
+// whether it was used or whether the event already started
+func (uc *Usecase) RefundTransaction(ctx context.Context, adminID, transactionID uuid.UUID, note *string) (*ticket.Ticket, error) {
+	txn, err := uc.GetTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if txn.Status != ticket.TransactionSuccess {
+		return nil, ErrNotRefundable
+	}
+
+	t, err := uc.ticketUsecase.ForceRefund(ctx, txn.TicketID)
+	if err != nil {
+		switch err {
+		case ticketUsecase.ErrCannotRefund:
+			return nil, ErrNotRefundable
+		case ticketUsecase.ErrTicketNotFound:
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	uc.record(ctx, adminID, moderation.ActionForceRefund, moderation.TargetTransaction, transactionID, note)
+
+	return t, nil
+}
+
+// UpdateCommunity updates any community on behalf of its owner
+func (uc *Usecase) UpdateCommunity(ctx context.Context, adminID, communityID uuid.UUID, req *community.UpdateCommunityRequest) (*community.Community, error) {
+	comm, err := uc.getCommunity(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	comm.ApplyUpdate(req)
+	if err := uc.communityRepo.Update(ctx, comm); err != nil {
+		return nil, err
+	}
+
+	uc.record(ctx, adminID, moderation.ActionUpdateCommunity, moderation.TargetCommunity, communityID, nil)
+
+	return comm, nil
+}
+
+// DeleteCommunity deletes any community
+func (uc *Usecase) DeleteCommunity(ctx context.Context, adminID, communityID uuid.UUID, note *string) error {
+	if _, err := uc.getCommunity(ctx, communityID); err != nil {
+		return err
+	}
+
+	if err := uc.communityRepo.Delete(ctx, communityID); err != nil {
+		return err
+	}
+
+	uc.record(ctx, adminID, moderation.ActionDeleteCommunity, moderation.TargetCommunity, communityID, note)
+
+	return nil
+}
+
+// TransferCommunity makes another user the owner of a community; the previous owner stays on as an admin
+func (uc *Usecase) TransferCommunity(ctx context.Context, adminID, communityID uuid.UUID, req *community.TransferCommunityRequest) (*community.Community, error) {
+	comm, err := uc.getCommunity(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if comm.CreatorID == req.NewOwnerID {
+		return nil, ErrAlreadyOwner
+	}
+
+	newOwner, err := uc.userRepo.GetByID(ctx, req.NewOwnerID)
+	if err != nil {
+		return nil, ErrNewOwnerNotFound
+	}
+	if newOwner.IsSuspended(time.Now()) {
+		return nil, ErrNewOwnerSuspended
+	}
+
+	if err := uc.communityRepo.TransferOwnership(ctx, communityID, req.NewOwnerID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCommunityNotFound
+		}
+		return nil, err
+	}
+	comm.CreatorID = req.NewOwnerID
+
+	note := "new owner: " + req.NewOwnerID.String()
+	uc.record(ctx, adminID, moderation.ActionTransferCommunity, moderation.TargetCommunity, communityID, &note)
+
+	return comm, nil
+}
+
+// getEvent gets an event after an admin change
+func (uc *Usecase) getEvent(ctx context.Context, eventID uuid.UUID) (*event.Event, error) {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+	return evt, nil
+}
+
+// getCommunity gets a community, mapping a missing one to ErrCommunityNotFound
+func (uc *Usecase) getCommunity(ctx context.Context, communityID uuid.UUID) (*community.Community, error) {
+	comm, err := uc.communityRepo.GetByID(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if comm == nil {
+		return nil, ErrCommunityNotFound
+	}
+	return comm, nil
+}
+
+// record writes an admin action to the audit trail
+func (uc *Usecase) record(ctx context.Context, adminID uuid.UUID, actionType moderation.ActionType, targetType moderation.TargetType, targetID uuid.UUID, note *string) {
+	action := &moderation.Action{
+		ID:         uuid.New(),
+		ActorID:    &adminID,
+		Action:     actionType,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Note:       note,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := uc.moderationRepo.RecordAction(ctx, action); err != nil {
+		// Log error but don't fail, the change is already made
+	}
+}
+
+func normalizeLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
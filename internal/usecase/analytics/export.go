@@ -0,0 +1,105 @@
+package analytics
+
+import (
+	"context"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/ticket"
+	eventUsecase "github.com/anigmaa/backend/internal/usecase/event"
+	"github.com/anigmaa/backend/pkg/spreadsheet"
+	"github.com/google/uuid"
+)
+
+// ExportEventAttendees writes the attendee list of an event with check-in status to w
+// Names and emails are masked unless the attendee agreed to share them with hosts
+// Authorization happens before anything is written, so a returned ErrEventNotFound or
+// ErrUnauthorized means w is still untouched
+func (uc *Usecase) ExportEventAttendees(ctx context.Context, eventID, userID uuid.UUID, w spreadsheet.Writer) error {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return ErrEventNotFound
+	}
+
+	// Host and analytics staff only: door staff need the check-in list, not a download of everyone's contact details
+	allowed, err := eventUsecase.HasPermission(ctx, uc.eventRepo, evt, userID, event.PermissionViewAnalytics)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrUnauthorized
+	}
+
+	if err := w.WriteHeader(
+		"Ticket ID", "Attendance Code", "Name", "Email", "Tier", "Price Paid", "Status",
+		"Purchased At", "Checked In", "Checked In At", "Check-in Device",
+	); err != nil {
+		return err
+	}
+
+	return uc.ticketRepo.StreamAttendees(ctx, eventID, func(row *ticket.AttendeeExportRow) error {
+		name, email := maskContact(row.UserName, row.UserEmail, row.ContactShared)
+		return w.WriteRow(
+			row.TicketID.String(), row.AttendanceCode, name, email, row.TierName, row.PricePaid, string(row.Status),
+			row.PurchasedAt, row.IsCheckedIn, row.CheckedInAt, row.CheckedInDeviceID,
+		)
+	})
+}
+
+// ExportEventTransactions writes the payment transactions of an event to w
+// statusFilter limits the export to one transaction status when not empty
+func (uc *Usecase) ExportEventTransactions(ctx context.Context, eventID, hostID uuid.UUID, statusFilter string, w spreadsheet.Writer) error {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return ErrEventNotFound
+	}
+
+	if err := uc.authorize(ctx, evt, hostID); err != nil {
+		return err
+	}
+
+	if err := w.WriteHeader(
+		"Transaction ID", "Ticket ID", "Buyer Name", "Buyer Email", "Tier", "Amount", "Promo Code",
+		"Discount", "Payment Method", "Status", "Purchased At", "Completed At", "Checked In",
+	); err != nil {
+		return err
+	}
+
+	return uc.ticketRepo.StreamTransactions(ctx, eventID, statusFilter, func(row *ticket.TransactionExportRow) error {
+		name, email := maskContact(row.UserName, row.UserEmail, row.ContactShared)
+		return w.WriteRow(
+			row.TransactionID, row.TicketID.String(), name, email, row.TierName, row.Amount, row.PromoCode,
+			row.DiscountAmount, row.PaymentMethod, string(row.Status), row.CreatedAt, row.CompletedAt, row.IsCheckedIn,
+		)
+	})
+}
+
+// ExportHostEventRevenue writes revenue per event for all events of a host to w
+// statusFilter limits the export to one event status when not empty
+func (uc *Usecase) ExportHostEventRevenue(ctx context.Context, hostID uuid.UUID, statusFilter string, w spreadsheet.Writer) error {
+	if err := w.WriteHeader(
+		"Event ID", "Title", "Category", "Status", "Start Time", "Capacity", "Tickets Sold", "Checked In",
+		"Revenue", "Refunded", "Discounts", "Net Revenue", "Fill Rate (%)",
+	); err != nil {
+		return err
+	}
+
+	return uc.ticketRepo.StreamHostEventRevenue(ctx, hostID, statusFilter, func(row *ticket.EventRevenueRow) error {
+		var fillRate float64
+		if row.MaxAttendees > 0 {
+			fillRate = float64(row.TicketsSold) / float64(row.MaxAttendees) * 100
+		}
+		return w.WriteRow(
+			row.EventID.String(), row.Title, row.Category, row.Status, row.StartTime, row.MaxAttendees,
+			row.TicketsSold, row.TicketsCheckedIn, row.Revenue, row.RefundedAmount, row.Discounts,
+			row.Revenue-row.RefundedAmount, fillRate,
+		)
+	})
+}
+
+// maskContact anonymizes an attendee's name and email unless they consented to share them
+func maskContact(name, email string, shared bool) (string, string) {
+	if shared {
+		return name, email
+	}
+	return anonymizeName(name), anonymizeEmail(email)
+}
@@ -2,31 +2,66 @@ package analytics
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/interaction"
+	"github.com/anigmaa/backend/internal/domain/promo"
 	"github.com/anigmaa/backend/internal/domain/ticket"
+	"github.com/anigmaa/backend/internal/domain/waitlist"
+	"github.com/anigmaa/backend/internal/repository/redis"
+	eventUsecase "github.com/anigmaa/backend/internal/usecase/event"
 	"github.com/google/uuid"
 )
 
 var (
-	ErrEventNotFound = errors.New("event not found")
-	ErrUnauthorized  = errors.New("unauthorized")
+	ErrEventNotFound   = errors.New("event not found")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrInvalidBucket   = errors.New("invalid time bucket")
+	ErrInvalidTimezone = errors.New("invalid timezone")
+)
+
+// completedEventCacheTTL is how long analytics of a completed event are cached
+const completedEventCacheTTL = 24 * time.Hour
+
+// eventCacheVersionKey holds a token that is part of every cached analytics key of an event,
+// so replacing it drops the entries of all buckets and timezones at once
+func eventCacheVersionKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("analytics:event:%s:version", eventID)
+}
+
+// TimeBucket is the size of the time buckets sales are grouped into
+type TimeBucket string
+
+const (
+	BucketHour TimeBucket = "hour"
+	BucketDay  TimeBucket = "day"
+	BucketWeek TimeBucket = "week" // Weeks start on Monday
 )
 
 // Usecase represents the analytics use case
 type Usecase struct {
-	eventRepo  event.Repository
-	ticketRepo ticket.Repository
+	eventRepo       event.Repository
+	ticketRepo      ticket.Repository
+	waitlistRepo    waitlist.Repository
+	promoRepo       promo.Repository
+	interactionRepo interaction.Repository
+	cache           redis.CacheRepository
 }
 
 // NewUsecase creates a new analytics usecase
-func NewUsecase(eventRepo event.Repository, ticketRepo ticket.Repository) *Usecase {
+func NewUsecase(eventRepo event.Repository, ticketRepo ticket.Repository, waitlistRepo waitlist.Repository, promoRepo promo.Repository, interactionRepo interaction.Repository, cache redis.CacheRepository) *Usecase {
 	return &Usecase{
-		eventRepo:  eventRepo,
-		ticketRepo: ticketRepo,
+		eventRepo:       eventRepo,
+		ticketRepo:      ticketRepo,
+		waitlistRepo:    waitlistRepo,
+		promoRepo:       promoRepo,
+		interactionRepo: interactionRepo,
+		cache:           cache,
 	}
 }
 
@@ -42,12 +77,28 @@ type EventAnalytics struct {
 	MaxAttendees     int                  `json:"max_attendees"`
 	TicketsSold      int                  `json:"tickets_sold"`
 	TicketsCheckedIn int                  `json:"tickets_checked_in"`
+	WaitlistCount    int                  `json:"waitlist_count"` // Users currently waiting for a seat
 	Revenue          RevenueStats         `json:"revenue"`
 	Transactions     TransactionStats     `json:"transactions"`
 	AttendanceRate   float64              `json:"attendance_rate"` // Percentage of tickets sold vs max attendees
 	CheckInRate      float64              `json:"check_in_rate"`   // Percentage of checked in vs tickets sold
 	PaymentMethods   []PaymentMethodStats `json:"payment_methods"`
-	TimelineStats    []TimelineStats      `json:"timeline_stats"` // Sales over time (daily)
+	Tiers            []TierStats          `json:"tiers"`          // Sales per ticket tier
+	Funnel           FunnelStats          `json:"funnel"`         // View -> ticket page -> purchase -> check-in
+	Bucket           TimeBucket           `json:"bucket"`         // Size of the timeline buckets
+	Timezone         string               `json:"timezone"`       // Timezone the timeline buckets follow
+	TimelineStats    []TimelineStats      `json:"timeline_stats"` // Sales over time, oldest bucket first
+	Shares           []ShareStats         `json:"shares"`         // Shares of posts about the event per platform
+}
+
+// FunnelStats represents the conversion funnel of an event
+// Each rate is the percentage of people from the previous step who reached the next one
+type FunnelStats struct {
+	event.Funnel
+	TicketPageRate float64 `json:"ticket_page_rate"` // Viewers who opened the ticket page
+	PurchaseRate   float64 `json:"purchase_rate"`    // Ticket page visitors who bought
+	CheckInRate    float64 `json:"check_in_rate"`    // Purchasers who checked in
+	ConversionRate float64 `json:"conversion_rate"`  // Viewers who bought
 }
 
 // RevenueStats represents revenue statistics
@@ -76,9 +127,27 @@ type PaymentMethodStats struct {
 	Percentage  float64 `json:"percentage"` // Percentage of total transactions
 }
 
+// ShareStats represents how often posts about an event were shared on a platform
+type ShareStats struct {
+	Platform   string  `json:"platform"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"` // Percentage of all shares
+}
+
+// TierStats represents sales breakdown for a ticket tier
+type TierStats struct {
+	TierID          uuid.UUID `json:"tier_id"`
+	Name            string    `json:"name"`
+	Price           float64   `json:"price"`
+	Quota           int       `json:"quota"`
+	TicketsSold     int       `json:"tickets_sold"` // Active and pending tickets
+	Revenue         float64   `json:"revenue"`
+	RefundedRevenue float64   `json:"refunded_revenue"`
+}
+
 // TimelineStats represents sales statistics over time
 type TimelineStats struct {
-	Date         time.Time `json:"date"`
+	Date         time.Time `json:"date"` // Start of the hour, day or week
 	TicketsSold  int       `json:"tickets_sold"`
 	Revenue      float64   `json:"revenue"`
 	Transactions int       `json:"transactions"`
@@ -86,17 +155,19 @@ type TimelineStats struct {
 
 // TransactionDetail represents detailed transaction information
 type TransactionDetail struct {
-	TransactionID string     `json:"transaction_id"`
-	TicketID      uuid.UUID  `json:"ticket_id"`
-	BuyerName     string     `json:"buyer_name"`  // Anonymized: "John D."
-	BuyerEmail    string     `json:"buyer_email"` // Anonymized: "j***@example.com"
-	Amount        float64    `json:"amount"`
-	PaymentMethod string     `json:"payment_method"`
-	Status        string     `json:"status"`
-	PurchasedAt   time.Time  `json:"purchased_at"`
-	CompletedAt   *time.Time `json:"completed_at"`
-	IsCheckedIn   bool       `json:"is_checked_in"`
-	CheckedInAt   *time.Time `json:"checked_in_at"`
+	TransactionID  string     `json:"transaction_id"`
+	TicketID       uuid.UUID  `json:"ticket_id"`
+	BuyerName      string     `json:"buyer_name"`  // Anonymized: "John D."
+	BuyerEmail     string     `json:"buyer_email"` // Anonymized: "j***@example.com"
+	Amount         float64    `json:"amount"`
+	PromoCode      *string    `json:"promo_code,omitempty"` // Code redeemed for this ticket
+	DiscountAmount float64    `json:"discount_amount"`
+	PaymentMethod  string     `json:"payment_method"`
+	Status         string     `json:"status"`
+	PurchasedAt    time.Time  `json:"purchased_at"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	IsCheckedIn    bool       `json:"is_checked_in"`
+	CheckedInAt    *time.Time `json:"checked_in_at"`
 }
 
 // HostRevenueSummary represents overall revenue summary for a host
@@ -108,11 +179,44 @@ type HostRevenueSummary struct {
 	TotalTicketsSold   int                  `json:"total_tickets_sold"`
 	TotalRevenue       float64              `json:"total_revenue"`
 	TotalRefunded      float64              `json:"total_refunded"`
+	TotalDiscounts     float64              `json:"total_discounts"` // Promo code discounts on paid tickets
 	NetRevenue         float64              `json:"net_revenue"`
 	AverageTicketPrice float64              `json:"average_ticket_price"`
 	TopEvent           *EventRevenueSummary `json:"top_event"` // Highest revenue event
 	RevenueByMonth     []MonthlyRevenue     `json:"revenue_by_month"`
 	RevenueByCategory  []CategoryRevenue    `json:"revenue_by_category"`
+	PromoCodes         []PromoCodeStats     `json:"promo_codes"`
+	Comparison         *PeriodComparison    `json:"comparison,omitempty"` // Only for date-bounded periods
+}
+
+// PromoCodeStats represents redemptions of a promo code on paid tickets
+type PromoCodeStats struct {
+	PromoCodeID   uuid.UUID `json:"promo_code_id"`
+	Code          string    `json:"code"`
+	Redemptions   int       `json:"redemptions"`
+	TotalDiscount float64   `json:"total_discount"`
+}
+
+// RevenueTotals represents the headline revenue numbers of a period
+type RevenueTotals struct {
+	TotalEvents      int     `json:"total_events"`
+	TotalTicketsSold int     `json:"total_tickets_sold"`
+	TotalRevenue     float64 `json:"total_revenue"`
+	TotalRefunded    float64 `json:"total_refunded"`
+	TotalDiscounts   float64 `json:"total_discounts"`
+	NetRevenue       float64 `json:"net_revenue"`
+}
+
+// PeriodComparison compares a revenue summary with the period before it
+// Changes are percentages and nil when the previous period had nothing to compare with
+type PeriodComparison struct {
+	PreviousStart     time.Time     `json:"previous_start"`
+	PreviousEnd       time.Time     `json:"previous_end"`
+	Previous          RevenueTotals `json:"previous"`
+	EventsChange      *float64      `json:"events_change"`
+	TicketsSoldChange *float64      `json:"tickets_sold_change"`
+	RevenueChange     *float64      `json:"revenue_change"`
+	NetRevenueChange  *float64      `json:"net_revenue_change"`
 }
 
 // EventRevenueSummary represents summary of an event with revenue
@@ -150,21 +254,48 @@ type CategoryRevenue struct {
 }
 
 // GetEventAnalytics retrieves comprehensive analytics for an event
-func (uc *Usecase) GetEventAnalytics(ctx context.Context, eventID, hostID uuid.UUID) (*EventAnalytics, error) {
-	// Get event and verify host ownership
+// Sales are grouped into hour, day or week buckets in the given IANA timezone
+// Analytics of completed events are cached since their numbers rarely move any more,
+// refunds made after the event drop the cached entries through InvalidateEventAnalytics
+func (uc *Usecase) GetEventAnalytics(ctx context.Context, eventID, hostID uuid.UUID, bucket TimeBucket, timezone string) (*EventAnalytics, error) {
+	if bucket == "" {
+		bucket = BucketDay
+	}
+	if !bucket.IsValid() {
+		return nil, ErrInvalidBucket
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	// Get event and verify the user is the host or may view analytics
 	evt, err := uc.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return nil, ErrEventNotFound
 	}
 
-	if evt.HostID != hostID {
-		return nil, ErrUnauthorized
+	if err := uc.authorize(ctx, evt, hostID); err != nil {
+		return nil, err
 	}
 
-	// Get all tickets for the event
-	tickets, err := uc.ticketRepo.GetByEventID(ctx, eventID)
-	if err != nil {
-		return nil, err
+	// Serve completed events from cache
+	var cacheKey string
+	cacheable := evt.Status == event.StatusCompleted
+	if cacheable {
+		// No version yet until the event's analytics are first invalidated
+		version, _ := uc.cache.Get(ctx, eventCacheVersionKey(eventID))
+		cacheKey = fmt.Sprintf("analytics:event:%s:%s:%s:%s", eventID, version, bucket, timezone)
+
+		if cached, err := uc.cache.Get(ctx, cacheKey); err == nil {
+			var analytics EventAnalytics
+			if err := json.Unmarshal([]byte(cached), &analytics); err == nil {
+				return &analytics, nil
+			}
+		}
 	}
 
 	// Initialize analytics
@@ -181,70 +312,139 @@ func (uc *Usecase) GetEventAnalytics(ctx context.Context, eventID, hostID uuid.U
 		Revenue:        RevenueStats{},
 		Transactions:   TransactionStats{},
 		PaymentMethods: []PaymentMethodStats{},
+		Tiers:          []TierStats{},
+		Bucket:         bucket,
+		Timezone:       timezone,
 		TimelineStats:  []TimelineStats{},
+		Shares:         []ShareStats{},
 	}
 
-	// Calculate statistics
-	var checkedInCount int
-	paymentMethodMap := make(map[string]*PaymentMethodStats)
-	timelineMap := make(map[string]*TimelineStats)
+	// Get ticket tiers for the per-tier breakdown
+	tiers, err := uc.ticketRepo.GetTiersByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, tkt := range tickets {
-		if tkt.IsCheckedIn {
-			checkedInCount++
+	tierMap := make(map[uuid.UUID]*TierStats)
+	for _, t := range tiers {
+		tierMap[t.ID] = &TierStats{
+			TierID:      t.ID,
+			Name:        t.Name,
+			Price:       t.Price,
+			Quota:       t.Quota,
+			TicketsSold: t.Sold,
 		}
+	}
 
-		// Get transactions for this ticket
-		transactions, err := uc.ticketRepo.GetTransactionsByTicketID(ctx, tkt.ID)
-		if err != nil {
-			continue
+	// Track tier revenue
+	tierRevenue, err := uc.ticketRepo.GetTierRevenue(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tr := range tierRevenue {
+		if ts, exists := tierMap[tr.TierID]; exists {
+			ts.Revenue = tr.Revenue
+			ts.RefundedRevenue = tr.RefundedRevenue
 		}
+	}
 
-		for _, txn := range transactions {
-			analytics.Transactions.TotalTransactions++
-
-			switch txn.Status {
-			case ticket.TransactionSuccess:
-				analytics.Transactions.SuccessfulTransactions++
-				analytics.Revenue.TotalRevenue += txn.Amount
-			case ticket.TransactionPending:
-				analytics.Transactions.PendingTransactions++
-				analytics.Revenue.PendingRevenue += txn.Amount
-			case ticket.TransactionFailed:
-				analytics.Transactions.FailedTransactions++
-			case ticket.TransactionRefunded:
-				analytics.Transactions.RefundedTransactions++
-				analytics.Revenue.RefundedRevenue += txn.Amount
-			}
-
-			// Track payment methods (only for successful transactions)
-			if txn.Status == ticket.TransactionSuccess {
-				if _, exists := paymentMethodMap[txn.PaymentMethod]; !exists {
-					paymentMethodMap[txn.PaymentMethod] = &PaymentMethodStats{
-						Method: txn.PaymentMethod,
-					}
-				}
-				paymentMethodMap[txn.PaymentMethod].Count++
-				paymentMethodMap[txn.PaymentMethod].TotalAmount += txn.Amount
-			}
+	// Transaction counts and revenue per status
+	totals, err := uc.ticketRepo.GetTransactionTotals(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range totals {
+		analytics.Transactions.TotalTransactions += t.Count
+
+		switch t.Status {
+		case ticket.TransactionSuccess:
+			analytics.Transactions.SuccessfulTransactions = t.Count
+			analytics.Revenue.TotalRevenue = t.Amount
+		case ticket.TransactionPending:
+			analytics.Transactions.PendingTransactions = t.Count
+			analytics.Revenue.PendingRevenue = t.Amount
+		case ticket.TransactionFailed:
+			analytics.Transactions.FailedTransactions = t.Count
+		case ticket.TransactionRefunded:
+			analytics.Transactions.RefundedTransactions = t.Count
+			analytics.Revenue.RefundedRevenue = t.Amount
+		}
+	}
 
-			// Track timeline (group by date)
-			if txn.CompletedAt != nil && txn.Status == ticket.TransactionSuccess {
-				dateKey := txn.CompletedAt.Format("2006-01-02")
-				if _, exists := timelineMap[dateKey]; !exists {
-					timelineMap[dateKey] = &TimelineStats{
-						Date: time.Date(txn.CompletedAt.Year(), txn.CompletedAt.Month(), txn.CompletedAt.Day(), 0, 0, 0, 0, txn.CompletedAt.Location()),
-					}
-				}
-				timelineMap[dateKey].TicketsSold++
-				timelineMap[dateKey].Revenue += txn.Amount
-				timelineMap[dateKey].Transactions++
-			}
+	// Track payment methods (only for successful transactions)
+	methods, err := uc.ticketRepo.GetPaymentMethodTotals(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	totalSuccessful := analytics.Transactions.SuccessfulTransactions
+	for _, m := range methods {
+		pm := PaymentMethodStats{
+			Method:      m.Method,
+			Count:       m.Count,
+			TotalAmount: m.Amount,
+		}
+		if totalSuccessful > 0 {
+			pm.Percentage = float64(pm.Count) / float64(totalSuccessful) * 100
 		}
+		analytics.PaymentMethods = append(analytics.PaymentMethods, pm)
 	}
 
+	// Sales timeline; bucket starts come back as wall-clock time in the requested timezone
+	buckets, err := uc.ticketRepo.GetSalesTimeline(ctx, eventID, string(bucket), timezone)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range buckets {
+		start := b.BucketStart
+		analytics.TimelineStats = append(analytics.TimelineStats, TimelineStats{
+			Date:         time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), 0, 0, 0, loc),
+			TicketsSold:  b.Transactions,
+			Revenue:      b.Revenue,
+			Transactions: b.Transactions,
+		})
+	}
+
+	checkedInCount, err := uc.ticketRepo.GetCheckedInCount(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
 	analytics.TicketsCheckedIn = checkedInCount
 
+	// Conversion funnel
+	funnel, err := uc.eventRepo.GetFunnel(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	analytics.Funnel = FunnelStats{
+		Funnel:         *funnel,
+		TicketPageRate: percentage(funnel.TicketPageViews, funnel.Views),
+		PurchaseRate:   percentage(funnel.Purchasers, funnel.TicketPageViews),
+		CheckInRate:    percentage(funnel.CheckedIn, funnel.Purchasers),
+		ConversionRate: percentage(funnel.Purchasers, funnel.Views),
+	}
+
+	// Shares of posts the event is attached to
+	shares, err := uc.interactionRepo.GetShareCountsByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	totalShares := 0
+	for _, s := range shares {
+		totalShares += s.Count
+	}
+	for _, s := range shares {
+		analytics.Shares = append(analytics.Shares, ShareStats{
+			Platform:   s.Platform,
+			Count:      s.Count,
+			Percentage: percentage(s.Count, totalShares),
+		})
+	}
+
+	// Waitlist demand
+	if waitlistCount, err := uc.waitlistRepo.CountWaiting(ctx, eventID); err == nil {
+		analytics.WaitlistCount = waitlistCount
+	}
+
 	// Calculate rates
 	if evt.MaxAttendees > 0 {
 		analytics.AttendanceRate = float64(evt.TicketsSold) / float64(evt.MaxAttendees) * 100
@@ -257,37 +457,45 @@ func (uc *Usecase) GetEventAnalytics(ctx context.Context, eventID, hostID uuid.U
 	analytics.Revenue.NetRevenue = analytics.Revenue.TotalRevenue - analytics.Revenue.RefundedRevenue
 
 	// Calculate expected revenue
-	if evt.Price != nil && evt.MaxAttendees > 0 {
+	if len(tiers) > 0 {
+		for _, t := range tiers {
+			analytics.Revenue.ExpectedRevenue += t.Price * float64(t.Quota)
+		}
+	} else if evt.Price != nil && evt.MaxAttendees > 0 {
 		analytics.Revenue.ExpectedRevenue = *evt.Price * float64(evt.MaxAttendees)
 	}
 
-	// Convert payment method map to slice and calculate percentages
-	totalSuccessful := analytics.Transactions.SuccessfulTransactions
-	for _, pm := range paymentMethodMap {
-		if totalSuccessful > 0 {
-			pm.Percentage = float64(pm.Count) / float64(totalSuccessful) * 100
-		}
-		analytics.PaymentMethods = append(analytics.PaymentMethods, *pm)
+	// Keep tiers in their display order
+	for _, t := range tiers {
+		analytics.Tiers = append(analytics.Tiers, *tierMap[t.ID])
 	}
 
-	// Convert timeline map to slice and sort
-	for _, tl := range timelineMap {
-		analytics.TimelineStats = append(analytics.TimelineStats, *tl)
+	if cacheable {
+		if data, err := json.Marshal(analytics); err == nil {
+			// Log error but don't fail
+			_ = uc.cache.Set(ctx, cacheKey, data, completedEventCacheTTL)
+		}
 	}
 
 	return analytics, nil
 }
 
+// InvalidateEventAnalytics drops the cached analytics of an event, e.g. after a refund
+// The version token outlives every entry cached under the previous one
+func (uc *Usecase) InvalidateEventAnalytics(ctx context.Context, eventID uuid.UUID) error {
+	return uc.cache.Set(ctx, eventCacheVersionKey(eventID), uuid.NewString(), completedEventCacheTTL)
+}
+
 // GetEventTransactions retrieves detailed transaction list for an event
 func (uc *Usecase) GetEventTransactions(ctx context.Context, eventID, hostID uuid.UUID, statusFilter string, limit, offset int) ([]TransactionDetail, error) {
-	// Get event and verify host ownership
+	// Get event and verify the user is the host or may view analytics
 	evt, err := uc.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return nil, ErrEventNotFound
 	}
 
-	if evt.HostID != hostID {
-		return nil, ErrUnauthorized
+	if err := uc.authorize(ctx, evt, hostID); err != nil {
+		return nil, err
 	}
 
 	// Get all tickets for the event
@@ -296,6 +504,17 @@ func (uc *Usecase) GetEventTransactions(ctx context.Context, eventID, hostID uui
 		return nil, err
 	}
 
+	// Get promo code redemptions keyed by ticket
+	redemptions, err := uc.promoRepo.GetRedemptionsByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	redemptionMap := make(map[uuid.UUID]promo.RedemptionWithCode)
+	for _, r := range redemptions {
+		redemptionMap[r.TicketID] = r
+	}
+
 	var transactions []TransactionDetail
 
 	for _, tkt := range tickets {
@@ -328,6 +547,12 @@ func (uc *Usecase) GetEventTransactions(ctx context.Context, eventID, hostID uui
 				CheckedInAt:   tkt.CheckedInAt,
 			}
 
+			if r, ok := redemptionMap[tkt.ID]; ok {
+				code := r.Code
+				detail.PromoCode = &code
+				detail.DiscountAmount = r.DiscountAmount
+			}
+
 			transactions = append(transactions, detail)
 		}
 	}
@@ -347,14 +572,14 @@ func (uc *Usecase) GetEventTransactions(ctx context.Context, eventID, hostID uui
 
 // CountEventTransactions counts total transactions for an event with optional status filter
 func (uc *Usecase) CountEventTransactions(ctx context.Context, eventID, hostID uuid.UUID, statusFilter string) (int, error) {
-	// Get event and verify host ownership
+	// Get event and verify the user is the host or may view analytics
 	evt, err := uc.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
 		return 0, ErrEventNotFound
 	}
 
-	if evt.HostID != hostID {
-		return 0, ErrUnauthorized
+	if err := uc.authorize(ctx, evt, hostID); err != nil {
+		return 0, err
 	}
 
 	// Get all tickets for the event
@@ -385,17 +610,27 @@ func (uc *Usecase) CountEventTransactions(ctx context.Context, eventID, hostID u
 }
 
 // GetHostRevenueSummary retrieves comprehensive revenue summary for a host
-func (uc *Usecase) GetHostRevenueSummary(ctx context.Context, hostID uuid.UUID, startDate, endDate *time.Time) (*HostRevenueSummary, error) {
-	// Get all events by host
-	events, err := uc.eventRepo.GetByHostID(ctx, hostID)
+// startDate and endDate filter events by start time. When previousStart and previousEnd
+// are given the summary is compared with that earlier period.
+func (uc *Usecase) GetHostRevenueSummary(ctx context.Context, hostID uuid.UUID, startDate, endDate, previousStart, previousEnd *time.Time) (*HostRevenueSummary, error) {
+	// Revenue per event, aggregated in SQL
+	rows, err := uc.ticketRepo.GetHostEventRevenue(ctx, hostID, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 
+	totals := sumRevenue(rows)
 	summary := &HostRevenueSummary{
 		HostID:            hostID,
+		TotalEvents:       totals.TotalEvents,
+		TotalTicketsSold:  totals.TotalTicketsSold,
+		TotalRevenue:      totals.TotalRevenue,
+		TotalRefunded:     totals.TotalRefunded,
+		TotalDiscounts:    totals.TotalDiscounts,
+		NetRevenue:        totals.NetRevenue,
 		RevenueByMonth:    []MonthlyRevenue{},
 		RevenueByCategory: []CategoryRevenue{},
+		PromoCodes:        []PromoCodeStats{},
 	}
 
 	monthlyMap := make(map[string]*MonthlyRevenue)
@@ -403,102 +638,47 @@ func (uc *Usecase) GetHostRevenueSummary(ctx context.Context, hostID uuid.UUID,
 	var topEvent *EventRevenueSummary
 	var maxRevenue float64
 
-	for _, evt := range events {
-		// Apply date filter if provided
-		if startDate != nil && evt.StartTime.Before(*startDate) {
-			continue
-		}
-		if endDate != nil && evt.StartTime.After(*endDate) {
-			continue
-		}
-
-		summary.TotalEvents++
+	for i := range rows {
+		row := &rows[i]
 
 		// Count event status
-		switch evt.Status {
+		switch event.EventStatus(row.Status) {
 		case event.StatusCompleted:
 			summary.CompletedEvents++
 		case event.StatusUpcoming, event.StatusOngoing:
 			summary.UpcomingEvents++
 		}
 
-		summary.TotalTicketsSold += evt.TicketsSold
-
-		// Get tickets and calculate revenue for this event
-		tickets, err := uc.ticketRepo.GetByEventID(ctx, evt.ID)
-		if err != nil {
-			continue
-		}
-
-		var eventRevenue float64
-		var eventRefunded float64
-
-		for _, tkt := range tickets {
-			transactions, err := uc.ticketRepo.GetTransactionsByTicketID(ctx, tkt.ID)
-			if err != nil {
-				continue
-			}
-
-			for _, txn := range transactions {
-				if txn.Status == ticket.TransactionSuccess {
-					eventRevenue += txn.Amount
-				} else if txn.Status == ticket.TransactionRefunded {
-					eventRefunded += txn.Amount
-				}
-			}
-		}
-
-		summary.TotalRevenue += eventRevenue
-		summary.TotalRefunded += eventRefunded
-
 		// Track top event
-		if eventRevenue > maxRevenue {
-			maxRevenue = eventRevenue
-			topEvent = &EventRevenueSummary{
-				EventID:        evt.ID,
-				Title:          evt.Title,
-				Category:       string(evt.Category),
-				Status:         string(evt.Status),
-				StartTime:      evt.StartTime,
-				Price:          evt.Price,
-				IsFree:         evt.IsFree,
-				MaxAttendees:   evt.MaxAttendees,
-				TicketsSold:    evt.TicketsSold,
-				Revenue:        eventRevenue,
-				RefundedAmount: eventRefunded,
-				NetRevenue:     eventRevenue - eventRefunded,
-			}
-			if evt.MaxAttendees > 0 {
-				topEvent.FillRate = float64(evt.TicketsSold) / float64(evt.MaxAttendees) * 100
-			}
+		if row.Revenue > maxRevenue {
+			maxRevenue = row.Revenue
+			top := toEventRevenueSummary(row)
+			topEvent = &top
 		}
 
 		// Track monthly revenue
-		monthKey := evt.StartTime.Format("2006-01")
+		monthKey := row.StartTime.Format("2006-01")
 		if _, exists := monthlyMap[monthKey]; !exists {
 			monthlyMap[monthKey] = &MonthlyRevenue{
-				Year:  evt.StartTime.Year(),
-				Month: int(evt.StartTime.Month()),
+				Year:  row.StartTime.Year(),
+				Month: int(row.StartTime.Month()),
 			}
 		}
 		monthlyMap[monthKey].EventsCount++
-		monthlyMap[monthKey].TicketsSold += evt.TicketsSold
-		monthlyMap[monthKey].Revenue += eventRevenue
+		monthlyMap[monthKey].TicketsSold += row.TicketsSold
+		monthlyMap[monthKey].Revenue += row.Revenue
 
 		// Track category revenue
-		categoryKey := string(evt.Category)
-		if _, exists := categoryMap[categoryKey]; !exists {
-			categoryMap[categoryKey] = &CategoryRevenue{
-				Category: categoryKey,
+		if _, exists := categoryMap[row.Category]; !exists {
+			categoryMap[row.Category] = &CategoryRevenue{
+				Category: row.Category,
 			}
 		}
-		categoryMap[categoryKey].EventsCount++
-		categoryMap[categoryKey].TicketsSold += evt.TicketsSold
-		categoryMap[categoryKey].Revenue += eventRevenue
+		categoryMap[row.Category].EventsCount++
+		categoryMap[row.Category].TicketsSold += row.TicketsSold
+		categoryMap[row.Category].Revenue += row.Revenue
 	}
 
-	summary.NetRevenue = summary.TotalRevenue - summary.TotalRefunded
-
 	// Calculate average ticket price
 	if summary.TotalTicketsSold > 0 {
 		summary.AverageTicketPrice = summary.TotalRevenue / float64(summary.TotalTicketsSold)
@@ -514,69 +694,59 @@ func (uc *Usecase) GetHostRevenueSummary(ctx context.Context, hostID uuid.UUID,
 		summary.RevenueByCategory = append(summary.RevenueByCategory, *cr)
 	}
 
+	// Tally promo code redemptions on tickets that are still paid
+	promoStats, err := uc.promoRepo.GetHostRedemptionStats(ctx, hostID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, ps := range promoStats {
+		summary.PromoCodes = append(summary.PromoCodes, PromoCodeStats{
+			PromoCodeID:   ps.PromoCodeID,
+			Code:          ps.Code,
+			Redemptions:   ps.Redemptions,
+			TotalDiscount: ps.TotalDiscount,
+		})
+	}
+
+	// Compare with the previous period
+	if previousStart != nil && previousEnd != nil {
+		previousRows, err := uc.ticketRepo.GetHostEventRevenue(ctx, hostID, previousStart, previousEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		previous := sumRevenue(previousRows)
+		summary.Comparison = &PeriodComparison{
+			PreviousStart:     *previousStart,
+			PreviousEnd:       *previousEnd,
+			Previous:          previous,
+			EventsChange:      percentChange(float64(totals.TotalEvents), float64(previous.TotalEvents)),
+			TicketsSoldChange: percentChange(float64(totals.TotalTicketsSold), float64(previous.TotalTicketsSold)),
+			RevenueChange:     percentChange(totals.TotalRevenue, previous.TotalRevenue),
+			NetRevenueChange:  percentChange(totals.NetRevenue, previous.NetRevenue),
+		}
+	}
+
 	return summary, nil
 }
 
 // GetHostEventsList retrieves list of events with revenue information
 func (uc *Usecase) GetHostEventsList(ctx context.Context, hostID uuid.UUID, statusFilter string, limit, offset int) ([]EventRevenueSummary, error) {
-	// Get all events by host
-	events, err := uc.eventRepo.GetByHostID(ctx, hostID)
+	// Revenue per event, aggregated in SQL
+	rows, err := uc.ticketRepo.GetHostEventRevenue(ctx, hostID, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var eventSummaries []EventRevenueSummary
 
-	for _, evt := range events {
+	for i := range rows {
 		// Apply status filter
-		if statusFilter != "" && string(evt.Status) != statusFilter {
-			continue
-		}
-
-		// Get tickets and calculate revenue for this event
-		tickets, err := uc.ticketRepo.GetByEventID(ctx, evt.ID)
-		if err != nil {
+		if statusFilter != "" && rows[i].Status != statusFilter {
 			continue
 		}
 
-		var eventRevenue float64
-		var eventRefunded float64
-
-		for _, tkt := range tickets {
-			transactions, err := uc.ticketRepo.GetTransactionsByTicketID(ctx, tkt.ID)
-			if err != nil {
-				continue
-			}
-
-			for _, txn := range transactions {
-				if txn.Status == ticket.TransactionSuccess {
-					eventRevenue += txn.Amount
-				} else if txn.Status == ticket.TransactionRefunded {
-					eventRefunded += txn.Amount
-				}
-			}
-		}
-
-		summary := EventRevenueSummary{
-			EventID:        evt.ID,
-			Title:          evt.Title,
-			Category:       string(evt.Category),
-			Status:         string(evt.Status),
-			StartTime:      evt.StartTime,
-			Price:          evt.Price,
-			IsFree:         evt.IsFree,
-			MaxAttendees:   evt.MaxAttendees,
-			TicketsSold:    evt.TicketsSold,
-			Revenue:        eventRevenue,
-			RefundedAmount: eventRefunded,
-			NetRevenue:     eventRevenue - eventRefunded,
-		}
-
-		if evt.MaxAttendees > 0 {
-			summary.FillRate = float64(evt.TicketsSold) / float64(evt.MaxAttendees) * 100
-		}
-
-		eventSummaries = append(eventSummaries, summary)
+		eventSummaries = append(eventSummaries, toEventRevenueSummary(&rows[i]))
 	}
 
 	// Apply pagination
@@ -613,15 +783,88 @@ func (uc *Usecase) CountHostEventsList(ctx context.Context, hostID uuid.UUID, st
 	return count, nil
 }
 
+// IsValid reports whether the bucket is one of the supported sizes
+func (b TimeBucket) IsValid() bool {
+	return b == BucketHour || b == BucketDay || b == BucketWeek
+}
+
+// toEventRevenueSummary converts an event revenue row to its API representation
+func toEventRevenueSummary(row *ticket.EventRevenueRow) EventRevenueSummary {
+	summary := EventRevenueSummary{
+		EventID:        row.EventID,
+		Title:          row.Title,
+		Category:       row.Category,
+		Status:         row.Status,
+		StartTime:      row.StartTime,
+		Price:          row.Price,
+		IsFree:         row.IsFree,
+		MaxAttendees:   row.MaxAttendees,
+		TicketsSold:    row.TicketsSold,
+		Revenue:        row.Revenue,
+		RefundedAmount: row.RefundedAmount,
+		NetRevenue:     row.Revenue - row.RefundedAmount,
+	}
+
+	if row.MaxAttendees > 0 {
+		summary.FillRate = float64(row.TicketsSold) / float64(row.MaxAttendees) * 100
+	}
+
+	return summary
+}
+
+// sumRevenue adds up the revenue of a set of events
+func sumRevenue(rows []ticket.EventRevenueRow) RevenueTotals {
+	var totals RevenueTotals
+	for _, row := range rows {
+		totals.TotalEvents++
+		totals.TotalTicketsSold += row.TicketsSold
+		totals.TotalRevenue += row.Revenue
+		totals.TotalRefunded += row.RefundedAmount
+		totals.TotalDiscounts += row.Discounts
+	}
+	totals.NetRevenue = totals.TotalRevenue - totals.TotalRefunded
+	return totals
+}
+
+// percentage returns part as a percentage of whole, 0 when whole is 0
+func percentage(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole) * 100
+}
+
+// percentChange returns the change from previous to current in percent
+// nil when previous is 0 since no meaningful percentage exists
+func percentChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := (current - previous) / previous * 100
+	return &change
+}
+
 // Helper functions for anonymization
 
+// authorize checks that a user is the host or holds the view_analytics permission on the event
+func (uc *Usecase) authorize(ctx context.Context, evt *event.Event, userID uuid.UUID) error {
+	allowed, err := eventUsecase.HasPermission(ctx, uc.eventRepo, evt, userID, event.PermissionViewAnalytics)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
 // anonymizeName anonymizes a full name (e.g., "John Doe" -> "John D.")
 func anonymizeName(name string) string {
-	parts := strings.Split(name, " ")
+	parts := strings.Fields(name)
 	if len(parts) < 2 {
 		return name
 	}
-	return parts[0] + " " + string(parts[1][0]) + "."
+	return parts[0] + " " + string([]rune(parts[1])[0]) + "."
 }
 
 // anonymizeEmail anonymizes an email address (e.g., "john@example.com" -> "j***@example.com")
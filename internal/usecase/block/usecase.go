@@ -0,0 +1,160 @@
+package block
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/block"
+	"github.com/anigmaa/backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound    = errors.New("user not found")
+	ErrCannotBlockSelf = errors.New("cannot block yourself")
+	ErrAlreadyBlocked  = errors.New("user is already blocked")
+	ErrNotBlocked      = errors.New("user is not blocked")
+	ErrCannotMuteSelf  = errors.New("cannot mute yourself")
+	ErrAlreadyMuted    = errors.New("user is already muted")
+	ErrNotMuted        = errors.New("user is not muted")
+)
+
+// Usecase handles block and mute business logic
+type Usecase struct {
+	blockRepo block.Repository
+	userRepo  user.Repository
+}
+
+// NewUsecase creates a new block usecase
+func NewUsecase(blockRepo block.Repository, userRepo user.Repository) *Usecase {
+	return &Usecase{
+		blockRepo: blockRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// Block blocks a user and removes the follows between both users
+func (uc *Usecase) Block(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	if blockerID == blockedID {
+		return ErrCannotBlockSelf
+	}
+
+	// Check if user to block exists
+	if _, err := uc.userRepo.GetByID(ctx, blockedID); err != nil {
+		return ErrUserNotFound
+	}
+
+	blocked, err := uc.blockRepo.HasBlocked(ctx, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrAlreadyBlocked
+	}
+
+	b := &block.Block{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	}
+	if err := uc.blockRepo.Block(ctx, b); err != nil {
+		return err
+	}
+
+	// Remove existing follows in both directions
+	for _, pair := range [][2]uuid.UUID{{blockerID, blockedID}, {blockedID, blockerID}} {
+		following, err := uc.userRepo.IsFollowing(ctx, pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+		if following {
+			if err := uc.userRepo.Unfollow(ctx, pair[0], pair[1]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Unblock removes a block. Follows removed by the block are not restored.
+func (uc *Usecase) Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	if err := uc.blockRepo.Unblock(ctx, blockerID, blockedID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotBlocked
+		}
+		return err
+	}
+	return nil
+}
+
+// GetBlocked gets the users a user blocked
+func (uc *Usecase) GetBlocked(ctx context.Context, userID uuid.UUID, limit, offset int) ([]block.ListedUser, error) {
+	limit = normalizeLimit(limit)
+	return uc.blockRepo.GetBlocked(ctx, userID, limit, offset)
+}
+
+// CountBlocked counts the users a user blocked
+func (uc *Usecase) CountBlocked(ctx context.Context, userID uuid.UUID) (int, error) {
+	return uc.blockRepo.CountBlocked(ctx, userID)
+}
+
+// Mute mutes a user
+func (uc *Usecase) Mute(ctx context.Context, muterID, mutedID uuid.UUID) error {
+	if muterID == mutedID {
+		return ErrCannotMuteSelf
+	}
+
+	// Check if user to mute exists
+	if _, err := uc.userRepo.GetByID(ctx, mutedID); err != nil {
+		return ErrUserNotFound
+	}
+
+	muted, err := uc.blockRepo.IsMuted(ctx, muterID, mutedID)
+	if err != nil {
+		return err
+	}
+	if muted {
+		return ErrAlreadyMuted
+	}
+
+	return uc.blockRepo.Mute(ctx, &block.Mute{
+		MuterID:   muterID,
+		MutedID:   mutedID,
+		CreatedAt: time.Now(),
+	})
+}
+
+// Unmute removes a mute
+func (uc *Usecase) Unmute(ctx context.Context, muterID, mutedID uuid.UUID) error {
+	if err := uc.blockRepo.Unmute(ctx, muterID, mutedID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotMuted
+		}
+		return err
+	}
+	return nil
+}
+
+// GetMuted gets the users a user muted
+func (uc *Usecase) GetMuted(ctx context.Context, userID uuid.UUID, limit, offset int) ([]block.ListedUser, error) {
+	limit = normalizeLimit(limit)
+	return uc.blockRepo.GetMuted(ctx, userID, limit, offset)
+}
+
+// CountMuted counts the users a user muted
+func (uc *Usecase) CountMuted(ctx context.Context, userID uuid.UUID) (int, error) {
+	return uc.blockRepo.CountMuted(ctx, userID)
+}
+
+func normalizeLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
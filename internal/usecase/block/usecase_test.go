@@ -0,0 +1,196 @@
+package block
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/anigmaa/backend/internal/domain/block"
+	"github.com/anigmaa/backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// fakeBlockRepo keeps blocks and mutes in memory
+type fakeBlockRepo struct {
+	block.Repository
+	blocks map[[2]uuid.UUID]bool
+	mutes  map[[2]uuid.UUID]bool
+}
+
+func newFakeBlockRepo() *fakeBlockRepo {
+	return &fakeBlockRepo{
+		blocks: map[[2]uuid.UUID]bool{},
+		mutes:  map[[2]uuid.UUID]bool{},
+	}
+}
+
+func (r *fakeBlockRepo) Block(ctx context.Context, b *block.Block) error {
+	r.blocks[[2]uuid.UUID{b.BlockerID, b.BlockedID}] = true
+	return nil
+}
+
+func (r *fakeBlockRepo) Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	key := [2]uuid.UUID{blockerID, blockedID}
+	if !r.blocks[key] {
+		return sql.ErrNoRows
+	}
+	delete(r.blocks, key)
+	return nil
+}
+
+func (r *fakeBlockRepo) HasBlocked(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	return r.blocks[[2]uuid.UUID{blockerID, blockedID}], nil
+}
+
+func (r *fakeBlockRepo) Mute(ctx context.Context, m *block.Mute) error {
+	r.mutes[[2]uuid.UUID{m.MuterID, m.MutedID}] = true
+	return nil
+}
+
+func (r *fakeBlockRepo) Unmute(ctx context.Context, muterID, mutedID uuid.UUID) error {
+	key := [2]uuid.UUID{muterID, mutedID}
+	if !r.mutes[key] {
+		return sql.ErrNoRows
+	}
+	delete(r.mutes, key)
+	return nil
+}
+
+func (r *fakeBlockRepo) IsMuted(ctx context.Context, muterID, mutedID uuid.UUID) (bool, error) {
+	return r.mutes[[2]uuid.UUID{muterID, mutedID}], nil
+}
+
+// fakeUserRepo knows a fixed set of users and their follows
+type fakeUserRepo struct {
+	user.Repository
+	users   map[uuid.UUID]bool
+	follows map[[2]uuid.UUID]bool
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	if !r.users[id] {
+		return nil, sql.ErrNoRows
+	}
+	return &user.User{ID: id}, nil
+}
+
+func (r *fakeUserRepo) IsFollowing(ctx context.Context, followerID, followingID uuid.UUID) (bool, error) {
+	return r.follows[[2]uuid.UUID{followerID, followingID}], nil
+}
+
+func (r *fakeUserRepo) Unfollow(ctx context.Context, followerID, followingID uuid.UUID) error {
+	delete(r.follows, [2]uuid.UUID{followerID, followingID})
+	return nil
+}
+
+func setup(t *testing.T) (*Usecase, *fakeBlockRepo, *fakeUserRepo, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	alice, bob := uuid.New(), uuid.New()
+	blockRepo := newFakeBlockRepo()
+	userRepo := &fakeUserRepo{
+		users:   map[uuid.UUID]bool{alice: true, bob: true},
+		follows: map[[2]uuid.UUID]bool{},
+	}
+	return NewUsecase(blockRepo, userRepo), blockRepo, userRepo, alice, bob
+}
+
+func TestBlockRemovesFollowsBothWays(t *testing.T) {
+	uc, blockRepo, userRepo, alice, bob := setup(t)
+	carol := uuid.New()
+	userRepo.users[carol] = true
+	userRepo.follows[[2]uuid.UUID{alice, bob}] = true
+	userRepo.follows[[2]uuid.UUID{bob, alice}] = true
+	userRepo.follows[[2]uuid.UUID{carol, bob}] = true
+
+	if err := uc.Block(context.Background(), alice, bob); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+
+	if !blockRepo.blocks[[2]uuid.UUID{alice, bob}] {
+		t.Error("block was not stored")
+	}
+	if userRepo.follows[[2]uuid.UUID{alice, bob}] || userRepo.follows[[2]uuid.UUID{bob, alice}] {
+		t.Error("follows between the blocked users were not removed")
+	}
+	if !userRepo.follows[[2]uuid.UUID{carol, bob}] {
+		t.Error("unrelated follow was removed")
+	}
+}
+
+func TestBlockErrors(t *testing.T) {
+	uc, _, _, alice, bob := setup(t)
+	ctx := context.Background()
+
+	if err := uc.Block(ctx, alice, alice); err != ErrCannotBlockSelf {
+		t.Errorf("blocking self: error = %v, want %v", err, ErrCannotBlockSelf)
+	}
+	if err := uc.Block(ctx, alice, uuid.New()); err != ErrUserNotFound {
+		t.Errorf("blocking unknown user: error = %v, want %v", err, ErrUserNotFound)
+	}
+	if err := uc.Block(ctx, alice, bob); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	if err := uc.Block(ctx, alice, bob); err != ErrAlreadyBlocked {
+		t.Errorf("blocking twice: error = %v, want %v", err, ErrAlreadyBlocked)
+	}
+
+	// The blocked user can still block back
+	if err := uc.Block(ctx, bob, alice); err != nil {
+		t.Errorf("blocking back: error = %v", err)
+	}
+}
+
+func TestUnblock(t *testing.T) {
+	uc, blockRepo, _, alice, bob := setup(t)
+	ctx := context.Background()
+
+	if err := uc.Unblock(ctx, alice, bob); err != ErrNotBlocked {
+		t.Errorf("unblocking without a block: error = %v, want %v", err, ErrNotBlocked)
+	}
+	if err := uc.Block(ctx, alice, bob); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	if err := uc.Unblock(ctx, bob, alice); err != ErrNotBlocked {
+		t.Errorf("blocked user lifting the block: error = %v, want %v", err, ErrNotBlocked)
+	}
+	if err := uc.Unblock(ctx, alice, bob); err != nil {
+		t.Errorf("Unblock() error = %v", err)
+	}
+	if len(blockRepo.blocks) != 0 {
+		t.Error("block was not removed")
+	}
+}
+
+func TestMute(t *testing.T) {
+	uc, blockRepo, userRepo, alice, bob := setup(t)
+	ctx := context.Background()
+	userRepo.follows[[2]uuid.UUID{alice, bob}] = true
+
+	if err := uc.Mute(ctx, alice, alice); err != ErrCannotMuteSelf {
+		t.Errorf("muting self: error = %v, want %v", err, ErrCannotMuteSelf)
+	}
+	if err := uc.Mute(ctx, alice, uuid.New()); err != ErrUserNotFound {
+		t.Errorf("muting unknown user: error = %v, want %v", err, ErrUserNotFound)
+	}
+	if err := uc.Mute(ctx, alice, bob); err != nil {
+		t.Fatalf("Mute() error = %v", err)
+	}
+	if err := uc.Mute(ctx, alice, bob); err != ErrAlreadyMuted {
+		t.Errorf("muting twice: error = %v, want %v", err, ErrAlreadyMuted)
+	}
+
+	// Muting is one-sided and keeps the follow
+	if !userRepo.follows[[2]uuid.UUID{alice, bob}] {
+		t.Error("mute removed the follow")
+	}
+	if len(blockRepo.blocks) != 0 {
+		t.Error("mute created a block")
+	}
+
+	if err := uc.Unmute(ctx, alice, bob); err != nil {
+		t.Errorf("Unmute() error = %v", err)
+	}
+	if err := uc.Unmute(ctx, alice, bob); err != ErrNotMuted {
+		t.Errorf("unmuting twice: error = %v, want %v", err, ErrNotMuted)
+	}
+}
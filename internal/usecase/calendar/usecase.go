@@ -0,0 +1,199 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/calendar"
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/pkg/ical"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEventNotFound = errors.New("event not found")
+	ErrFeedNotFound  = errors.New("calendar feed not found")
+)
+
+const (
+	productID = "-//Anigmaa//Events//EN"
+
+	// uidDomain makes event UIDs globally unique; never change it or every
+	// subscriber gets duplicate entries
+	uidDomain = "anigmaa.com"
+
+	// feedEventLimit caps the hosted and the joined events in a feed
+	feedEventLimit = 500
+
+	// feedRefreshInterval is the polling interval suggested to calendar apps
+	feedRefreshInterval = time.Hour
+)
+
+// Usecase handles iCalendar export business logic
+type Usecase struct {
+	eventRepo    event.Repository
+	calendarRepo calendar.Repository
+	publicURL    string
+}
+
+// NewUsecase creates a new calendar usecase
+// publicURL is the externally reachable base URL used to build feed links
+func NewUsecase(eventRepo event.Repository, calendarRepo calendar.Repository, publicURL string) *Usecase {
+	return &Usecase{
+		eventRepo:    eventRepo,
+		calendarRepo: calendarRepo,
+		publicURL:    publicURL,
+	}
+}
+
+// GetEventCalendar builds an add-to-calendar document for a single event
+func (uc *Usecase) GetEventCalendar(ctx context.Context, eventID uuid.UUID) (*ical.Calendar, error) {
+	evt, err := uc.eventRepo.GetWithDetails(ctx, eventID, uuid.Nil)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	return &ical.Calendar{
+		ProductID: productID,
+		Name:      evt.Title,
+		Events:    []ical.Event{toCalendarEvent(evt)},
+	}, nil
+}
+
+// GetFeed gets the subscription URLs of a user's calendar feed, creating the feed on first use
+func (uc *Usecase) GetFeed(ctx context.Context, userID uuid.UUID) (*calendar.Feed, error) {
+	token, err := uc.calendarRepo.GetFeedToken(ctx, userID)
+	if err == nil {
+		return uc.toFeed(token), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return uc.ResetFeed(ctx, userID)
+}
+
+// ResetFeed replaces the feed token of a user; the old subscription URL stops working
+func (uc *Usecase) ResetFeed(ctx context.Context, userID uuid.UUID) (*calendar.Feed, error) {
+	value, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	token := &calendar.FeedToken{
+		UserID:    userID,
+		Token:     value,
+		CreatedAt: time.Now(),
+	}
+	if err := uc.calendarRepo.SaveFeedToken(ctx, token); err != nil {
+		return nil, err
+	}
+
+	return uc.toFeed(token), nil
+}
+
+// GetFeedCalendar builds the subscription feed behind a feed token: the events the
+// user hosts and the events they joined. Cancelled events stay in the feed marked
+// as cancelled, so calendar apps remove or strike them through.
+func (uc *Usecase) GetFeedCalendar(ctx context.Context, value string) (*ical.Calendar, error) {
+	token, err := uc.calendarRepo.GetFeedTokenByValue(ctx, value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFeedNotFound
+		}
+		return nil, err
+	}
+
+	hosted, err := uc.eventRepo.GetByHost(ctx, token.UserID, feedEventLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	joined, err := uc.eventRepo.GetJoinedEvents(ctx, token.UserID, feedEventLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(hosted)+len(joined))
+	events := make([]ical.Event, 0, len(hosted)+len(joined))
+	for _, list := range [][]event.EventWithDetails{hosted, joined} {
+		for i := range list {
+			if seen[list[i].ID] {
+				continue
+			}
+			seen[list[i].ID] = true
+			events = append(events, toCalendarEvent(&list[i]))
+		}
+	}
+
+	return &ical.Calendar{
+		ProductID:       productID,
+		Name:            "Anigmaa",
+		RefreshInterval: feedRefreshInterval,
+		Events:          events,
+	}, nil
+}
+
+func (uc *Usecase) toFeed(token *calendar.FeedToken) *calendar.Feed {
+	url := fmt.Sprintf("%s/api/v1/calendar/%s.ics", uc.publicURL, token.Token)
+
+	webcalURL := url
+	if i := strings.Index(url, "://"); i >= 0 {
+		webcalURL = "webcal" + url[i:]
+	}
+
+	return &calendar.Feed{
+		URL:       url,
+		WebcalURL: webcalURL,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+// toCalendarEvent maps an event to a VEVENT. The UID only depends on the event ID
+// so the entry stays the same across edits; the database bumps Sequence on changes.
+func toCalendarEvent(e *event.EventWithDetails) ical.Event {
+	status := ical.StatusConfirmed
+	if e.Status == event.StatusCancelled {
+		status = ical.StatusCancelled
+	}
+
+	location := e.LocationName
+	if e.LocationAddress != "" && e.LocationAddress != e.LocationName {
+		location += ", " + e.LocationAddress
+	}
+
+	description := e.Description
+	if e.HostName != "" {
+		description = fmt.Sprintf("Hosted by %s\n\n%s", e.HostName, e.Description)
+	}
+
+	lat, lng := e.LocationLat, e.LocationLng
+	return ical.Event{
+		UID:          fmt.Sprintf("%s@%s", e.ID, uidDomain),
+		Sequence:     e.Sequence,
+		Summary:      e.Title,
+		Description:  description,
+		Location:     location,
+		Lat:          &lat,
+		Lng:          &lng,
+		Start:        e.StartTime,
+		End:          e.EndTime,
+		Status:       status,
+		Created:      e.CreatedAt,
+		LastModified: e.UpdatedAt,
+	}
+}
+
+// generateToken generates a secure random feed token
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
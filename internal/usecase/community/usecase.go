@@ -118,21 +118,7 @@ func (uc *Usecase) UpdateCommunity(ctx context.Context, communityID, userID uuid
 	}
 
 	// Update fields
-	if req.Name != nil {
-		comm.Name = *req.Name
-	}
-	if req.Description != nil {
-		comm.Description = req.Description
-	}
-	if req.AvatarURL != nil {
-		comm.AvatarURL = req.AvatarURL
-	}
-	if req.CoverURL != nil {
-		comm.CoverURL = req.CoverURL
-	}
-	if req.Privacy != nil {
-		comm.Privacy = *req.Privacy
-	}
+	comm.ApplyUpdate(req)
 
 	if err := uc.communityRepo.Update(ctx, comm); err != nil {
 		return nil, err
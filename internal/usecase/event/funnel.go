@@ -0,0 +1,35 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/google/uuid"
+)
+
+// RecordPageView counts a visit to an event page in the event's conversion funnel
+// viewerID is uuid.Nil for visitors who are not logged in. Visits by the host and
+// staff are skipped so they do not inflate the funnel.
+// Shared by the event and ticket usecases; failures are ignored since a lost page view
+// must never break the page itself
+func RecordPageView(ctx context.Context, eventRepo event.Repository, evt *event.Event, viewerID uuid.UUID, stage event.FunnelStage) {
+	view := &event.PageView{
+		ID:       uuid.New(),
+		EventID:  evt.ID,
+		Stage:    stage,
+		ViewedAt: time.Now(),
+	}
+
+	if viewerID != uuid.Nil {
+		if evt.HostID == viewerID {
+			return
+		}
+		if _, err := eventRepo.GetStaffMember(ctx, evt.ID, viewerID); err == nil {
+			return
+		}
+		view.UserID = &viewerID
+	}
+
+	_ = eventRepo.RecordPageView(ctx, view)
+}
@@ -0,0 +1,36 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/google/uuid"
+)
+
+// HasPermission reports whether a user may perform a management action on an event
+// The host may do everything; co-hosts and staff only what their role grants
+// When several permissions are given, holding any one of them is enough
+// Shared by the event, ticket, Q&A and analytics usecases so access is decided in one place
+func HasPermission(ctx context.Context, eventRepo event.Repository, evt *event.Event, userID uuid.UUID, permissions ...event.Permission) (bool, error) {
+	if evt.HostID == userID {
+		return true, nil
+	}
+
+	staff, err := eventRepo.GetStaffMember(ctx, evt.ID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, p := range permissions {
+		if staff.Can(p) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
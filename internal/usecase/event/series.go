@@ -0,0 +1,498 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/pkg/rrule"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSeriesTimezone = "Asia/Jakarta"
+
+	// maxOccurrencesPerRun caps how many occurrences one generation pass creates,
+	// so a daily rule cannot flood the events table; the next pass continues
+	maxOccurrencesPerRun = 100
+
+	// maxFollowingOccurrences bounds how many occurrences one "this and following" edit touches
+	maxFollowingOccurrences = 500
+
+	// seriesPreviewLimit is how many upcoming occurrences are returned with a series
+	seriesPreviewLimit = 20
+)
+
+// CreateSeries creates a recurring event and generates its occurrences up to the scheduling horizon
+func (uc *Usecase) CreateSeries(ctx context.Context, hostID uuid.UUID, req *event.CreateSeriesRequest) (*event.SeriesWithDetails, error) {
+	if !req.EndTime.After(req.StartTime) {
+		return nil, ErrInvalidTimeRange
+	}
+	if req.StartTime.Before(time.Now()) {
+		return nil, ErrPastEvent
+	}
+	if !req.IsFree && (req.Price == nil || *req.Price <= 0) {
+		return nil, errors.New("price must be set for paid events")
+	}
+
+	rule, err := rrule.Parse(req.Recurrence)
+	if err != nil {
+		return nil, ErrInvalidRecurrence
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = defaultSeriesTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, hostID); err != nil {
+		return nil, errors.New("host user not found")
+	}
+
+	now := time.Now()
+	series := &event.Series{
+		ID:                   uuid.New(),
+		HostID:               hostID,
+		Recurrence:           rule.String(),
+		Timezone:             timezone,
+		FirstStart:           req.StartTime.In(loc),
+		DurationMinutes:      int(req.EndTime.Sub(req.StartTime).Minutes()),
+		Title:                req.Title,
+		Description:          req.Description,
+		Category:             req.Category,
+		LocationName:         req.LocationName,
+		LocationAddress:      req.LocationAddress,
+		LocationLat:          req.LocationLat,
+		LocationLng:          req.LocationLng,
+		MaxAttendees:         req.MaxAttendees,
+		Price:                req.Price,
+		IsFree:               req.IsFree,
+		Privacy:              req.Privacy,
+		Requirements:         req.Requirements,
+		TicketingEnabled:     req.TicketingEnabled,
+		AllowTicketTransfers: true,
+		ImageURLs:            req.ImageURLs,
+		GeneratedUntil:       req.StartTime.Add(-time.Second), // nothing generated yet
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	if req.AllowTicketTransfers != nil {
+		series.AllowTicketTransfers = *req.AllowTicketTransfers
+	}
+	if series.ImageURLs == nil {
+		series.ImageURLs = []string{}
+	}
+	if series.DurationMinutes < 1 {
+		return nil, ErrInvalidTimeRange
+	}
+
+	occurrences := uc.nextOccurrences(series, rule, loc, now.Add(uc.seriesHorizon))
+	if len(occurrences) == 0 {
+		return nil, ErrNoOccurrences
+	}
+
+	if err := uc.eventRepo.CreateSeries(ctx, series); err != nil {
+		return nil, err
+	}
+	if err := uc.eventRepo.AddOccurrences(ctx, series, occurrences); err != nil {
+		return nil, err
+	}
+
+	// Increment events created for user stats, once per series
+	_ = uc.userRepo.IncrementEventsCreated(ctx, hostID)
+
+	return uc.GetSeries(ctx, series.ID, hostID)
+}
+
+// GetSeries gets a series with its upcoming occurrences
+func (uc *Usecase) GetSeries(ctx context.Context, seriesID, userID uuid.UUID) (*event.SeriesWithDetails, error) {
+	series, err := uc.eventRepo.GetSeriesWithDetails(ctx, seriesID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+
+	series.Occurrences, err = uc.eventRepo.GetSeriesOccurrences(ctx, seriesID, time.Now(), seriesPreviewLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// UpdateFollowingOccurrences applies a change to an occurrence, every later
+// upcoming occurrence of its series and the template of occurrences generated
+// from now on. Earlier occurrences keep their details. Moving the start time
+// shifts every occurrence by the same amount but may not change its day.
+func (uc *Usecase) UpdateFollowingOccurrences(ctx context.Context, eventID, userID uuid.UUID, req *event.UpdateSeriesRequest) (*event.SeriesWithDetails, error) {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+	if evt.SeriesID == nil {
+		return nil, ErrNotInSeries
+	}
+
+	series, err := uc.getSeries(ctx, *evt.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user is the host or may edit the event
+	if err := uc.authorize(ctx, evt, userID, event.PermissionEditEvent); err != nil {
+		return nil, err
+	}
+	if !series.IsActive() {
+		return nil, ErrSeriesEnded
+	}
+
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	// Work out the time shift and new duration from the chosen occurrence
+	newStart := evt.StartTime
+	if req.StartTime != nil {
+		newStart = *req.StartTime
+	}
+	newEnd := newStart.Add(evt.EndTime.Sub(evt.StartTime))
+	if req.EndTime != nil {
+		newEnd = *req.EndTime
+	}
+	if !newEnd.After(newStart) {
+		return nil, ErrInvalidTimeRange
+	}
+	if !sameDay(evt.StartTime.In(loc), newStart.In(loc)) {
+		return nil, ErrSeriesDayChange
+	}
+	shift := newStart.Sub(evt.StartTime)
+	duration := newEnd.Sub(newStart)
+
+	// Update the template; moving the rule start and horizon together keeps
+	// already generated occurrences from being generated again
+	applySeriesChanges(series, req)
+	series.FirstStart = series.FirstStart.Add(shift)
+	series.GeneratedUntil = series.GeneratedUntil.Add(shift)
+	series.DurationMinutes = int(duration.Minutes())
+	if series.DurationMinutes < 1 {
+		return nil, ErrInvalidTimeRange
+	}
+	series.UpdatedAt = time.Now()
+
+	if err := uc.eventRepo.UpdateSeries(ctx, series); err != nil {
+		return nil, err
+	}
+
+	occurrences, err := uc.eventRepo.GetSeriesOccurrences(ctx, series.ID, evt.StartTime, maxFollowingOccurrences)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range occurrences {
+		occurrence := &occurrences[i].Event
+		if occurrence.Status != event.StatusUpcoming {
+			continue
+		}
+
+		previousMaxAttendees := occurrence.MaxAttendees
+		applyOccurrenceChanges(occurrence, req)
+		occurrence.StartTime = occurrence.StartTime.Add(shift)
+		occurrence.EndTime = occurrence.StartTime.Add(duration)
+		occurrence.UpdatedAt = series.UpdatedAt
+
+		if err := uc.eventRepo.Update(ctx, occurrence); err != nil {
+			return nil, err
+		}
+
+		if req.ImageURLs != nil {
+			if err := uc.replaceImages(ctx, occurrence.ID, *req.ImageURLs); err != nil {
+				return nil, err
+			}
+		}
+
+		// Raising capacity frees seats for the waitlist
+		if occurrence.MaxAttendees > previousMaxAttendees {
+			_, _ = uc.waitlistUsecase.PromoteWaitlist(ctx, occurrence.ID)
+		}
+	}
+
+	return uc.GetSeries(ctx, series.ID, userID)
+}
+
+// EndSeries stops generating occurrences and cancels the upcoming ones, refunding their tickets
+// Past occurrences and their tickets are kept. Besides the host, staff who may edit the
+// next upcoming occurrence can end the series.
+func (uc *Usecase) EndSeries(ctx context.Context, seriesID, userID uuid.UUID) error {
+	series, err := uc.getSeries(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	occurrences, err := uc.eventRepo.GetSeriesOccurrences(ctx, seriesID, now, maxFollowingOccurrences)
+	if err != nil {
+		return err
+	}
+
+	if series.HostID != userID {
+		allowed := false
+		for i := range occurrences {
+			if occurrences[i].Status != event.StatusUpcoming {
+				continue
+			}
+			allowed, err = HasPermission(ctx, uc.eventRepo, &occurrences[i].Event, userID, event.PermissionEditEvent)
+			if err != nil {
+				return err
+			}
+			break
+		}
+		if !allowed {
+			return ErrUnauthorized
+		}
+	}
+	if !series.IsActive() {
+		return ErrSeriesEnded
+	}
+
+	series.EndedAt = &now
+	series.UpdatedAt = now
+	if err := uc.eventRepo.UpdateSeries(ctx, series); err != nil {
+		return err
+	}
+
+	for _, occurrence := range occurrences {
+		if occurrence.Status != event.StatusUpcoming {
+			continue
+		}
+		if err := uc.eventRepo.UpdateStatus(ctx, occurrence.ID, event.StatusCancelled); err != nil {
+			return err
+		}
+		if err := uc.ticketRefunder.RefundEventTickets(ctx, occurrence.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FollowSeries follows a series to keep its upcoming occurrences in the user's series list
+func (uc *Usecase) FollowSeries(ctx context.Context, seriesID, userID uuid.UUID) error {
+	series, err := uc.getSeries(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+	if !series.IsActive() {
+		return ErrSeriesEnded
+	}
+
+	return uc.eventRepo.FollowSeries(ctx, seriesID, userID)
+}
+
+// UnfollowSeries stops following a series
+func (uc *Usecase) UnfollowSeries(ctx context.Context, seriesID, userID uuid.UUID) error {
+	if _, err := uc.getSeries(ctx, seriesID); err != nil {
+		return err
+	}
+
+	return uc.eventRepo.UnfollowSeries(ctx, seriesID, userID)
+}
+
+// GetFollowedSeries gets the series a user follows
+func (uc *Usecase) GetFollowedSeries(ctx context.Context, userID uuid.UUID, limit, offset int) ([]event.SeriesWithDetails, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return uc.eventRepo.GetFollowedSeries(ctx, userID, limit, offset)
+}
+
+// CountFollowedSeries counts the series a user follows
+func (uc *Usecase) CountFollowedSeries(ctx context.Context, userID uuid.UUID) (int, error) {
+	return uc.eventRepo.CountFollowedSeries(ctx, userID)
+}
+
+// ExtendSeries generates occurrences for every active series up to the scheduling horizon
+// Meant to run periodically; a series that fails is skipped until the next run
+func (uc *Usecase) ExtendSeries(ctx context.Context) error {
+	horizon := time.Now().Add(uc.seriesHorizon)
+
+	series, err := uc.eventRepo.GetSeriesToExtend(ctx, horizon)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for i := range series {
+		s := &series[i]
+
+		rule, err := rrule.Parse(s.Recurrence)
+		if err != nil {
+			continue
+		}
+		loc, err := time.LoadLocation(s.Timezone)
+		if err != nil {
+			continue
+		}
+
+		occurrences := uc.nextOccurrences(s, rule, loc, horizon)
+		if err := uc.eventRepo.AddOccurrences(ctx, s, occurrences); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// nextOccurrences builds the occurrences after the series' generation horizon up
+// to horizon and moves the series horizon past them
+func (uc *Usecase) nextOccurrences(s *event.Series, rule *rrule.Rule, loc *time.Location, horizon time.Time) []*event.Event {
+	var occurrences []*event.Event
+
+	s.GeneratedUntil = s.GeneratedUntil.In(loc)
+	for _, start := range rule.Occurrences(s.FirstStart.In(loc), horizon, 0) {
+		if !start.After(s.GeneratedUntil) {
+			continue
+		}
+		if len(occurrences) == maxOccurrencesPerRun {
+			// Continue from the last generated occurrence next time
+			s.GeneratedUntil = occurrences[len(occurrences)-1].StartTime
+			return occurrences
+		}
+		occurrences = append(occurrences, s.NewOccurrence(start))
+	}
+
+	s.GeneratedUntil = horizon
+	return occurrences
+}
+
+func (uc *Usecase) getSeries(ctx context.Context, seriesID uuid.UUID) (*event.Series, error) {
+	series, err := uc.eventRepo.GetSeries(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	return series, nil
+}
+
+func (uc *Usecase) replaceImages(ctx context.Context, eventID uuid.UUID, imageURLs []string) error {
+	if err := uc.eventRepo.DeleteAllImages(ctx, eventID); err != nil {
+		return err
+	}
+
+	if len(imageURLs) == 0 {
+		return nil
+	}
+
+	images := make([]event.EventImage, len(imageURLs))
+	for i, url := range imageURLs {
+		images[i] = event.EventImage{
+			EventID:  eventID,
+			ImageURL: url,
+			Order:    i,
+		}
+	}
+	return uc.eventRepo.AddImages(ctx, images)
+}
+
+// applySeriesChanges copies the provided fields of a series edit into the series template
+func applySeriesChanges(s *event.Series, req *event.UpdateSeriesRequest) {
+	if req.Title != nil {
+		s.Title = *req.Title
+	}
+	if req.Description != nil {
+		s.Description = *req.Description
+	}
+	if req.Category != nil {
+		s.Category = *req.Category
+	}
+	if req.LocationName != nil {
+		s.LocationName = *req.LocationName
+	}
+	if req.LocationAddress != nil {
+		s.LocationAddress = *req.LocationAddress
+	}
+	if req.LocationLat != nil {
+		s.LocationLat = *req.LocationLat
+	}
+	if req.LocationLng != nil {
+		s.LocationLng = *req.LocationLng
+	}
+	if req.MaxAttendees != nil {
+		s.MaxAttendees = *req.MaxAttendees
+	}
+	if req.Price != nil {
+		s.Price = req.Price
+	}
+	if req.Privacy != nil {
+		s.Privacy = *req.Privacy
+	}
+	if req.Requirements != nil {
+		s.Requirements = req.Requirements
+	}
+	if req.AllowTicketTransfers != nil {
+		s.AllowTicketTransfers = *req.AllowTicketTransfers
+	}
+	if req.ImageURLs != nil {
+		s.ImageURLs = *req.ImageURLs
+	}
+}
+
+// applyOccurrenceChanges copies the provided fields of a series edit into one occurrence
+func applyOccurrenceChanges(e *event.Event, req *event.UpdateSeriesRequest) {
+	if req.Title != nil {
+		e.Title = *req.Title
+	}
+	if req.Description != nil {
+		e.Description = *req.Description
+	}
+	if req.Category != nil {
+		e.Category = *req.Category
+	}
+	if req.LocationName != nil {
+		e.LocationName = *req.LocationName
+	}
+	if req.LocationAddress != nil {
+		e.LocationAddress = *req.LocationAddress
+	}
+	if req.LocationLat != nil {
+		e.LocationLat = *req.LocationLat
+	}
+	if req.LocationLng != nil {
+		e.LocationLng = *req.LocationLng
+	}
+	if req.MaxAttendees != nil {
+		e.MaxAttendees = *req.MaxAttendees
+	}
+	if req.Price != nil {
+		e.Price = req.Price
+	}
+	if req.Privacy != nil {
+		e.Privacy = *req.Privacy
+	}
+	if req.Requirements != nil {
+		e.Requirements = req.Requirements
+	}
+	if req.AllowTicketTransfers != nil {
+		e.AllowTicketTransfers = *req.AllowTicketTransfers
+	}
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -3,16 +3,18 @@ package event
 import (
 	"context"
 	"errors"
+	"math"
 	"time"
 
 	"github.com/anigmaa/backend/internal/domain/event"
 	"github.com/anigmaa/backend/internal/domain/user"
+	waitlistUsecase "github.com/anigmaa/backend/internal/usecase/waitlist"
 	"github.com/google/uuid"
 )
 
 var (
 	ErrEventNotFound     = errors.New("event not found")
-	ErrUnauthorized      = errors.New("unauthorized - not event host")
+	ErrUnauthorized      = errors.New("unauthorized - not event host or missing permission")
 	ErrEventFull         = errors.New("event is full")
 	ErrAlreadyJoined     = errors.New("already joined this event")
 	ErrNotJoined         = errors.New("not joined this event")
@@ -20,19 +22,44 @@ var (
 	ErrPastEvent         = errors.New("cannot create event in the past")
 	ErrCannotLeaveAsHost = errors.New("host cannot leave their own event")
 	ErrCannotCancelPast  = errors.New("cannot cancel past event")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrStaffNotFound     = errors.New("staff member not found")
+	ErrHostIsStaff       = errors.New("host already manages this event")
+	ErrInvalidPermission = errors.New("invalid staff permission")
+	ErrInvalidBounds     = errors.New("min_lat, min_lng, max_lat and max_lng are required and min must be below max")
+	ErrSeriesNotFound    = errors.New("event series not found")
+	ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+	ErrInvalidTimezone   = errors.New("invalid timezone")
+	ErrNoOccurrences     = errors.New("recurrence rule has no occurrences in the scheduling horizon")
+	ErrSeriesEnded       = errors.New("event series has ended")
+	ErrNotInSeries       = errors.New("event is not part of a series")
+	ErrSeriesDayChange   = errors.New("occurrences of a series cannot move to another day")
 )
 
+// TicketRefunder refunds the tickets of a cancelled event and releases its attendees
+// Implemented by the ticket usecase, which itself depends on this package
+type TicketRefunder interface {
+	RefundEventTickets(ctx context.Context, eventID uuid.UUID) error
+}
+
 // Usecase handles event business logic
 type Usecase struct {
-	eventRepo event.Repository
-	userRepo  user.Repository
+	eventRepo       event.Repository
+	userRepo        user.Repository
+	waitlistUsecase *waitlistUsecase.Usecase
+	ticketRefunder  TicketRefunder
+	seriesHorizon   time.Duration
 }
 
 // NewUsecase creates a new event usecase
-func NewUsecase(eventRepo event.Repository, userRepo user.Repository) *Usecase {
+// seriesHorizon is how far ahead occurrences of recurring events are generated
+func NewUsecase(eventRepo event.Repository, userRepo user.Repository, waitlistUsecase *waitlistUsecase.Usecase, ticketRefunder TicketRefunder, seriesHorizon time.Duration) *Usecase {
 	return &Usecase{
-		eventRepo: eventRepo,
-		userRepo:  userRepo,
+		eventRepo:       eventRepo,
+		userRepo:        userRepo,
+		waitlistUsecase: waitlistUsecase,
+		ticketRefunder:  ticketRefunder,
+		seriesHorizon:   seriesHorizon,
 	}
 }
 
@@ -57,27 +84,32 @@ func (uc *Usecase) CreateEvent(ctx context.Context, hostID uuid.UUID, req *event
 	// Create event
 	now := time.Now()
 	newEvent := &event.Event{
-		ID:               uuid.New(),
-		HostID:           hostID,
-		Title:            req.Title,
-		Description:      req.Description,
-		Category:         req.Category,
-		StartTime:        req.StartTime,
-		EndTime:          req.EndTime,
-		LocationName:     req.LocationName,
-		LocationAddress:  req.LocationAddress,
-		LocationLat:      req.LocationLat,
-		LocationLng:      req.LocationLng,
-		MaxAttendees:     req.MaxAttendees,
-		Price:            req.Price,
-		IsFree:           req.IsFree,
-		Status:           event.StatusUpcoming,
-		Privacy:          req.Privacy,
-		Requirements:     req.Requirements,
-		TicketingEnabled: req.TicketingEnabled,
-		TicketsSold:      0,
-		CreatedAt:        now,
-		UpdatedAt:        now,
+		ID:                   uuid.New(),
+		HostID:               hostID,
+		Title:                req.Title,
+		Description:          req.Description,
+		Category:             req.Category,
+		StartTime:            req.StartTime,
+		EndTime:              req.EndTime,
+		LocationName:         req.LocationName,
+		LocationAddress:      req.LocationAddress,
+		LocationLat:          req.LocationLat,
+		LocationLng:          req.LocationLng,
+		MaxAttendees:         req.MaxAttendees,
+		Price:                req.Price,
+		IsFree:               req.IsFree,
+		Status:               event.StatusUpcoming,
+		Privacy:              req.Privacy,
+		Requirements:         req.Requirements,
+		TicketingEnabled:     req.TicketingEnabled,
+		AllowTicketTransfers: true,
+		TicketsSold:          0,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+
+	if req.AllowTicketTransfers != nil {
+		newEvent.AllowTicketTransfers = *req.AllowTicketTransfers
 	}
 
 	// Validate pricing
@@ -128,6 +160,20 @@ func (uc *Usecase) GetEventWithDetails(ctx context.Context, eventID, userID uuid
 	if err != nil {
 		return nil, ErrEventNotFound
 	}
+
+	// Co-hosts are shown next to the host; plain staff stay internal
+	staff, err := uc.eventRepo.GetStaff(ctx, eventID)
+	if err == nil {
+		for _, m := range staff {
+			if m.Role == event.RoleCoHost {
+				evt.CoHosts = append(evt.CoHosts, m)
+			}
+		}
+	}
+
+	// Count the visit in the host's conversion funnel
+	RecordPageView(ctx, uc.eventRepo, &evt.Event, userID, event.FunnelView)
+
 	return evt, nil
 }
 
@@ -139,11 +185,13 @@ func (uc *Usecase) UpdateEvent(ctx context.Context, eventID, userID uuid.UUID, r
 		return nil, ErrEventNotFound
 	}
 
-	// Check if user is the host
-	if existingEvent.HostID != userID {
-		return nil, ErrUnauthorized
+	// Check if user is the host or may edit the event
+	if err := uc.authorize(ctx, existingEvent, userID, event.PermissionEditEvent); err != nil {
+		return nil, err
 	}
 
+	previousMaxAttendees := existingEvent.MaxAttendees
+
 	// Update fields if provided
 	if req.Title != nil {
 		existingEvent.Title = *req.Title
@@ -187,6 +235,9 @@ func (uc *Usecase) UpdateEvent(ctx context.Context, eventID, userID uuid.UUID, r
 	if req.Status != nil {
 		existingEvent.Status = *req.Status
 	}
+	if req.AllowTicketTransfers != nil {
+		existingEvent.AllowTicketTransfers = *req.AllowTicketTransfers
+	}
 
 	// Validate time range
 	if !existingEvent.EndTime.After(existingEvent.StartTime) {
@@ -223,6 +274,13 @@ func (uc *Usecase) UpdateEvent(ctx context.Context, eventID, userID uuid.UUID, r
 		}
 	}
 
+	// Raising capacity frees seats for the waitlist
+	if existingEvent.MaxAttendees > previousMaxAttendees {
+		if _, err := uc.waitlistUsecase.PromoteWaitlist(ctx, eventID); err != nil {
+			// Log error but don't fail
+		}
+	}
+
 	return existingEvent, nil
 }
 
@@ -302,6 +360,65 @@ func (uc *Usecase) GetNearbyEvents(ctx context.Context, lat, lng, radiusKm float
 	return uc.eventRepo.GetNearby(ctx, lat, lng, radiusKm, limit)
 }
 
+// Map clustering tuning. At zoom z a map tile spans 360/2^z degrees; cells are
+// a quarter tile so a phone screen shows at most a few dozen clusters.
+const (
+	mapClusterMaxZoom   = 16   // from this zoom on every event gets its own pin
+	mapPinGridSize      = 1e-6 // ~10 cm, only merges events at the same venue
+	mapDefaultZoom      = 12
+	mapDefaultClusters  = 300
+	mapMaxClusters      = 1000
+	mapCellsPerTileSide = 4
+)
+
+// GetMapView returns the events inside the filter's bounding box, clustered
+// on a grid that shrinks as the zoom level grows
+func (uc *Usecase) GetMapView(ctx context.Context, filter *event.EventFilter) (*event.MapView, error) {
+	if filter.MinLat == nil || filter.MinLng == nil || filter.MaxLat == nil || filter.MaxLng == nil {
+		return nil, ErrInvalidBounds
+	}
+	if *filter.MinLat >= *filter.MaxLat || *filter.MinLng >= *filter.MaxLng {
+		return nil, ErrInvalidBounds
+	}
+
+	zoom := mapDefaultZoom
+	if filter.Zoom != nil {
+		zoom = *filter.Zoom
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = mapDefaultClusters
+	}
+	if limit > mapMaxClusters {
+		limit = mapMaxClusters
+	}
+
+	clustered := zoom < mapClusterMaxZoom
+	gridSize := mapPinGridSize
+	if clustered {
+		gridSize = 360 / math.Pow(2, float64(zoom)) / mapCellsPerTileSide
+	}
+
+	clusters, err := uc.eventRepo.GetMapClusters(ctx, filter, gridSize, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, cl := range clusters {
+		total += cl.Count
+	}
+
+	return &event.MapView{
+		Zoom:        zoom,
+		Clustered:   clustered,
+		TotalEvents: total,
+		Truncated:   len(clusters) == limit,
+		Clusters:    clusters,
+	}, nil
+}
+
 // CountEvents counts total events matching filter
 func (uc *Usecase) CountEvents(ctx context.Context, filter *event.EventFilter) (int, error) {
 	return uc.eventRepo.CountEvents(ctx, filter)
@@ -317,9 +434,9 @@ func (uc *Usecase) CountJoinedEvents(ctx context.Context, userID uuid.UUID) (int
 	return uc.eventRepo.CountJoinedEvents(ctx, userID)
 }
 
-// CountAttendees counts total attendees for an event
-func (uc *Usecase) CountAttendees(ctx context.Context, eventID uuid.UUID) (int, error) {
-	return uc.eventRepo.CountAttendees(ctx, eventID)
+// CountAttendees counts attendees of an event visible to the viewer
+func (uc *Usecase) CountAttendees(ctx context.Context, eventID, viewerID uuid.UUID) (int, error) {
+	return uc.eventRepo.CountAttendees(ctx, eventID, viewerID)
 }
 
 // JoinEvent joins an event
@@ -344,6 +461,15 @@ func (uc *Usecase) JoinEvent(ctx context.Context, eventID, userID uuid.UUID) err
 		return ErrAlreadyJoined
 	}
 
+	// Check seat availability (seats offered to waitlisted users are held for them)
+	offer, err := uc.waitlistUsecase.CheckSeat(ctx, evt, userID, 1)
+	if err != nil {
+		if err == waitlistUsecase.ErrEventFull {
+			return ErrEventFull
+		}
+		return err
+	}
+
 	// Create attendee record
 	attendee := &event.EventAttendee{
 		ID:       uuid.New(),
@@ -353,7 +479,18 @@ func (uc *Usecase) JoinEvent(ctx context.Context, eventID, userID uuid.UUID) err
 		Status:   event.AttendeeConfirmed,
 	}
 
-	return uc.eventRepo.Join(ctx, attendee)
+	if err := uc.eventRepo.Join(ctx, attendee); err != nil {
+		return err
+	}
+
+	// Seat came from a waitlist offer, mark it claimed
+	if offer != nil {
+		if err := uc.waitlistUsecase.ClaimOffer(ctx, offer, nil); err != nil {
+			// Log error but don't fail
+		}
+	}
+
+	return nil
 }
 
 // LeaveEvent leaves an event
@@ -378,11 +515,21 @@ func (uc *Usecase) LeaveEvent(ctx context.Context, eventID, userID uuid.UUID) er
 		return ErrNotJoined
 	}
 
-	return uc.eventRepo.Leave(ctx, eventID, userID)
+	if err := uc.eventRepo.Leave(ctx, eventID, userID); err != nil {
+		return err
+	}
+
+	// Offer the freed seat to the waitlist
+	if _, err := uc.waitlistUsecase.PromoteWaitlist(ctx, eventID); err != nil {
+		// Log error but don't fail
+	}
+
+	return nil
 }
 
-// GetAttendees gets event attendees
-func (uc *Usecase) GetAttendees(ctx context.Context, eventID uuid.UUID, limit, offset int) ([]event.EventAttendee, error) {
+// GetAttendees gets event attendees visible to the viewer.
+// Attendees who hide their events are only listed to themselves and the host.
+func (uc *Usecase) GetAttendees(ctx context.Context, eventID, viewerID uuid.UUID, limit, offset int) ([]event.EventAttendee, error) {
 	// Check if event exists
 	_, err := uc.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
@@ -396,7 +543,7 @@ func (uc *Usecase) GetAttendees(ctx context.Context, eventID uuid.UUID, limit, o
 		limit = 100
 	}
 
-	return uc.eventRepo.GetAttendees(ctx, eventID, limit, offset)
+	return uc.eventRepo.GetAttendees(ctx, eventID, viewerID, limit, offset)
 }
 
 // IsAttending checks if a user is attending an event
@@ -490,9 +637,9 @@ func (uc *Usecase) AddEventImages(ctx context.Context, eventID, userID uuid.UUID
 		return ErrEventNotFound
 	}
 
-	// Check if user is the host
-	if existingEvent.HostID != userID {
-		return ErrUnauthorized
+	// Check if user is the host or may edit the event
+	if err := uc.authorize(ctx, existingEvent, userID, event.PermissionEditEvent); err != nil {
+		return err
 	}
 
 	// Get current images to determine the next order index
@@ -523,11 +670,165 @@ func (uc *Usecase) DeleteEventImage(ctx context.Context, eventID, imageID, userI
 		return ErrEventNotFound
 	}
 
-	// Check if user is the host
-	if existingEvent.HostID != userID {
-		return ErrUnauthorized
+	// Check if user is the host or may edit the event
+	if err := uc.authorize(ctx, existingEvent, userID, event.PermissionEditEvent); err != nil {
+		return err
 	}
 
 	// Delete the image
 	return uc.eventRepo.DeleteImage(ctx, imageID)
 }
+
+// GetStaff gets the co-hosts and staff of an event (host, co-hosts and staff only)
+func (uc *Usecase) GetStaff(ctx context.Context, eventID, userID uuid.UUID) ([]event.StaffMember, error) {
+	existingEvent, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	if existingEvent.HostID != userID {
+		if _, err := uc.eventRepo.GetStaffMember(ctx, eventID, userID); err != nil {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	return uc.eventRepo.GetStaff(ctx, eventID)
+}
+
+// AddStaff adds a co-host or staff member to an event (host only)
+// Adding a user who is already on the team replaces their role and permissions
+func (uc *Usecase) AddStaff(ctx context.Context, eventID, hostID uuid.UUID, req *event.AddStaffRequest) ([]event.StaffMember, error) {
+	existingEvent, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	// Check if user is the host
+	if existingEvent.HostID != hostID {
+		return nil, ErrUnauthorized
+	}
+
+	if req.UserID == hostID {
+		return nil, ErrHostIsStaff
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	role := req.Role
+	if role == "" {
+		role = event.RoleStaff
+	}
+
+	permissions, err := normalizePermissions(role, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	staff := &event.EventStaff{
+		EventID:     eventID,
+		UserID:      req.UserID,
+		Role:        role,
+		Permissions: permissions,
+		AddedBy:     hostID,
+	}
+	if err := uc.eventRepo.AddStaff(ctx, staff); err != nil {
+		return nil, err
+	}
+
+	return uc.eventRepo.GetStaff(ctx, eventID)
+}
+
+// UpdateStaff changes the role or permissions of a co-host or staff member (host only)
+func (uc *Usecase) UpdateStaff(ctx context.Context, eventID, hostID, staffUserID uuid.UUID, req *event.UpdateStaffRequest) ([]event.StaffMember, error) {
+	existingEvent, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	// Check if user is the host
+	if existingEvent.HostID != hostID {
+		return nil, ErrUnauthorized
+	}
+
+	staff, err := uc.eventRepo.GetStaffMember(ctx, eventID, staffUserID)
+	if err != nil {
+		return nil, ErrStaffNotFound
+	}
+
+	if req.Role != nil {
+		staff.Role = *req.Role
+	}
+	if req.Permissions != nil {
+		staff.Permissions = *req.Permissions
+	}
+
+	staff.Permissions, err = normalizePermissions(staff.Role, staff.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventRepo.UpdateStaff(ctx, staff); err != nil {
+		return nil, ErrStaffNotFound
+	}
+
+	return uc.eventRepo.GetStaff(ctx, eventID)
+}
+
+// RemoveStaff removes a co-host or staff member from an event (host only)
+func (uc *Usecase) RemoveStaff(ctx context.Context, eventID, hostID, staffUserID uuid.UUID) error {
+	existingEvent, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return ErrEventNotFound
+	}
+
+	// Check if user is the host
+	if existingEvent.HostID != hostID {
+		return ErrUnauthorized
+	}
+
+	if err := uc.eventRepo.RemoveStaff(ctx, eventID, staffUserID); err != nil {
+		return ErrStaffNotFound
+	}
+
+	return nil
+}
+
+// authorize checks that a user is the host or holds the permission on the event
+func (uc *Usecase) authorize(ctx context.Context, evt *event.Event, userID uuid.UUID, permission event.Permission) error {
+	allowed, err := HasPermission(ctx, uc.eventRepo, evt, userID, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// normalizePermissions validates staff permissions and applies role defaults
+// Co-hosts hold every permission, so nothing is stored for them
+func normalizePermissions(role event.StaffRole, permissions []event.Permission) ([]event.Permission, error) {
+	if role == event.RoleCoHost {
+		return []event.Permission{}, nil
+	}
+
+	if len(permissions) == 0 {
+		return []event.Permission{event.PermissionCheckIn}, nil
+	}
+
+	seen := make(map[event.Permission]bool, len(permissions))
+	result := make([]event.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		if !p.IsValid() {
+			return nil, ErrInvalidPermission
+		}
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,319 @@
+package moderation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/moderation"
+	"github.com/anigmaa/backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTargetNotFound     = errors.New("reported content not found")
+	ErrCannotReportSelf   = errors.New("cannot report your own content")
+	ErrAlreadyReported    = errors.New("already reported")
+	ErrReportNotFound     = errors.New("report not found")
+	ErrReportResolved     = errors.New("report is already resolved")
+	ErrNotContent         = errors.New("profiles cannot be removed, suspend the user instead")
+	ErrReportMismatch     = errors.New("report is not about this user")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrCannotSuspendSelf  = errors.New("cannot suspend yourself")
+	ErrCannotSuspendStaff = errors.New("cannot suspend platform staff")
+	ErrNotSuspended       = errors.New("user is not suspended")
+)
+
+// Usecase handles content reports and moderation business logic
+type Usecase struct {
+	moderationRepo    moderation.Repository
+	userRepo          user.Repository
+	autoHideThreshold int
+}
+
+// NewUsecase creates a new moderation usecase
+// Content is hidden once autoHideThreshold users reported it; 0 disables auto-hiding
+func NewUsecase(moderationRepo moderation.Repository, userRepo user.Repository, autoHideThreshold int) *Usecase {
+	return &Usecase{
+		moderationRepo:    moderationRepo,
+		userRepo:          userRepo,
+		autoHideThreshold: autoHideThreshold,
+	}
+}
+
+// Report files a user's report. Reports on the same target are grouped into one open report,
+// and content reaching the threshold is hidden until an admin reviews it.
+func (uc *Usecase) Report(ctx context.Context, reporterID uuid.UUID, req *moderation.CreateReportRequest) (*moderation.Filing, error) {
+	ownerID, err := uc.moderationRepo.GetTargetOwner(ctx, req.TargetType, req.TargetID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTargetNotFound
+		}
+		return nil, err
+	}
+	if ownerID == reporterID {
+		return nil, ErrCannotReportSelf
+	}
+
+	reported, err := uc.moderationRepo.HasReported(ctx, reporterID, req.TargetType, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if reported {
+		return nil, ErrAlreadyReported
+	}
+
+	filing := &moderation.Filing{
+		ID:         uuid.New(),
+		ReporterID: reporterID,
+		Reason:     req.Reason,
+		Details:    req.Details,
+		CreatedAt:  time.Now(),
+	}
+
+	report, err := uc.moderationRepo.FileReport(ctx, req.TargetType, req.TargetID, filing)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.shouldAutoHide(report) {
+		note := fmt.Sprintf("Hidden after %d reports", report.ReportsCount)
+		action := &moderation.Action{
+			ID:         uuid.New(),
+			Action:     moderation.ActionAutoHide,
+			TargetType: report.TargetType,
+			TargetID:   report.TargetID,
+			ReportID:   &report.ID,
+			Note:       &note,
+			CreatedAt:  time.Now(),
+		}
+		if err := uc.moderationRepo.HideReport(ctx, report.ID, action); err != nil {
+			// Log error but don't fail, the next report retries
+		}
+	}
+
+	return filing, nil
+}
+
+// shouldAutoHide reports whether an open report reached the threshold for hiding its content
+func (uc *Usecase) shouldAutoHide(report *moderation.Report) bool {
+	return uc.autoHideThreshold > 0 &&
+		report.TargetType.IsContent() &&
+		!report.IsHidden &&
+		report.ReportsCount >= uc.autoHideThreshold
+}
+
+// GetReports gets the moderation queue, open reports by default
+func (uc *Usecase) GetReports(ctx context.Context, filter *moderation.ReportFilter) ([]moderation.Report, error) {
+	normalizeReportFilter(filter)
+	return uc.moderationRepo.GetReports(ctx, filter)
+}
+
+// CountReports counts reports in the moderation queue
+func (uc *Usecase) CountReports(ctx context.Context, filter *moderation.ReportFilter) (int, error) {
+	normalizeReportFilter(filter)
+	return uc.moderationRepo.CountReports(ctx, filter)
+}
+
+// GetReport gets a report with every filing and the owner of the reported target
+func (uc *Usecase) GetReport(ctx context.Context, reportID uuid.UUID) (*moderation.ReportWithDetails, error) {
+	report, err := uc.moderationRepo.GetReport(ctx, reportID)
+	if err != nil {
+		return nil, ErrReportNotFound
+	}
+
+	filings, err := uc.moderationRepo.GetFilings(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &moderation.ReportWithDetails{
+		Report:  *report,
+		Filings: filings,
+	}
+
+	// The target may have been deleted since it was reported
+	ownerID, err := uc.moderationRepo.GetTargetOwner(ctx, report.TargetType, report.TargetID)
+	if err == nil {
+		details.OwnerID = &ownerID
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// DismissReport closes a report without action. Auto-hidden content becomes visible again.
+func (uc *Usecase) DismissReport(ctx context.Context, reportID, adminID uuid.UUID, req *moderation.ResolveReportRequest) (*moderation.Report, error) {
+	report, err := uc.getOpenReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.resolve(ctx, report, adminID, moderation.StatusDismissed, false, moderation.ActionDismiss, req.Note)
+}
+
+// RemoveContent closes a report and keeps the reported content hidden for good
+func (uc *Usecase) RemoveContent(ctx context.Context, reportID, adminID uuid.UUID, req *moderation.ResolveReportRequest) (*moderation.Report, error) {
+	report, err := uc.getOpenReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !report.TargetType.IsContent() {
+		return nil, ErrNotContent
+	}
+
+	return uc.resolve(ctx, report, adminID, moderation.StatusActioned, true, moderation.ActionRemoveContent, req.Note)
+}
+
+// resolve closes an open report and records the admin's decision
+func (uc *Usecase) resolve(ctx context.Context, report *moderation.Report, adminID uuid.UUID, status moderation.ReportStatus, hidden bool, actionType moderation.ActionType, note *string) (*moderation.Report, error) {
+	action := &moderation.Action{
+		ID:         uuid.New(),
+		ActorID:    &adminID,
+		Action:     actionType,
+		TargetType: report.TargetType,
+		TargetID:   report.TargetID,
+		ReportID:   &report.ID,
+		Note:       note,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := uc.moderationRepo.ResolveReport(ctx, report.ID, status, hidden, adminID, action); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReportResolved
+		}
+		return nil, err
+	}
+
+	return uc.moderationRepo.GetReport(ctx, report.ID)
+}
+
+// SuspendUser suspends a user for a number of days, or indefinitely.
+// Suspended users cannot sign in, their issued tokens stop working and their profile is hidden from everyone else.
+// When the suspension comes from a report, that report is resolved as actioned.
+func (uc *Usecase) SuspendUser(ctx context.Context, userID, adminID uuid.UUID, req *moderation.SuspendUserRequest) error {
+	if userID == adminID {
+		return ErrCannotSuspendSelf
+	}
+
+	target, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if target.IsStaff() {
+		return ErrCannotSuspendStaff
+	}
+
+	var report *moderation.Report
+	if req.ReportID != nil {
+		report, err = uc.getOpenReport(ctx, *req.ReportID)
+		if err != nil {
+			return err
+		}
+
+		ownerID, err := uc.moderationRepo.GetTargetOwner(ctx, report.TargetType, report.TargetID)
+		if err != nil || ownerID != userID {
+			return ErrReportMismatch
+		}
+	}
+
+	now := time.Now()
+	var until *time.Time
+	if req.DurationDays != nil {
+		end := now.AddDate(0, 0, *req.DurationDays)
+		until = &end
+	}
+
+	action := &moderation.Action{
+		ID:         uuid.New(),
+		ActorID:    &adminID,
+		Action:     moderation.ActionSuspendUser,
+		TargetType: moderation.TargetUser,
+		TargetID:   userID,
+		ReportID:   req.ReportID,
+		Note:       &req.Reason,
+		CreatedAt:  now,
+	}
+
+	if err := uc.moderationRepo.SuspendUser(ctx, userID, until, req.Reason, action); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	// The suspension is already in the audit trail with the report attached
+	if report != nil {
+		if err := uc.moderationRepo.ResolveReport(ctx, report.ID, moderation.StatusActioned, report.IsHidden, adminID, nil); err != nil && err != sql.ErrNoRows {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnsuspendUser lifts a user's suspension
+func (uc *Usecase) UnsuspendUser(ctx context.Context, userID, adminID uuid.UUID, req *moderation.UnsuspendUserRequest) error {
+	action := &moderation.Action{
+		ID:         uuid.New(),
+		ActorID:    &adminID,
+		Action:     moderation.ActionUnsuspendUser,
+		TargetType: moderation.TargetUser,
+		TargetID:   userID,
+		Note:       req.Note,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := uc.moderationRepo.UnsuspendUser(ctx, userID, action); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotSuspended
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetActions gets the moderation audit trail, most recent first
+func (uc *Usecase) GetActions(ctx context.Context, filter *moderation.ActionFilter) ([]moderation.Action, error) {
+	filter.Limit = normalizeLimit(filter.Limit)
+	return uc.moderationRepo.GetActions(ctx, filter)
+}
+
+// CountActions counts entries in the moderation audit trail
+func (uc *Usecase) CountActions(ctx context.Context, filter *moderation.ActionFilter) (int, error) {
+	return uc.moderationRepo.CountActions(ctx, filter)
+}
+
+// getOpenReport gets a report that is still waiting for review
+func (uc *Usecase) getOpenReport(ctx context.Context, reportID uuid.UUID) (*moderation.Report, error) {
+	report, err := uc.moderationRepo.GetReport(ctx, reportID)
+	if err != nil {
+		return nil, ErrReportNotFound
+	}
+	if report.Status != moderation.StatusOpen {
+		return nil, ErrReportResolved
+	}
+	return report, nil
+}
+
+func normalizeReportFilter(filter *moderation.ReportFilter) {
+	if filter.Status == "" {
+		filter.Status = moderation.StatusOpen
+	}
+	filter.Limit = normalizeLimit(filter.Limit)
+}
+
+func normalizeLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
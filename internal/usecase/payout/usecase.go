@@ -0,0 +1,411 @@
+package payout
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/payout"
+	"github.com/anigmaa/backend/internal/domain/ticket"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEventNotFound       = errors.New("event not found")
+	ErrBankAccountNotFound = errors.New("bank account not found")
+	ErrPayoutNotFound      = errors.New("payout not found")
+	ErrPayoutNotOpen       = errors.New("payout is no longer requested")
+	ErrInsufficientBalance = errors.New("insufficient available balance")
+	ErrInvalidAmount       = errors.New("payout amount must be at least 0.01")
+	ErrUnauthorized        = errors.New("unauthorized")
+)
+
+// Usecase handles the settlement ledger and host payouts
+type Usecase struct {
+	payoutRepo         payout.Repository
+	eventRepo          event.Repository
+	platformFeePercent float64
+	holdPeriod         time.Duration
+}
+
+// NewUsecase creates a new payout usecase
+// platformFeePercent is taken from every ticket sale; holdPeriod is how long after
+// an event ends its sales stay held before the host can withdraw them
+func NewUsecase(payoutRepo payout.Repository, eventRepo event.Repository, platformFeePercent float64, holdPeriod time.Duration) *Usecase {
+	return &Usecase{
+		payoutRepo:         payoutRepo,
+		eventRepo:          eventRepo,
+		platformFeePercent: platformFeePercent,
+		holdPeriod:         holdPeriod,
+	}
+}
+
+// RecordTicketSale credits the host for a paid ticket, minus the platform fee
+// Recording the same ticket again is a no-op, so payment webhook retries are safe
+func (uc *Usecase) RecordTicketSale(ctx context.Context, t *ticket.Ticket, amount float64) error {
+	amount = payout.RoundAmount(amount)
+	if amount <= 0 {
+		return nil
+	}
+
+	evt, err := uc.eventRepo.GetByID(ctx, t.EventID)
+	if err != nil {
+		return ErrEventNotFound
+	}
+
+	fee := payout.RoundAmount(amount * uc.platformFeePercent / 100)
+	hostShare := payout.RoundAmount(amount - fee)
+	availableAt := evt.EndTime.Add(uc.holdPeriod)
+	description := "Ticket sale: " + evt.Title
+
+	txn := &payout.Transaction{
+		Kind:        payout.KindTicketSale,
+		ReferenceID: t.ID,
+		EventID:     &evt.ID,
+		Description: &description,
+		Entries: []payout.Entry{
+			{Account: payout.AccountGatewayCash, EventID: &evt.ID, Direction: payout.Debit, Amount: amount},
+		},
+	}
+	if hostShare > 0 {
+		txn.Entries = append(txn.Entries, payout.Entry{
+			Account:     payout.AccountHostPayable,
+			HostID:      &evt.HostID,
+			EventID:     &evt.ID,
+			Direction:   payout.Credit,
+			Amount:      hostShare,
+			AvailableAt: &availableAt,
+		})
+	}
+	if fee > 0 {
+		txn.Entries = append(txn.Entries, payout.Entry{
+			Account: payout.AccountPlatformFees, EventID: &evt.ID, Direction: payout.Credit, Amount: fee,
+		})
+	}
+
+	return ignoreAlreadyPosted(uc.payoutRepo.PostTransaction(ctx, txn))
+}
+
+// RecordTicketRefund debits the host by reversing the sale of a refunded ticket
+// Tickets that were never recorded as sold (free or unpaid) have nothing to reverse
+func (uc *Usecase) RecordTicketRefund(ctx context.Context, ticketID uuid.UUID) error {
+	sale, err := uc.payoutRepo.GetTransactionByReference(ctx, payout.KindTicketSale, ticketID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	description := "Ticket refund"
+	if sale.Description != nil {
+		description = "Refund of " + *sale.Description
+	}
+
+	// Reversed entries keep their hold date so a refunded sale leaves the held balance too
+	txn := &payout.Transaction{
+		Kind:        payout.KindTicketRefund,
+		ReferenceID: ticketID,
+		EventID:     sale.EventID,
+		Description: &description,
+		Entries:     make([]payout.Entry, 0, len(sale.Entries)),
+	}
+	for _, e := range sale.Entries {
+		txn.Entries = append(txn.Entries, payout.Entry{
+			Account:     e.Account,
+			HostID:      e.HostID,
+			EventID:     e.EventID,
+			Direction:   opposite(e.Direction),
+			Amount:      e.Amount,
+			AvailableAt: e.AvailableAt,
+		})
+	}
+
+	return ignoreAlreadyPosted(uc.payoutRepo.PostTransaction(ctx, txn))
+}
+
+// GetBalance gets what the platform owes a host
+func (uc *Usecase) GetBalance(ctx context.Context, hostID uuid.UUID) (*payout.Balance, error) {
+	return uc.payoutRepo.GetHostBalance(ctx, hostID, time.Now())
+}
+
+// GetStatement gets the ledger entries of a host's balance
+func (uc *Usecase) GetStatement(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]payout.StatementEntry, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return uc.payoutRepo.GetHostStatement(ctx, hostID, limit, offset)
+}
+
+// CountStatement counts the ledger entries of a host's balance
+func (uc *Usecase) CountStatement(ctx context.Context, hostID uuid.UUID) (int, error) {
+	return uc.payoutRepo.CountHostStatement(ctx, hostID)
+}
+
+// AddBankAccount registers a bank account the host receives payouts on
+func (uc *Usecase) AddBankAccount(ctx context.Context, userID uuid.UUID, req *payout.CreateBankAccountRequest) (*payout.BankAccount, error) {
+	account := &payout.BankAccount{
+		UserID:            userID,
+		BankName:          req.BankName,
+		AccountNumber:     req.AccountNumber,
+		AccountHolderName: req.AccountHolderName,
+	}
+
+	if err := uc.payoutRepo.CreateBankAccount(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetBankAccounts gets the active bank accounts of a host
+func (uc *Usecase) GetBankAccounts(ctx context.Context, userID uuid.UUID) ([]payout.BankAccount, error) {
+	return uc.payoutRepo.GetBankAccountsByUser(ctx, userID)
+}
+
+// RemoveBankAccount deactivates a bank account (owner only)
+func (uc *Usecase) RemoveBankAccount(ctx context.Context, accountID, userID uuid.UUID) error {
+	account, err := uc.payoutRepo.GetBankAccountByID(ctx, accountID)
+	if err != nil || !account.IsActive {
+		return ErrBankAccountNotFound
+	}
+
+	if account.UserID != userID {
+		return ErrUnauthorized
+	}
+
+	if err := uc.payoutRepo.DeactivateBankAccount(ctx, accountID); err != nil {
+		return ErrBankAccountNotFound
+	}
+
+	return nil
+}
+
+// RequestPayout withdraws part of a host's available balance to one of their bank accounts
+func (uc *Usecase) RequestPayout(ctx context.Context, hostID uuid.UUID, req *payout.RequestPayoutRequest) (*payout.Payout, error) {
+	amount := payout.RoundAmount(req.Amount)
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	account, err := uc.payoutRepo.GetBankAccountByID(ctx, req.BankAccountID)
+	if err != nil || !account.IsActive || account.UserID != hostID {
+		return nil, ErrBankAccountNotFound
+	}
+
+	p := &payout.Payout{
+		ID:            uuid.New(),
+		HostID:        hostID,
+		BankAccountID: account.ID,
+		Amount:        amount,
+	}
+
+	// Move the amount from the host's balance to payouts in transit
+	description := "Payout to " + account.BankName + " " + maskAccountNumber(account.AccountNumber)
+	txn := &payout.Transaction{
+		Kind:        payout.KindPayoutRequest,
+		Description: &description,
+		Entries: []payout.Entry{
+			{Account: payout.AccountHostPayable, HostID: &hostID, Direction: payout.Debit, Amount: amount},
+			{Account: payout.AccountPayoutClearing, Direction: payout.Credit, Amount: amount},
+		},
+	}
+
+	if err := uc.payoutRepo.CreatePayout(ctx, p, txn, time.Now()); err != nil {
+		if errors.Is(err, payout.ErrInsufficientBalance) {
+			return nil, ErrInsufficientBalance
+		}
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// GetPayouts gets the payouts of a host
+func (uc *Usecase) GetPayouts(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]payout.PayoutWithDetails, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return uc.payoutRepo.GetPayoutsByHost(ctx, hostID, limit, offset)
+}
+
+// CancelPayout withdraws a payout request that has not been processed yet (host only)
+func (uc *Usecase) CancelPayout(ctx context.Context, payoutID, hostID uuid.UUID) (*payout.Payout, error) {
+	p, err := uc.payoutRepo.GetPayoutByID(ctx, payoutID)
+	if err != nil {
+		return nil, ErrPayoutNotFound
+	}
+
+	if p.HostID != hostID {
+		return nil, ErrUnauthorized
+	}
+
+	return uc.resolve(ctx, p, payout.StatusCancelled, hostID, nil, nil)
+}
+
+// GetPayoutQueue gets payouts in a status for admins, requested payouts by default
+func (uc *Usecase) GetPayoutQueue(ctx context.Context, status payout.PayoutStatus, limit, offset int) ([]payout.PayoutWithDetails, error) {
+	if status == "" {
+		status = payout.StatusRequested
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return uc.payoutRepo.GetPayoutsByStatus(ctx, status, limit, offset)
+}
+
+// MarkPayoutPaid records that an admin transferred a requested payout
+func (uc *Usecase) MarkPayoutPaid(ctx context.Context, payoutID, adminID uuid.UUID, req *payout.MarkPaidRequest) (*payout.Payout, error) {
+	p, err := uc.payoutRepo.GetPayoutByID(ctx, payoutID)
+	if err != nil {
+		return nil, ErrPayoutNotFound
+	}
+
+	return uc.resolve(ctx, p, payout.StatusPaid, adminID, &req.Reference, nil)
+}
+
+// RejectPayout refuses a requested payout and returns its amount to the host's balance
+func (uc *Usecase) RejectPayout(ctx context.Context, payoutID, adminID uuid.UUID, req *payout.RejectPayoutRequest) (*payout.Payout, error) {
+	p, err := uc.payoutRepo.GetPayoutByID(ctx, payoutID)
+	if err != nil {
+		return nil, ErrPayoutNotFound
+	}
+
+	return uc.resolve(ctx, p, payout.StatusRejected, adminID, nil, &req.Reason)
+}
+
+// GetReconciliationReport compares the ledger with ticket_transactions for tickets purchased in the period
+func (uc *Usecase) GetReconciliationReport(ctx context.Context, startDate, endDate *time.Time) (*payout.ReconciliationReport, error) {
+	lines, err := uc.payoutRepo.GetReconciliationLines(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := uc.payoutRepo.GetAccountTotals(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	unbalanced, err := uc.payoutRepo.CountUnbalancedTransactions(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	paid, err := uc.payoutRepo.SumPayouts(ctx, payout.StatusPaid, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &payout.ReconciliationReport{
+		StartDate:              startDate,
+		EndDate:                endDate,
+		TicketsCompared:        len(lines),
+		PlatformFees:           payout.RoundAmount(-totals.PlatformFees),
+		HostPayable:            payout.RoundAmount(-totals.HostPayable),
+		PayoutsInTransit:       payout.RoundAmount(-totals.PayoutClearing),
+		PayoutsPaid:            payout.RoundAmount(paid),
+		UnbalancedTransactions: unbalanced,
+		Mismatches:             []payout.ReconciliationLine{},
+	}
+
+	var transactionsNet, ledgerNet int64
+	for _, line := range lines {
+		transactionsNet += payout.Cents(line.TransactionsNet)
+		ledgerNet += payout.Cents(line.LedgerNet)
+
+		if payout.Cents(line.TransactionsNet) != payout.Cents(line.LedgerNet) {
+			line.Difference = payout.RoundAmount(line.LedgerNet - line.TransactionsNet)
+			report.Mismatches = append(report.Mismatches, line)
+		}
+	}
+
+	report.TransactionsNet = float64(transactionsNet) / 100
+	report.LedgerNet = float64(ledgerNet) / 100
+	report.Difference = float64(ledgerNet-transactionsNet) / 100
+
+	return report, nil
+}
+
+// resolve moves a requested payout to its final status with the matching ledger posting
+func (uc *Usecase) resolve(ctx context.Context, p *payout.Payout, status payout.PayoutStatus, processedBy uuid.UUID, reference, note *string) (*payout.Payout, error) {
+	if !p.IsOpen() {
+		return nil, ErrPayoutNotOpen
+	}
+
+	now := time.Now()
+	p.Status = status
+	p.Reference = reference
+	p.Note = note
+	p.ProcessedAt = &now
+	p.ProcessedBy = &processedBy
+
+	txn := &payout.Transaction{
+		ReferenceID: p.ID,
+	}
+	if status == payout.StatusPaid {
+		// Money left the platform's account
+		description := "Payout sent"
+		txn.Kind = payout.KindPayoutPaid
+		txn.Description = &description
+		txn.Entries = []payout.Entry{
+			{Account: payout.AccountPayoutClearing, Direction: payout.Debit, Amount: p.Amount},
+			{Account: payout.AccountGatewayCash, Direction: payout.Credit, Amount: p.Amount},
+		}
+	} else {
+		// Give the amount back to the host
+		description := "Payout " + string(status)
+		txn.Kind = payout.KindPayoutReversal
+		txn.Description = &description
+		txn.Entries = []payout.Entry{
+			{Account: payout.AccountPayoutClearing, Direction: payout.Debit, Amount: p.Amount},
+			{Account: payout.AccountHostPayable, HostID: &p.HostID, Direction: payout.Credit, Amount: p.Amount},
+		}
+	}
+
+	if err := uc.payoutRepo.ResolvePayout(ctx, p, txn); err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, payout.ErrAlreadyPosted) {
+			return nil, ErrPayoutNotOpen
+		}
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ignoreAlreadyPosted treats a repeated posting as success
+func ignoreAlreadyPosted(err error) error {
+	if errors.Is(err, payout.ErrAlreadyPosted) {
+		return nil
+	}
+	return err
+}
+
+// opposite returns the other side of a ledger entry
+func opposite(d payout.Direction) payout.Direction {
+	if d == payout.Debit {
+		return payout.Credit
+	}
+	return payout.Debit
+}
+
+// maskAccountNumber keeps only the last four digits of an account number
+func maskAccountNumber(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return "****" + number[len(number)-4:]
+}
@@ -0,0 +1,412 @@
+package payout
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/payout"
+	"github.com/anigmaa/backend/internal/domain/ticket"
+	"github.com/google/uuid"
+)
+
+// fakeLedger keeps ledger transactions, bank accounts and payouts in memory
+// Like the database it posts each (kind, reference) once and rejects unbalanced transactions
+type fakeLedger struct {
+	payout.Repository
+	transactions []*payout.Transaction
+	accounts     map[uuid.UUID]*payout.BankAccount
+	payouts      map[uuid.UUID]*payout.Payout
+}
+
+func newFakeLedger() *fakeLedger {
+	return &fakeLedger{
+		accounts: map[uuid.UUID]*payout.BankAccount{},
+		payouts:  map[uuid.UUID]*payout.Payout{},
+	}
+}
+
+func (r *fakeLedger) PostTransaction(ctx context.Context, txn *payout.Transaction) error {
+	if !txn.IsBalanced() {
+		return payout.ErrUnbalanced
+	}
+	for _, posted := range r.transactions {
+		if posted.Kind == txn.Kind && posted.ReferenceID == txn.ReferenceID {
+			return payout.ErrAlreadyPosted
+		}
+	}
+	r.transactions = append(r.transactions, txn)
+	return nil
+}
+
+func (r *fakeLedger) GetTransactionByReference(ctx context.Context, kind payout.TransactionKind, referenceID uuid.UUID) (*payout.Transaction, error) {
+	for _, posted := range r.transactions {
+		if posted.Kind == kind && posted.ReferenceID == referenceID {
+			return posted, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeLedger) GetBankAccountByID(ctx context.Context, accountID uuid.UUID) (*payout.BankAccount, error) {
+	account, ok := r.accounts[accountID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return account, nil
+}
+
+func (r *fakeLedger) CreatePayout(ctx context.Context, p *payout.Payout, txn *payout.Transaction, now time.Time) error {
+	if payout.Cents(r.available(p.HostID, now)) < payout.Cents(p.Amount) {
+		return payout.ErrInsufficientBalance
+	}
+
+	p.Status = payout.StatusRequested
+	p.RequestedAt = now
+	txn.ReferenceID = p.ID
+	if err := r.PostTransaction(ctx, txn); err != nil {
+		return err
+	}
+
+	stored := *p
+	r.payouts[p.ID] = &stored
+	return nil
+}
+
+func (r *fakeLedger) GetPayoutByID(ctx context.Context, payoutID uuid.UUID) (*payout.Payout, error) {
+	p, ok := r.payouts[payoutID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *p
+	return &copied, nil
+}
+
+func (r *fakeLedger) ResolvePayout(ctx context.Context, p *payout.Payout, txn *payout.Transaction) error {
+	stored, ok := r.payouts[p.ID]
+	if !ok || stored.Status != payout.StatusRequested {
+		return sql.ErrNoRows
+	}
+	if err := r.PostTransaction(ctx, txn); err != nil {
+		return err
+	}
+
+	resolved := *p
+	r.payouts[p.ID] = &resolved
+	return nil
+}
+
+// available sums what a host can withdraw at now: host_payable credits minus debits, without held sales
+func (r *fakeLedger) available(hostID uuid.UUID, now time.Time) float64 {
+	var cents int64
+	for _, txn := range r.transactions {
+		for _, e := range txn.Entries {
+			if e.Account != payout.AccountHostPayable || e.HostID == nil || *e.HostID != hostID {
+				continue
+			}
+			if e.AvailableAt != nil && e.AvailableAt.After(now) {
+				continue
+			}
+			if e.Direction == payout.Credit {
+				cents += payout.Cents(e.Amount)
+			} else {
+				cents -= payout.Cents(e.Amount)
+			}
+		}
+	}
+	return float64(cents) / 100
+}
+
+// accountTotal sums an account's entries as credits minus debits
+func (r *fakeLedger) accountTotal(account payout.Account) float64 {
+	var cents int64
+	for _, txn := range r.transactions {
+		for _, e := range txn.Entries {
+			if e.Account != account {
+				continue
+			}
+			if e.Direction == payout.Credit {
+				cents += payout.Cents(e.Amount)
+			} else {
+				cents -= payout.Cents(e.Amount)
+			}
+		}
+	}
+	return float64(cents) / 100
+}
+
+func (r *fakeLedger) count(kind payout.TransactionKind) int {
+	n := 0
+	for _, txn := range r.transactions {
+		if txn.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeEventRepo knows a single event
+type fakeEventRepo struct {
+	event.Repository
+	event *event.Event
+}
+
+func (r *fakeEventRepo) GetByID(ctx context.Context, id uuid.UUID) (*event.Event, error) {
+	if r.event == nil || r.event.ID != id {
+		return nil, sql.ErrNoRows
+	}
+	copied := *r.event
+	return &copied, nil
+}
+
+// newEvent is an event that ended the given time ago
+func newEvent(endedAgo time.Duration) *event.Event {
+	end := time.Now().Add(-endedAgo)
+	return &event.Event{
+		ID:        uuid.New(),
+		HostID:    uuid.New(),
+		Title:     "Test event",
+		StartTime: end.Add(-2 * time.Hour),
+		EndTime:   end,
+	}
+}
+
+func paidTicket(evt *event.Event) *ticket.Ticket {
+	return &ticket.Ticket{ID: uuid.New(), UserID: uuid.New(), EventID: evt.ID, Status: ticket.StatusActive}
+}
+
+func TestRecordTicketSalePostsBalancedEntries(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     float64
+		feePercent float64
+		wantHost   float64
+		wantFee    float64
+		wantPosted bool
+	}{
+		{name: "fee taken from the sale", amount: 100000, feePercent: 5, wantHost: 95000, wantFee: 5000, wantPosted: true},
+		{name: "fee rounded to cents", amount: 10.01, feePercent: 2.5, wantHost: 9.76, wantFee: 0.25, wantPosted: true},
+		{name: "no platform fee", amount: 50, feePercent: 0, wantHost: 50, wantPosted: true},
+		{name: "whole sale is fee", amount: 50, feePercent: 100, wantFee: 50, wantPosted: true},
+		{name: "free ticket", amount: 0, feePercent: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			evt := newEvent(0)
+			ledger := newFakeLedger()
+			uc := NewUsecase(ledger, &fakeEventRepo{event: evt}, tt.feePercent, 24*time.Hour)
+
+			if err := uc.RecordTicketSale(ctx, paidTicket(evt), tt.amount); err != nil {
+				t.Fatalf("RecordTicketSale: %v", err)
+			}
+
+			if got := ledger.count(payout.KindTicketSale) == 1; got != tt.wantPosted {
+				t.Fatalf("sale posted = %v, want %v", got, tt.wantPosted)
+			}
+			if !tt.wantPosted {
+				return
+			}
+			if !ledger.transactions[0].IsBalanced() {
+				t.Error("sale is not balanced")
+			}
+			if got := ledger.accountTotal(payout.AccountHostPayable); got != tt.wantHost {
+				t.Errorf("host payable = %v, want %v", got, tt.wantHost)
+			}
+			if got := ledger.accountTotal(payout.AccountPlatformFees); got != tt.wantFee {
+				t.Errorf("platform fees = %v, want %v", got, tt.wantFee)
+			}
+			if got := -ledger.accountTotal(payout.AccountGatewayCash); got != tt.amount {
+				t.Errorf("gateway cash = %v, want %v", got, tt.amount)
+			}
+		})
+	}
+}
+
+func TestRecordTicketPostsEachTicketOnce(t *testing.T) {
+	tests := []struct {
+		name        string
+		sales       int
+		refunds     int
+		wantSales   int
+		wantRefunds int
+		wantHost    float64
+	}{
+		{name: "sale", sales: 1, wantSales: 1, wantHost: 90},
+		{name: "sale posted twice", sales: 2, wantSales: 1, wantHost: 90},
+		{name: "refund after sale", sales: 1, refunds: 1, wantSales: 1, wantRefunds: 1},
+		{name: "refund posted twice", sales: 1, refunds: 2, wantSales: 1, wantRefunds: 1},
+		{name: "refund of a ticket never sold", refunds: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			evt := newEvent(0)
+			ledger := newFakeLedger()
+			uc := NewUsecase(ledger, &fakeEventRepo{event: evt}, 10, 24*time.Hour)
+			tkt := paidTicket(evt)
+
+			for i := 0; i < tt.sales; i++ {
+				if err := uc.RecordTicketSale(ctx, tkt, 100); err != nil {
+					t.Fatalf("RecordTicketSale: %v", err)
+				}
+			}
+			for i := 0; i < tt.refunds; i++ {
+				if err := uc.RecordTicketRefund(ctx, tkt.ID); err != nil {
+					t.Fatalf("RecordTicketRefund: %v", err)
+				}
+			}
+
+			if got := ledger.count(payout.KindTicketSale); got != tt.wantSales {
+				t.Errorf("sales posted = %d, want %d", got, tt.wantSales)
+			}
+			if got := ledger.count(payout.KindTicketRefund); got != tt.wantRefunds {
+				t.Errorf("refunds posted = %d, want %d", got, tt.wantRefunds)
+			}
+			for _, txn := range ledger.transactions {
+				if !txn.IsBalanced() {
+					t.Errorf("%s transaction is not balanced", txn.Kind)
+				}
+			}
+			if got := ledger.accountTotal(payout.AccountHostPayable); got != tt.wantHost {
+				t.Errorf("host payable = %v, want %v", got, tt.wantHost)
+			}
+		})
+	}
+}
+
+func TestRequestPayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		endedAgo time.Duration // Before now; the hold period is a day
+		refunded bool
+		amount   float64
+		wantErr  error
+	}{
+		{name: "part of the balance", endedAgo: 48 * time.Hour, amount: 50},
+		{name: "whole balance", endedAgo: 48 * time.Hour, amount: 90},
+		{name: "more than the balance", endedAgo: 48 * time.Hour, amount: 90.01, wantErr: ErrInsufficientBalance},
+		{name: "sale still held", endedAgo: time.Hour, amount: 50, wantErr: ErrInsufficientBalance},
+		{name: "sale refunded", endedAgo: 48 * time.Hour, refunded: true, amount: 50, wantErr: ErrInsufficientBalance},
+		{name: "nothing to pay out", endedAgo: 48 * time.Hour, amount: 0.001, wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			evt := newEvent(tt.endedAgo)
+			ledger := newFakeLedger()
+			uc := NewUsecase(ledger, &fakeEventRepo{event: evt}, 10, 24*time.Hour)
+
+			account := &payout.BankAccount{ID: uuid.New(), UserID: evt.HostID, BankName: "BCA", AccountNumber: "1234567890", IsActive: true}
+			ledger.accounts[account.ID] = account
+
+			tkt := paidTicket(evt)
+			if err := uc.RecordTicketSale(ctx, tkt, 100); err != nil {
+				t.Fatalf("RecordTicketSale: %v", err)
+			}
+			if tt.refunded {
+				if err := uc.RecordTicketRefund(ctx, tkt.ID); err != nil {
+					t.Fatalf("RecordTicketRefund: %v", err)
+				}
+			}
+
+			p, err := uc.RequestPayout(ctx, evt.HostID, &payout.RequestPayoutRequest{BankAccountID: account.ID, Amount: tt.amount})
+			if err != tt.wantErr {
+				t.Fatalf("RequestPayout err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if got := ledger.count(payout.KindPayoutRequest); got != 0 {
+					t.Errorf("payout requests posted = %d, want 0", got)
+				}
+				return
+			}
+
+			if p.Status != payout.StatusRequested {
+				t.Errorf("payout status = %s, want requested", p.Status)
+			}
+			if got := ledger.available(evt.HostID, time.Now()); got != payout.RoundAmount(90-tt.amount) {
+				t.Errorf("available after payout = %v, want %v", got, payout.RoundAmount(90-tt.amount))
+			}
+			if got := ledger.accountTotal(payout.AccountPayoutClearing); got != tt.amount {
+				t.Errorf("payouts in transit = %v, want %v", got, tt.amount)
+			}
+		})
+	}
+}
+
+func TestResolvePayout(t *testing.T) {
+	tests := []struct {
+		name          string
+		resolve       func(uc *Usecase, ctx context.Context, p *payout.Payout) (*payout.Payout, error)
+		wantStatus    payout.PayoutStatus
+		wantAvailable float64
+	}{
+		{
+			name: "paid",
+			resolve: func(uc *Usecase, ctx context.Context, p *payout.Payout) (*payout.Payout, error) {
+				return uc.MarkPayoutPaid(ctx, p.ID, uuid.New(), &payout.MarkPaidRequest{Reference: "TRF-1"})
+			},
+			wantStatus:    payout.StatusPaid,
+			wantAvailable: 40,
+		},
+		{
+			name: "rejected",
+			resolve: func(uc *Usecase, ctx context.Context, p *payout.Payout) (*payout.Payout, error) {
+				return uc.RejectPayout(ctx, p.ID, uuid.New(), &payout.RejectPayoutRequest{Reason: "Wrong account"})
+			},
+			wantStatus:    payout.StatusRejected,
+			wantAvailable: 90,
+		},
+		{
+			name: "cancelled by the host",
+			resolve: func(uc *Usecase, ctx context.Context, p *payout.Payout) (*payout.Payout, error) {
+				return uc.CancelPayout(ctx, p.ID, p.HostID)
+			},
+			wantStatus:    payout.StatusCancelled,
+			wantAvailable: 90,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			evt := newEvent(48 * time.Hour)
+			ledger := newFakeLedger()
+			uc := NewUsecase(ledger, &fakeEventRepo{event: evt}, 10, 24*time.Hour)
+
+			account := &payout.BankAccount{ID: uuid.New(), UserID: evt.HostID, BankName: "BCA", AccountNumber: "1234567890", IsActive: true}
+			ledger.accounts[account.ID] = account
+			if err := uc.RecordTicketSale(ctx, paidTicket(evt), 100); err != nil {
+				t.Fatalf("RecordTicketSale: %v", err)
+			}
+			requested, err := uc.RequestPayout(ctx, evt.HostID, &payout.RequestPayoutRequest{BankAccountID: account.ID, Amount: 50})
+			if err != nil {
+				t.Fatalf("RequestPayout: %v", err)
+			}
+
+			p, err := tt.resolve(uc, ctx, requested)
+			if err != nil {
+				t.Fatalf("resolve: %v", err)
+			}
+			if p.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", p.Status, tt.wantStatus)
+			}
+			if got := ledger.available(evt.HostID, time.Now()); got != tt.wantAvailable {
+				t.Errorf("available = %v, want %v", got, tt.wantAvailable)
+			}
+			if got := ledger.accountTotal(payout.AccountPayoutClearing); got != 0 {
+				t.Errorf("payouts in transit = %v, want 0", got)
+			}
+
+			// A payout is resolved once
+			if _, err := tt.resolve(uc, ctx, requested); err != ErrPayoutNotOpen {
+				t.Errorf("resolving again err = %v, want ErrPayoutNotOpen", err)
+			}
+		})
+	}
+}
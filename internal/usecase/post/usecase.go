@@ -2,9 +2,11 @@ package post
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"time"
 
+	"github.com/anigmaa/backend/internal/domain/block"
 	"github.com/anigmaa/backend/internal/domain/comment"
 	"github.com/anigmaa/backend/internal/domain/event"
 	"github.com/anigmaa/backend/internal/domain/interaction"
@@ -25,8 +27,18 @@ var (
 	ErrNotBookmarked     = errors.New("not bookmarked")
 	ErrCannotRepostOwn   = errors.New("cannot repost your own post")
 	ErrEventNotFound     = errors.New("attached event not found")
+	ErrReplyTooDeep      = errors.New("replies cannot be nested any deeper")
+	ErrInvalidSort       = errors.New("invalid comment sort")
+	ErrEditWindowClosed  = errors.New("can no longer be edited")
+	ErrEditLocked        = errors.New("has too much engagement to be edited")
+	ErrInvalidSchedule   = errors.New("scheduled time must be in the future")
+	ErrAlreadyPublished  = errors.New("post is already published")
+	ErrNotScheduled      = errors.New("post is not scheduled")
 )
 
+// maxMentions caps the users a post can mention
+const maxMentions = 20
+
 // Usecase handles post business logic
 type Usecase struct {
 	postRepo        post.Repository
@@ -34,6 +46,10 @@ type Usecase struct {
 	interactionRepo interaction.Repository
 	eventRepo       event.Repository
 	userRepo        user.Repository
+	blockRepo       block.Repository
+
+	editWindow          time.Duration // How long after posting content can be edited, 0 for no limit
+	editEngagementLimit int           // Engagement after which content can no longer be edited, 0 for no limit
 }
 
 // NewUsecase creates a new post usecase
@@ -43,13 +59,19 @@ func NewUsecase(
 	interactionRepo interaction.Repository,
 	eventRepo event.Repository,
 	userRepo user.Repository,
+	blockRepo block.Repository,
+	editWindow time.Duration,
+	editEngagementLimit int,
 ) *Usecase {
 	return &Usecase{
-		postRepo:        postRepo,
-		commentRepo:     commentRepo,
-		interactionRepo: interactionRepo,
-		eventRepo:       eventRepo,
-		userRepo:        userRepo,
+		postRepo:            postRepo,
+		commentRepo:         commentRepo,
+		interactionRepo:     interactionRepo,
+		eventRepo:           eventRepo,
+		userRepo:            userRepo,
+		blockRepo:           blockRepo,
+		editWindow:          editWindow,
+		editEngagementLimit: editEngagementLimit,
 	}
 }
 
@@ -71,8 +93,21 @@ func (uc *Usecase) CreatePost(ctx context.Context, authorID uuid.UUID, req *post
 		attachedEventID = *req.AttachedEventID
 	}
 
-	// Create post
+	// A publish time schedules the post, otherwise it is published right away unless saved as a draft
 	now := time.Now()
+	status := post.StatusPublished
+	if req.ScheduledAt != nil {
+		if !req.ScheduledAt.After(now) {
+			return nil, ErrInvalidSchedule
+		}
+		status = post.StatusScheduled
+	} else if req.Status == post.StatusScheduled {
+		return nil, ErrInvalidSchedule
+	} else if req.Status == post.StatusDraft {
+		status = post.StatusDraft
+	}
+
+	// Create post
 	newPost := &post.Post{
 		ID:              uuid.New(),
 		AuthorID:        authorID,
@@ -80,6 +115,8 @@ func (uc *Usecase) CreatePost(ctx context.Context, authorID uuid.UUID, req *post
 		Type:            req.Type,
 		AttachedEventID: attachedEventID,
 		Visibility:      req.Visibility,
+		Status:          status,
+		ScheduledAt:     req.ScheduledAt,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 		LikesCount:      0,
@@ -108,6 +145,11 @@ func (uc *Usecase) CreatePost(ctx context.Context, authorID uuid.UUID, req *post
 		}
 	}
 
+	// Link the @mentioned users
+	if err := uc.updateMentions(ctx, newPost, req.Mentions); err != nil {
+		// Log error but don't fail post creation
+	}
+
 	return newPost, nil
 }
 
@@ -126,10 +168,21 @@ func (uc *Usecase) GetPostWithDetails(ctx context.Context, postID, userID uuid.U
 	if err != nil {
 		return nil, ErrPostNotFound
 	}
+
+	// Blocked users do not see each other's posts
+	blocked, err := uc.isBlocked(ctx, userID, p.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, ErrPostNotFound
+	}
 	return p, nil
 }
 
-// UpdatePost updates a post
+// UpdatePost updates a post. Content edits keep the previous content as a revision
+// and are only allowed within the edit window and below the engagement limit.
+// Drafts and scheduled posts can be edited freely and (re)scheduled.
 func (uc *Usecase) UpdatePost(ctx context.Context, postID, userID uuid.UUID, req *post.UpdatePostRequest) (*post.Post, error) {
 	// Get existing post
 	existingPost, err := uc.postRepo.GetByID(ctx, postID)
@@ -142,24 +195,183 @@ func (uc *Usecase) UpdatePost(ctx context.Context, postID, userID uuid.UUID, req
 		return nil, ErrUnauthorized
 	}
 
-	// Update fields if provided
-	if req.Content != nil {
+	readStatus := existingPost.Status
+	now := time.Now()
+	if req.ScheduledAt != nil {
+		if existingPost.IsPublished() {
+			return nil, ErrAlreadyPublished
+		}
+		if !req.ScheduledAt.After(now) {
+			return nil, ErrInvalidSchedule
+		}
+		existingPost.Status = post.StatusScheduled
+		existingPost.ScheduledAt = req.ScheduledAt
+	}
+
+	var revision *post.Revision
+	contentChanged := req.Content != nil && *req.Content != existingPost.Content
+	if contentChanged && !existingPost.IsPublished() {
+		// Nobody has seen the post yet, so there is nothing to keep
 		existingPost.Content = *req.Content
+	} else if contentChanged {
+		if err := uc.checkEditable(existingPost.CreatedAt, existingPost.Engagement(), now); err != nil {
+			return nil, err
+		}
+		revision = &post.Revision{
+			PostID:     existingPost.ID,
+			Content:    existingPost.Content,
+			WrittenAt:  lastWritten(existingPost.CreatedAt, existingPost.EditedAt),
+			ReplacedAt: now,
+		}
+		existingPost.Content = *req.Content
+		existingPost.EditedAt = &now
 	}
+
+	// Update fields if provided
 	if req.Visibility != nil {
 		existingPost.Visibility = *req.Visibility
 	}
 
-	existingPost.UpdatedAt = time.Now()
+	existingPost.UpdatedAt = now
 
 	// Save changes
-	if err := uc.postRepo.Update(ctx, existingPost); err != nil {
+	if revision == nil {
+		if err := uc.postRepo.Update(ctx, existingPost, readStatus); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// Published by the background publisher in the meantime
+				return nil, ErrAlreadyPublished
+			}
+			return nil, err
+		}
+	} else if err := uc.postRepo.Edit(ctx, existingPost, revision); err != nil {
+		return nil, err
+	}
+
+	if !contentChanged {
+		return existingPost, nil
+	}
+
+	if err := uc.updateMentions(ctx, existingPost, nil); err != nil {
+		// Log error but don't fail
+	}
+
+	return existingPost, nil
+}
+
+// PublishPost publishes a draft or scheduled post right away
+func (uc *Usecase) PublishPost(ctx context.Context, postID, userID uuid.UUID) (*post.Post, error) {
+	// Get existing post
+	existingPost, err := uc.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	// Check if user is the author
+	if existingPost.AuthorID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if existingPost.IsPublished() {
+		return nil, ErrAlreadyPublished
+	}
+
+	now := time.Now()
+	if err := uc.postRepo.Publish(ctx, postID, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Published by the background publisher in the meantime
+			return nil, ErrAlreadyPublished
+		}
 		return nil, err
 	}
 
+	existingPost.Status = post.StatusPublished
+	existingPost.ScheduledAt = nil
+	existingPost.CreatedAt = now
+	existingPost.UpdatedAt = now
+
 	return existingPost, nil
 }
 
+// CancelScheduledPost cancels the publication of a scheduled post, keeping it as a draft
+func (uc *Usecase) CancelScheduledPost(ctx context.Context, postID, userID uuid.UUID) (*post.Post, error) {
+	// Get existing post
+	existingPost, err := uc.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	// Check if user is the author
+	if existingPost.AuthorID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if existingPost.Status != post.StatusScheduled {
+		return nil, ErrNotScheduled
+	}
+
+	existingPost.Status = post.StatusDraft
+	existingPost.ScheduledAt = nil
+	existingPost.UpdatedAt = time.Now()
+
+	if err := uc.postRepo.Update(ctx, existingPost, post.StatusScheduled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Published by the background publisher in the meantime
+			return nil, ErrNotScheduled
+		}
+		return nil, err
+	}
+
+	return existingPost, nil
+}
+
+// GetDrafts gets the author's drafts and scheduled posts, next to be published first
+func (uc *Usecase) GetDrafts(ctx context.Context, authorID uuid.UUID, limit, offset int) ([]post.PostWithDetails, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	posts, err := uc.postRepo.GetUnpublished(ctx, authorID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	drafts := make([]post.PostWithDetails, 0, len(posts))
+	for _, p := range posts {
+		details, err := uc.postRepo.GetWithDetails(ctx, p.ID, authorID)
+		if err != nil {
+			// Skip posts published or deleted in the meantime
+			continue
+		}
+		drafts = append(drafts, *details)
+	}
+
+	return drafts, nil
+}
+
+// CountDrafts counts the author's drafts and scheduled posts
+func (uc *Usecase) CountDrafts(ctx context.Context, authorID uuid.UUID) (int, error) {
+	return uc.postRepo.CountUnpublished(ctx, authorID)
+}
+
+// PublishScheduledPosts publishes the scheduled posts that are due
+func (uc *Usecase) PublishScheduledPosts(ctx context.Context) error {
+	_, err := uc.postRepo.PublishDue(ctx, time.Now())
+	return err
+}
+
+// GetPostRevisions gets the previous contents of a post, most recent first
+func (uc *Usecase) GetPostRevisions(ctx context.Context, postID, userID uuid.UUID) ([]post.Revision, error) {
+	// Check if post exists and is visible to the user
+	if _, err := uc.GetPostWithDetails(ctx, postID, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.postRepo.GetRevisions(ctx, postID)
+}
+
 // DeletePost deletes a post
 func (uc *Usecase) DeletePost(ctx context.Context, postID, userID uuid.UUID) error {
 	// Get existing post
@@ -230,10 +442,10 @@ func (uc *Usecase) ListPosts(ctx context.Context, filter *post.PostFilter, userI
 
 // LikePost likes a post
 func (uc *Usecase) LikePost(ctx context.Context, postID, userID uuid.UUID) error {
-	// Check if post exists
-	_, err := uc.postRepo.GetByID(ctx, postID)
+	// Check if post exists and is visible to the user
+	_, err := uc.getVisiblePost(ctx, postID, userID)
 	if err != nil {
-		return ErrPostNotFound
+		return err
 	}
 
 	// Check if already liked
@@ -291,9 +503,9 @@ func (uc *Usecase) UnlikePost(ctx context.Context, postID, userID uuid.UUID) err
 // RepostPost reposts a post
 func (uc *Usecase) RepostPost(ctx context.Context, userID uuid.UUID, req *post.RepostRequest) error {
 	// Check if post exists
-	originalPost, err := uc.postRepo.GetByID(ctx, req.PostID)
+	originalPost, err := uc.getVisiblePost(ctx, req.PostID, userID)
 	if err != nil {
-		return ErrPostNotFound
+		return err
 	}
 
 	// Check if trying to repost own post
@@ -355,10 +567,10 @@ func (uc *Usecase) UndoRepost(ctx context.Context, postID, userID uuid.UUID) err
 
 // BookmarkPost bookmarks a post
 func (uc *Usecase) BookmarkPost(ctx context.Context, postID, userID uuid.UUID) error {
-	// Check if post exists
-	_, err := uc.postRepo.GetByID(ctx, postID)
+	// Check if post exists and is visible to the user
+	_, err := uc.getVisiblePost(ctx, postID, userID)
 	if err != nil {
-		return ErrPostNotFound
+		return err
 	}
 
 	// Check if already bookmarked
@@ -445,11 +657,12 @@ func (uc *Usecase) CountBookmarks(ctx context.Context, userID uuid.UUID) (int, e
 }
 
 // SharePost tracks a post share
+// Sharing again to the same platform within interaction.ShareWindow is not counted twice
 func (uc *Usecase) SharePost(ctx context.Context, postID, userID uuid.UUID, platform *string) error {
-	// Check if post exists
-	_, err := uc.postRepo.GetByID(ctx, postID)
+	// Check if post exists and is visible to the user
+	_, err := uc.getVisiblePost(ctx, postID, userID)
 	if err != nil {
-		return ErrPostNotFound
+		return err
 	}
 
 	// Create share record
@@ -461,28 +674,102 @@ func (uc *Usecase) SharePost(ctx context.Context, postID, userID uuid.UUID, plat
 		CreatedAt: time.Now(),
 	}
 
-	if err := uc.interactionRepo.Share(ctx, share); err != nil {
-		return err
+	// Note: shares_count is automatically updated via database trigger
+	return uc.interactionRepo.Share(ctx, share)
+}
+
+// GetShareSummary gets how often a post was shared per platform. Only the author can see it.
+func (uc *Usecase) GetShareSummary(ctx context.Context, postID, userID uuid.UUID) (*interaction.ShareSummary, error) {
+	p, err := uc.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+	if p.AuthorID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	platforms, err := uc.interactionRepo.GetShareCountsByPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &interaction.ShareSummary{Platforms: platforms}
+	for _, platform := range platforms {
+		summary.Total += platform.Count
+	}
+
+	return summary, nil
+}
+
+// GetLikers gets the users who liked a post, with the viewer's follow state
+func (uc *Usecase) GetLikers(ctx context.Context, postID, viewerID uuid.UUID, limit, offset int) ([]interaction.Interactor, error) {
+	if _, err := uc.getVisiblePost(ctx, postID, viewerID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 20
 	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return uc.interactionRepo.GetLikers(ctx, interaction.LikeablePost, postID, viewerID, limit, offset)
+}
 
-	// Increment shares count
-	return uc.postRepo.IncrementShares(ctx, postID)
+// CountLikers counts the users who liked a post, as listed by GetLikers
+func (uc *Usecase) CountLikers(ctx context.Context, postID, viewerID uuid.UUID) (int, error) {
+	return uc.interactionRepo.CountLikers(ctx, interaction.LikeablePost, postID, viewerID)
+}
+
+// GetReposters gets the users who reposted a post, with the viewer's follow state
+func (uc *Usecase) GetReposters(ctx context.Context, postID, viewerID uuid.UUID, limit, offset int) ([]interaction.Interactor, error) {
+	if _, err := uc.getVisiblePost(ctx, postID, viewerID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return uc.interactionRepo.GetReposters(ctx, postID, viewerID, limit, offset)
+}
+
+// CountReposters counts the users who reposted a post, as listed by GetReposters
+func (uc *Usecase) CountReposters(ctx context.Context, postID, viewerID uuid.UUID) (int, error) {
+	return uc.interactionRepo.CountReposters(ctx, postID, viewerID)
 }
 
 // CreateComment creates a comment on a post
 func (uc *Usecase) CreateComment(ctx context.Context, authorID uuid.UUID, req *comment.CreateCommentRequest) (*comment.CommentWithDetails, error) {
-	// Check if post exists
-	_, err := uc.postRepo.GetByID(ctx, req.PostID)
+	// Check if post exists and is visible to the author
+	_, err := uc.getVisiblePost(ctx, req.PostID, authorID)
 	if err != nil {
-		return nil, ErrPostNotFound
+		return nil, err
 	}
 
-	// If parent comment is specified, verify it exists
+	// If parent comment is specified, verify it exists on the post and its author did not block the replier
+	depth := 0
 	if req.ParentCommentID != nil {
-		_, err := uc.commentRepo.GetByID(ctx, *req.ParentCommentID)
+		parent, err := uc.commentRepo.GetByID(ctx, *req.ParentCommentID)
+		if err != nil || parent.PostID != req.PostID || parent.IsTombstone() {
+			return nil, ErrCommentNotFound
+		}
+		blocked, err := uc.isBlocked(ctx, authorID, parent.AuthorID)
 		if err != nil {
+			return nil, err
+		}
+		if blocked {
 			return nil, ErrCommentNotFound
 		}
+
+		depth = parent.Depth + 1
+		if depth > comment.MaxDepth {
+			return nil, ErrReplyTooDeep
+		}
 	}
 
 	// Create comment
@@ -492,21 +779,18 @@ func (uc *Usecase) CreateComment(ctx context.Context, authorID uuid.UUID, req *c
 		PostID:          req.PostID,
 		AuthorID:        authorID,
 		ParentCommentID: req.ParentCommentID,
+		Depth:           depth,
 		Content:         req.Content,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 		LikesCount:      0,
 	}
 
+	// Note: comments_count is automatically updated via database trigger
 	if err := uc.commentRepo.Create(ctx, newComment); err != nil {
 		return nil, err
 	}
 
-	// Increment post comments count
-	if err := uc.postRepo.IncrementComments(ctx, req.PostID); err != nil {
-		// Log error but don't fail
-	}
-
 	// Fetch comment with details to include author info
 	commentWithDetails, err := uc.commentRepo.GetWithDetails(ctx, newComment.ID, authorID)
 	if err != nil {
@@ -529,7 +813,7 @@ func (uc *Usecase) CreateComment(ctx context.Context, authorID uuid.UUID, req *c
 func (uc *Usecase) UpdateComment(ctx context.Context, commentID, userID uuid.UUID, req *comment.UpdateCommentRequest) (*comment.Comment, error) {
 	// Get existing comment
 	existingComment, err := uc.commentRepo.GetByID(ctx, commentID)
-	if err != nil {
+	if err != nil || existingComment.IsTombstone() {
 		return nil, ErrCommentNotFound
 	}
 
@@ -538,23 +822,82 @@ func (uc *Usecase) UpdateComment(ctx context.Context, commentID, userID uuid.UUI
 		return nil, ErrUnauthorized
 	}
 
+	if req.Content == existingComment.Content {
+		return existingComment, nil
+	}
+
+	now := time.Now()
+	if err := uc.checkEditable(existingComment.CreatedAt, existingComment.LikesCount, now); err != nil {
+		return nil, err
+	}
+
+	// Keep the previous content as a revision
+	revision := &comment.Revision{
+		CommentID:  existingComment.ID,
+		Content:    existingComment.Content,
+		WrittenAt:  lastWritten(existingComment.CreatedAt, existingComment.EditedAt),
+		ReplacedAt: now,
+	}
+
 	// Update content
 	existingComment.Content = req.Content
-	existingComment.UpdatedAt = time.Now()
+	existingComment.UpdatedAt = now
+	existingComment.Edited = true
+	existingComment.EditedAt = &now
 
 	// Save changes
-	if err := uc.commentRepo.Update(ctx, existingComment); err != nil {
+	if err := uc.commentRepo.Edit(ctx, existingComment, revision); err != nil {
 		return nil, err
 	}
 
 	return existingComment, nil
 }
 
-// DeleteComment deletes a comment
+// GetCommentRevisions gets the previous contents of a comment, most recent first
+func (uc *Usecase) GetCommentRevisions(ctx context.Context, commentID, userID uuid.UUID) ([]comment.Revision, error) {
+	// Check if comment exists and is visible to the user
+	existingComment, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil || existingComment.IsTombstone() {
+		return nil, ErrCommentNotFound
+	}
+	if _, err := uc.getVisiblePost(ctx, existingComment.PostID, userID); err != nil {
+		return nil, err
+	}
+	blocked, err := uc.isBlocked(ctx, userID, existingComment.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, ErrCommentNotFound
+	}
+
+	return uc.commentRepo.GetRevisions(ctx, commentID)
+}
+
+// checkEditable checks that content created at the given time with the given engagement can still be edited
+func (uc *Usecase) checkEditable(createdAt time.Time, engagement int, now time.Time) error {
+	if uc.editWindow > 0 && now.Sub(createdAt) > uc.editWindow {
+		return ErrEditWindowClosed
+	}
+	if uc.editEngagementLimit > 0 && engagement >= uc.editEngagementLimit {
+		return ErrEditLocked
+	}
+	return nil
+}
+
+// lastWritten returns when content was last written: its last edit, or its creation if never edited
+func lastWritten(createdAt time.Time, editedAt *time.Time) time.Time {
+	if editedAt != nil {
+		return *editedAt
+	}
+	return createdAt
+}
+
+// DeleteComment deletes a comment. A comment with replies becomes a tombstone so the thread stays intact.
 func (uc *Usecase) DeleteComment(ctx context.Context, commentID, userID uuid.UUID) error {
 	// Get existing comment
 	existingComment, err := uc.commentRepo.GetByID(ctx, commentID)
-	if err != nil {
+	if err != nil || existingComment.IsTombstone() {
 		return ErrCommentNotFound
 	}
 
@@ -563,25 +906,70 @@ func (uc *Usecase) DeleteComment(ctx context.Context, commentID, userID uuid.UUI
 		return ErrUnauthorized
 	}
 
+	hasReplies, err := uc.commentRepo.HasReplies(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if hasReplies {
+		// Note: comments_count is automatically updated via database trigger
+		return uc.commentRepo.Tombstone(ctx, commentID)
+	}
+
 	// Delete comment
+	// Note: comments_count is automatically updated via database trigger
 	if err := uc.commentRepo.Delete(ctx, commentID); err != nil {
 		return err
 	}
 
-	// Decrement post comments count
-	if err := uc.postRepo.DecrementComments(ctx, existingComment.PostID); err != nil {
+	// Tombstones left without replies have nothing to keep in place any more
+	if err := uc.pruneTombstones(ctx, existingComment.ParentCommentID); err != nil {
 		// Log error but don't fail
 	}
 
 	return nil
 }
 
-// GetCommentsByPost gets comments for a post
-func (uc *Usecase) GetCommentsByPost(ctx context.Context, postID, userID uuid.UUID, limit, offset int) ([]comment.CommentWithDetails, error) {
-	// Check if post exists
-	_, err := uc.postRepo.GetByID(ctx, postID)
+// pruneTombstones deletes the tombstone ancestors of a deleted comment that no longer have replies
+func (uc *Usecase) pruneTombstones(ctx context.Context, parentCommentID *uuid.UUID) error {
+	for parentCommentID != nil {
+		parent, err := uc.commentRepo.GetByID(ctx, *parentCommentID)
+		if err != nil {
+			return err
+		}
+		if !parent.IsTombstone() {
+			return nil
+		}
+
+		hasReplies, err := uc.commentRepo.HasReplies(ctx, parent.ID)
+		if err != nil {
+			return err
+		}
+		if hasReplies {
+			return nil
+		}
+
+		if err := uc.commentRepo.Delete(ctx, parent.ID); err != nil {
+			return err
+		}
+		parentCommentID = parent.ParentCommentID
+	}
+
+	return nil
+}
+
+// GetCommentsByPost gets the top-level comments of a post, most liked first unless another sort is given
+func (uc *Usecase) GetCommentsByPost(ctx context.Context, postID, userID uuid.UUID, sort comment.Sort, limit, offset int) ([]comment.CommentWithDetails, error) {
+	if sort == "" {
+		sort = comment.SortTop
+	}
+	if !sort.IsValid() {
+		return nil, ErrInvalidSort
+	}
+
+	// Check if post exists and is visible to the user
+	_, err := uc.getVisiblePost(ctx, postID, userID)
 	if err != nil {
-		return nil, ErrPostNotFound
+		return nil, err
 	}
 
 	if limit <= 0 {
@@ -591,15 +979,27 @@ func (uc *Usecase) GetCommentsByPost(ctx context.Context, postID, userID uuid.UU
 		limit = 100
 	}
 
-	return uc.commentRepo.GetByPost(ctx, postID, userID, limit, offset)
+	return uc.commentRepo.GetByPost(ctx, postID, userID, sort, limit, offset)
 }
 
-// GetCommentReplies gets replies to a comment
-func (uc *Usecase) GetCommentReplies(ctx context.Context, parentCommentID, userID uuid.UUID, limit, offset int) ([]comment.CommentWithDetails, error) {
-	// Check if parent comment exists
-	_, err := uc.commentRepo.GetByID(ctx, parentCommentID)
+// GetCommentReplies gets a page of direct replies to a comment, oldest first.
+// The page starts after the given cursor; the returned cursor is empty on the last page.
+func (uc *Usecase) GetCommentReplies(ctx context.Context, parentCommentID, userID uuid.UUID, cursor string, limit int) ([]comment.CommentWithDetails, string, error) {
+	var after *comment.Cursor
+	if cursor != "" {
+		var err error
+		if after, err = comment.DecodeCursor(cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Check if parent comment exists and its post is visible to the user
+	parent, err := uc.commentRepo.GetByID(ctx, parentCommentID)
 	if err != nil {
-		return nil, ErrCommentNotFound
+		return nil, "", ErrCommentNotFound
+	}
+	if _, err := uc.getVisiblePost(ctx, parent.PostID, userID); err != nil {
+		return nil, "", err
 	}
 
 	if limit <= 0 {
@@ -609,14 +1009,31 @@ func (uc *Usecase) GetCommentReplies(ctx context.Context, parentCommentID, userI
 		limit = 100
 	}
 
-	return uc.commentRepo.GetReplies(ctx, parentCommentID, userID, limit, offset)
+	// Fetch one extra reply to know whether another page follows
+	replies, err := uc.commentRepo.GetReplies(ctx, parentCommentID, userID, after, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(replies) <= limit {
+		return replies, "", nil
+	}
+
+	replies = replies[:limit]
+	return replies, comment.NewCursor(&replies[limit-1].Comment).Encode(), nil
 }
 
 // LikeComment likes a comment
 func (uc *Usecase) LikeComment(ctx context.Context, commentID, userID uuid.UUID) error {
 	// Check if comment exists
-	_, err := uc.commentRepo.GetByID(ctx, commentID)
+	existingComment, err := uc.commentRepo.GetByID(ctx, commentID)
+	if err != nil || existingComment.IsTombstone() {
+		return ErrCommentNotFound
+	}
+	blocked, err := uc.isBlocked(ctx, userID, existingComment.AuthorID)
 	if err != nil {
+		return err
+	}
+	if blocked {
 		return ErrCommentNotFound
 	}
 
@@ -671,3 +1088,63 @@ func (uc *Usecase) UnlikeComment(ctx context.Context, commentID, userID uuid.UUI
 	// Decrement likes count
 	return uc.commentRepo.DecrementLikes(ctx, commentID)
 }
+
+// getVisiblePost gets a published post unless the viewer and the post author blocked each other
+func (uc *Usecase) getVisiblePost(ctx context.Context, postID, viewerID uuid.UUID) (*post.Post, error) {
+	p, err := uc.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	if !p.IsPublished() {
+		return nil, ErrPostNotFound
+	}
+
+	blocked, err := uc.isBlocked(ctx, viewerID, p.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, ErrPostNotFound
+	}
+
+	return p, nil
+}
+
+// updateMentions links a post to the users it mentions by @username, plus the extra usernames given.
+// Users in a block relation with the author are not linked.
+func (uc *Usecase) updateMentions(ctx context.Context, p *post.Post, extra []string) error {
+	usernames := user.ExtractMentions(p.Content)
+	for _, username := range extra {
+		usernames = append(usernames, user.NormalizeUsername(username))
+	}
+	if len(usernames) > maxMentions {
+		usernames = usernames[:maxMentions]
+	}
+
+	userIDs, err := uc.userRepo.GetIDsByUsernames(ctx, usernames)
+	if err != nil {
+		return err
+	}
+
+	mentioned := make([]uuid.UUID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		blocked, err := uc.isBlocked(ctx, p.AuthorID, userID)
+		if err != nil {
+			return err
+		}
+		if !blocked {
+			mentioned = append(mentioned, userID)
+		}
+	}
+
+	return uc.postRepo.SetMentions(ctx, p.ID, mentioned)
+}
+
+// isBlocked checks if the viewer and another user blocked each other. Anonymous viewers are never blocked.
+func (uc *Usecase) isBlocked(ctx context.Context, viewerID, otherID uuid.UUID) (bool, error) {
+	if viewerID == uuid.Nil || viewerID == otherID {
+		return false, nil
+	}
+	return uc.blockRepo.IsBlocked(ctx, viewerID, otherID)
+}
@@ -0,0 +1,237 @@
+package promo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/promo"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCodeNotFound     = errors.New("promo code not found")
+	ErrCodeExists       = errors.New("promo code already exists")
+	ErrCodeNotValid     = errors.New("promo code is not valid for this purchase")
+	ErrCodeExhausted    = errors.New("promo code redemption limit reached")
+	ErrUserLimitReached = errors.New("promo code already used the maximum number of times")
+	ErrInvalidDiscount  = errors.New("invalid discount")
+	ErrInvalidEvent     = errors.New("promo code can only be restricted to your own events")
+	ErrUnauthorized     = errors.New("unauthorized")
+)
+
+// Usecase handles promo code business logic
+type Usecase struct {
+	promoRepo promo.Repository
+	eventRepo event.Repository
+}
+
+// NewUsecase creates a new promo code usecase
+func NewUsecase(promoRepo promo.Repository, eventRepo event.Repository) *Usecase {
+	return &Usecase{
+		promoRepo: promoRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+// CreateCode creates a promo code for a host
+func (uc *Usecase) CreateCode(ctx context.Context, hostID uuid.UUID, req *promo.CreateCodeRequest) (*promo.Code, error) {
+	// Percentage discounts cannot exceed 100%
+	if req.DiscountType == promo.DiscountPercentage && req.DiscountValue > 100 {
+		return nil, ErrInvalidDiscount
+	}
+
+	if req.ValidFrom != nil && req.ValidUntil != nil && !req.ValidUntil.After(*req.ValidFrom) {
+		return nil, ErrInvalidDiscount
+	}
+
+	// Codes are matched case-insensitively
+	codeText := strings.ToUpper(req.Code)
+	if _, err := uc.promoRepo.GetByHostAndCode(ctx, hostID, codeText); err == nil {
+		return nil, ErrCodeExists
+	}
+
+	if err := uc.checkEventsOwned(ctx, hostID, req.EventIDs); err != nil {
+		return nil, err
+	}
+
+	maxPerUser := 1
+	if req.MaxPerUser != nil {
+		maxPerUser = *req.MaxPerUser
+	}
+
+	eventIDs := req.EventIDs
+	if eventIDs == nil {
+		eventIDs = []uuid.UUID{}
+	}
+
+	code := &promo.Code{
+		ID:             uuid.New(),
+		HostID:         hostID,
+		Code:           codeText,
+		Description:    req.Description,
+		DiscountType:   req.DiscountType,
+		DiscountValue:  req.DiscountValue,
+		MaxRedemptions: req.MaxRedemptions,
+		MaxPerUser:     maxPerUser,
+		ValidFrom:      req.ValidFrom,
+		ValidUntil:     req.ValidUntil,
+		IsActive:       true,
+		EventIDs:       eventIDs,
+	}
+
+	if err := uc.promoRepo.Create(ctx, code); err != nil {
+		return nil, err
+	}
+
+	return code, nil
+}
+
+// GetHostCodes gets all promo codes of a host
+func (uc *Usecase) GetHostCodes(ctx context.Context, hostID uuid.UUID, limit, offset int) ([]promo.Code, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return uc.promoRepo.GetByHost(ctx, hostID, limit, offset)
+}
+
+// GetCode gets a promo code (owner only)
+func (uc *Usecase) GetCode(ctx context.Context, codeID, hostID uuid.UUID) (*promo.Code, error) {
+	code, err := uc.promoRepo.GetByID(ctx, codeID)
+	if err != nil {
+		return nil, ErrCodeNotFound
+	}
+
+	if code.HostID != hostID {
+		return nil, ErrUnauthorized
+	}
+
+	return code, nil
+}
+
+// UpdateCode updates a promo code (owner only)
+// The discount itself cannot change once created so past redemptions stay consistent
+func (uc *Usecase) UpdateCode(ctx context.Context, codeID, hostID uuid.UUID, req *promo.UpdateCodeRequest) (*promo.Code, error) {
+	code, err := uc.GetCode(ctx, codeID, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.Description != nil {
+		code.Description = req.Description
+	}
+	if req.MaxRedemptions != nil {
+		code.MaxRedemptions = req.MaxRedemptions
+	}
+	if req.MaxPerUser != nil {
+		code.MaxPerUser = *req.MaxPerUser
+	}
+	if req.ValidFrom != nil {
+		code.ValidFrom = req.ValidFrom
+	}
+	if req.ValidUntil != nil {
+		code.ValidUntil = req.ValidUntil
+	}
+	if req.IsActive != nil {
+		code.IsActive = *req.IsActive
+	}
+
+	if code.ValidFrom != nil && code.ValidUntil != nil && !code.ValidUntil.After(*code.ValidFrom) {
+		return nil, ErrInvalidDiscount
+	}
+
+	if err := uc.promoRepo.Update(ctx, code); err != nil {
+		return nil, err
+	}
+
+	if req.EventIDs != nil {
+		if err := uc.checkEventsOwned(ctx, hostID, *req.EventIDs); err != nil {
+			return nil, err
+		}
+		if err := uc.promoRepo.SetEvents(ctx, code.ID, *req.EventIDs); err != nil {
+			return nil, err
+		}
+		code.EventIDs = *req.EventIDs
+	}
+
+	return code, nil
+}
+
+// DeactivateCode stops a promo code from being redeemed (owner only)
+// Codes are never deleted so redemptions remain visible in analytics
+func (uc *Usecase) DeactivateCode(ctx context.Context, codeID, hostID uuid.UUID) error {
+	code, err := uc.GetCode(ctx, codeID, hostID)
+	if err != nil {
+		return err
+	}
+
+	code.IsActive = false
+	return uc.promoRepo.Update(ctx, code)
+}
+
+// Quote looks up a promo code for an event purchase and calculates its discount
+// Limits are checked again atomically by Redeem
+func (uc *Usecase) Quote(ctx context.Context, evt *event.Event, codeText string, price float64) (*promo.Code, float64, error) {
+	code, err := uc.promoRepo.GetByHostAndCode(ctx, evt.HostID, strings.TrimSpace(codeText))
+	if err != nil {
+		return nil, 0, ErrCodeNotFound
+	}
+
+	if !code.IsValidAt(time.Now()) || !code.AppliesTo(evt.ID) || price <= 0 {
+		return nil, 0, ErrCodeNotValid
+	}
+
+	if code.IsExhausted() {
+		return nil, 0, ErrCodeExhausted
+	}
+
+	return code, code.Discount(price), nil
+}
+
+// Redeem records a promo code redemption for a ticket
+func (uc *Usecase) Redeem(ctx context.Context, redemption *promo.Redemption) error {
+	err := uc.promoRepo.Redeem(ctx, redemption)
+	switch err {
+	case promo.ErrCodeExhausted:
+		return ErrCodeExhausted
+	case promo.ErrUserLimitReached:
+		return ErrUserLimitReached
+	}
+	return err
+}
+
+// ReleaseRedemption gives a use back to the promo code of a ticket that was never paid
+func (uc *Usecase) ReleaseRedemption(ctx context.Context, ticketID uuid.UUID) error {
+	return uc.promoRepo.ReleaseRedemption(ctx, ticketID)
+}
+
+// RestoreRedemption takes the promo code uses of a ticket paid after it expired back
+// Returns false when the ticket had no released redemption
+func (uc *Usecase) RestoreRedemption(ctx context.Context, ticketID uuid.UUID) (bool, error) {
+	restored, err := uc.promoRepo.RestoreRedemption(ctx, ticketID)
+	switch err {
+	case promo.ErrCodeExhausted:
+		return false, ErrCodeExhausted
+	case promo.ErrUserLimitReached:
+		return false, ErrUserLimitReached
+	}
+	return restored, err
+}
+
+// checkEventsOwned verifies every restricted event belongs to the host
+func (uc *Usecase) checkEventsOwned(ctx context.Context, hostID uuid.UUID, eventIDs []uuid.UUID) error {
+	for _, eventID := range eventIDs {
+		evt, err := uc.eventRepo.GetByID(ctx, eventID)
+		if err != nil || evt.HostID != hostID {
+			return ErrInvalidEvent
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package promo
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/promo"
+	"github.com/google/uuid"
+)
+
+// fakePromoRepo holds one code and enforces its limits on redemption like the database
+type fakePromoRepo struct {
+	promo.Repository
+	code     *promo.Code
+	perUser  map[uuid.UUID]int
+	released map[uuid.UUID]*promo.Redemption // Released redemption by ticket
+}
+
+func newFakePromoRepo(code *promo.Code) *fakePromoRepo {
+	return &fakePromoRepo{
+		code:     code,
+		perUser:  map[uuid.UUID]int{},
+		released: map[uuid.UUID]*promo.Redemption{},
+	}
+}
+
+func (r *fakePromoRepo) GetByHostAndCode(ctx context.Context, hostID uuid.UUID, code string) (*promo.Code, error) {
+	if r.code.HostID != hostID || r.code.Code != code {
+		return nil, sql.ErrNoRows
+	}
+	copied := *r.code
+	return &copied, nil
+}
+
+func (r *fakePromoRepo) take(userID uuid.UUID, quantity int) error {
+	if r.code.MaxRedemptions != nil && r.code.RedemptionCount+quantity > *r.code.MaxRedemptions {
+		return promo.ErrCodeExhausted
+	}
+	if r.perUser[userID]+quantity > r.code.MaxPerUser {
+		return promo.ErrUserLimitReached
+	}
+	r.code.RedemptionCount += quantity
+	r.perUser[userID] += quantity
+	return nil
+}
+
+func (r *fakePromoRepo) Redeem(ctx context.Context, red *promo.Redemption) error {
+	return r.take(red.UserID, red.Quantity)
+}
+
+func (r *fakePromoRepo) RestoreRedemption(ctx context.Context, ticketID uuid.UUID) (bool, error) {
+	red, ok := r.released[ticketID]
+	if !ok {
+		return false, nil
+	}
+	if err := r.take(red.UserID, red.Quantity); err != nil {
+		return false, err
+	}
+	delete(r.released, ticketID)
+	return true, nil
+}
+
+func limit(n int) *int {
+	return &n
+}
+
+func TestPromoCodeLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxRedemptions *int
+		maxPerUser     int
+		redeemed       int // Uses taken by other users
+		userRedeemed   int // Uses taken by the buyer
+		quantity       int
+		wantQuoteErr   error
+		wantRedeemErr  error
+	}{
+		{name: "below the limit", maxRedemptions: limit(10), maxPerUser: 5, redeemed: 9, quantity: 1},
+		{name: "at the limit", maxRedemptions: limit(10), maxPerUser: 5, redeemed: 10, quantity: 1, wantQuoteErr: ErrCodeExhausted, wantRedeemErr: ErrCodeExhausted},
+		{name: "order larger than the uses left", maxRedemptions: limit(10), maxPerUser: 5, redeemed: 8, quantity: 3, wantRedeemErr: ErrCodeExhausted},
+		{name: "unlimited", maxPerUser: 5, redeemed: 1000, quantity: 5},
+		{name: "buyer at their limit", maxRedemptions: limit(10), maxPerUser: 2, userRedeemed: 2, quantity: 1, wantRedeemErr: ErrUserLimitReached},
+		{name: "order larger than the buyer's uses left", maxPerUser: 2, userRedeemed: 1, quantity: 2, wantRedeemErr: ErrUserLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			evt := &event.Event{ID: uuid.New(), HostID: uuid.New()}
+			buyerID := uuid.New()
+
+			repo := newFakePromoRepo(&promo.Code{
+				ID:              uuid.New(),
+				HostID:          evt.HostID,
+				Code:            "EARLY",
+				DiscountType:    promo.DiscountPercentage,
+				DiscountValue:   10,
+				MaxRedemptions:  tt.maxRedemptions,
+				MaxPerUser:      tt.maxPerUser,
+				RedemptionCount: tt.redeemed + tt.userRedeemed,
+				IsActive:        true,
+			})
+			repo.perUser[buyerID] = tt.userRedeemed
+			uc := NewUsecase(repo, nil)
+
+			code, _, err := uc.Quote(ctx, evt, "EARLY", 100)
+			if err != tt.wantQuoteErr {
+				t.Fatalf("Quote err = %v, want %v", err, tt.wantQuoteErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = uc.Redeem(ctx, &promo.Redemption{PromoCodeID: code.ID, UserID: buyerID, EventID: evt.ID, TicketID: uuid.New(), Quantity: tt.quantity})
+			if err != tt.wantRedeemErr {
+				t.Fatalf("Redeem err = %v, want %v", err, tt.wantRedeemErr)
+			}
+
+			wantCount := tt.redeemed + tt.userRedeemed
+			if err == nil {
+				wantCount += tt.quantity
+			}
+			if repo.code.RedemptionCount != wantCount {
+				t.Errorf("redemption count = %d, want %d", repo.code.RedemptionCount, wantCount)
+			}
+		})
+	}
+}
+
+func TestRestoreRedemptionAtLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		redeemed     int // Uses taken while the ticket's redemption was released
+		hasRedeemed  bool
+		wantRestored bool
+		wantErr      error
+	}{
+		{name: "uses left", redeemed: 1, hasRedeemed: true, wantRestored: true},
+		{name: "code used up in the meantime", redeemed: 2, hasRedeemed: true, wantErr: ErrCodeExhausted},
+		{name: "ticket without a released redemption", redeemed: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketID := uuid.New()
+			repo := newFakePromoRepo(&promo.Code{
+				ID:              uuid.New(),
+				MaxRedemptions:  limit(2),
+				MaxPerUser:      1,
+				RedemptionCount: tt.redeemed,
+				IsActive:        true,
+			})
+			if tt.hasRedeemed {
+				repo.released[ticketID] = &promo.Redemption{TicketID: ticketID, UserID: uuid.New(), Quantity: 1}
+			}
+			uc := NewUsecase(repo, nil)
+
+			restored, err := uc.RestoreRedemption(context.Background(), ticketID)
+			if err != tt.wantErr {
+				t.Fatalf("RestoreRedemption err = %v, want %v", err, tt.wantErr)
+			}
+			if restored != tt.wantRestored {
+				t.Errorf("restored = %v, want %v", restored, tt.wantRestored)
+			}
+			if repo.code.RedemptionCount > *repo.code.MaxRedemptions {
+				t.Errorf("redemption count %d is over the limit", repo.code.RedemptionCount)
+			}
+		})
+	}
+}
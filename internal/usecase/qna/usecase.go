@@ -7,6 +7,7 @@ import (
 
 	"github.com/anigmaa/backend/internal/domain/event"
 	"github.com/anigmaa/backend/internal/domain/qna"
+	eventUsecase "github.com/anigmaa/backend/internal/usecase/event"
 	"github.com/google/uuid"
 )
 
@@ -78,7 +79,7 @@ func (uc *Usecase) GetEventQnA(ctx context.Context, eventID, userID uuid.UUID, l
 	return uc.qnaRepo.GetByEvent(ctx, eventID, userID, limit, offset)
 }
 
-// AnswerQuestion answers a question (event organizer only)
+// AnswerQuestion answers a question (host or moderate_qna permission)
 func (uc *Usecase) AnswerQuestion(ctx context.Context, qnaID, userID uuid.UUID, req *qna.AnswerQnARequest) (*qna.QnA, error) {
 	// Get Q&A
 	q, err := uc.qnaRepo.GetByID(ctx, qnaID)
@@ -91,8 +92,10 @@ func (uc *Usecase) AnswerQuestion(ctx context.Context, qnaID, userID uuid.UUID,
 		return nil, ErrAlreadyAnswered
 	}
 
-	// TODO: Verify user is event organizer
-	// For now, allow anyone to answer
+	// Verify user can moderate the event's Q&A
+	if err := uc.authorizeModerator(ctx, q.EventID, userID); err != nil {
+		return nil, err
+	}
 
 	// Update Q&A with answer
 	now := time.Now()
@@ -146,7 +149,7 @@ func (uc *Usecase) RemoveUpvote(ctx context.Context, qnaID, userID uuid.UUID) er
 	return nil
 }
 
-// DeleteQuestion deletes a question (author only)
+// DeleteQuestion deletes a question (author, host or moderate_qna permission)
 func (uc *Usecase) DeleteQuestion(ctx context.Context, qnaID, userID uuid.UUID) error {
 	// Get Q&A
 	q, err := uc.qnaRepo.GetByID(ctx, qnaID)
@@ -154,9 +157,11 @@ func (uc *Usecase) DeleteQuestion(ctx context.Context, qnaID, userID uuid.UUID)
 		return ErrQnANotFound
 	}
 
-	// Check if user is the author
+	// The author can always delete their own question, otherwise require moderation rights
 	if q.AskedByID != userID {
-		return ErrUnauthorized
+		if err := uc.authorizeModerator(ctx, q.EventID, userID); err != nil {
+			return err
+		}
 	}
 
 	return uc.qnaRepo.Delete(ctx, qnaID)
@@ -166,3 +171,20 @@ func (uc *Usecase) DeleteQuestion(ctx context.Context, qnaID, userID uuid.UUID)
 func (uc *Usecase) CountEventQnA(ctx context.Context, eventID uuid.UUID) (int, error) {
 	return uc.qnaRepo.CountEventQnA(ctx, eventID)
 }
+
+// authorizeModerator checks that the user can moderate Q&A for the event
+func (uc *Usecase) authorizeModerator(ctx context.Context, eventID, userID uuid.UUID) error {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return ErrEventNotFound
+	}
+
+	allowed, err := eventUsecase.HasPermission(ctx, uc.eventRepo, evt, userID, event.PermissionModerateQnA)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrUnauthorized
+	}
+	return nil
+}
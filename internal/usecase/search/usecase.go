@@ -0,0 +1,123 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/search"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidType     = errors.New("invalid search type")
+	ErrQueryTooShort   = errors.New("search query must be at least 2 characters")
+	ErrInvalidLocation = errors.New("lat and lng must be provided together")
+)
+
+// previewLimit is how many results of each type type=all returns
+const previewLimit = 5
+
+// Usecase handles unified search business logic
+type Usecase struct {
+	searchRepo search.Repository
+}
+
+// NewUsecase creates a new search usecase
+func NewUsecase(searchRepo search.Repository) *Usecase {
+	return &Usecase{
+		searchRepo: searchRepo,
+	}
+}
+
+// Search runs a query across events, posts, hashtags, users and communities.
+// type=all returns the top few results of every type; a specific type returns
+// a page of that type only. Facets are always filled in.
+// viewerID is uuid.Nil for anonymous callers.
+func (uc *Usecase) Search(ctx context.Context, req *search.SearchRequest, viewerID uuid.UUID) (*search.Results, error) {
+	query := strings.TrimSpace(req.Query)
+	if len([]rune(query)) < 2 {
+		return nil, ErrQueryTooShort
+	}
+
+	searchType := req.Type
+	if searchType == "" {
+		searchType = search.TypeAll
+	}
+	if !searchType.IsValid() {
+		return nil, ErrInvalidType
+	}
+
+	if (req.Lat == nil) != (req.Lng == nil) {
+		return nil, ErrInvalidLocation
+	}
+
+	params := &search.Params{
+		Query:  query,
+		Lat:    req.Lat,
+		Lng:    req.Lng,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+		Now:    time.Now(),
+		Viewer: viewerID,
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+	if searchType == search.TypeAll {
+		params.Limit = previewLimit
+		params.Offset = 0
+	}
+
+	results := &search.Results{
+		Query:       query,
+		Type:        searchType,
+		Events:      []search.EventResult{},
+		Posts:       []search.PostResult{},
+		Hashtags:    []search.HashtagResult{},
+		Users:       []search.UserResult{},
+		Communities: []search.CommunityResult{},
+	}
+
+	facets, err := uc.searchRepo.CountResults(ctx, query, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	results.Facets = *facets
+
+	all := searchType == search.TypeAll
+	if all || searchType == search.TypeEvents {
+		if results.Events, err = uc.searchRepo.SearchEvents(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+	if all || searchType == search.TypePosts {
+		if results.Posts, err = uc.searchRepo.SearchPosts(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+	if all || searchType == search.TypeHashtags {
+		if results.Hashtags, err = uc.searchRepo.SearchHashtags(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+	if all || searchType == search.TypeUsers {
+		if results.Users, err = uc.searchRepo.SearchUsers(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+	if all || searchType == search.TypeCommunities {
+		if results.Communities, err = uc.searchRepo.SearchCommunities(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
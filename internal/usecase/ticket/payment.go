@@ -0,0 +1,192 @@
+package ticket
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/ticket"
+	"github.com/google/uuid"
+)
+
+// ProcessPaymentCallback applies a payment gateway notification to the tickets it paid for
+// A transaction ID covers every ticket of an order paid together. Repeated notifications
+// are safe: only tickets still waiting for the outcome change.
+func (uc *Usecase) ProcessPaymentCallback(ctx context.Context, transactionID string, status ticket.TransactionStatus) error {
+	if err := uc.ticketRepo.UpdateTransactionStatus(ctx, transactionID, status); err != nil {
+		return ErrTransactionNotFound
+	}
+
+	// An order paid in one transaction has one transaction record per ticket
+	transactions, err := uc.ticketRepo.GetTransactionsByTransactionID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case ticket.TransactionSuccess:
+		for i := range transactions {
+			uc.settlePaidTicket(ctx, &transactions[i])
+		}
+	case ticket.TransactionFailed:
+		uc.cancelUnpaidTickets(ctx, transactions)
+	case ticket.TransactionRefunded:
+		// Refunded through the gateway, take the sales back out of the hosts' balances
+		for _, transaction := range transactions {
+			if err := uc.payoutUsecase.RecordTicketRefund(ctx, transaction.TicketID); err != nil {
+				// Log error but continue with other tickets
+			}
+		}
+		if len(transactions) > 0 {
+			uc.invalidateAnalytics(ctx, transactions[0].TicketID)
+		}
+	}
+
+	return nil
+}
+
+// settlePaidTicket activates a ticket whose payment succeeded and credits the host
+// A ticket paid after its hold lapsed only gets a seat if one is still free and its promo
+// code can still be used, otherwise it is refunded
+func (uc *Usecase) settlePaidTicket(ctx context.Context, transaction *ticket.TicketTransaction) {
+	current, err := uc.ticketRepo.GetByID(ctx, transaction.TicketID)
+	if err != nil {
+		// Ticket not found, but transaction is updated, skip it
+		return
+	}
+
+	// A lapsed hold gave its promo code uses back, the discount only stands if the code can take them again
+	restored := false
+	if current.Status == ticket.StatusExpired || current.Status == ticket.StatusCancelled {
+		restored, err = uc.promoUsecase.RestoreRedemption(ctx, current.ID)
+		if err != nil {
+			uc.refundLatePayment(ctx, transaction)
+			return
+		}
+	}
+
+	// Capacity is checked again under the event lock for tickets whose hold lapsed
+	t, err := uc.ticketRepo.ActivatePaidTicket(ctx, transaction.TicketID)
+	if err != nil && restored {
+		if err := uc.promoUsecase.ReleaseRedemption(ctx, current.ID); err != nil {
+			// Log error but don't fail
+		}
+	}
+	if err == sql.ErrNoRows {
+		// Already settled (repeated notification)
+		return
+	}
+	if err != nil {
+		// No seat left, or the buyer got another live ticket for the event in the meantime
+		uc.refundLatePayment(ctx, transaction)
+		return
+	}
+
+	// Credit the host's balance, minus the platform fee
+	if err := uc.payoutUsecase.RecordTicketSale(ctx, t, transaction.Amount); err != nil {
+		// Log error but don't fail
+	}
+
+	// Unassigned order tickets join the event once handed to someone
+	if !t.IsAssigned {
+		return
+	}
+
+	attendee := &event.EventAttendee{
+		ID:       uuid.New(),
+		EventID:  t.EventID,
+		UserID:   t.UserID,
+		JoinedAt: time.Now(),
+		Status:   event.AttendeeConfirmed,
+	}
+	if err := uc.eventRepo.Join(ctx, attendee); err != nil {
+		// Log error but don't fail
+	}
+
+	// Increment events attended for user stats
+	if err := uc.userRepo.IncrementEventsAttended(ctx, t.UserID); err != nil {
+		// Log error but don't fail
+	}
+}
+
+// refundLatePayment refunds a payment that arrived for a ticket that can no longer be activated
+// The sale was never credited to the host, so there is nothing to take back out of their balance
+func (uc *Usecase) refundLatePayment(ctx context.Context, transaction *ticket.TicketTransaction) {
+	t, err := uc.ticketRepo.GetByID(ctx, transaction.TicketID)
+	if err != nil {
+		return
+	}
+
+	t.Status = ticket.StatusRefunded
+	if err := uc.ticketRepo.Update(ctx, t); err != nil {
+		// Log error but don't fail
+	}
+
+	// In production, you would initiate actual refund through payment gateway
+	refundTransaction := &ticket.TicketTransaction{
+		ID:            uuid.New(),
+		TicketID:      t.ID,
+		TransactionID: uuid.New().String(), // Would be from payment gateway
+		Amount:        transaction.Amount,
+		PaymentMethod: transaction.PaymentMethod,
+		Status:        ticket.TransactionRefunded,
+		CreatedAt:     time.Now(),
+	}
+	if err := uc.ticketRepo.CreateTransaction(ctx, refundTransaction); err != nil {
+		// Log error but don't fail
+	}
+
+	// Completed events keep their analytics cached
+	if err := uc.analyticsUsecase.InvalidateEventAnalytics(ctx, t.EventID); err != nil {
+		// Log error but don't fail
+	}
+}
+
+// invalidateAnalytics drops the cached analytics of the event a refunded ticket belongs to
+// An order is paid in one transaction, so all of its tickets share the event
+func (uc *Usecase) invalidateAnalytics(ctx context.Context, ticketID uuid.UUID) {
+	t, err := uc.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return
+	}
+
+	if err := uc.analyticsUsecase.InvalidateEventAnalytics(ctx, t.EventID); err != nil {
+		// Log error but don't fail
+	}
+}
+
+// cancelUnpaidTickets cancels the tickets of a failed payment that are still awaiting it,
+// gives their promo code uses back and offers the freed seats to the waitlist
+func (uc *Usecase) cancelUnpaidTickets(ctx context.Context, transactions []ticket.TicketTransaction) {
+	var eventID uuid.UUID
+	for _, transaction := range transactions {
+		t, err := uc.ticketRepo.GetByID(ctx, transaction.TicketID)
+		if err != nil {
+			continue
+		}
+
+		// Only tickets still awaiting payment are affected
+		if t.Status != ticket.StatusPending {
+			continue
+		}
+		eventID = t.EventID
+
+		t.Status = ticket.StatusCancelled
+		if err := uc.ticketRepo.Update(ctx, t); err != nil {
+			continue
+		}
+
+		// Unpaid ticket, give the promo code use back
+		if err := uc.promoUsecase.ReleaseRedemption(ctx, t.ID); err != nil {
+			// Log error but don't fail
+		}
+	}
+
+	// Offer the freed seats to the waitlist
+	if eventID != uuid.Nil {
+		if _, err := uc.waitlistUsecase.PromoteWaitlist(ctx, eventID); err != nil {
+			// Log error but don't fail
+		}
+	}
+}
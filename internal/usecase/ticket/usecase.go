@@ -2,13 +2,26 @@ package ticket
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/anigmaa/backend/internal/domain/block"
 	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/promo"
 	"github.com/anigmaa/backend/internal/domain/ticket"
 	"github.com/anigmaa/backend/internal/domain/user"
 	"github.com/anigmaa/backend/internal/infrastructure/payment"
+	analyticsUsecase "github.com/anigmaa/backend/internal/usecase/analytics"
+	eventUsecase "github.com/anigmaa/backend/internal/usecase/event"
+	payoutUsecase "github.com/anigmaa/backend/internal/usecase/payout"
+	promoUsecase "github.com/anigmaa/backend/internal/usecase/promo"
+	waitlistUsecase "github.com/anigmaa/backend/internal/usecase/waitlist"
 	"github.com/anigmaa/backend/pkg/qrcode"
 	"github.com/anigmaa/backend/pkg/utils"
 	"github.com/google/uuid"
@@ -17,7 +30,7 @@ import (
 var (
 	ErrTicketNotFound        = errors.New("ticket not found")
 	ErrEventNotFound         = errors.New("event not found")
-	ErrEventFull             = errors.New("event is full")
+	ErrEventFull             = ticket.ErrEventFull
 	ErrAlreadyPurchased      = errors.New("already purchased ticket for this event")
 	ErrInvalidAttendanceCode = errors.New("invalid attendance code")
 	ErrAlreadyCheckedIn      = errors.New("ticket already checked in")
@@ -25,28 +38,74 @@ var (
 	ErrCannotRefund          = errors.New("ticket cannot be refunded")
 	ErrEventStarted          = errors.New("event has already started")
 	ErrUnauthorized          = errors.New("unauthorized")
+	ErrTierNotFound          = errors.New("ticket tier not found")
+	ErrTierRequired          = errors.New("ticket tier is required for this event")
+	ErrTierSoldOut           = ticket.ErrTierSoldOut
+	ErrTierNotOnSale         = errors.New("ticket tier is not on sale")
+	ErrTierHasTickets        = errors.New("ticket tier already has tickets")
+	ErrInvalidTier           = errors.New("invalid ticket tier")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrTicketAssigned        = errors.New("ticket is already assigned")
+	ErrRecipientHasTicket    = errors.New("recipient already has a ticket for this event")
+	ErrRecipientNotFound     = errors.New("recipient not found")
+	ErrInviteNotFound        = errors.New("ticket invite not found")
+	ErrTransferNotFound      = errors.New("ticket transfer not found")
+	ErrTransferPending       = errors.New("ticket already has a pending transfer")
+	ErrTransferResolved      = errors.New("ticket transfer is no longer pending")
+	ErrTransfersDisabled     = errors.New("ticket transfers are disabled for this event")
+	ErrTicketNotTransferable = errors.New("only active, unused tickets can be transferred")
+	ErrCannotTransferToSelf  = errors.New("cannot transfer a ticket to yourself")
+	ErrInvalidQRCode         = errors.New("invalid ticket QR code")
+	ErrCheckInCodeRequired   = errors.New("QR code or attendance code is required")
+	ErrTicketUnassigned      = errors.New("ticket has not been assigned to an attendee")
+	ErrBlocked               = errors.New("cannot hand a ticket to or from a blocked user")
+	ErrTransactionNotFound   = errors.New("transaction not found")
 )
 
 // Usecase handles ticket business logic
 type Usecase struct {
-	ticketRepo     ticket.Repository
-	eventRepo      event.Repository
-	userRepo       user.Repository
-	midtransClient *payment.MidtransClient
+	ticketRepo       ticket.Repository
+	eventRepo        event.Repository
+	userRepo         user.Repository
+	blockRepo        block.Repository
+	midtransClient   *payment.MidtransClient
+	waitlistUsecase  *waitlistUsecase.Usecase
+	promoUsecase     *promoUsecase.Usecase
+	payoutUsecase    *payoutUsecase.Usecase
+	analyticsUsecase *analyticsUsecase.Usecase
+	qrSigner         *qrcode.Signer
+	snapshotSigner   *qrcode.SnapshotSigner
+	pendingTicketTTL time.Duration
 }
 
 // NewUsecase creates a new ticket usecase
-func NewUsecase(ticketRepo ticket.Repository, eventRepo event.Repository, userRepo user.Repository, midtransClient *payment.MidtransClient) *Usecase {
+// pendingTicketTTL is how long an unpaid ticket holds its seat before expiring
+func NewUsecase(ticketRepo ticket.Repository, eventRepo event.Repository, userRepo user.Repository, blockRepo block.Repository, midtransClient *payment.MidtransClient, waitlistUsecase *waitlistUsecase.Usecase, promoUsecase *promoUsecase.Usecase, payoutUsecase *payoutUsecase.Usecase, analyticsUsecase *analyticsUsecase.Usecase, qrSigner *qrcode.Signer, snapshotSigner *qrcode.SnapshotSigner, pendingTicketTTL time.Duration) *Usecase {
 	return &Usecase{
-		ticketRepo:     ticketRepo,
-		eventRepo:      eventRepo,
-		userRepo:       userRepo,
-		midtransClient: midtransClient,
+		ticketRepo:       ticketRepo,
+		eventRepo:        eventRepo,
+		userRepo:         userRepo,
+		blockRepo:        blockRepo,
+		midtransClient:   midtransClient,
+		waitlistUsecase:  waitlistUsecase,
+		promoUsecase:     promoUsecase,
+		payoutUsecase:    payoutUsecase,
+		analyticsUsecase: analyticsUsecase,
+		qrSigner:         qrSigner,
+		snapshotSigner:   snapshotSigner,
+		pendingTicketTTL: pendingTicketTTL,
 	}
 }
 
-// PurchaseTicket purchases a ticket for an event
+// PurchaseTicket purchases one or more tickets for an event in a single order
+// The buyer gets the first ticket; any extra tickets stay unassigned until the
+// buyer hands them to other users. Paid orders are billed as one Midtrans transaction.
 func (uc *Usecase) PurchaseTicket(ctx context.Context, userID uuid.UUID, req *ticket.PurchaseTicketRequest) (*ticket.PurchaseTicketResponse, error) {
+	quantity := req.Quantity
+	if quantity <= 0 {
+		quantity = 1
+	}
+
 	// Get event
 	evt, err := uc.eventRepo.GetByID(ctx, req.EventID)
 	if err != nil {
@@ -60,51 +119,108 @@ func (uc *Usecase) PurchaseTicket(ctx context.Context, userID uuid.UUID, req *ti
 
 	// Check if user already has a ticket for this event
 	existingTicket, err := uc.ticketRepo.GetUserTicketForEvent(ctx, userID, req.EventID)
-	if err == nil && existingTicket != nil {
+	if err == nil && existingTicket != nil && existingTicket.HoldsSeat() {
 		return nil, ErrAlreadyPurchased
 	}
 
-	// Verify user exists
-	usr, err := uc.userRepo.GetByID(ctx, userID)
+	// Check seat availability for the whole order (seats offered to waitlisted users are held for them)
+	offer, err := uc.waitlistUsecase.CheckSeat(ctx, evt, userID, quantity)
 	if err != nil {
-		return nil, errors.New("user not found")
+		if err == waitlistUsecase.ErrEventFull {
+			return nil, ErrEventFull
+		}
+		return nil, err
 	}
 
-	// Generate attendance code
-	attendanceCode, err := utils.GenerateAttendanceCode()
+	// Resolve the ticket tier, if the event sells tiers
+	tier, err := uc.resolveTier(ctx, evt, req.TierID, quantity)
 	if err != nil {
 		return nil, err
 	}
 
+	// Verify user exists
+	usr, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
 	// Determine price
-	pricePaid := 0.0
-	if !evt.IsFree && evt.Price != nil {
-		pricePaid = *evt.Price
+	listPrice := 0.0
+	if tier != nil {
+		listPrice = tier.Price
+	} else if !evt.IsFree && evt.Price != nil {
+		listPrice = *evt.Price
+	}
+
+	// Apply promo code discount (per ticket)
+	var promoCode *promo.Code
+	discount := 0.0
+	if req.PromoCode != nil && *req.PromoCode != "" {
+		promoCode, discount, err = uc.promoUsecase.Quote(ctx, evt, *req.PromoCode, listPrice)
+		if err != nil {
+			return nil, err
+		}
 	}
+	pricePaid := listPrice - discount
 
-	// For paid events, ticket starts as pending until payment is confirmed
-	// For free events, ticket is active immediately
+	// For paid events, tickets start as pending until payment is confirmed
+	// For free events, tickets are active immediately
 	ticketStatus := ticket.StatusActive
 	if !evt.IsFree && pricePaid > 0 {
 		ticketStatus = ticket.StatusPending
 	}
 
-	// Create ticket
-	now := time.Now()
-	newTicket := &ticket.Ticket{
+	// Create order with one ticket per seat, each with its own attendance code
+	order := &ticket.Order{
 		ID:             uuid.New(),
-		UserID:         userID,
+		BuyerID:        userID,
 		EventID:        req.EventID,
-		AttendanceCode: attendanceCode,
-		PricePaid:      pricePaid,
-		PurchasedAt:    now,
-		IsCheckedIn:    false,
-		Status:         ticketStatus,
+		TierID:         req.TierID,
+		Quantity:       quantity,
+		UnitPrice:      listPrice,
+		DiscountAmount: discount * float64(quantity),
+		TotalAmount:    pricePaid * float64(quantity),
+	}
+
+	// Attendance codes are generated by the repository, unique within the event
+	tickets := make([]*ticket.Ticket, quantity)
+	for i := range tickets {
+		tickets[i] = &ticket.Ticket{
+			ID:          uuid.New(),
+			UserID:      userID,
+			EventID:     req.EventID,
+			TierID:      req.TierID,
+			IsAssigned:  i == 0, // Buyer's own ticket
+			PricePaid:   pricePaid,
+			IsCheckedIn: false,
+			Status:      ticketStatus,
+		}
 	}
 
-	if err := uc.ticketRepo.Create(ctx, newTicket); err != nil {
+	// Capacity and tier quota are checked again atomically by the repository
+	if err := uc.ticketRepo.CreateOrder(ctx, order, tickets); err != nil {
 		return nil, err
 	}
+	newTicket := tickets[0]
+	now := order.CreatedAt
+
+	// Redeem the promo code for the whole order, recorded on the buyer's ticket;
+	// every discounted ticket counts against the code's limits
+	if promoCode != nil {
+		redemption := &promo.Redemption{
+			PromoCodeID:    promoCode.ID,
+			UserID:         userID,
+			EventID:        req.EventID,
+			TicketID:       newTicket.ID,
+			Quantity:       quantity,
+			OriginalAmount: listPrice * float64(quantity),
+			DiscountAmount: order.DiscountAmount,
+		}
+		if err := uc.promoUsecase.Redeem(ctx, redemption); err != nil {
+			_ = uc.ticketRepo.DeleteOrder(ctx, order.ID)
+			return nil, err
+		}
+	}
 
 	// Prepare response
 	response := &ticket.PurchaseTicketResponse{
@@ -114,13 +230,13 @@ func (uc *Usecase) PurchaseTicket(ctx context.Context, userID uuid.UUID, req *ti
 	// For paid events, create Midtrans payment
 	if !evt.IsFree && pricePaid > 0 {
 		// Generate order ID
-		orderID := payment.GenerateOrderID(newTicket.ID)
+		orderID := payment.GenerateOrderID(order.ID)
 
 		// Create Snap payment request
 		snapReq := &payment.SnapRequest{
 			TransactionDetails: payment.TransactionDetails{
 				OrderID:     orderID,
-				GrossAmount: pricePaid,
+				GrossAmount: order.TotalAmount,
 			},
 			CustomerDetails: payment.CustomerDetails{
 				FirstName: usr.Name,
@@ -131,47 +247,77 @@ func (uc *Usecase) PurchaseTicket(ctx context.Context, userID uuid.UUID, req *ti
 				{
 					ID:       evt.ID.String(),
 					Name:     evt.Title,
-					Price:    pricePaid,
-					Quantity: 1,
+					Price:    listPrice,
+					Quantity: quantity,
 				},
 			},
 		}
 
+		// Bill the tier as the line item so the payment page shows what was bought
+		if tier != nil {
+			snapReq.ItemDetails[0].ID = tier.ID.String()
+			snapReq.ItemDetails[0].Name = tierItemName(evt.Title, tier.Name)
+		}
+
+		// Show the discount as its own negative line so items add up to the gross amount
+		if promoCode != nil && discount > 0 {
+			snapReq.ItemDetails = append(snapReq.ItemDetails, payment.ItemDetail{
+				ID:       "PROMO-" + promoCode.Code,
+				Name:     "Promo " + promoCode.Code,
+				Price:    -discount,
+				Quantity: quantity,
+			})
+		}
+
+		// Payment page closes when the pending tickets would expire and release their seats
+		if uc.pendingTicketTTL > 0 {
+			snapReq.Expiry = &payment.Expiry{
+				Unit:     "minute",
+				Duration: int(uc.pendingTicketTTL.Minutes()),
+			}
+		}
+
 		// Call Midtrans Snap API to create payment token
 		snapResp, err := uc.midtransClient.CreateSnapToken(ctx, snapReq)
 		if err != nil {
-			// If Midtrans API fails, delete the ticket and return error
-			_ = uc.ticketRepo.Delete(ctx, newTicket.ID)
+			// If Midtrans API fails, delete the order and return error
+			_ = uc.promoUsecase.ReleaseRedemption(ctx, newTicket.ID)
+			_ = uc.ticketRepo.DeleteOrder(ctx, order.ID)
 			return nil, errors.New("failed to create payment: " + err.Error())
 		}
 
-		// Create transaction record with pending status
-		transaction := &ticket.TicketTransaction{
-			ID:            uuid.New(),
-			TicketID:      newTicket.ID,
-			TransactionID: orderID,
-			Amount:        pricePaid,
-			PaymentMethod: "midtrans",
-			Status:        ticket.TransactionPending,
-			CreatedAt:     now,
-			CompletedAt:   nil, // Will be set by webhook when payment is confirmed
-		}
-
+		paymentMethod := "midtrans"
 		if req.PaymentMethod != nil {
-			transaction.PaymentMethod = *req.PaymentMethod
+			paymentMethod = *req.PaymentMethod
 		}
 
-		if err := uc.ticketRepo.CreateTransaction(ctx, transaction); err != nil {
-			// If transaction creation fails, delete ticket and return error
-			_ = uc.ticketRepo.Delete(ctx, newTicket.ID)
-			return nil, errors.New("failed to create transaction: " + err.Error())
+		// Create one pending transaction record per ticket, all sharing the Midtrans order ID,
+		// so each ticket can be refunded on its own
+		for _, t := range tickets {
+			transaction := &ticket.TicketTransaction{
+				ID:            uuid.New(),
+				TicketID:      t.ID,
+				TransactionID: orderID,
+				Amount:        pricePaid,
+				PaymentMethod: paymentMethod,
+				Status:        ticket.TransactionPending,
+				CreatedAt:     now,
+				CompletedAt:   nil, // Will be set by webhook when payment is confirmed
+			}
+
+			if err := uc.ticketRepo.CreateTransaction(ctx, transaction); err != nil {
+				// If transaction creation fails, delete the order and return error
+				_ = uc.promoUsecase.ReleaseRedemption(ctx, newTicket.ID)
+				_ = uc.ticketRepo.DeleteOrder(ctx, order.ID)
+				return nil, errors.New("failed to create transaction: " + err.Error())
+			}
 		}
 
 		// Add payment info to response
 		response.PaymentToken = &snapResp.Token
 		response.PaymentURL = &snapResp.RedirectURL
 	} else {
-		// For free events, immediately join the event
+		// For free events, the buyer immediately joins the event
 		attendee := &event.EventAttendee{
 			ID:       uuid.New(),
 			EventID:  req.EventID,
@@ -189,16 +335,285 @@ func (uc *Usecase) PurchaseTicket(ctx context.Context, userID uuid.UUID, req *ti
 		}
 	}
 
-	// Generate QR code for the ticket
-	qrCode, err := qrcode.GenerateTicketQR(newTicket.ID, newTicket.EventID, newTicket.UserID, newTicket.AttendanceCode)
-	if err == nil {
-		response.QRCode = &qrCode
+	// Seat came from a waitlist offer, mark it claimed
+	if offer != nil {
+		if err := uc.waitlistUsecase.ClaimOffer(ctx, offer, &newTicket.ID); err != nil {
+			// Log error but don't fail
+		}
 	}
-	// If QR generation fails, continue without it (don't fail the request)
+
+	// Return every ticket of the order with its own QR code
+	response.Order = &ticket.OrderWithTickets{
+		Order:   *order,
+		Tickets: make([]ticket.TicketWithDetails, 0, len(tickets)),
+	}
+	for _, t := range tickets {
+		td := ticket.TicketWithDetails{
+			Ticket:         *t,
+			UserName:       usr.Name,
+			UserEmail:      usr.Email,
+			EventTitle:     evt.Title,
+			EventStartTime: evt.StartTime,
+			EventLocation:  evt.LocationName,
+		}
+		if tier != nil {
+			td.TierName = &tier.Name
+		}
+
+		// If QR generation fails for a ticket, continue without it (don't fail the request)
+		qrCode, err := uc.qrSigner.GenerateTicketQR(t.ID, t.EventID, t.UserID, t.AttendanceCode)
+		if err == nil {
+			td.QRCode = &qrCode
+		}
+		response.Order.Tickets = append(response.Order.Tickets, td)
+	}
+	response.QRCode = response.Order.Tickets[0].QRCode
 
 	return response, nil
 }
 
+// resolveTier validates the requested tier of an event has room for the given number of tickets
+// Returns nil when the event has no tiers and none was requested
+func (uc *Usecase) resolveTier(ctx context.Context, evt *event.Event, tierID *uuid.UUID, quantity int) (*ticket.Tier, error) {
+	if tierID == nil {
+		tiers, err := uc.ticketRepo.GetTiersByEvent(ctx, evt.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(tiers) > 0 {
+			return nil, ErrTierRequired
+		}
+		return nil, nil
+	}
+
+	tier, err := uc.ticketRepo.GetTierByID(ctx, *tierID)
+	if err != nil || tier.EventID != evt.ID {
+		return nil, ErrTierNotFound
+	}
+
+	if !tier.IsOnSale(time.Now()) {
+		return nil, ErrTierNotOnSale
+	}
+
+	sold, err := uc.ticketRepo.CountTierSeatsTaken(ctx, tier.ID)
+	if err != nil {
+		return nil, err
+	}
+	if sold+quantity > tier.Quota {
+		return nil, ErrTierSoldOut
+	}
+
+	return tier, nil
+}
+
+// tierItemName builds the Midtrans item name for a tier (Midtrans limits names to 50 characters)
+func tierItemName(eventTitle, tierName string) string {
+	name := []rune(eventTitle + " - " + tierName)
+	if len(name) > 50 {
+		name = name[:50]
+	}
+	return string(name)
+}
+
+// CreateTier creates a ticket tier for an event (host or edit_event permission)
+func (uc *Usecase) CreateTier(ctx context.Context, eventID, hostID uuid.UUID, req *ticket.CreateTierRequest) (*ticket.Tier, error) {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	if err := uc.authorize(ctx, evt, hostID, event.PermissionEditEvent); err != nil {
+		return nil, err
+	}
+
+	tier := &ticket.Tier{
+		ID:           uuid.New(),
+		EventID:      eventID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Price:        req.Price,
+		Quota:        req.Quota,
+		SaleStartsAt: req.SaleStartsAt,
+		SaleEndsAt:   req.SaleEndsAt,
+		SortOrder:    req.SortOrder,
+	}
+
+	otherQuotas, err := uc.sumTierQuotas(ctx, eventID, tier.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTier(evt, tier, otherQuotas); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ticketRepo.CreateTier(ctx, tier); err != nil {
+		return nil, err
+	}
+
+	return tier, nil
+}
+
+// UpdateTier updates a ticket tier (host or edit_event permission)
+// Price cannot change once tickets have been issued for the tier
+func (uc *Usecase) UpdateTier(ctx context.Context, eventID, tierID, hostID uuid.UUID, req *ticket.UpdateTierRequest) (*ticket.Tier, error) {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	if err := uc.authorize(ctx, evt, hostID, event.PermissionEditEvent); err != nil {
+		return nil, err
+	}
+
+	tier, err := uc.ticketRepo.GetTierByID(ctx, tierID)
+	if err != nil || tier.EventID != eventID {
+		return nil, ErrTierNotFound
+	}
+
+	issued, err := uc.ticketRepo.CountTierTickets(ctx, tierID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.Name != nil {
+		tier.Name = *req.Name
+	}
+	if req.Description != nil {
+		tier.Description = req.Description
+	}
+	if req.Price != nil && *req.Price != tier.Price {
+		if issued > 0 {
+			return nil, ErrTierHasTickets
+		}
+		tier.Price = *req.Price
+	}
+	if req.Quota != nil {
+		tier.Quota = *req.Quota
+	}
+	if req.SaleStartsAt != nil {
+		tier.SaleStartsAt = req.SaleStartsAt
+	}
+	if req.SaleEndsAt != nil {
+		tier.SaleEndsAt = req.SaleEndsAt
+	}
+	if req.SortOrder != nil {
+		tier.SortOrder = *req.SortOrder
+	}
+
+	otherQuotas, err := uc.sumTierQuotas(ctx, eventID, tier.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTier(evt, tier, otherQuotas); err != nil {
+		return nil, err
+	}
+
+	// Quota cannot drop below seats already taken
+	sold, err := uc.ticketRepo.CountTierSeatsTaken(ctx, tierID)
+	if err != nil {
+		return nil, err
+	}
+	if tier.Quota < sold {
+		return nil, ErrInvalidTier
+	}
+
+	if err := uc.ticketRepo.UpdateTier(ctx, tier); err != nil {
+		return nil, err
+	}
+
+	return tier, nil
+}
+
+// DeleteTier deletes a ticket tier that has no tickets (host or edit_event permission)
+func (uc *Usecase) DeleteTier(ctx context.Context, eventID, tierID, hostID uuid.UUID) error {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return ErrEventNotFound
+	}
+
+	if err := uc.authorize(ctx, evt, hostID, event.PermissionEditEvent); err != nil {
+		return err
+	}
+
+	tier, err := uc.ticketRepo.GetTierByID(ctx, tierID)
+	if err != nil || tier.EventID != eventID {
+		return ErrTierNotFound
+	}
+
+	issued, err := uc.ticketRepo.CountTierTickets(ctx, tierID)
+	if err != nil {
+		return err
+	}
+	if issued > 0 {
+		return ErrTierHasTickets
+	}
+
+	return uc.ticketRepo.DeleteTier(ctx, tierID)
+}
+
+// GetEventTiers gets the ticket tiers of an event with live availability
+func (uc *Usecase) GetEventTiers(ctx context.Context, eventID, viewerID uuid.UUID) ([]ticket.TierWithAvailability, error) {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	// Opening the tiers is the ticket page step of the host's conversion funnel
+	eventUsecase.RecordPageView(ctx, uc.eventRepo, evt, viewerID, event.FunnelTicketPage)
+
+	tiers, err := uc.ticketRepo.GetTiersByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range tiers {
+		tiers[i].Remaining = tiers[i].Quota - tiers[i].Sold
+		if tiers[i].Remaining < 0 {
+			tiers[i].Remaining = 0
+		}
+		tiers[i].IsOnSale = tiers[i].Tier.IsOnSale(now) && tiers[i].Remaining > 0
+	}
+
+	return tiers, nil
+}
+
+// validateTier checks a tier against its event and the quotas of the event's other tiers
+func validateTier(evt *event.Event, tier *ticket.Tier, otherQuotas int) error {
+	// Free events cannot sell priced tiers
+	if evt.IsFree && tier.Price > 0 {
+		return ErrInvalidTier
+	}
+
+	if tier.SaleStartsAt != nil && tier.SaleEndsAt != nil && !tier.SaleEndsAt.After(*tier.SaleStartsAt) {
+		return ErrInvalidTier
+	}
+
+	// Tiers together cannot promise more seats than the event has
+	if evt.MaxAttendees > 0 && otherQuotas+tier.Quota > evt.MaxAttendees {
+		return ErrInvalidTier
+	}
+
+	return nil
+}
+
+// sumTierQuotas sums the quotas of an event's tiers, leaving out the given tier
+func (uc *Usecase) sumTierQuotas(ctx context.Context, eventID, exceptTierID uuid.UUID) (int, error) {
+	tiers, err := uc.ticketRepo.GetTiersByEvent(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, t := range tiers {
+		if t.ID != exceptTierID {
+			total += t.Quota
+		}
+	}
+	return total, nil
+}
+
 // GetTicketByID gets a ticket by ID
 func (uc *Usecase) GetTicketByID(ctx context.Context, ticketID uuid.UUID) (*ticket.Ticket, error) {
 	t, err := uc.ticketRepo.GetByID(ctx, ticketID)
@@ -221,7 +636,7 @@ func (uc *Usecase) GetTicketWithDetails(ctx context.Context, ticketID, userID uu
 	}
 
 	// Generate QR code for the ticket
-	qrCode, err := qrcode.GenerateTicketQR(t.ID, t.EventID, t.UserID, t.AttendanceCode)
+	qrCode, err := uc.qrSigner.GenerateTicketQR(t.ID, t.EventID, t.UserID, t.AttendanceCode)
 	if err == nil {
 		t.QRCode = &qrCode
 	}
@@ -246,7 +661,7 @@ func (uc *Usecase) GetUserTickets(ctx context.Context, userID uuid.UUID, limit,
 
 	// Generate QR codes for all tickets
 	for i := range tickets {
-		qrCode, err := qrcode.GenerateTicketQR(tickets[i].ID, tickets[i].EventID, tickets[i].UserID, tickets[i].AttendanceCode)
+		qrCode, err := uc.qrSigner.GenerateTicketQR(tickets[i].ID, tickets[i].EventID, tickets[i].UserID, tickets[i].AttendanceCode)
 		if err == nil {
 			tickets[i].QRCode = &qrCode
 		}
@@ -261,7 +676,7 @@ func (uc *Usecase) CountUserTickets(ctx context.Context, userID uuid.UUID) (int,
 	return uc.ticketRepo.CountUserTickets(ctx, userID)
 }
 
-// GetEventTickets gets all tickets for an event (host only)
+// GetEventTickets gets all tickets for an event (host, check-in or analytics staff)
 func (uc *Usecase) GetEventTickets(ctx context.Context, eventID, requestingUserID uuid.UUID, limit, offset int) ([]ticket.TicketWithDetails, error) {
 	// Get event
 	evt, err := uc.eventRepo.GetByID(ctx, eventID)
@@ -269,9 +684,9 @@ func (uc *Usecase) GetEventTickets(ctx context.Context, eventID, requestingUserI
 		return nil, ErrEventNotFound
 	}
 
-	// Check if requesting user is the host
-	if evt.HostID != requestingUserID {
-		return nil, ErrUnauthorized
+	// Check if requesting user is the host or staff working the event
+	if err := uc.authorize(ctx, evt, requestingUserID, event.PermissionCheckIn, event.PermissionViewAnalytics); err != nil {
+		return nil, err
 	}
 
 	if limit <= 0 {
@@ -288,32 +703,82 @@ func (uc *Usecase) GetEventTickets(ctx context.Context, eventID, requestingUserI
 
 	// Generate QR codes for all tickets
 	for i := range tickets {
-		qrCode, err := qrcode.GenerateTicketQR(tickets[i].ID, tickets[i].EventID, tickets[i].UserID, tickets[i].AttendanceCode)
+		qrCode, err := uc.qrSigner.GenerateTicketQR(tickets[i].ID, tickets[i].EventID, tickets[i].UserID, tickets[i].AttendanceCode)
 		if err == nil {
 			tickets[i].QRCode = &qrCode
 		}
 		// If QR generation fails for a ticket, continue without it
 	}
 
+	// Attach transfer history so the host can see who held each ticket
+	transfers, err := uc.ticketRepo.GetTransfersByEvent(ctx, eventID)
+	if err == nil {
+		byTicket := make(map[uuid.UUID][]ticket.TransferWithDetails)
+		for _, tr := range transfers {
+			byTicket[tr.TicketID] = append(byTicket[tr.TicketID], tr)
+		}
+		for i := range tickets {
+			tickets[i].Transfers = byTicket[tickets[i].ID]
+		}
+	}
+	// If history cannot be loaded, return the tickets without it
+
 	return tickets, nil
 }
 
-// CheckIn checks in a ticket using attendance code
-func (uc *Usecase) CheckIn(ctx context.Context, eventID uuid.UUID, req *ticket.CheckInRequest) (*ticket.Ticket, error) {
-	// Validate attendance code format
-	if !utils.ValidateAttendanceCode(req.AttendanceCode) {
-		return nil, ErrInvalidAttendanceCode
+// CheckIn checks an attendee in at the door using the ticket QR code or attendance code (host or staff only)
+// Scanning a ticket that is already checked in succeeds again with AlreadyCheckedIn set
+func (uc *Usecase) CheckIn(ctx context.Context, eventID, staffUserID uuid.UUID, req *ticket.CheckInRequest) (*ticket.CheckInResult, error) {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
 	}
 
-	// Get ticket by attendance code
-	t, err := uc.ticketRepo.GetByAttendanceCode(ctx, req.AttendanceCode)
-	if err != nil {
-		return nil, ErrTicketNotFound
+	if err := uc.authorize(ctx, evt, staffUserID, event.PermissionCheckIn); err != nil {
+		return nil, err
 	}
 
-	// Verify ticket is for the correct event
-	if t.EventID != eventID {
-		return nil, ErrTicketNotFound
+	var t *ticket.Ticket
+	switch {
+	case req.QRCode != "":
+		// Verify the QR signature before trusting anything in it
+		data, err := uc.qrSigner.DecodeTicketQR(req.QRCode)
+		if err != nil {
+			return nil, ErrInvalidQRCode
+		}
+
+		if data.EventID != eventID {
+			return nil, ErrTicketNotFound
+		}
+
+		t, err = uc.ticketRepo.GetByID(ctx, data.TicketID)
+		if err != nil || t.EventID != eventID {
+			return nil, ErrTicketNotFound
+		}
+
+		// A transfer replaces the attendance code, so QR codes of earlier holders stop working
+		if t.AttendanceCode != data.AttendanceCode || t.UserID != data.UserID {
+			return nil, ErrInvalidQRCode
+		}
+	case req.AttendanceCode != "":
+		code := strings.ToUpper(strings.TrimSpace(req.AttendanceCode))
+
+		// Validate attendance code format
+		if !utils.ValidateAttendanceCode(code) {
+			return nil, ErrInvalidAttendanceCode
+		}
+
+		t, err = uc.ticketRepo.GetByAttendanceCode(ctx, eventID, code)
+		if err != nil {
+			return nil, ErrTicketNotFound
+		}
+	default:
+		return nil, ErrCheckInCodeRequired
+	}
+
+	// Unassigned order tickets have no attendee yet
+	if !t.IsAssigned {
+		return nil, ErrTicketUnassigned
 	}
 
 	// Check if ticket is active
@@ -321,63 +786,341 @@ func (uc *Usecase) CheckIn(ctx context.Context, eventID uuid.UUID, req *ticket.C
 		return nil, ErrTicketNotActive
 	}
 
-	// Check if already checked in
-	if t.IsCheckedIn {
-		return nil, ErrAlreadyCheckedIn
-	}
-
-	// Perform check-in
-	if err := uc.ticketRepo.CheckIn(ctx, t.ID); err != nil {
-		return nil, err
+	alreadyCheckedIn := t.IsCheckedIn
+	if !alreadyCheckedIn {
+		if err := uc.ticketRepo.CheckIn(ctx, t.ID); err != nil {
+			// Another scanner may have checked the ticket in first
+			current, getErr := uc.ticketRepo.GetByID(ctx, t.ID)
+			if getErr != nil || !current.IsCheckedIn {
+				return nil, err
+			}
+			alreadyCheckedIn = true
+		}
 	}
 
-	// Get updated ticket
-	updatedTicket, err := uc.ticketRepo.GetByID(ctx, t.ID)
+	details, err := uc.ticketRepo.GetWithDetails(ctx, t.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return updatedTicket, nil
+	return &ticket.CheckInResult{
+		TicketID:          details.ID,
+		EventID:           details.EventID,
+		AttendeeID:        details.UserID,
+		AttendeeName:      details.UserName,
+		AttendeeAvatarURL: details.UserAvatarURL,
+		TierName:          details.TierName,
+		CheckedInAt:       details.CheckedInAt,
+		AlreadyCheckedIn:  alreadyCheckedIn,
+	}, nil
 }
 
-// CancelTicket cancels a ticket and issues refund (if applicable)
-func (uc *Usecase) CancelTicket(ctx context.Context, ticketID, userID uuid.UUID) error {
-	// Get ticket
-	t, err := uc.ticketRepo.GetByID(ctx, ticketID)
+// offlineClockSkew is how far in the future a device clock may be before its scans are refused
+const offlineClockSkew = 5 * time.Minute
+
+// GetCheckInSnapshot builds the signed list of valid tickets a scanner device downloads before going offline (host or check_in permission)
+func (uc *Usecase) GetCheckInSnapshot(ctx context.Context, eventID, staffUserID uuid.UUID) (*ticket.CheckInSnapshot, error) {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
-		return ErrTicketNotFound
+		return nil, ErrEventNotFound
 	}
 
-	// Verify user owns this ticket
-	if t.UserID != userID {
-		return ErrUnauthorized
+	if err := uc.authorize(ctx, evt, staffUserID, event.PermissionCheckIn); err != nil {
+		return nil, err
 	}
 
-	// Check if ticket can be cancelled
-	if !t.CanBeRefunded() {
-		return ErrCannotRefund
+	tickets, err := uc.ticketRepo.GetCheckInRoster(ctx, eventID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get event to check timing
-	evt, err := uc.eventRepo.GetByID(ctx, t.EventID)
-	if err != nil {
-		return ErrEventNotFound
+	snapshot := &ticket.CheckInSnapshot{
+		EventID:     eventID,
+		GeneratedAt: time.Now(),
+		Tickets:     make([]ticket.SnapshotTicket, 0, len(tickets)),
+	}
+	for _, t := range tickets {
+		snapshot.Tickets = append(snapshot.Tickets, ticket.SnapshotTicket{
+			TicketID:     t.ID,
+			AttendeeName: t.UserName,
+			TierName:     t.TierName,
+			CheckedIn:    t.IsCheckedIn,
+		})
 	}
 
-	// Check if event has already started
-	if evt.IsStartingSoon() || evt.IsOngoing() || evt.IsCompleted() {
-		return ErrEventStarted
+	// Sign the snapshot as serialized without its signature
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
 	}
+	snapshot.Signature = uc.snapshotSigner.Sign(payload)
 
-	// Update ticket status
-	t.Status = ticket.StatusCancelled
-	if err := uc.ticketRepo.Update(ctx, t); err != nil {
-		return err
+	return snapshot, nil
+}
+
+// GetCheckInPublicKey gets the public key scanner devices verify check-in snapshots with
+func (uc *Usecase) GetCheckInPublicKey() string {
+	return uc.snapshotSigner.PublicKey()
+}
+
+// SyncOfflineCheckIns reconciles check-ins a scanner device recorded while offline (host or check_in permission)
+// Scans are applied oldest first and the earliest scan of a ticket wins across all devices,
+// so batches give the same result whatever order they are uploaded in. Every scan is reported back.
+func (uc *Usecase) SyncOfflineCheckIns(ctx context.Context, eventID, staffUserID uuid.UUID, req *ticket.SyncCheckInsRequest) (*ticket.SyncCheckInsResult, error) {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
 	}
 
-	// Leave the event
-	if err := uc.eventRepo.Leave(ctx, t.EventID, userID); err != nil {
-		// Log error but don't fail
+	if err := uc.authorize(ctx, evt, staffUserID, event.PermissionCheckIn); err != nil {
+		return nil, err
+	}
+
+	deviceID := strings.TrimSpace(req.DeviceID)
+
+	// Postgres keeps microseconds, so compare scan times at that precision
+	scans := make([]ticket.OfflineScan, len(req.CheckIns))
+	for i, scan := range req.CheckIns {
+		scan.ScannedAt = scan.ScannedAt.UTC().Truncate(time.Microsecond)
+		scans[i] = scan
+	}
+	sort.SliceStable(scans, func(i, j int) bool {
+		return scans[i].ScannedAt.Before(scans[j].ScannedAt)
+	})
+
+	result := &ticket.SyncCheckInsResult{
+		EventID:  eventID,
+		DeviceID: deviceID,
+		Results:  make([]ticket.OfflineScanResult, 0, len(scans)),
+	}
+	logs := make([]ticket.OfflineCheckIn, 0, len(scans))
+
+	now := time.Now()
+	for _, scan := range scans {
+		scanResult, err := uc.reconcileOfflineScan(ctx, eventID, deviceID, scan, now)
+		if err != nil {
+			return nil, err
+		}
+
+		switch scanResult.Status {
+		case ticket.OfflineCheckInAccepted:
+			result.Accepted++
+		case ticket.OfflineCheckInDuplicate:
+			result.Duplicates++
+		case ticket.OfflineCheckInRejected:
+			result.Rejected++
+		}
+		result.Results = append(result.Results, scanResult)
+
+		entry := ticket.OfflineCheckIn{
+			EventID:    eventID,
+			TicketID:   scan.TicketID,
+			DeviceID:   deviceID,
+			ScannedAt:  scan.ScannedAt,
+			UploadedBy: staffUserID,
+			Status:     scanResult.Status,
+		}
+		if scanResult.Reason != "" {
+			reason := scanResult.Reason
+			entry.Reason = &reason
+		}
+		logs = append(logs, entry)
+	}
+
+	if err := uc.ticketRepo.CreateOfflineCheckIns(ctx, logs); err != nil {
+		// Log error but don't fail, the check-ins themselves are applied
+	}
+
+	return result, nil
+}
+
+// reconcileOfflineScan applies a single offline scan and reports its outcome
+func (uc *Usecase) reconcileOfflineScan(ctx context.Context, eventID uuid.UUID, deviceID string, scan ticket.OfflineScan, now time.Time) (ticket.OfflineScanResult, error) {
+	result := ticket.OfflineScanResult{
+		TicketID:  scan.TicketID,
+		ScannedAt: scan.ScannedAt,
+		Status:    ticket.OfflineCheckInRejected,
+	}
+
+	if scan.ScannedAt.After(now.Add(offlineClockSkew)) {
+		result.Reason = "scan time is in the future"
+		return result, nil
+	}
+
+	t, err := uc.ticketRepo.GetByID(ctx, scan.TicketID)
+	if err != nil || t.EventID != eventID {
+		result.Reason = ErrTicketNotFound.Error()
+		return result, nil
+	}
+	if !t.IsAssigned {
+		result.Reason = ErrTicketUnassigned.Error()
+		return result, nil
+	}
+	if t.Status != ticket.StatusActive {
+		result.Reason = ErrTicketNotActive.Error()
+		return result, nil
+	}
+
+	// A transfer replaces the attendance code, so the previous holder's ticket no longer gets in
+	if !strings.EqualFold(t.AttendanceCode, strings.TrimSpace(scan.AttendanceCode)) {
+		result.Reason = ErrInvalidAttendanceCode.Error()
+		return result, nil
+	}
+
+	if err := uc.ticketRepo.CheckInOffline(ctx, t.ID, deviceID, scan.ScannedAt); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return result, err
+	}
+
+	// Report whichever scan holds the check-in now
+	current, err := uc.ticketRepo.GetByID(ctx, t.ID)
+	if err != nil {
+		return result, err
+	}
+	result.CheckedInAt = current.CheckedInAt
+	result.CheckedInDeviceID = current.CheckedInDeviceID
+
+	if current.CheckedInAt != nil && current.CheckedInAt.Equal(scan.ScannedAt) &&
+		current.CheckedInDeviceID != nil && *current.CheckedInDeviceID == deviceID {
+		result.Status = ticket.OfflineCheckInAccepted
+		return result, nil
+	}
+
+	result.Status = ticket.OfflineCheckInDuplicate
+	result.Reason = ErrAlreadyCheckedIn.Error()
+	return result, nil
+}
+
+// authorize checks that a user is the host or holds one of the permissions on the event
+func (uc *Usecase) authorize(ctx context.Context, evt *event.Event, userID uuid.UUID, permissions ...event.Permission) error {
+	allowed, err := eventUsecase.HasPermission(ctx, uc.eventRepo, evt, userID, permissions...)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CancelTicket cancels a ticket and issues refund (if applicable)
+func (uc *Usecase) CancelTicket(ctx context.Context, ticketID, userID uuid.UUID) error {
+	// Get ticket
+	t, err := uc.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return ErrTicketNotFound
+	}
+
+	// Verify user owns this ticket
+	if t.UserID != userID {
+		return ErrUnauthorized
+	}
+
+	// Check if ticket can be cancelled
+	if !t.CanBeRefunded() {
+		return ErrCannotRefund
+	}
+
+	// Get event to check timing
+	evt, err := uc.eventRepo.GetByID(ctx, t.EventID)
+	if err != nil {
+		return ErrEventNotFound
+	}
+
+	// Check if event has already started
+	if evt.IsStartingSoon() || evt.IsOngoing() || evt.IsCompleted() {
+		return ErrEventStarted
+	}
+
+	return uc.refund(ctx, t, ticket.StatusCancelled)
+}
+
+// ForceRefund refunds a ticket on behalf of platform staff, regardless of who holds it,
+// whether it was used or whether the event already started
+func (uc *Usecase) ForceRefund(ctx context.Context, ticketID uuid.UUID) (*ticket.Ticket, error) {
+	t, err := uc.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	if t.Status != ticket.StatusActive {
+		return nil, ErrCannotRefund
+	}
+
+	if err := uc.refund(ctx, t, ticket.StatusRefunded); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// RefundEventTickets refunds every active ticket of a cancelled event
+func (uc *Usecase) RefundEventTickets(ctx context.Context, eventID uuid.UUID) error {
+	tickets, err := uc.ticketRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	for i := range tickets {
+		if tickets[i].Status != ticket.StatusActive {
+			continue
+		}
+		if err := uc.refund(ctx, &tickets[i], ticket.StatusRefunded); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CancelUpcomingTickets cancels and refunds a user's active tickets for events that did not start yet
+func (uc *Usecase) CancelUpcomingTickets(ctx context.Context, userID uuid.UUID) error {
+	const pageSize = 100
+
+	// Collect first, cancelling while paging would shift the pages
+	var upcoming []ticket.Ticket
+	now := time.Now()
+	for offset := 0; ; offset += pageSize {
+		tickets, err := uc.ticketRepo.GetByUser(ctx, userID, pageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, t := range tickets {
+			if t.Status == ticket.StatusActive && t.EventStartTime.After(now) {
+				upcoming = append(upcoming, t.Ticket)
+			}
+		}
+		if len(tickets) < pageSize {
+			break
+		}
+	}
+
+	for i := range upcoming {
+		if err := uc.refund(ctx, &upcoming[i], ticket.StatusCancelled); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// refund moves a ticket to the given status, records the refund of a paid ticket and frees its seat
+func (uc *Usecase) refund(ctx context.Context, t *ticket.Ticket, status ticket.TicketStatus) error {
+	// Update ticket status
+	t.Status = status
+	if err := uc.ticketRepo.Update(ctx, t); err != nil {
+		return err
+	}
+
+	// A cancelled ticket can no longer be handed over
+	if pending, err := uc.ticketRepo.GetPendingTransferByTicket(ctx, t.ID); err == nil && pending != nil {
+		uc.expireTransfer(ctx, pending)
+	}
+
+	// Leave the event (unassigned order tickets never joined it)
+	if t.IsAssigned {
+		if err := uc.eventRepo.Leave(ctx, t.EventID, t.UserID); err != nil {
+			// Log error but don't fail
+		}
 	}
 
 	// For paid tickets, create refund transaction
@@ -398,6 +1141,21 @@ func (uc *Usecase) CancelTicket(ctx context.Context, ticketID, userID uuid.UUID)
 		if err := uc.ticketRepo.CreateTransaction(ctx, refundTransaction); err != nil {
 			// Log error but don't fail cancellation
 		}
+
+		// Take the sale back out of the host's balance
+		if err := uc.payoutUsecase.RecordTicketRefund(ctx, t.ID); err != nil {
+			// Log error but don't fail cancellation
+		}
+	}
+
+	// Completed events keep their analytics cached
+	if err := uc.analyticsUsecase.InvalidateEventAnalytics(ctx, t.EventID); err != nil {
+		// Log error but don't fail cancellation
+	}
+
+	// Offer the freed seat to the waitlist
+	if _, err := uc.waitlistUsecase.PromoteWaitlist(ctx, t.EventID); err != nil {
+		// Log error but don't fail
 	}
 
 	return nil
@@ -411,9 +1169,9 @@ func (uc *Usecase) GetCheckedInCount(ctx context.Context, eventID, requestingUse
 		return 0, ErrEventNotFound
 	}
 
-	// Check if requesting user is the host
-	if evt.HostID != requestingUserID {
-		return 0, ErrUnauthorized
+	// Check if requesting user is the host or staff working the event
+	if err := uc.authorize(ctx, evt, requestingUserID, event.PermissionCheckIn, event.PermissionViewAnalytics); err != nil {
+		return 0, err
 	}
 
 	return uc.ticketRepo.GetCheckedInCount(ctx, eventID)
@@ -474,41 +1232,394 @@ func (uc *Usecase) GetTransaction(ctx context.Context, transactionID string, use
 	return transaction, nil
 }
 
-// ProcessPaymentCallback handles payment gateway callback
-// This would be called by Midtrans webhook in production
-func (uc *Usecase) ProcessPaymentCallback(ctx context.Context, transactionID string, status ticket.TransactionStatus) error {
-	// Get transaction
-	transaction, err := uc.ticketRepo.GetTransaction(ctx, transactionID)
-	if err != nil {
-		return errors.New("transaction not found")
+// ExpirePendingTickets expires unpaid tickets older than the pending TTL,
+// releases their promo code redemptions and offers their seats to the waitlist
+// This should be called periodically by a background job
+func (uc *Usecase) ExpirePendingTickets(ctx context.Context) error {
+	if uc.pendingTicketTTL <= 0 {
+		return nil
 	}
 
-	// Update transaction status
-	if err := uc.ticketRepo.UpdateTransactionStatus(ctx, transactionID, status); err != nil {
+	expired, err := uc.ticketRepo.ExpirePendingTickets(ctx, time.Now().Add(-uc.pendingTicketTTL))
+	if err != nil {
 		return err
 	}
 
-	// If payment failed, cancel the ticket
-	if status == ticket.TransactionFailed {
-		t, err := uc.ticketRepo.GetByID(ctx, transaction.TicketID)
-		if err != nil {
-			return err
+	promoted := make(map[uuid.UUID]bool)
+	for _, t := range expired {
+		// Unpaid ticket, give the promo code use back
+		if err := uc.promoUsecase.ReleaseRedemption(ctx, t.ID); err != nil {
+			// Log error but continue with other tickets
 		}
 
-		t.Status = ticket.StatusCancelled
-		if err := uc.ticketRepo.Update(ctx, t); err != nil {
-			return err
+		if promoted[t.EventID] {
+			continue
 		}
+		promoted[t.EventID] = true
 
-		// Remove from event attendees
-		if err := uc.eventRepo.Leave(ctx, t.EventID, t.UserID); err != nil {
-			// Log error but don't fail
+		if _, err := uc.waitlistUsecase.PromoteWaitlist(ctx, t.EventID); err != nil {
+			// Log error but continue with other events
 		}
 	}
 
 	return nil
 }
 
+// GetOrder gets an order with all of its tickets (buyer only)
+func (uc *Usecase) GetOrder(ctx context.Context, orderID, userID uuid.UUID) (*ticket.OrderWithTickets, error) {
+	o, err := uc.ticketRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	if o.BuyerID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	tickets, err := uc.ticketRepo.GetTicketsByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate QR codes for all tickets
+	for i := range tickets {
+		qrCode, err := uc.qrSigner.GenerateTicketQR(tickets[i].ID, tickets[i].EventID, tickets[i].UserID, tickets[i].AttendanceCode)
+		if err == nil {
+			tickets[i].QRCode = &qrCode
+		}
+		// If QR generation fails for a ticket, continue without it
+	}
+
+	return &ticket.OrderWithTickets{Order: *o, Tickets: tickets}, nil
+}
+
+// AssignTicket hands an unassigned order ticket to another user (buyer only)
+func (uc *Usecase) AssignTicket(ctx context.Context, ticketID, buyerID, recipientID uuid.UUID) (*ticket.TicketWithDetails, error) {
+	t, err := uc.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	// Unassigned tickets are held by the buyer
+	if t.UserID != buyerID {
+		return nil, ErrUnauthorized
+	}
+
+	return uc.assign(ctx, t, recipientID)
+}
+
+// CreateTicketInvite creates a shareable invite link for an unassigned order ticket (buyer only)
+// Creating a new invite invalidates the previous link
+func (uc *Usecase) CreateTicketInvite(ctx context.Context, ticketID, buyerID uuid.UUID) (*ticket.TicketInvite, error) {
+	t, err := uc.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	if t.UserID != buyerID {
+		return nil, ErrUnauthorized
+	}
+
+	if t.IsAssigned {
+		return nil, ErrTicketAssigned
+	}
+
+	token, err := generateInviteToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ticketRepo.SetInviteToken(ctx, ticketID, token); err != nil {
+		return nil, err
+	}
+
+	return &ticket.TicketInvite{
+		TicketID: ticketID,
+		Token:    token,
+		ClaimURL: "/api/v1/tickets/claim/" + token,
+	}, nil
+}
+
+// ClaimTicketInvite assigns the ticket behind an invite link to the current user
+func (uc *Usecase) ClaimTicketInvite(ctx context.Context, token string, userID uuid.UUID) (*ticket.TicketWithDetails, error) {
+	t, err := uc.ticketRepo.GetByInviteToken(ctx, token)
+	if err != nil {
+		return nil, ErrInviteNotFound
+	}
+
+	return uc.assign(ctx, t, userID)
+}
+
+// assign gives an unassigned ticket to a recipient, who then joins the event
+// Like a transfer, the ticket gets a new attendance code and QR; the buyer's copy stops working
+func (uc *Usecase) assign(ctx context.Context, t *ticket.Ticket, recipientID uuid.UUID) (*ticket.TicketWithDetails, error) {
+	if t.IsAssigned {
+		return nil, ErrTicketAssigned
+	}
+
+	// Only paid tickets can be handed over
+	if t.Status != ticket.StatusActive {
+		return nil, ErrTicketNotActive
+	}
+
+	evt, err := uc.eventRepo.GetByID(ctx, t.EventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	if !evt.StartTime.After(time.Now()) {
+		return nil, ErrEventStarted
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, recipientID); err != nil {
+		return nil, ErrRecipientNotFound
+	}
+
+	// Buyer and recipient must not have blocked each other
+	blocked, err := uc.blockRepo.IsBlocked(ctx, t.UserID, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, ErrBlocked
+	}
+
+	// One live ticket per user per event
+	existingTicket, err := uc.ticketRepo.GetUserTicketForEvent(ctx, recipientID, t.EventID)
+	if err == nil && existingTicket != nil && existingTicket.HoldsSeat() {
+		return nil, ErrRecipientHasTicket
+	}
+
+	if err := uc.ticketRepo.AssignTicket(ctx, t.ID, recipientID); err != nil {
+		return nil, ErrTicketAssigned
+	}
+
+	// Recipient takes the seat held by the ticket
+	attendee := &event.EventAttendee{
+		ID:       uuid.New(),
+		EventID:  t.EventID,
+		UserID:   recipientID,
+		JoinedAt: time.Now(),
+		Status:   event.AttendeeConfirmed,
+	}
+	if err := uc.eventRepo.Join(ctx, attendee); err != nil {
+		// Log error but don't fail
+	}
+
+	// Increment events attended for user stats
+	if err := uc.userRepo.IncrementEventsAttended(ctx, recipientID); err != nil {
+		// Log error but don't fail
+	}
+
+	return uc.GetTicketWithDetails(ctx, t.ID, recipientID)
+}
+
+// generateInviteToken generates a secure random ticket invite token
+func generateInviteToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// TransferTicket offers a ticket to another user, who must accept it before the event starts
+// The ticket stays with the sender until the transfer is accepted
+func (uc *Usecase) TransferTicket(ctx context.Context, ticketID, userID uuid.UUID, req *ticket.TransferTicketRequest) (*ticket.Transfer, error) {
+	t, err := uc.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	// Verify user owns this ticket
+	if t.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if !t.CanBeTransferred() {
+		return nil, ErrTicketNotTransferable
+	}
+
+	if req.ToUserID == userID {
+		return nil, ErrCannotTransferToSelf
+	}
+
+	evt, err := uc.eventRepo.GetByID(ctx, t.EventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	if !evt.AllowTicketTransfers {
+		return nil, ErrTransfersDisabled
+	}
+
+	if !evt.StartTime.After(time.Now()) {
+		return nil, ErrEventStarted
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, req.ToUserID); err != nil {
+		return nil, ErrRecipientNotFound
+	}
+
+	// Sender and recipient must not have blocked each other
+	blocked, err := uc.blockRepo.IsBlocked(ctx, userID, req.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, ErrBlocked
+	}
+
+	// One live ticket per user per event
+	existingTicket, err := uc.ticketRepo.GetUserTicketForEvent(ctx, req.ToUserID, t.EventID)
+	if err == nil && existingTicket != nil && existingTicket.HoldsSeat() {
+		return nil, ErrRecipientHasTicket
+	}
+
+	// One open transfer per ticket
+	if pending, err := uc.ticketRepo.GetPendingTransferByTicket(ctx, t.ID); err == nil && pending != nil {
+		return nil, ErrTransferPending
+	}
+
+	transfer := &ticket.Transfer{
+		TicketID:          t.ID,
+		EventID:           t.EventID,
+		FromUserID:        userID,
+		ToUserID:          req.ToUserID,
+		OldAttendanceCode: t.AttendanceCode,
+	}
+
+	if err := uc.ticketRepo.CreateTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// GetIncomingTransfers gets the ticket transfers waiting for the user to accept or decline
+func (uc *Usecase) GetIncomingTransfers(ctx context.Context, userID uuid.UUID) ([]ticket.TransferWithDetails, error) {
+	return uc.ticketRepo.GetIncomingTransfers(ctx, userID)
+}
+
+// AcceptTransfer moves a transferred ticket to the recipient
+// The ticket gets a new attendance code and QR; the sender's copy stops working
+func (uc *Usecase) AcceptTransfer(ctx context.Context, transferID, userID uuid.UUID) (*ticket.TicketWithDetails, error) {
+	tr, err := uc.ticketRepo.GetTransferByID(ctx, transferID)
+	if err != nil {
+		return nil, ErrTransferNotFound
+	}
+
+	if tr.ToUserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if tr.Status != ticket.TransferPending {
+		return nil, ErrTransferResolved
+	}
+
+	t, err := uc.ticketRepo.GetByID(ctx, tr.TicketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	// The sender may have used or cancelled the ticket in the meantime
+	if t.UserID != tr.FromUserID || !t.CanBeTransferred() {
+		uc.expireTransfer(ctx, tr)
+		return nil, ErrTicketNotTransferable
+	}
+
+	evt, err := uc.eventRepo.GetByID(ctx, t.EventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	if !evt.AllowTicketTransfers {
+		uc.expireTransfer(ctx, tr)
+		return nil, ErrTransfersDisabled
+	}
+
+	if !evt.StartTime.After(time.Now()) {
+		uc.expireTransfer(ctx, tr)
+		return nil, ErrEventStarted
+	}
+
+	// One live ticket per user per event
+	existingTicket, err := uc.ticketRepo.GetUserTicketForEvent(ctx, userID, t.EventID)
+	if err == nil && existingTicket != nil && existingTicket.HoldsSeat() {
+		return nil, ErrRecipientHasTicket
+	}
+
+	if err := uc.ticketRepo.CompleteTransfer(ctx, tr); err != nil {
+		return nil, ErrTicketNotTransferable
+	}
+
+	// The seat moves from the sender to the recipient
+	if err := uc.eventRepo.Leave(ctx, t.EventID, tr.FromUserID); err != nil {
+		// Log error but don't fail
+	}
+
+	attendee := &event.EventAttendee{
+		ID:       uuid.New(),
+		EventID:  t.EventID,
+		UserID:   userID,
+		JoinedAt: time.Now(),
+		Status:   event.AttendeeConfirmed,
+	}
+	if err := uc.eventRepo.Join(ctx, attendee); err != nil {
+		// Log error but don't fail
+	}
+
+	// Increment events attended for user stats
+	if err := uc.userRepo.IncrementEventsAttended(ctx, userID); err != nil {
+		// Log error but don't fail
+	}
+
+	return uc.GetTicketWithDetails(ctx, t.ID, userID)
+}
+
+// DeclineTransfer rejects a ticket transfer (recipient only)
+func (uc *Usecase) DeclineTransfer(ctx context.Context, transferID, userID uuid.UUID) error {
+	tr, err := uc.ticketRepo.GetTransferByID(ctx, transferID)
+	if err != nil {
+		return ErrTransferNotFound
+	}
+
+	if tr.ToUserID != userID {
+		return ErrUnauthorized
+	}
+
+	if err := uc.ticketRepo.UpdateTransferStatus(ctx, tr.ID, ticket.TransferDeclined); err != nil {
+		return ErrTransferResolved
+	}
+
+	return nil
+}
+
+// CancelTransfer withdraws a ticket transfer that has not been accepted yet (sender only)
+func (uc *Usecase) CancelTransfer(ctx context.Context, transferID, userID uuid.UUID) error {
+	tr, err := uc.ticketRepo.GetTransferByID(ctx, transferID)
+	if err != nil {
+		return ErrTransferNotFound
+	}
+
+	if tr.FromUserID != userID {
+		return ErrUnauthorized
+	}
+
+	if err := uc.ticketRepo.UpdateTransferStatus(ctx, tr.ID, ticket.TransferCancelled); err != nil {
+		return ErrTransferResolved
+	}
+
+	return nil
+}
+
+// expireTransfer closes a pending transfer that can no longer be accepted
+func (uc *Usecase) expireTransfer(ctx context.Context, tr *ticket.Transfer) {
+	if err := uc.ticketRepo.UpdateTransferStatus(ctx, tr.ID, ticket.TransferExpired); err != nil {
+		// Log error but don't fail
+	}
+}
+
 // GetUpcomingTickets gets upcoming tickets for a user
 func (uc *Usecase) GetUpcomingTickets(ctx context.Context, userID uuid.UUID, limit int) ([]ticket.TicketWithDetails, error) {
 	if limit <= 0 {
@@ -0,0 +1,596 @@
+package ticket
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/payout"
+	"github.com/anigmaa/backend/internal/domain/promo"
+	"github.com/anigmaa/backend/internal/domain/ticket"
+	"github.com/anigmaa/backend/internal/domain/user"
+	"github.com/anigmaa/backend/internal/domain/waitlist"
+	"github.com/anigmaa/backend/internal/repository/redis"
+	analyticsUsecase "github.com/anigmaa/backend/internal/usecase/analytics"
+	payoutUsecase "github.com/anigmaa/backend/internal/usecase/payout"
+	promoUsecase "github.com/anigmaa/backend/internal/usecase/promo"
+	waitlistUsecase "github.com/anigmaa/backend/internal/usecase/waitlist"
+	"github.com/google/uuid"
+)
+
+// fakeTicketRepo keeps tickets and their payment transactions in memory
+// Seats of its one event are held by its tickets, seatsTaken other holders and open waitlist offers
+type fakeTicketRepo struct {
+	ticket.Repository
+	event        *event.Event
+	tickets      map[uuid.UUID]*ticket.Ticket
+	transactions []ticket.TicketTransaction
+	seatsTaken   int
+	offers       int
+}
+
+func (r *fakeTicketRepo) GetByID(ctx context.Context, ticketID uuid.UUID) (*ticket.Ticket, error) {
+	t, ok := r.tickets[ticketID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *t
+	return &copied, nil
+}
+
+func (r *fakeTicketRepo) Update(ctx context.Context, t *ticket.Ticket) error {
+	if _, ok := r.tickets[t.ID]; !ok {
+		return sql.ErrNoRows
+	}
+	copied := *t
+	r.tickets[t.ID] = &copied
+	return nil
+}
+
+func (r *fakeTicketRepo) CreateTransaction(ctx context.Context, transaction *ticket.TicketTransaction) error {
+	r.transactions = append(r.transactions, *transaction)
+	return nil
+}
+
+func (r *fakeTicketRepo) UpdateTransactionStatus(ctx context.Context, transactionID string, status ticket.TransactionStatus) error {
+	found := false
+	for i := range r.transactions {
+		if r.transactions[i].TransactionID == transactionID {
+			r.transactions[i].Status = status
+			found = true
+		}
+	}
+	if !found {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *fakeTicketRepo) GetTransactionsByTransactionID(ctx context.Context, transactionID string) ([]ticket.TicketTransaction, error) {
+	var transactions []ticket.TicketTransaction
+	for _, t := range r.transactions {
+		if t.TransactionID == transactionID {
+			transactions = append(transactions, t)
+		}
+	}
+	return transactions, nil
+}
+
+func (r *fakeTicketRepo) GetPendingTransferByTicket(ctx context.Context, ticketID uuid.UUID) (*ticket.Transfer, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeTicketRepo) CountSeatsTaken(ctx context.Context, eventID uuid.UUID) (int, error) {
+	return r.taken(), nil
+}
+
+// taken counts the seats held by tickets, not counting open waitlist offers
+func (r *fakeTicketRepo) taken() int {
+	n := r.seatsTaken
+	for _, t := range r.tickets {
+		if t.HoldsSeat() {
+			n++
+		}
+	}
+	return n
+}
+
+// free counts the seats nobody holds or was offered
+func (r *fakeTicketRepo) free() int {
+	return r.event.MaxAttendees - r.taken() - r.offers
+}
+
+func (r *fakeTicketRepo) ActivatePaidTicket(ctx context.Context, ticketID uuid.UUID) (*ticket.Ticket, error) {
+	t, ok := r.tickets[ticketID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	switch t.Status {
+	case ticket.StatusPending:
+	case ticket.StatusExpired, ticket.StatusCancelled:
+		if r.event.Status == event.StatusCancelled {
+			return nil, ticket.ErrEventCancelled
+		}
+		if r.free() <= 0 {
+			return nil, ticket.ErrEventFull
+		}
+	default:
+		return nil, sql.ErrNoRows
+	}
+
+	t.Status = ticket.StatusActive
+	copied := *t
+	return &copied, nil
+}
+
+// refunds returns the refund transactions recorded for a ticket
+func (r *fakeTicketRepo) refunds(ticketID uuid.UUID) int {
+	n := 0
+	for _, t := range r.transactions {
+		if t.TicketID == ticketID && t.Status == ticket.TransactionRefunded {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeEventRepo knows a fixed set of events and records who joined them
+type fakeEventRepo struct {
+	event.Repository
+	events    map[uuid.UUID]*event.Event
+	attendees map[uuid.UUID]bool
+}
+
+func (r *fakeEventRepo) GetByID(ctx context.Context, id uuid.UUID) (*event.Event, error) {
+	evt, ok := r.events[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *evt
+	return &copied, nil
+}
+
+func (r *fakeEventRepo) Join(ctx context.Context, attendee *event.EventAttendee) error {
+	r.attendees[attendee.UserID] = true
+	return nil
+}
+
+func (r *fakeEventRepo) Leave(ctx context.Context, eventID, userID uuid.UUID) error {
+	delete(r.attendees, userID)
+	return nil
+}
+
+// fakeUserRepo only tracks attendance stats
+type fakeUserRepo struct {
+	user.Repository
+}
+
+func (r *fakeUserRepo) IncrementEventsAttended(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+// fakeWaitlistRepo offers the free seats of the ticket repo's event to the users waiting, in line order
+type fakeWaitlistRepo struct {
+	waitlist.Repository
+	tickets  *fakeTicketRepo
+	waiting  []uuid.UUID
+	offered  []uuid.UUID
+	promoted []uuid.UUID
+}
+
+func (r *fakeWaitlistRepo) OfferFreeSeats(ctx context.Context, eventID uuid.UUID, expiresAt time.Time) ([]waitlist.Entry, error) {
+	r.promoted = append(r.promoted, eventID)
+
+	var entries []waitlist.Entry
+	for len(r.waiting) > 0 && r.tickets.free() > 0 {
+		userID := r.waiting[0]
+		r.waiting = r.waiting[1:]
+		r.offered = append(r.offered, userID)
+		r.tickets.offers++
+
+		entries = append(entries, waitlist.Entry{
+			ID:             uuid.New(),
+			EventID:        eventID,
+			UserID:         userID,
+			Status:         waitlist.StatusOffered,
+			OfferExpiresAt: &expiresAt,
+		})
+	}
+	return entries, nil
+}
+
+// fakePromoRepo has one code with a use limit, each redeemed ticket using it once
+type fakePromoRepo struct {
+	promo.Repository
+	maxRedemptions int
+	inUse          map[uuid.UUID]bool // Redeemed ticket -> use not released
+	released       []uuid.UUID
+}
+
+func (r *fakePromoRepo) used() int {
+	n := 0
+	for _, inUse := range r.inUse {
+		if inUse {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *fakePromoRepo) ReleaseRedemption(ctx context.Context, ticketID uuid.UUID) error {
+	r.released = append(r.released, ticketID)
+	if r.inUse[ticketID] {
+		r.inUse[ticketID] = false
+	}
+	return nil
+}
+
+func (r *fakePromoRepo) RestoreRedemption(ctx context.Context, ticketID uuid.UUID) (bool, error) {
+	inUse, redeemed := r.inUse[ticketID]
+	if !redeemed || inUse {
+		return false, nil
+	}
+	if r.used() >= r.maxRedemptions {
+		return false, promo.ErrCodeExhausted
+	}
+	r.inUse[ticketID] = true
+	return true, nil
+}
+
+// fakeLedger posts each (kind, reference) transaction once, like the ledger's unique key
+type fakeLedger struct {
+	payout.Repository
+	posted []*payout.Transaction
+}
+
+func (r *fakeLedger) PostTransaction(ctx context.Context, txn *payout.Transaction) error {
+	if !txn.IsBalanced() {
+		return payout.ErrUnbalanced
+	}
+	for _, p := range r.posted {
+		if p.Kind == txn.Kind && p.ReferenceID == txn.ReferenceID {
+			return payout.ErrAlreadyPosted
+		}
+	}
+	r.posted = append(r.posted, txn)
+	return nil
+}
+
+func (r *fakeLedger) GetTransactionByReference(ctx context.Context, kind payout.TransactionKind, referenceID uuid.UUID) (*payout.Transaction, error) {
+	for _, p := range r.posted {
+		if p.Kind == kind && p.ReferenceID == referenceID {
+			return p, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeLedger) count(kind payout.TransactionKind) int {
+	n := 0
+	for _, p := range r.posted {
+		if p.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeCache keeps cache entries in memory, ignoring expiry
+type fakeCache struct {
+	redis.CacheRepository
+	entries map[string]string
+}
+
+func (c *fakeCache) Get(ctx context.Context, key string) (string, error) {
+	value, ok := c.entries[key]
+	if !ok {
+		return "", errors.New("cache miss")
+	}
+	return value, nil
+}
+
+func (c *fakeCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.entries[key] = fmt.Sprint(value)
+	return nil
+}
+
+// testEnv wires a ticket usecase to in-memory repositories around one upcoming paid event
+type testEnv struct {
+	uc       *Usecase
+	tickets  *fakeTicketRepo
+	events   *fakeEventRepo
+	waitlist *fakeWaitlistRepo
+	promos   *fakePromoRepo
+	ledger   *fakeLedger
+	cache    *fakeCache
+	event    *event.Event
+}
+
+func newTestEnv(maxAttendees int) *testEnv {
+	start := time.Now().Add(48 * time.Hour)
+	evt := &event.Event{
+		ID:           uuid.New(),
+		HostID:       uuid.New(),
+		Title:        "Test event",
+		StartTime:    start,
+		EndTime:      start.Add(2 * time.Hour),
+		MaxAttendees: maxAttendees,
+		Status:       event.StatusUpcoming,
+	}
+
+	env := &testEnv{
+		tickets:  &fakeTicketRepo{event: evt, tickets: map[uuid.UUID]*ticket.Ticket{}},
+		events:   &fakeEventRepo{events: map[uuid.UUID]*event.Event{evt.ID: evt}, attendees: map[uuid.UUID]bool{}},
+		waitlist: &fakeWaitlistRepo{},
+		promos:   &fakePromoRepo{maxRedemptions: 1, inUse: map[uuid.UUID]bool{}},
+		ledger:   &fakeLedger{},
+		cache:    &fakeCache{entries: map[string]string{}},
+		event:    evt,
+	}
+	env.waitlist.tickets = env.tickets
+
+	waitlistUC := waitlistUsecase.NewUsecase(env.waitlist, env.events, env.tickets, time.Hour)
+	promoUC := promoUsecase.NewUsecase(env.promos, env.events)
+	payoutUC := payoutUsecase.NewUsecase(env.ledger, env.events, 10, 24*time.Hour)
+	analyticsUC := analyticsUsecase.NewUsecase(env.events, env.tickets, env.waitlist, env.promos, nil, env.cache)
+	env.uc = NewUsecase(env.tickets, env.events, &fakeUserRepo{}, nil, nil, waitlistUC, promoUC, payoutUC, analyticsUC, nil, nil, 15*time.Minute)
+
+	return env
+}
+
+// addPaidTicket adds a ticket of the event paid through the given gateway transaction
+func (env *testEnv) addPaidTicket(transactionID string, status ticket.TicketStatus, assigned bool) *ticket.Ticket {
+	t := &ticket.Ticket{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		EventID:     env.event.ID,
+		IsAssigned:  assigned,
+		PricePaid:   100,
+		PurchasedAt: time.Now(),
+		Status:      status,
+	}
+	env.tickets.tickets[t.ID] = t
+	env.tickets.transactions = append(env.tickets.transactions, ticket.TicketTransaction{
+		ID:            uuid.New(),
+		TicketID:      t.ID,
+		TransactionID: transactionID,
+		Amount:        t.PricePaid,
+		PaymentMethod: "midtrans",
+		Status:        ticket.TransactionPending,
+	})
+	return t
+}
+
+func (env *testEnv) status(t *ticket.Ticket) ticket.TicketStatus {
+	return env.tickets.tickets[t.ID].Status
+}
+
+func TestProcessPaymentCallbackActivatesPendingTickets(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(10)
+	buyerTicket := env.addPaidTicket("order-1", ticket.StatusPending, true)
+	spareTicket := env.addPaidTicket("order-1", ticket.StatusPending, false)
+
+	// Gateways retry notifications, the second one must not change anything
+	for i := 0; i < 2; i++ {
+		if err := env.uc.ProcessPaymentCallback(ctx, "order-1", ticket.TransactionSuccess); err != nil {
+			t.Fatalf("ProcessPaymentCallback: %v", err)
+		}
+	}
+
+	for _, tkt := range []*ticket.Ticket{buyerTicket, spareTicket} {
+		if got := env.status(tkt); got != ticket.StatusActive {
+			t.Errorf("ticket status = %s, want active", got)
+		}
+	}
+	if got := env.ledger.count(payout.KindTicketSale); got != 2 {
+		t.Errorf("sales posted = %d, want 2", got)
+	}
+	if !env.events.attendees[buyerTicket.UserID] {
+		t.Error("buyer did not join the event")
+	}
+	if len(env.events.attendees) != 1 {
+		t.Errorf("attendees = %d, want 1 (unassigned tickets do not join)", len(env.events.attendees))
+	}
+}
+
+func TestProcessPaymentCallbackLatePayment(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     ticket.TicketStatus
+		seatsTaken int
+		offers     int
+		cancelled  bool
+		promo      bool // Ticket was discounted, its promo code use was released on expiry
+		promoTaken bool // Someone else used the code's last use since
+		want       ticket.TicketStatus
+	}{
+		{name: "expired with a free seat", status: ticket.StatusExpired, seatsTaken: 5, want: ticket.StatusActive},
+		{name: "cancelled with a free seat", status: ticket.StatusCancelled, seatsTaken: 5, want: ticket.StatusActive},
+		{name: "expired on a full event", status: ticket.StatusExpired, seatsTaken: 10, want: ticket.StatusRefunded},
+		{name: "last seat offered to the waitlist", status: ticket.StatusExpired, seatsTaken: 9, offers: 1, want: ticket.StatusRefunded},
+		{name: "event cancelled", status: ticket.StatusExpired, seatsTaken: 0, cancelled: true, want: ticket.StatusRefunded},
+		{name: "promo code still available", status: ticket.StatusExpired, seatsTaken: 5, promo: true, want: ticket.StatusActive},
+		{name: "promo code used up since", status: ticket.StatusExpired, seatsTaken: 5, promo: true, promoTaken: true, want: ticket.StatusRefunded},
+		{name: "promo code available on a full event", status: ticket.StatusExpired, seatsTaken: 10, promo: true, want: ticket.StatusRefunded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(10)
+			env.tickets.seatsTaken = tt.seatsTaken
+			env.tickets.offers = tt.offers
+			if tt.cancelled {
+				env.event.Status = event.StatusCancelled
+			}
+			tkt := env.addPaidTicket("order-late", tt.status, true)
+			if tt.promo {
+				env.promos.inUse[tkt.ID] = false
+			}
+			if tt.promoTaken {
+				env.promos.inUse[uuid.New()] = true
+			}
+
+			if err := env.uc.ProcessPaymentCallback(ctx, "order-late", ticket.TransactionSuccess); err != nil {
+				t.Fatalf("ProcessPaymentCallback: %v", err)
+			}
+
+			if got := env.status(tkt); got != tt.want {
+				t.Fatalf("ticket status = %s, want %s", got, tt.want)
+			}
+
+			if tt.promo && env.promos.inUse[tkt.ID] != (tt.want == ticket.StatusActive) {
+				t.Errorf("promo code use taken = %v, want it taken only when the ticket is active", env.promos.inUse[tkt.ID])
+			}
+
+			sales := env.ledger.count(payout.KindTicketSale)
+			refunds := env.tickets.refunds(tkt.ID)
+			if tt.want == ticket.StatusActive {
+				if sales != 1 || refunds != 0 {
+					t.Errorf("sales = %d, refunds = %d; want the sale posted and nothing refunded", sales, refunds)
+				}
+			} else {
+				if sales != 0 || refunds != 1 {
+					t.Errorf("sales = %d, refunds = %d; want no sale and one refund", sales, refunds)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessPaymentCallbackFailureCancelsOnlyPendingTickets(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(10)
+	pending := env.addPaidTicket("order-2", ticket.StatusPending, true)
+	active := env.addPaidTicket("order-2", ticket.StatusActive, false)
+
+	if err := env.uc.ProcessPaymentCallback(ctx, "order-2", ticket.TransactionFailed); err != nil {
+		t.Fatalf("ProcessPaymentCallback: %v", err)
+	}
+
+	if got := env.status(pending); got != ticket.StatusCancelled {
+		t.Errorf("pending ticket status = %s, want cancelled", got)
+	}
+	if got := env.status(active); got != ticket.StatusActive {
+		t.Errorf("active ticket status = %s, want active", got)
+	}
+	if len(env.promos.released) != 1 || env.promos.released[0] != pending.ID {
+		t.Errorf("released redemptions = %v, want only the cancelled ticket", env.promos.released)
+	}
+	if len(env.waitlist.promoted) == 0 {
+		t.Error("freed seat was not offered to the waitlist")
+	}
+}
+
+func TestProcessPaymentCallbackRefundReversesSales(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(10)
+	tkt := env.addPaidTicket("order-3", ticket.StatusPending, true)
+
+	if err := env.uc.ProcessPaymentCallback(ctx, "order-3", ticket.TransactionSuccess); err != nil {
+		t.Fatalf("ProcessPaymentCallback: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := env.uc.ProcessPaymentCallback(ctx, "order-3", ticket.TransactionRefunded); err != nil {
+			t.Fatalf("ProcessPaymentCallback: %v", err)
+		}
+	}
+
+	if got := env.ledger.count(payout.KindTicketRefund); got != 1 {
+		t.Errorf("refunds posted for %s = %d, want 1", tkt.ID, got)
+	}
+	if _, ok := env.cache.entries["analytics:event:"+env.event.ID.String()+":version"]; !ok {
+		t.Error("cached analytics of the event were not invalidated")
+	}
+}
+
+func TestRefundOffersSeatToWaitlist(t *testing.T) {
+	tests := []struct {
+		name       string
+		refund     func(env *testEnv, tkt *ticket.Ticket) error
+		waiting    int
+		wantStatus ticket.TicketStatus
+		wantOffers int
+	}{
+		{
+			name: "holder cancels",
+			refund: func(env *testEnv, tkt *ticket.Ticket) error {
+				return env.uc.CancelTicket(context.Background(), tkt.ID, tkt.UserID)
+			},
+			waiting:    2,
+			wantStatus: ticket.StatusCancelled,
+			wantOffers: 1,
+		},
+		{
+			name: "staff refunds",
+			refund: func(env *testEnv, tkt *ticket.Ticket) error {
+				_, err := env.uc.ForceRefund(context.Background(), tkt.ID)
+				return err
+			},
+			waiting:    2,
+			wantStatus: ticket.StatusRefunded,
+			wantOffers: 1,
+		},
+		{
+			name: "nobody waiting",
+			refund: func(env *testEnv, tkt *ticket.Ticket) error {
+				return env.uc.CancelTicket(context.Background(), tkt.ID, tkt.UserID)
+			},
+			wantStatus: ticket.StatusCancelled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(3)
+			env.tickets.seatsTaken = 2
+			tkt := env.addPaidTicket("order-4", ticket.StatusPending, true)
+			if err := env.uc.ProcessPaymentCallback(ctx, "order-4", ticket.TransactionSuccess); err != nil {
+				t.Fatalf("ProcessPaymentCallback: %v", err)
+			}
+			for i := 0; i < tt.waiting; i++ {
+				env.waitlist.waiting = append(env.waitlist.waiting, uuid.New())
+			}
+			queue := env.waitlist.waiting
+
+			if err := tt.refund(env, tkt); err != nil {
+				t.Fatalf("refund: %v", err)
+			}
+
+			if got := env.status(tkt); got != tt.wantStatus {
+				t.Errorf("ticket status = %s, want %s", got, tt.wantStatus)
+			}
+			if env.events.attendees[tkt.UserID] {
+				t.Error("refunded holder is still attending")
+			}
+			if got := env.ledger.count(payout.KindTicketRefund); got != 1 {
+				t.Errorf("refunds posted = %d, want 1", got)
+			}
+			if got := len(env.waitlist.offered); got != tt.wantOffers {
+				t.Fatalf("offers = %d, want %d", got, tt.wantOffers)
+			}
+			if tt.wantOffers > 0 && env.waitlist.offered[0] != queue[0] {
+				t.Error("seat was not offered to the first user in line")
+			}
+			if got := env.tickets.free(); got != 1-tt.wantOffers {
+				t.Errorf("free seats = %d, want %d", got, 1-tt.wantOffers)
+			}
+		})
+	}
+}
+
+func TestProcessPaymentCallbackUnknownTransaction(t *testing.T) {
+	env := newTestEnv(10)
+
+	err := env.uc.ProcessPaymentCallback(context.Background(), "missing", ticket.TransactionSuccess)
+	if err != ErrTransactionNotFound {
+		t.Fatalf("err = %v, want ErrTransactionNotFound", err)
+	}
+}
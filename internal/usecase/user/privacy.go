@@ -0,0 +1,288 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// GetProfileForViewer gets a user's profile with the fields hidden by their privacy settings removed.
+// viewerID is uuid.Nil for anonymous viewers. Blocked users do not see each other's profile,
+// and suspended users are only visible to themselves.
+func (uc *Usecase) GetProfileForViewer(ctx context.Context, userID, viewerID uuid.UUID) (*user.UserProfile, error) {
+	if err := uc.ensureNotBlocked(ctx, userID, viewerID); err != nil {
+		return nil, err
+	}
+
+	profile, err := uc.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewerID != userID && profile.User.IsSuspended(time.Now()) {
+		return nil, ErrUserNotFound
+	}
+
+	audience, err := uc.audience(ctx, userID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	projected := profile.ProjectFor(audience)
+	if viewerID != uuid.Nil && !audience.IsSelf {
+		projected.IsFollowing = &audience.IsFollower
+		if !audience.IsFollower {
+			requested, err := uc.userRepo.HasFollowRequest(ctx, viewerID, userID)
+			if err == nil {
+				projected.IsFollowRequested = &requested
+			}
+		}
+	}
+
+	return projected, nil
+}
+
+// EnsureEventsVisible returns ErrEventsPrivate when the viewer may not see the events a user hosts and joins
+func (uc *Usecase) EnsureEventsVisible(ctx context.Context, userID, viewerID uuid.UUID) error {
+	privacy, audience, err := uc.privacyFor(ctx, userID, viewerID)
+	if err != nil {
+		return err
+	}
+	if !privacy.CanViewEvents(audience) {
+		return ErrEventsPrivate
+	}
+	return nil
+}
+
+// UpdatePrivacy updates a user's privacy settings.
+// Opening up following approves every pending follow request.
+func (uc *Usecase) UpdatePrivacy(ctx context.Context, userID uuid.UUID, req *user.UpdatePrivacyRequest) (*user.UserPrivacy, error) {
+	// Check if user exists
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	privacy, err := uc.userRepo.GetPrivacy(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	wasOpen := privacy.AllowFollowers
+
+	// Update fields if provided
+	if req.ProfileVisible != nil {
+		privacy.ProfileVisible = *req.ProfileVisible
+	}
+	if req.EventsVisible != nil {
+		privacy.EventsVisible = *req.EventsVisible
+	}
+	if req.AllowFollowers != nil {
+		privacy.AllowFollowers = *req.AllowFollowers
+	}
+	if req.ShowEmail != nil {
+		privacy.ShowEmail = *req.ShowEmail
+	}
+	if req.ShowLocation != nil {
+		privacy.ShowLocation = *req.ShowLocation
+	}
+	if req.ShareContactWithHosts != nil {
+		privacy.ShareContactWithHosts = *req.ShareContactWithHosts
+	}
+
+	// Save changes
+	if err := uc.userRepo.UpdatePrivacy(ctx, privacy); err != nil {
+		return nil, err
+	}
+
+	if !wasOpen && privacy.AllowFollowers {
+		if err := uc.userRepo.ApproveAllFollowRequests(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return privacy, nil
+}
+
+// GetFollowRequests gets pending follow requests sent to a user
+func (uc *Usecase) GetFollowRequests(ctx context.Context, userID uuid.UUID, limit, offset int) ([]user.FollowRequestWithUser, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	requests, err := uc.userRepo.GetFollowRequests(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	requesters := make([]user.User, len(requests))
+	for i := range requests {
+		requesters[i] = requests[i].Requester
+	}
+	requesters, err = uc.projectUsers(ctx, requesters, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocked requesters are left out, so match the projected users back by ID
+	projected := make(map[uuid.UUID]user.User, len(requesters))
+	for _, u := range requesters {
+		projected[u.ID] = u
+	}
+	visible := make([]user.FollowRequestWithUser, 0, len(requests))
+	for _, req := range requests {
+		requester, ok := projected[req.Requester.ID]
+		if !ok {
+			continue
+		}
+		req.Requester = requester
+		visible = append(visible, req)
+	}
+
+	return visible, nil
+}
+
+// CountFollowRequests counts pending follow requests sent to a user
+func (uc *Usecase) CountFollowRequests(ctx context.Context, userID uuid.UUID) (int, error) {
+	return uc.userRepo.CountFollowRequests(ctx, userID)
+}
+
+// ApproveFollowRequest accepts a pending follow request from requesterID
+func (uc *Usecase) ApproveFollowRequest(ctx context.Context, userID, requesterID uuid.UUID) error {
+	if err := uc.userRepo.ApproveFollowRequest(ctx, requesterID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrRequestNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// DeclineFollowRequest rejects a pending follow request from requesterID
+func (uc *Usecase) DeclineFollowRequest(ctx context.Context, userID, requesterID uuid.UUID) error {
+	if err := uc.userRepo.DeleteFollowRequest(ctx, requesterID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrRequestNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// EnsureProfileVisible returns ErrProfilePrivate when the viewer may not see the user's profile content
+func (uc *Usecase) EnsureProfileVisible(ctx context.Context, userID, viewerID uuid.UUID) error {
+	privacy, audience, err := uc.privacyFor(ctx, userID, viewerID)
+	if err != nil {
+		return err
+	}
+	if !privacy.CanViewProfile(audience) {
+		return ErrProfilePrivate
+	}
+	return nil
+}
+
+// privacyFor loads a user's privacy settings and the viewer's relation to them
+func (uc *Usecase) privacyFor(ctx context.Context, userID, viewerID uuid.UUID) (*user.UserPrivacy, user.Audience, error) {
+	// Check if user exists
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, user.Audience{}, ErrUserNotFound
+	}
+	if err := uc.ensureNotBlocked(ctx, userID, viewerID); err != nil {
+		return nil, user.Audience{}, err
+	}
+
+	privacy, err := uc.userRepo.GetPrivacy(ctx, userID)
+	if err != nil {
+		return nil, user.Audience{}, err
+	}
+
+	audience, err := uc.audience(ctx, userID, viewerID)
+	if err != nil {
+		return nil, user.Audience{}, err
+	}
+
+	return privacy, audience, nil
+}
+
+// ensureNotBlocked hides a user from a viewer in a block relation with them as if they did not exist
+func (uc *Usecase) ensureNotBlocked(ctx context.Context, userID, viewerID uuid.UUID) error {
+	if viewerID == uuid.Nil || viewerID == userID {
+		return nil
+	}
+
+	blocked, err := uc.blockRepo.IsBlocked(ctx, viewerID, userID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// audience works out how the viewer relates to the owner of a profile
+func (uc *Usecase) audience(ctx context.Context, ownerID, viewerID uuid.UUID) (user.Audience, error) {
+	if viewerID == uuid.Nil {
+		return user.Audience{}, nil
+	}
+	if viewerID == ownerID {
+		return user.Audience{IsSelf: true}, nil
+	}
+
+	isFollowing, err := uc.userRepo.IsFollowing(ctx, viewerID, ownerID)
+	if err != nil {
+		return user.Audience{}, err
+	}
+
+	return user.Audience{IsFollower: isFollowing}, nil
+}
+
+// projectUsers applies each user's privacy settings to a list shown to the viewer and drops blocked users.
+// Lists do not look up follow relations, so hidden profiles are projected as for a stranger.
+func (uc *Usecase) projectUsers(ctx context.Context, users []user.User, viewerID uuid.UUID) ([]user.User, error) {
+	if len(users) == 0 {
+		return []user.User{}, nil
+	}
+
+	if viewerID != uuid.Nil {
+		blockedIDs, err := uc.blockRepo.GetBlockedIDs(ctx, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		if len(blockedIDs) > 0 {
+			blocked := make(map[uuid.UUID]bool, len(blockedIDs))
+			for _, id := range blockedIDs {
+				blocked[id] = true
+			}
+			visible := make([]user.User, 0, len(users))
+			for _, u := range users {
+				if !blocked[u.ID] {
+					visible = append(visible, u)
+				}
+			}
+			users = visible
+		}
+	}
+
+	ids := make([]uuid.UUID, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+
+	privacies, err := uc.userRepo.GetPrivacyByUsers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	projected := make([]user.User, len(users))
+	for i, u := range users {
+		privacy := privacies[u.ID]
+		projected[i] = privacy.ProjectUser(u, user.Audience{IsSelf: u.ID == viewerID})
+	}
+
+	return projected, nil
+}
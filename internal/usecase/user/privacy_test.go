@@ -0,0 +1,101 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/block"
+	"github.com/anigmaa/backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// fakeUserRepo returns a fixed page of follow requests
+type fakeUserRepo struct {
+	user.Repository
+	requests []user.FollowRequestWithUser
+}
+
+func (r *fakeUserRepo) GetFollowRequests(ctx context.Context, targetID uuid.UUID, limit, offset int) ([]user.FollowRequestWithUser, error) {
+	return r.requests, nil
+}
+
+func (r *fakeUserRepo) GetPrivacyByUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]user.UserPrivacy, error) {
+	privacies := make(map[uuid.UUID]user.UserPrivacy, len(userIDs))
+	for _, id := range userIDs {
+		privacies[id] = user.DefaultPrivacy(id)
+	}
+	return privacies, nil
+}
+
+// fakeBlockRepo reports a fixed set of blocked users
+type fakeBlockRepo struct {
+	block.Repository
+	blocked []uuid.UUID
+}
+
+func (r *fakeBlockRepo) GetBlockedIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return r.blocked, nil
+}
+
+func followRequest(requesterID uuid.UUID, name string) user.FollowRequestWithUser {
+	return user.FollowRequestWithUser{
+		ID:        uuid.New(),
+		CreatedAt: time.Now(),
+		Requester: user.User{ID: requesterID, Name: name},
+	}
+}
+
+func TestGetFollowRequestsSkipsBlockedRequesters(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.New()
+	alice, blocked, carol := uuid.New(), uuid.New(), uuid.New()
+
+	requests := []user.FollowRequestWithUser{
+		followRequest(alice, "alice"),
+		followRequest(blocked, "blocked"),
+		followRequest(carol, "carol"),
+	}
+	uc := NewUsecase(&fakeUserRepo{requests: requests}, nil, &fakeBlockRepo{blocked: []uuid.UUID{blocked}}, nil, "")
+
+	got, err := uc.GetFollowRequests(ctx, owner, 20, 0)
+	if err != nil {
+		t.Fatalf("GetFollowRequests: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+
+	want := []user.FollowRequestWithUser{requests[0], requests[2]}
+	for i, req := range got {
+		if req.ID != want[i].ID {
+			t.Errorf("request %d: got id %s, want %s", i, req.ID, want[i].ID)
+		}
+		if req.Requester.ID != want[i].Requester.ID || req.Requester.Name != want[i].Requester.Name {
+			t.Errorf("request %d: requester %s (%s) does not belong to the request, want %s (%s)",
+				i, req.Requester.ID, req.Requester.Name, want[i].Requester.ID, want[i].Requester.Name)
+		}
+	}
+}
+
+func TestGetFollowRequestsWithoutBlocks(t *testing.T) {
+	ctx := context.Background()
+	requests := []user.FollowRequestWithUser{
+		followRequest(uuid.New(), "alice"),
+		followRequest(uuid.New(), "bob"),
+	}
+	uc := NewUsecase(&fakeUserRepo{requests: requests}, nil, &fakeBlockRepo{}, nil, "")
+
+	got, err := uc.GetFollowRequests(ctx, uuid.New(), 20, 0)
+	if err != nil {
+		t.Fatalf("GetFollowRequests: %v", err)
+	}
+	if len(got) != len(requests) {
+		t.Fatalf("got %d requests, want %d", len(got), len(requests))
+	}
+	for i, req := range got {
+		if req.ID != requests[i].ID || req.Requester.ID != requests[i].Requester.ID {
+			t.Errorf("request %d does not match its requester", i)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/anigmaa/backend/internal/domain/auth"
+	"github.com/anigmaa/backend/internal/domain/block"
 	"github.com/anigmaa/backend/internal/domain/user"
 	"github.com/anigmaa/backend/pkg/jwt"
 	"github.com/google/uuid"
@@ -26,21 +27,29 @@ var (
 	ErrCannotFollowSelf = errors.New("cannot follow yourself")
 	ErrInvalidToken     = errors.New("invalid or expired token")
 	ErrTokenAlreadyUsed = errors.New("token has already been used")
+	ErrProfilePrivate   = errors.New("this profile is private")
+	ErrEventsPrivate    = errors.New("this user's events are private")
+	ErrFollowRequested  = errors.New("follow request already sent")
+	ErrRequestNotFound  = errors.New("follow request not found")
+	ErrBlocked          = errors.New("you cannot interact with this user")
+	ErrUserSuspended    = errors.New("account is suspended")
 )
 
 // Usecase handles user business logic
 type Usecase struct {
 	userRepo       user.Repository
 	authTokenRepo  auth.Repository
+	blockRepo      block.Repository
 	jwtManager     *jwt.JWTManager
 	googleClientID string
 }
 
 // NewUsecase creates a new user usecase
-func NewUsecase(userRepo user.Repository, authTokenRepo auth.Repository, jwtManager *jwt.JWTManager, googleClientID string) *Usecase {
+func NewUsecase(userRepo user.Repository, authTokenRepo auth.Repository, blockRepo block.Repository, jwtManager *jwt.JWTManager, googleClientID string) *Usecase {
 	return &Usecase{
 		userRepo:       userRepo,
 		authTokenRepo:  authTokenRepo,
+		blockRepo:      blockRepo,
 		jwtManager:     jwtManager,
 		googleClientID: googleClientID,
 	}
@@ -68,8 +77,12 @@ func (uc *Usecase) RefreshToken(ctx context.Context, refreshToken string) (*Auth
 		return nil, ErrUserNotFound
 	}
 
+	if existingUser.IsSuspended(time.Now()) {
+		return nil, ErrUserSuspended
+	}
+
 	// Generate new tokens
-	accessToken, err := uc.jwtManager.Generate(existingUser.ID, existingUser.Email)
+	accessToken, err := uc.jwtManager.Generate(existingUser.ID, existingUser.Email, string(existingUser.Role), existingUser.PermissionNames())
 	if err != nil {
 		return nil, err
 	}
@@ -105,25 +118,32 @@ func (uc *Usecase) GetByID(ctx context.Context, userID uuid.UUID) (*user.User, e
 	return existingUser, nil
 }
 
-// GetByUsername gets a user by username (which is actually user ID as string for Google Auth)
-// This method exists for backwards compatibility with profile endpoints
+// GetByUsername gets a user by username, ignoring case.
+// Usernames given up and user IDs from older share links resolve too: callers compare
+// the returned user's username with the one asked for to redirect.
 func (uc *Usecase) GetByUsername(ctx context.Context, username string) (*user.User, error) {
-	// Try to parse as UUID (user ID)
-	userID, err := uuid.Parse(username)
-	if err != nil {
-		return nil, ErrUserNotFound
+	username = user.NormalizeUsername(username)
+
+	if existingUser, err := uc.userRepo.GetByUsername(ctx, username); err == nil {
+		return existingUser, nil
+	}
+	if existingUser, err := uc.userRepo.GetByPreviousUsername(ctx, username); err == nil {
+		return existingUser, nil
 	}
-	return uc.GetByID(ctx, userID)
+	if userID, err := uuid.Parse(username); err == nil {
+		return uc.GetByID(ctx, userID)
+	}
+
+	return nil, ErrUserNotFound
 }
 
-// GetProfileByUsername gets a user profile by username (which is actually user ID as string)
-func (uc *Usecase) GetProfileByUsername(ctx context.Context, username string) (*user.UserProfile, error) {
-	// Try to parse as UUID (user ID)
-	userID, err := uuid.Parse(username)
+// GetProfileByUsername gets a user profile by username as seen by the viewer, resolving like GetByUsername
+func (uc *Usecase) GetProfileByUsername(ctx context.Context, username string, viewerID uuid.UUID) (*user.UserProfile, error) {
+	existingUser, err := uc.GetByUsername(ctx, username)
 	if err != nil {
-		return nil, ErrUserNotFound
+		return nil, err
 	}
-	return uc.GetProfile(ctx, userID)
+	return uc.GetProfileForViewer(ctx, existingUser.ID, viewerID)
 }
 
 // UpdateProfile updates a user's profile
@@ -191,7 +211,7 @@ func (uc *Usecase) UpdateSettings(ctx context.Context, userID uuid.UUID, req *us
 		return nil, ErrUserNotFound
 	}
 
-	settings := profile.Settings
+	settings := *profile.Settings
 
 	// Update fields if provided
 	if req.PushNotifications != nil {
@@ -221,33 +241,71 @@ func (uc *Usecase) UpdateSettings(ctx context.Context, userID uuid.UUID, req *us
 	return &settings, nil
 }
 
-// Follow follows a user
-func (uc *Usecase) Follow(ctx context.Context, followerID, followingID uuid.UUID) error {
+// Follow follows a user, or sends a follow request when they do not allow open following
+func (uc *Usecase) Follow(ctx context.Context, followerID, followingID uuid.UUID) (*user.FollowResult, error) {
 	// Check if trying to follow self
 	if followerID == followingID {
-		return ErrCannotFollowSelf
+		return nil, ErrCannotFollowSelf
+	}
+
+	// Blocked users cannot follow each other
+	blocked, err := uc.blockRepo.IsBlocked(ctx, followerID, followingID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, ErrBlocked
 	}
 
 	// Check if already following
 	isFollowing, err := uc.userRepo.IsFollowing(ctx, followerID, followingID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if isFollowing {
-		return ErrAlreadyFollowing
+		return nil, ErrAlreadyFollowing
 	}
 
 	// Check if user to follow exists
 	_, err = uc.userRepo.GetByID(ctx, followingID)
 	if err != nil {
-		return ErrUserNotFound
+		return nil, ErrUserNotFound
+	}
+
+	privacy, err := uc.userRepo.GetPrivacy(ctx, followingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !privacy.AllowFollowers {
+		requested, err := uc.userRepo.HasFollowRequest(ctx, followerID, followingID)
+		if err != nil {
+			return nil, err
+		}
+		if requested {
+			return nil, ErrFollowRequested
+		}
+
+		req := &user.FollowRequest{
+			ID:          uuid.New(),
+			RequesterID: followerID,
+			TargetID:    followingID,
+			CreatedAt:   time.Now(),
+		}
+		if err := uc.userRepo.CreateFollowRequest(ctx, req); err != nil {
+			return nil, err
+		}
+		return &user.FollowResult{Status: user.FollowStatusRequested}, nil
 	}
 
 	// Create follow relationship
-	return uc.userRepo.Follow(ctx, followerID, followingID)
+	if err := uc.userRepo.Follow(ctx, followerID, followingID); err != nil {
+		return nil, err
+	}
+	return &user.FollowResult{Status: user.FollowStatusFollowing}, nil
 }
 
-// Unfollow unfollows a user
+// Unfollow unfollows a user, or withdraws a pending follow request
 func (uc *Usecase) Unfollow(ctx context.Context, followerID, followingID uuid.UUID) error {
 	// Check if following
 	isFollowing, err := uc.userRepo.IsFollowing(ctx, followerID, followingID)
@@ -255,33 +313,45 @@ func (uc *Usecase) Unfollow(ctx context.Context, followerID, followingID uuid.UU
 		return err
 	}
 	if !isFollowing {
-		return ErrNotFollowing
+		if err := uc.userRepo.DeleteFollowRequest(ctx, followerID, followingID); err != nil {
+			if err == sql.ErrNoRows {
+				return ErrNotFollowing
+			}
+			return err
+		}
+		return nil
 	}
 
 	// Remove follow relationship
 	return uc.userRepo.Unfollow(ctx, followerID, followingID)
 }
 
-// GetFollowers gets a user's followers
-func (uc *Usecase) GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]user.User, error) {
-	// Check if user exists
-	_, err := uc.userRepo.GetByID(ctx, userID)
+// GetFollowers gets a user's followers as seen by the viewer
+func (uc *Usecase) GetFollowers(ctx context.Context, userID, viewerID uuid.UUID, limit, offset int) ([]user.User, error) {
+	if err := uc.EnsureProfileVisible(ctx, userID, viewerID); err != nil {
+		return nil, err
+	}
+
+	followers, err := uc.userRepo.GetFollowers(ctx, userID, limit, offset)
 	if err != nil {
-		return nil, ErrUserNotFound
+		return nil, err
 	}
 
-	return uc.userRepo.GetFollowers(ctx, userID, limit, offset)
+	return uc.projectUsers(ctx, followers, viewerID)
 }
 
-// GetFollowing gets users that a user is following
-func (uc *Usecase) GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]user.User, error) {
-	// Check if user exists
-	_, err := uc.userRepo.GetByID(ctx, userID)
+// GetFollowing gets users that a user is following as seen by the viewer
+func (uc *Usecase) GetFollowing(ctx context.Context, userID, viewerID uuid.UUID, limit, offset int) ([]user.User, error) {
+	if err := uc.EnsureProfileVisible(ctx, userID, viewerID); err != nil {
+		return nil, err
+	}
+
+	following, err := uc.userRepo.GetFollowing(ctx, userID, limit, offset)
 	if err != nil {
-		return nil, ErrUserNotFound
+		return nil, err
 	}
 
-	return uc.userRepo.GetFollowing(ctx, userID, limit, offset)
+	return uc.projectUsers(ctx, following, viewerID)
 }
 
 // IsFollowing checks if a user is following another user
@@ -299,13 +369,13 @@ func (uc *Usecase) CountFollowing(ctx context.Context, userID uuid.UUID) (int, e
 	return uc.userRepo.CountFollowing(ctx, userID)
 }
 
-// CountSearchResults counts total users matching search query
-func (uc *Usecase) CountSearchResults(ctx context.Context, query string) (int, error) {
-	return uc.userRepo.CountSearchResults(ctx, query)
+// CountSearchResults counts total users matching search query, as seen by the viewer
+func (uc *Usecase) CountSearchResults(ctx context.Context, query string, viewerID uuid.UUID) (int, error) {
+	return uc.userRepo.CountSearchResults(ctx, query, viewerID)
 }
 
-// SearchUsers searches for users by query
-func (uc *Usecase) SearchUsers(ctx context.Context, query string, limit, offset int) ([]user.User, error) {
+// SearchUsers searches for users by query, as seen by the viewer
+func (uc *Usecase) SearchUsers(ctx context.Context, query string, viewerID uuid.UUID, limit, offset int) ([]user.User, error) {
 	if limit <= 0 {
 		limit = 20
 	}
@@ -313,7 +383,12 @@ func (uc *Usecase) SearchUsers(ctx context.Context, query string, limit, offset
 		limit = 100
 	}
 
-	return uc.userRepo.SearchUsers(ctx, query, limit, offset)
+	users, err := uc.userRepo.SearchUsers(ctx, query, viewerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.projectUsers(ctx, users, viewerID)
 }
 
 // GetStats gets a user's statistics
@@ -340,17 +415,6 @@ func (uc *Usecase) VerifyEmail(ctx context.Context, userID uuid.UUID) error {
 	return uc.userRepo.Update(ctx, existingUser)
 }
 
-// DeleteAccount deletes a user account
-func (uc *Usecase) DeleteAccount(ctx context.Context, userID uuid.UUID) error {
-	// Check if user exists
-	_, err := uc.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return ErrUserNotFound
-	}
-
-	return uc.userRepo.Delete(ctx, userID)
-}
-
 // GoogleTokenInfo represents the user info from Google ID token
 // FlexibleBool can unmarshal from both boolean and string values
 type FlexibleBool bool
@@ -411,9 +475,18 @@ func (uc *Usecase) LoginWithGoogle(ctx context.Context, req *user.GoogleAuthRequ
 			LastLoginAt:     &now,
 			IsVerified:      true,  // Google OAuth users are auto-verified
 			IsEmailVerified: true,  // Google already verifies emails
+			Role:            user.RoleUser,
 		}
 
-		if err := uc.userRepo.Create(ctx, newUser); err != nil {
+		// Suggest a username from the Google name; another signup may take it first
+		for attempt := 1; ; attempt++ {
+			newUser.Username = uc.pickUsername(ctx, googleInfo.Name, googleInfo.Email)
+			err = uc.userRepo.Create(ctx, newUser)
+			if err != user.ErrUsernameTaken || attempt == 3 {
+				break
+			}
+		}
+		if err != nil {
 			return nil, fmt.Errorf("failed to create user: %w", err)
 		}
 
@@ -423,6 +496,9 @@ func (uc *Usecase) LoginWithGoogle(ctx context.Context, req *user.GoogleAuthRequ
 	} else {
 		// User exists, update last login
 		now := time.Now()
+		if existingUser.IsSuspended(now) {
+			return nil, ErrUserSuspended
+		}
 		existingUser.LastLoginAt = &now
 		if err := uc.userRepo.Update(ctx, existingUser); err != nil {
 			// Log error but don't fail login
@@ -430,7 +506,7 @@ func (uc *Usecase) LoginWithGoogle(ctx context.Context, req *user.GoogleAuthRequ
 	}
 
 	// Generate tokens
-	accessToken, err := uc.jwtManager.Generate(existingUser.ID, existingUser.Email)
+	accessToken, err := uc.jwtManager.Generate(existingUser.ID, existingUser.Email, string(existingUser.Role), existingUser.PermissionNames())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
@@ -0,0 +1,176 @@
+package user
+
+import (
+	"context"
+	"math/rand"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+const (
+	// usernameHoldPeriod is how long a username given up stays reserved for its previous owner,
+	// so old links keep pointing at them
+	usernameHoldPeriod = 30 * 24 * time.Hour
+
+	// usernameChangeCooldown is the minimum time between two username changes,
+	// so a user cannot reserve a string of usernames through the hold period
+	usernameChangeCooldown = 7 * 24 * time.Hour
+
+	// maxUsernameSuggestions caps the alternatives offered for a username
+	maxUsernameSuggestions = 5
+
+	// usernameSuffixAttempts caps the numbered variants tried when suggesting usernames
+	usernameSuffixAttempts = 10
+)
+
+// ChangeUsername changes a user's username. The previous one is kept in the history and
+// redirects to the new one; nobody else can take it during the hold period.
+// Changes are limited to one per cooldown; changing only the casing is always allowed.
+func (uc *Usecase) ChangeUsername(ctx context.Context, userID uuid.UUID, username string) (*user.User, error) {
+	username = user.NormalizeUsername(username)
+	if err := user.ValidateUsername(username); err != nil {
+		return nil, err
+	}
+
+	existingUser, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if existingUser.Username == username {
+		return existingUser, nil
+	}
+
+	available, err := uc.isUsernameAvailable(ctx, username, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		return nil, user.ErrUsernameTaken
+	}
+
+	if err := uc.userRepo.ChangeUsername(ctx, userID, username, time.Now().Add(-usernameChangeCooldown)); err != nil {
+		return nil, err
+	}
+
+	existingUser.Username = username
+	return existingUser, nil
+}
+
+// CheckUsername reports whether a user can take a username, with alternatives when they cannot
+func (uc *Usecase) CheckUsername(ctx context.Context, userID uuid.UUID, username string) (*user.UsernameAvailability, error) {
+	username = user.NormalizeUsername(username)
+	result := &user.UsernameAvailability{Username: username}
+
+	if err := user.ValidateUsername(username); err != nil {
+		result.Reason = err.Error()
+		return result, nil
+	}
+
+	available, err := uc.isUsernameAvailable(ctx, username, userID)
+	if err != nil {
+		return nil, err
+	}
+	if available {
+		result.Available = true
+		return result, nil
+	}
+
+	result.Reason = user.ErrUsernameTaken.Error()
+	bases := []string{username}
+	if existingUser, err := uc.userRepo.GetByID(ctx, userID); err == nil {
+		bases = append(bases, user.SuggestUsernames(existingUser.Name)...)
+	}
+	result.Suggestions, err = uc.availableUsernames(ctx, userID, bases, maxUsernameSuggestions)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SuggestUsernames suggests available usernames based on the user's name
+func (uc *Usecase) SuggestUsernames(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	existingUser, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	return uc.availableUsernames(ctx, userID, user.SuggestUsernames(existingUser.Name), maxUsernameSuggestions)
+}
+
+// GetUsernameHistory gets the usernames a user gave up, most recent first
+func (uc *Usecase) GetUsernameHistory(ctx context.Context, userID uuid.UUID) ([]user.UsernameChange, error) {
+	return uc.userRepo.GetUsernameHistory(ctx, userID)
+}
+
+// pickUsername picks an available username for a new account from its Google name,
+// then its email address. It never fails: the last resort is a random one.
+func (uc *Usecase) pickUsername(ctx context.Context, name, email string) string {
+	bases := user.SuggestUsernames(name)
+	if addr, err := mail.ParseAddress(email); err == nil {
+		local, _, _ := strings.Cut(addr.Address, "@")
+		bases = append(bases, user.SuggestUsernames(local)...)
+	}
+
+	usernames, err := uc.availableUsernames(ctx, uuid.Nil, bases, 1)
+	if err != nil || len(usernames) == 0 {
+		return user.UsernameWithSuffix("user", strconv.FormatUint(rand.Uint64(), 36))
+	}
+	return usernames[0]
+}
+
+// availableUsernames returns up to n of the candidate usernames the user can take,
+// topping them up with numbered variants
+func (uc *Usecase) availableUsernames(ctx context.Context, userID uuid.UUID, candidates []string, n int) ([]string, error) {
+	found := []string{}
+	seen := make(map[string]bool)
+
+	try := func(candidate string) error {
+		key := strings.ToLower(candidate)
+		if seen[key] || user.ValidateUsername(candidate) != nil {
+			return nil
+		}
+		seen[key] = true
+
+		available, err := uc.isUsernameAvailable(ctx, candidate, userID)
+		if err != nil {
+			return err
+		}
+		if available {
+			found = append(found, candidate)
+		}
+		return nil
+	}
+
+	for _, candidate := range candidates {
+		if len(found) >= n {
+			return found, nil
+		}
+		if err := try(candidate); err != nil {
+			return nil, err
+		}
+	}
+
+	bases := candidates
+	if len(bases) == 0 {
+		bases = []string{"user"}
+	}
+	for i := 0; i < usernameSuffixAttempts && len(found) < n; i++ {
+		suffix := strconv.Itoa(10 + rand.Intn(9990))
+		if err := try(user.UsernameWithSuffix(bases[i%len(bases)], suffix)); err != nil {
+			return nil, err
+		}
+	}
+
+	return found, nil
+}
+
+// isUsernameAvailable checks that nobody else holds a username or gave it up recently
+func (uc *Usecase) isUsernameAvailable(ctx context.Context, username string, userID uuid.UUID) (bool, error) {
+	return uc.userRepo.IsUsernameAvailable(ctx, username, userID, time.Now().Add(-usernameHoldPeriod))
+}
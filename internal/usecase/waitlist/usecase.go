@@ -0,0 +1,246 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/anigmaa/backend/internal/domain/event"
+	"github.com/anigmaa/backend/internal/domain/ticket"
+	"github.com/anigmaa/backend/internal/domain/waitlist"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEventNotFound     = errors.New("event not found")
+	ErrEventFull         = errors.New("event is full")
+	ErrEventStarted      = errors.New("event has already started")
+	ErrSeatsAvailable    = errors.New("event still has seats available")
+	ErrAlreadyAttending  = errors.New("already attending this event")
+	ErrAlreadyOnWaitlist = errors.New("already on the waitlist for this event")
+	ErrNotOnWaitlist     = errors.New("not on the waitlist for this event")
+	ErrHostCannotJoin    = errors.New("host cannot join their own event waitlist")
+)
+
+// Usecase handles event waitlist business logic
+type Usecase struct {
+	waitlistRepo waitlist.Repository
+	eventRepo    event.Repository
+	ticketRepo   ticket.Repository
+	claimWindow  time.Duration
+}
+
+// NewUsecase creates a new waitlist usecase
+// claimWindow is how long a promoted user has to claim their seat
+func NewUsecase(waitlistRepo waitlist.Repository, eventRepo event.Repository, ticketRepo ticket.Repository, claimWindow time.Duration) *Usecase {
+	return &Usecase{
+		waitlistRepo: waitlistRepo,
+		eventRepo:    eventRepo,
+		ticketRepo:   ticketRepo,
+		claimWindow:  claimWindow,
+	}
+}
+
+// JoinWaitlist adds a user to the waitlist of a full event
+func (uc *Usecase) JoinWaitlist(ctx context.Context, eventID, userID uuid.UUID) (*waitlist.WaitlistStatus, error) {
+	// Get event
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	if evt.HostID == userID {
+		return nil, ErrHostCannotJoin
+	}
+
+	// Waitlist closes once the event starts or is cancelled
+	if evt.Status == event.StatusCancelled || !evt.StartTime.After(time.Now()) {
+		return nil, ErrEventStarted
+	}
+
+	// Check if user already holds a seat
+	isAttending, err := uc.eventRepo.IsAttending(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isAttending {
+		return nil, ErrAlreadyAttending
+	}
+	existingTicket, err := uc.ticketRepo.GetUserTicketForEvent(ctx, userID, eventID)
+	if err == nil && existingTicket.HoldsSeat() {
+		return nil, ErrAlreadyAttending
+	}
+
+	// Check if already queued
+	existingEntry, err := uc.waitlistRepo.GetByEventAndUser(ctx, eventID, userID)
+	if err == nil && existingEntry.IsQueued() {
+		return nil, ErrAlreadyOnWaitlist
+	}
+
+	// Only full events have a waitlist
+	if _, err := uc.CheckSeat(ctx, evt, userID, 1); err == nil {
+		return nil, ErrSeatsAvailable
+	} else if err != ErrEventFull {
+		return nil, err
+	}
+
+	entry := &waitlist.Entry{
+		ID:      uuid.New(),
+		EventID: eventID,
+		UserID:  userID,
+	}
+
+	if err := uc.waitlistRepo.Upsert(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return uc.GetWaitlistStatus(ctx, eventID, userID)
+}
+
+// LeaveWaitlist removes a user from an event waitlist
+// Leaving with an unclaimed offer passes the seat to the next user
+func (uc *Usecase) LeaveWaitlist(ctx context.Context, eventID, userID uuid.UUID) error {
+	entry, err := uc.waitlistRepo.GetByEventAndUser(ctx, eventID, userID)
+	if err != nil || !entry.IsQueued() {
+		return ErrNotOnWaitlist
+	}
+
+	hadOffer := entry.HasActiveOffer()
+
+	if err := uc.waitlistRepo.UpdateStatus(ctx, entry.ID, waitlist.StatusLeft); err != nil {
+		return err
+	}
+
+	if hadOffer {
+		if _, err := uc.PromoteWaitlist(ctx, eventID); err != nil {
+			// Log error but don't fail
+		}
+	}
+
+	return nil
+}
+
+// GetWaitlistStatus gets a user's position on an event waitlist
+func (uc *Usecase) GetWaitlistStatus(ctx context.Context, eventID, userID uuid.UUID) (*waitlist.WaitlistStatus, error) {
+	entry, err := uc.waitlistRepo.GetByEventAndUser(ctx, eventID, userID)
+	if err != nil {
+		return nil, ErrNotOnWaitlist
+	}
+
+	size, err := uc.waitlistRepo.CountWaiting(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &waitlist.WaitlistStatus{
+		Entry:        entry,
+		WaitlistSize: size,
+	}
+
+	if entry.Status == waitlist.StatusWaiting {
+		position, err := uc.waitlistRepo.GetPosition(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		status.Position = position
+	}
+
+	return status, nil
+}
+
+// CountWaiting counts users waiting for a seat at an event
+func (uc *Usecase) CountWaiting(ctx context.Context, eventID uuid.UUID) (int, error) {
+	return uc.waitlistRepo.CountWaiting(ctx, eventID)
+}
+
+// CheckSeat checks whether a user can take the given number of seats at an event.
+// Seats offered to waitlisted users are held for them, so everyone else only
+// sees what remains. Returns the user's active offer (if any) so the caller can
+// mark it claimed once the seats are taken; the offer covers one of the seats.
+func (uc *Usecase) CheckSeat(ctx context.Context, evt *event.Event, userID uuid.UUID, seats int) (*waitlist.Entry, error) {
+	taken, err := uc.ticketRepo.CountSeatsTaken(ctx, evt.ID)
+	if err != nil {
+		return nil, err
+	}
+	if taken+seats > evt.MaxAttendees {
+		return nil, ErrEventFull
+	}
+
+	offers, err := uc.waitlistRepo.CountActiveOffers(ctx, evt.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// User holding an offer gets one seat reserved for them
+	entry, err := uc.waitlistRepo.GetByEventAndUser(ctx, evt.ID, userID)
+	if err == nil && entry.HasActiveOffer() {
+		if taken+offers-1+seats > evt.MaxAttendees {
+			return nil, ErrEventFull
+		}
+		return entry, nil
+	}
+
+	if taken+offers+seats > evt.MaxAttendees {
+		return nil, ErrEventFull
+	}
+
+	return nil, nil
+}
+
+// ClaimOffer marks a waitlist offer as used
+// ticketID is nil when the seat was taken by joining a non-ticketed event
+func (uc *Usecase) ClaimOffer(ctx context.Context, entry *waitlist.Entry, ticketID *uuid.UUID) error {
+	return uc.waitlistRepo.MarkClaimed(ctx, entry.ID, ticketID)
+}
+
+// PromoteWaitlist offers every free seat of an event to the next users in line
+// Called whenever a seat frees up: ticket cancellation, pending ticket expiry,
+// expired offers or the host raising MaxAttendees. Returns the number of offers made.
+func (uc *Usecase) PromoteWaitlist(ctx context.Context, eventID uuid.UUID) (int, error) {
+	evt, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return 0, ErrEventNotFound
+	}
+
+	// No point offering seats for events that already started
+	if evt.Status == event.StatusCancelled || !evt.StartTime.After(time.Now()) {
+		return 0, nil
+	}
+
+	// Offers never outlive the event start
+	expiresAt := time.Now().Add(uc.claimWindow)
+	if expiresAt.After(evt.StartTime) {
+		expiresAt = evt.StartTime
+	}
+
+	// Seats are counted under the event lock by the repository
+	offered, err := uc.waitlistRepo.OfferFreeSeats(ctx, eventID, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(offered), nil
+}
+
+// ExpireOffers expires unclaimed offers and passes their seats down the line
+// This should be called periodically by a background job
+func (uc *Usecase) ExpireOffers(ctx context.Context) error {
+	expired, err := uc.waitlistRepo.ExpireOffers(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	promoted := make(map[uuid.UUID]bool)
+	for _, entry := range expired {
+		if promoted[entry.EventID] {
+			continue
+		}
+		promoted[entry.EventID] = true
+
+		if _, err := uc.PromoteWaitlist(ctx, entry.EventID); err != nil {
+			// Log error but continue with other events
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+-- ============================================================================
+-- ROLLBACK: Event Waitlist
+-- ============================================================================
+-- NOTE: 'pending' cannot be removed from ticket_status without recreating
+-- the enum, so it is left in place.
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_tickets_purchased_at;
+DROP TABLE IF EXISTS event_waitlist;
+DROP TYPE IF EXISTS waitlist_status;
+
+DROP INDEX IF EXISTS idx_tickets_user_event_live;
+ALTER TABLE tickets ADD CONSTRAINT tickets_user_id_event_id_key UNIQUE (user_id, event_id);
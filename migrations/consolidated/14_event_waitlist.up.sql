@@ -0,0 +1,74 @@
+-- ============================================================================
+-- MIGRATION: Event Waitlist with Automatic Promotion
+-- ============================================================================
+-- This migration adds a waitlist for full events:
+-- 1. Adds 'pending' to ticket_status (paid tickets awaiting Midtrans payment)
+-- 2. Relaxes one-ticket-per-user so cancelled/expired tickets can be re-bought
+-- 3. Creates event_waitlist table with time-limited seat offers
+-- ============================================================================
+
+-- ============================================================================
+-- ENUMS
+-- ============================================================================
+
+-- Paid tickets are created as 'pending' until Midtrans confirms payment
+ALTER TYPE ticket_status ADD VALUE IF NOT EXISTS 'pending';
+
+-- Waitlist entry status
+DO $$ BEGIN
+    CREATE TYPE waitlist_status AS ENUM ('waiting', 'offered', 'claimed', 'expired', 'left');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+-- ============================================================================
+-- MODIFY TICKETS TABLE
+-- ============================================================================
+
+-- A user may only hold one live ticket per event, but cancelled, refunded
+-- or expired tickets must not block buying again (e.g. via a waitlist offer)
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_user_id_event_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_user_event_live
+    ON tickets(user_id, event_id)
+    WHERE status NOT IN ('cancelled', 'refunded', 'expired');
+
+-- ============================================================================
+-- EVENT WAITLIST TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS event_waitlist (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,  -- References users(id) from user service
+    status waitlist_status NOT NULL DEFAULT 'waiting',
+    offered_at TIMESTAMP WITH TIME ZONE,
+    offer_expires_at TIMESTAMP WITH TIME ZONE,
+    ticket_id UUID,  -- Ticket purchased with the offer, references tickets(id)
+    joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(event_id, user_id)
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+-- Queue order lookup (next waiting user for an event)
+CREATE INDEX IF NOT EXISTS idx_event_waitlist_queue ON event_waitlist(event_id, status, joined_at);
+
+-- Offer expiry sweep
+CREATE INDEX IF NOT EXISTS idx_event_waitlist_offer_expiry ON event_waitlist(offer_expires_at) WHERE status = 'offered';
+
+CREATE INDEX IF NOT EXISTS idx_event_waitlist_user ON event_waitlist(user_id);
+
+-- Pending ticket expiry sweep
+CREATE INDEX IF NOT EXISTS idx_tickets_purchased_at ON tickets(purchased_at);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Added 'pending' ticket status
+-- 2. Replaced UNIQUE(user_id, event_id) on tickets with a partial unique index
+-- 3. Created event_waitlist - FIFO queue with time-limited seat offers
+-- ============================================================================
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- ROLLBACK: Ticket Tiers
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_tickets_tier;
+ALTER TABLE tickets DROP COLUMN IF EXISTS tier_id;
+
+DROP TRIGGER IF EXISTS update_ticket_tiers_updated_at ON ticket_tiers;
+DROP TABLE IF EXISTS ticket_tiers;
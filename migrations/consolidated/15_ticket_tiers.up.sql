@@ -0,0 +1,56 @@
+-- ============================================================================
+-- MIGRATION: Ticket Tiers
+-- ============================================================================
+-- This migration adds multiple ticket tiers per event (early bird, VIP, ...):
+-- 1. Creates ticket_tiers table with price, quota and sale window per tier
+-- 2. Links tickets to the tier they were bought from
+-- ============================================================================
+
+-- ============================================================================
+-- TICKET TIERS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS ticket_tiers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    price DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (price >= 0),
+    quota INTEGER NOT NULL CHECK (quota > 0),
+    sale_starts_at TIMESTAMP WITH TIME ZONE,
+    sale_ends_at TIMESTAMP WITH TIME ZONE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (sale_ends_at IS NULL OR sale_starts_at IS NULL OR sale_ends_at > sale_starts_at)
+);
+
+-- ============================================================================
+-- MODIFY TICKETS TABLE
+-- ============================================================================
+
+-- NULL for tickets bought before tiers existed (priced from events.price)
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS tier_id UUID REFERENCES ticket_tiers(id) ON DELETE SET NULL;
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_ticket_tiers_event ON ticket_tiers(event_id, sort_order);
+CREATE INDEX IF NOT EXISTS idx_tickets_tier ON tickets(tier_id);
+
+-- ============================================================================
+-- TRIGGERS
+-- ============================================================================
+
+DROP TRIGGER IF EXISTS update_ticket_tiers_updated_at ON ticket_tiers;
+CREATE TRIGGER update_ticket_tiers_updated_at BEFORE UPDATE ON ticket_tiers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Created ticket_tiers - per-event tiers with price, quota and sale window
+-- 2. Added tickets.tier_id
+-- ============================================================================
@@ -0,0 +1,11 @@
+-- ============================================================================
+-- ROLLBACK: Promo Codes
+-- ============================================================================
+
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promo_code_events;
+
+DROP TRIGGER IF EXISTS update_promo_codes_updated_at ON promo_codes;
+DROP TABLE IF EXISTS promo_codes;
+
+DROP TYPE IF EXISTS discount_type;
@@ -0,0 +1,95 @@
+-- ============================================================================
+-- MIGRATION: Promo Codes
+-- ============================================================================
+-- This migration adds host-managed promo codes applied at ticket checkout:
+-- 1. Creates promo_codes table (percentage or fixed discount, limits, window)
+-- 2. Creates promo_code_events table to restrict a code to specific events
+-- 3. Creates promo_redemptions table linking a redemption to its ticket
+-- ============================================================================
+
+-- ============================================================================
+-- ENUMS
+-- ============================================================================
+
+DO $$ BEGIN
+    CREATE TYPE discount_type AS ENUM ('percentage', 'fixed');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- ============================================================================
+-- PROMO CODES TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    host_id UUID NOT NULL,  -- References users(id) from user service
+    code VARCHAR(32) NOT NULL,  -- Stored uppercase
+    description TEXT,
+    discount_type discount_type NOT NULL,
+    discount_value DECIMAL(10, 2) NOT NULL CHECK (discount_value > 0),
+    max_redemptions INTEGER CHECK (max_redemptions IS NULL OR max_redemptions > 0),  -- NULL = unlimited
+    max_per_user INTEGER NOT NULL DEFAULT 1 CHECK (max_per_user > 0),
+    redemption_count INTEGER NOT NULL DEFAULT 0 CHECK (redemption_count >= 0),
+    valid_from TIMESTAMP WITH TIME ZONE,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(host_id, code),
+    CHECK (discount_type <> 'percentage' OR discount_value <= 100),
+    CHECK (valid_until IS NULL OR valid_from IS NULL OR valid_until > valid_from)
+);
+
+-- ============================================================================
+-- PROMO CODE EVENTS TABLE
+-- ============================================================================
+-- A code without rows here applies to every event of its host
+
+CREATE TABLE IF NOT EXISTS promo_code_events (
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    PRIMARY KEY (promo_code_id, event_id)
+);
+
+-- ============================================================================
+-- PROMO REDEMPTIONS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,  -- References users(id) from user service
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_id UUID NOT NULL UNIQUE REFERENCES tickets(id) ON DELETE CASCADE,
+    original_amount DECIMAL(10, 2) NOT NULL,
+    discount_amount DECIMAL(10, 2) NOT NULL CHECK (discount_amount >= 0),
+    redeemed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_promo_codes_host ON promo_codes(host_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_promo_code_events_event ON promo_code_events(event_id);
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_code_user ON promo_redemptions(promo_code_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_event ON promo_redemptions(event_id);
+
+-- ============================================================================
+-- TRIGGERS
+-- ============================================================================
+
+DROP TRIGGER IF EXISTS update_promo_codes_updated_at ON promo_codes;
+CREATE TRIGGER update_promo_codes_updated_at BEFORE UPDATE ON promo_codes
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Created discount_type enum
+-- 2. Created promo_codes - host codes with usage limits and validity window
+-- 3. Created promo_code_events - optional event restriction
+-- 4. Created promo_redemptions - one row per discounted ticket
+-- ============================================================================
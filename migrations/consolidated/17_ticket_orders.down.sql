@@ -0,0 +1,18 @@
+-- ============================================================================
+-- ROLLBACK: Ticket Orders (Group Purchase)
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_ticket_transactions_txn_ticket;
+ALTER TABLE ticket_transactions ADD CONSTRAINT ticket_transactions_transaction_id_key UNIQUE (transaction_id);
+
+DROP INDEX IF EXISTS idx_tickets_user_event_live;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_user_event_live
+    ON tickets(user_id, event_id)
+    WHERE status NOT IN ('cancelled', 'refunded', 'expired');
+
+DROP INDEX IF EXISTS idx_tickets_order;
+ALTER TABLE tickets DROP COLUMN IF EXISTS invite_token;
+ALTER TABLE tickets DROP COLUMN IF EXISTS is_assigned;
+ALTER TABLE tickets DROP COLUMN IF EXISTS order_id;
+
+DROP TABLE IF EXISTS ticket_orders;
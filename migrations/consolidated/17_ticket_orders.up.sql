@@ -0,0 +1,71 @@
+-- ============================================================================
+-- MIGRATION: Ticket Orders (Group Purchase)
+-- ============================================================================
+-- This migration lets one order hold several tickets paid in one transaction:
+-- 1. Creates ticket_orders table
+-- 2. Links tickets to their order and tracks whether a ticket has a holder yet
+-- 3. Allows one Midtrans transaction to cover several tickets
+-- ============================================================================
+
+-- ============================================================================
+-- TICKET ORDERS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS ticket_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    buyer_id UUID NOT NULL,  -- References users(id) from user service
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    tier_id UUID REFERENCES ticket_tiers(id) ON DELETE SET NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (unit_price >= 0),
+    discount_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (discount_amount >= 0),
+    total_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (total_amount >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================================================
+-- MODIFY TICKETS TABLE
+-- ============================================================================
+
+-- NULL for tickets bought before orders existed
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS order_id UUID REFERENCES ticket_orders(id) ON DELETE SET NULL;
+
+-- Unassigned tickets are held by the buyer (user_id) until given to someone
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS is_assigned BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Invite token for handing an unassigned ticket over by link
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS invite_token VARCHAR(64) UNIQUE;
+
+-- One live seat per user per event only applies to assigned tickets;
+-- a buyer may hold any number of unassigned tickets
+DROP INDEX IF EXISTS idx_tickets_user_event_live;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_user_event_live
+    ON tickets(user_id, event_id)
+    WHERE status NOT IN ('cancelled', 'refunded', 'expired') AND is_assigned;
+
+-- ============================================================================
+-- MODIFY TICKET TRANSACTIONS TABLE
+-- ============================================================================
+
+-- Every ticket of an order gets its own transaction row sharing the order's
+-- Midtrans transaction ID, so refunds stay per ticket
+ALTER TABLE ticket_transactions DROP CONSTRAINT IF EXISTS ticket_transactions_transaction_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_transactions_txn_ticket
+    ON ticket_transactions(transaction_id, ticket_id);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_ticket_orders_buyer ON ticket_orders(buyer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_tickets_order ON tickets(order_id);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Created ticket_orders - one row per checkout, holding N tickets
+-- 2. Added tickets.order_id, tickets.is_assigned and tickets.invite_token
+-- 3. Scoped the one-live-ticket rule to assigned tickets
+-- 4. Replaced unique transaction_id with unique (transaction_id, ticket_id)
+-- ============================================================================
@@ -0,0 +1,7 @@
+-- ============================================================================
+-- ROLLBACK: Ticket Transfers
+-- ============================================================================
+
+DROP TABLE IF EXISTS ticket_transfers;
+ALTER TABLE events DROP COLUMN IF EXISTS allow_ticket_transfers;
+DROP TYPE IF EXISTS ticket_transfer_status;
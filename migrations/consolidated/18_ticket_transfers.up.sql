@@ -0,0 +1,64 @@
+-- ============================================================================
+-- MIGRATION: Ticket Transfers
+-- ============================================================================
+-- This migration lets ticket holders hand a ticket to another user:
+-- 1. Creates ticket_transfer_status enum
+-- 2. Creates ticket_transfers table (also the audit trail of past holders)
+-- 3. Adds per-event toggle for transfers
+-- ============================================================================
+
+-- ============================================================================
+-- ENUMS
+-- ============================================================================
+
+DO $$ BEGIN
+    CREATE TYPE ticket_transfer_status AS ENUM ('pending', 'accepted', 'declined', 'cancelled', 'expired');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- ============================================================================
+-- MODIFY EVENTS TABLE
+-- ============================================================================
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS allow_ticket_transfers BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- ============================================================================
+-- TICKET TRANSFERS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS ticket_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL,  -- References users(id) from user service
+    to_user_id UUID NOT NULL,    -- References users(id) from user service
+    status ticket_transfer_status NOT NULL DEFAULT 'pending',
+    old_attendance_code VARCHAR(4) NOT NULL,
+    new_attendance_code VARCHAR(4),  -- Set once accepted
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    CHECK (from_user_id <> to_user_id)
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+-- At most one open transfer per ticket
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_transfers_ticket_pending
+    ON ticket_transfers(ticket_id)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_ticket_transfers_ticket ON ticket_transfers(ticket_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_ticket_transfers_event ON ticket_transfers(event_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_ticket_transfers_to_user ON ticket_transfers(to_user_id, status);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Created ticket_transfer_status enum
+-- 2. Created ticket_transfers - one row per transfer offer, kept after it is resolved
+-- 3. Added events.allow_ticket_transfers (default TRUE)
+-- ============================================================================
@@ -0,0 +1,10 @@
+-- ============================================================================
+-- ROLLBACK: Ticket Check-in
+-- ============================================================================
+
+DROP TABLE IF EXISTS event_staff;
+
+-- Fails if two events issued the same code since the migration
+DROP INDEX IF EXISTS idx_tickets_event_attendance_code;
+ALTER TABLE tickets ADD CONSTRAINT tickets_attendance_code_key UNIQUE (attendance_code);
+CREATE INDEX IF NOT EXISTS idx_tickets_attendance_code ON tickets(attendance_code);
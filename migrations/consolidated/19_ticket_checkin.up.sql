@@ -0,0 +1,43 @@
+-- ============================================================================
+-- MIGRATION: Ticket Check-in
+-- ============================================================================
+-- This migration prepares check-in at the door:
+-- 1. Makes attendance codes unique per event instead of globally
+-- 2. Creates event_staff table for users the host delegates check-in to
+-- ============================================================================
+
+-- ============================================================================
+-- MODIFY TICKETS TABLE
+-- ============================================================================
+
+-- Codes are always looked up together with the event being checked in
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_attendance_code_key;
+DROP INDEX IF EXISTS idx_tickets_attendance_code;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tickets_event_attendance_code
+    ON tickets(event_id, attendance_code);
+
+-- ============================================================================
+-- EVENT STAFF TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS event_staff (
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,   -- References users(id) from user service
+    added_by UUID NOT NULL,  -- References users(id) from user service
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, user_id)
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_event_staff_user ON event_staff(user_id);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Replaced unique tickets.attendance_code with unique (event_id, attendance_code)
+-- 2. Created event_staff - users allowed to check attendees in for an event
+-- ============================================================================
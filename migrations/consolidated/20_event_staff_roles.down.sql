@@ -0,0 +1,7 @@
+-- ============================================================================
+-- ROLLBACK: Event Staff Roles
+-- ============================================================================
+
+ALTER TABLE event_staff DROP COLUMN IF EXISTS permissions;
+ALTER TABLE event_staff DROP COLUMN IF EXISTS role;
+DROP TYPE IF EXISTS event_staff_role;
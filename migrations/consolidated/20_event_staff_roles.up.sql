@@ -0,0 +1,36 @@
+-- ============================================================================
+-- MIGRATION: Event Staff Roles
+-- ============================================================================
+-- This migration turns event staff into co-hosts and staff with scoped permissions:
+-- 1. Creates event_staff_role enum
+-- 2. Adds role and permissions to event_staff
+-- ============================================================================
+
+-- ============================================================================
+-- ENUMS
+-- ============================================================================
+
+DO $$ BEGIN
+    CREATE TYPE event_staff_role AS ENUM ('co_host', 'staff');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- ============================================================================
+-- MODIFY EVENT STAFF TABLE
+-- ============================================================================
+
+ALTER TABLE event_staff ADD COLUMN IF NOT EXISTS role event_staff_role NOT NULL DEFAULT 'staff';
+
+-- Granted actions for staff: check_in, moderate_qna, view_analytics, edit_event
+-- Co-hosts hold every permission regardless of this column
+-- Existing staff were added for check-in only
+ALTER TABLE event_staff ADD COLUMN IF NOT EXISTS permissions TEXT[] NOT NULL DEFAULT '{check_in}';
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Created event_staff_role enum
+-- 2. Added event_staff.role (default staff) and event_staff.permissions (default check_in)
+-- ============================================================================
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- ROLLBACK: Offline Check-in Sync
+-- ============================================================================
+
+DROP TABLE IF EXISTS offline_check_ins;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS checked_in_device_id;
+
+DROP TYPE IF EXISTS offline_check_in_status;
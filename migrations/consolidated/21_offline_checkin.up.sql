@@ -0,0 +1,60 @@
+-- ============================================================================
+-- MIGRATION: Offline Check-in Sync
+-- ============================================================================
+-- This migration lets door staff check attendees in without a connection:
+-- 1. Creates offline_check_in_status enum
+-- 2. Records which scanner device checked a ticket in
+-- 3. Creates offline_check_ins table (log of every uploaded scan)
+-- ============================================================================
+
+-- ============================================================================
+-- ENUMS
+-- ============================================================================
+
+DO $$ BEGIN
+    CREATE TYPE offline_check_in_status AS ENUM ('accepted', 'duplicate', 'rejected');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- ============================================================================
+-- MODIFY TICKETS TABLE
+-- ============================================================================
+
+-- NULL for check-ins made online
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS checked_in_device_id VARCHAR(100);
+
+-- ============================================================================
+-- OFFLINE CHECK-INS TABLE
+-- ============================================================================
+
+-- ticket_id has no foreign key so scans of unknown tickets are kept as well
+CREATE TABLE IF NOT EXISTS offline_check_ins (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_id UUID NOT NULL,
+    device_id VARCHAR(100) NOT NULL,
+    scanned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    uploaded_by UUID NOT NULL,  -- References users(id) from user service
+    status offline_check_in_status NOT NULL,
+    reason TEXT,
+    uploaded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+-- Uploading the same batch twice does not log the scans twice
+CREATE UNIQUE INDEX IF NOT EXISTS idx_offline_check_ins_scan
+    ON offline_check_ins(ticket_id, device_id, scanned_at);
+CREATE INDEX IF NOT EXISTS idx_offline_check_ins_event ON offline_check_ins(event_id, uploaded_at DESC);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Created offline_check_in_status enum
+-- 2. Added tickets.checked_in_device_id
+-- 3. Created offline_check_ins - scans uploaded by scanner devices
+-- ============================================================================
@@ -0,0 +1,13 @@
+-- ============================================================================
+-- ROLLBACK: Host Payouts and Settlement Ledger
+-- ============================================================================
+
+DROP TABLE IF EXISTS payouts;
+DROP TABLE IF EXISTS host_bank_accounts;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
+
+DROP TYPE IF EXISTS payout_status;
+DROP TYPE IF EXISTS ledger_direction;
+DROP TYPE IF EXISTS ledger_account;
+DROP TYPE IF EXISTS ledger_transaction_kind;
@@ -0,0 +1,116 @@
+-- ============================================================================
+-- MIGRATION: Host Payouts and Settlement Ledger
+-- ============================================================================
+-- This migration records what the platform owes each host:
+-- 1. Creates ledger enums
+-- 2. Creates ledger_transactions and ledger_entries (double-entry ledger)
+-- 3. Creates host_bank_accounts table
+-- 4. Creates payouts table
+-- ============================================================================
+
+-- ============================================================================
+-- ENUMS
+-- ============================================================================
+
+DO $$ BEGIN
+    CREATE TYPE ledger_transaction_kind AS ENUM ('ticket_sale', 'ticket_refund', 'payout_request', 'payout_paid', 'payout_reversal');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+DO $$ BEGIN
+    CREATE TYPE ledger_account AS ENUM ('gateway_cash', 'host_payable', 'platform_fees', 'payout_clearing');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+DO $$ BEGIN
+    CREATE TYPE ledger_direction AS ENUM ('debit', 'credit');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+DO $$ BEGIN
+    CREATE TYPE payout_status AS ENUM ('requested', 'paid', 'rejected', 'cancelled');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- ============================================================================
+-- LEDGER TABLES
+-- ============================================================================
+
+-- One row per business event; its entries always balance (debits = credits)
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind ledger_transaction_kind NOT NULL,
+    reference_id UUID NOT NULL,  -- Ticket for sales and refunds, payout for payouts
+    event_id UUID REFERENCES events(id) ON DELETE SET NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (kind, reference_id)
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transaction_id UUID NOT NULL REFERENCES ledger_transactions(id) ON DELETE RESTRICT,
+    account ledger_account NOT NULL,
+    host_id UUID,  -- References users(id) from user service, set on host_payable entries
+    event_id UUID REFERENCES events(id) ON DELETE SET NULL,
+    direction ledger_direction NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount > 0),
+    available_at TIMESTAMP WITH TIME ZONE,  -- When host_payable entries can be paid out, NULL means immediately
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================================================
+-- HOST BANK ACCOUNTS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS host_bank_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,  -- References users(id) from user service
+    bank_name VARCHAR(100) NOT NULL,
+    account_number VARCHAR(50) NOT NULL,
+    account_holder_name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================================================
+-- PAYOUTS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS payouts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    host_id UUID NOT NULL,  -- References users(id) from user service
+    bank_account_id UUID NOT NULL REFERENCES host_bank_accounts(id) ON DELETE RESTRICT,
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount > 0),
+    status payout_status NOT NULL DEFAULT 'requested',
+    reference VARCHAR(100),  -- Bank transfer reference, set when paid
+    note TEXT,               -- Reason when rejected
+    requested_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP WITH TIME ZONE,
+    processed_by UUID  -- References users(id) from user service
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction ON ledger_entries(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_host ON ledger_entries(host_id, created_at DESC) WHERE host_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_ledger_transactions_created ON ledger_transactions(created_at);
+CREATE INDEX IF NOT EXISTS idx_host_bank_accounts_user ON host_bank_accounts(user_id) WHERE is_active = TRUE;
+CREATE INDEX IF NOT EXISTS idx_payouts_host ON payouts(host_id, requested_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payouts_status ON payouts(status, requested_at);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Created ledger_transaction_kind, ledger_account, ledger_direction and payout_status enums
+-- 2. Created ledger_transactions and ledger_entries - double-entry settlement ledger
+-- 3. Created host_bank_accounts - where hosts receive payouts
+-- 4. Created payouts - payout requests and their settlement
+-- ============================================================================
@@ -0,0 +1,5 @@
+-- ============================================================================
+-- ROLLBACK: Contact Consent for Host Exports
+-- ============================================================================
+
+ALTER TABLE user_privacy DROP COLUMN IF EXISTS share_contact_with_hosts;
@@ -0,0 +1,20 @@
+-- ============================================================================
+-- MIGRATION: Contact Consent for Host Exports
+-- ============================================================================
+-- This migration lets attendees opt in to sharing contact details with hosts:
+-- 1. Adds user_privacy.share_contact_with_hosts
+-- Attendee and transaction exports mask names and emails of everyone else
+-- ============================================================================
+
+-- ============================================================================
+-- MODIFY USER_PRIVACY TABLE
+-- ============================================================================
+
+ALTER TABLE user_privacy ADD COLUMN IF NOT EXISTS share_contact_with_hosts BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Added user_privacy.share_contact_with_hosts (opt-in, off by default)
+-- ============================================================================
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- ROLLBACK: Event Conversion Funnel
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_ticket_transactions_ticket_completed;
+
+DROP TABLE IF EXISTS event_page_views;
+
+DROP TYPE IF EXISTS event_funnel_stage;
@@ -0,0 +1,51 @@
+-- ============================================================================
+-- MIGRATION: Event Conversion Funnel
+-- ============================================================================
+-- This migration records event page visits so hosts can follow
+-- view -> ticket page -> purchase -> check-in:
+-- 1. Creates event_funnel_stage enum
+-- 2. Creates event_page_views table
+-- 3. Adds indexes for SQL-side sales aggregation
+-- ============================================================================
+
+-- ============================================================================
+-- ENUMS
+-- ============================================================================
+
+DO $$ BEGIN
+    CREATE TYPE event_funnel_stage AS ENUM ('view', 'ticket_page');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- ============================================================================
+-- EVENT PAGE VIEWS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS event_page_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID,  -- References users(id) from user service, NULL when not logged in
+    stage event_funnel_stage NOT NULL,
+    viewed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_event_page_views_event_stage ON event_page_views(event_id, stage);
+
+-- Timeline buckets group successful payments by completion time
+CREATE INDEX IF NOT EXISTS idx_ticket_transactions_ticket_completed
+    ON ticket_transactions(ticket_id, completed_at)
+    WHERE status = 'success';
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Created event_funnel_stage enum
+-- 2. Created event_page_views - one row per visit to an event or ticket page
+-- 3. Added partial index on successful transactions for timeline aggregation
+-- ============================================================================
@@ -0,0 +1,22 @@
+-- ============================================================================
+-- ROLLBACK: Full-Text Search
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_communities_name_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP INDEX IF EXISTS idx_events_title_trgm;
+DROP INDEX IF EXISTS idx_posts_hashtags;
+DROP INDEX IF EXISTS idx_communities_search_vector;
+DROP INDEX IF EXISTS idx_users_search_vector;
+DROP INDEX IF EXISTS idx_posts_search_vector;
+DROP INDEX IF EXISTS idx_events_search_vector;
+
+DROP TRIGGER IF EXISTS update_posts_hashtags ON posts;
+DROP FUNCTION IF EXISTS extract_post_hashtags();
+ALTER TABLE posts DROP COLUMN IF EXISTS hashtags;
+
+DROP FUNCTION IF EXISTS profile_search_vector(TEXT, TEXT);
+DROP FUNCTION IF EXISTS post_search_vector(TEXT);
+DROP FUNCTION IF EXISTS event_search_vector(TEXT, TEXT, TEXT);
+
+-- pg_trgm is left installed, other objects may use it
@@ -0,0 +1,101 @@
+-- ============================================================================
+-- MIGRATION: Full-Text Search
+-- ============================================================================
+-- This migration backs GET /search:
+-- 1. Enables pg_trgm for typo-tolerant matching
+-- 2. Adds search vector functions for events, posts, users and communities
+--    (Indonesian and English stemming side by side; names use the simple config)
+-- 3. Adds posts.hashtags, kept up to date from the post content by a trigger
+-- 4. Adds GIN indexes for the vectors, hashtags and trigram matching
+-- ============================================================================
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- ============================================================================
+-- SEARCH VECTORS
+-- ============================================================================
+-- Vectors are computed by immutable functions and indexed as expressions rather
+-- than stored as columns, so existing SELECT * scans are unaffected. Queries
+-- must call the same function for the index to be used.
+-- Weights: A = title/name, B = description/bio/content, C = location
+
+CREATE OR REPLACE FUNCTION event_search_vector(title TEXT, description TEXT, location_name TEXT)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('indonesian'::regconfig, coalesce(title, '')), 'A') ||
+           setweight(to_tsvector('english'::regconfig, coalesce(title, '')), 'A') ||
+           setweight(to_tsvector('indonesian'::regconfig, coalesce(description, '')), 'B') ||
+           setweight(to_tsvector('english'::regconfig, coalesce(description, '')), 'B') ||
+           setweight(to_tsvector('simple'::regconfig, coalesce(location_name, '')), 'C')
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION post_search_vector(content TEXT)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('indonesian'::regconfig, coalesce(content, '')), 'B') ||
+           setweight(to_tsvector('english'::regconfig, coalesce(content, '')), 'B')
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Used for users (name, bio) and communities (name, description)
+CREATE OR REPLACE FUNCTION profile_search_vector(name TEXT, about TEXT)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple'::regconfig, coalesce(name, '')), 'A') ||
+           setweight(to_tsvector('indonesian'::regconfig, coalesce(about, '')), 'B') ||
+           setweight(to_tsvector('english'::regconfig, coalesce(about, '')), 'B')
+$$ LANGUAGE sql IMMUTABLE;
+
+-- ============================================================================
+-- POST HASHTAGS
+-- ============================================================================
+
+-- Lowercase tags without the leading #, each tag once per post
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS hashtags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE OR REPLACE FUNCTION extract_post_hashtags()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.hashtags := ARRAY(
+        SELECT DISTINCT lower(m[1])
+        FROM regexp_matches(NEW.content, '#([[:alnum:]_]+)', 'g') AS m
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS update_posts_hashtags ON posts;
+CREATE TRIGGER update_posts_hashtags BEFORE INSERT OR UPDATE OF content ON posts
+    FOR EACH ROW EXECUTE FUNCTION extract_post_hashtags();
+
+-- Backfill existing posts
+UPDATE posts SET hashtags = ARRAY(
+    SELECT DISTINCT lower(m[1])
+    FROM regexp_matches(content, '#([[:alnum:]_]+)', 'g') AS m
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_events_search_vector
+    ON events USING GIN(event_search_vector(title, description, location_name));
+CREATE INDEX IF NOT EXISTS idx_posts_search_vector
+    ON posts USING GIN(post_search_vector(content));
+CREATE INDEX IF NOT EXISTS idx_users_search_vector
+    ON users USING GIN(profile_search_vector(name, bio));
+CREATE INDEX IF NOT EXISTS idx_communities_search_vector
+    ON communities USING GIN(profile_search_vector(name, description));
+
+CREATE INDEX IF NOT EXISTS idx_posts_hashtags ON posts USING GIN(hashtags);
+
+-- Trigram indexes for typo tolerance on short fields
+CREATE INDEX IF NOT EXISTS idx_events_title_trgm ON events USING GIN(title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING GIN(name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_communities_name_trgm ON communities USING GIN(name gin_trgm_ops);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Enabled pg_trgm
+-- 2. Added event/post/profile_search_vector functions with expression indexes
+-- 3. Added posts.hashtags with extract_post_hashtags trigger and backfill
+-- 4. Added GIN indexes for full-text, hashtag and trigram search
+-- ============================================================================
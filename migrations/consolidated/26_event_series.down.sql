@@ -0,0 +1,15 @@
+-- ============================================================================
+-- ROLLBACK: Recurring Event Series
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_event_series_followers_user;
+DROP INDEX IF EXISTS idx_events_series_occurrence;
+DROP INDEX IF EXISTS idx_event_series_active;
+DROP INDEX IF EXISTS idx_event_series_host;
+
+DROP TABLE IF EXISTS event_series_followers;
+
+ALTER TABLE events DROP COLUMN IF EXISTS occurrence_start;
+ALTER TABLE events DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS event_series;
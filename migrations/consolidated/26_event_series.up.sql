@@ -0,0 +1,85 @@
+-- ============================================================================
+-- MIGRATION: Recurring Event Series
+-- ============================================================================
+-- This migration lets hosts schedule repeating events:
+-- 1. Creates event_series table (recurrence rule plus the template for new occurrences)
+-- 2. Links events to their series (series_id, occurrence_start)
+-- 3. Creates event_series_followers table
+--
+-- Every occurrence is a regular row in events, so tickets, attendance, Q&A and
+-- analytics stay per occurrence.
+-- ============================================================================
+
+-- ============================================================================
+-- EVENT SERIES TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS event_series (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    host_id UUID NOT NULL,  -- References users(id) from user service
+    recurrence VARCHAR(255) NOT NULL,  -- RFC 5545 RRULE, e.g. FREQ=WEEKLY;BYDAY=TU
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta',
+    first_start TIMESTAMP WITH TIME ZONE NOT NULL,  -- DTSTART of the rule
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+
+    -- Template copied into each generated occurrence
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    category event_category NOT NULL,
+    location_name VARCHAR(255) NOT NULL,
+    location_address TEXT NOT NULL,
+    location_lat DECIMAL(10, 8) NOT NULL,
+    location_lng DECIMAL(11, 8) NOT NULL,
+    max_attendees INTEGER NOT NULL,
+    price DECIMAL(10, 2) CHECK (price >= 0),
+    is_free BOOLEAN NOT NULL DEFAULT TRUE,
+    privacy event_privacy NOT NULL DEFAULT 'public',
+    requirements TEXT,
+    ticketing_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    allow_ticket_transfers BOOLEAN NOT NULL DEFAULT TRUE,
+    image_urls TEXT[] NOT NULL DEFAULT '{}',
+
+    generated_until TIMESTAMP WITH TIME ZONE NOT NULL,  -- Occurrences up to here exist (or were deleted)
+    ended_at TIMESTAMP WITH TIME ZONE,  -- Set when the host ends the series
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================================================
+-- EVENT OCCURRENCES
+-- ============================================================================
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES event_series(id) ON DELETE SET NULL;
+-- Start time given by the rule; stays fixed when the occurrence is moved
+ALTER TABLE events ADD COLUMN IF NOT EXISTS occurrence_start TIMESTAMP WITH TIME ZONE;
+
+-- ============================================================================
+-- EVENT SERIES FOLLOWERS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS event_series_followers (
+    series_id UUID NOT NULL REFERENCES event_series(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,  -- References users(id) from user service
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (series_id, user_id)
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_event_series_host ON event_series(host_id);
+CREATE INDEX IF NOT EXISTS idx_event_series_active ON event_series(generated_until) WHERE ended_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_series_occurrence ON events(series_id, occurrence_start) WHERE series_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_event_series_followers_user ON event_series_followers(user_id);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Tables created:
+-- 1. event_series - Recurrence rule, template and generation horizon
+-- 2. event_series_followers - Users following a series
+--
+-- Columns added:
+-- 1. events.series_id, events.occurrence_start
+-- ============================================================================
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- ROLLBACK: Calendar Feeds
+-- ============================================================================
+
+DROP TABLE IF EXISTS calendar_feed_tokens;
+
+DROP TRIGGER IF EXISTS bump_events_sequence ON events;
+DROP FUNCTION IF EXISTS bump_event_sequence();
+ALTER TABLE events DROP COLUMN IF EXISTS sequence;
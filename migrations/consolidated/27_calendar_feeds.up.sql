@@ -0,0 +1,53 @@
+-- ============================================================================
+-- MIGRATION: Calendar Feeds
+-- ============================================================================
+-- This migration backs the iCalendar (.ics) exports:
+-- 1. Adds events.sequence, bumped whenever calendar apps must update an entry
+-- 2. Creates calendar_feed_tokens table (secret per-user subscription URLs)
+-- ============================================================================
+
+-- ============================================================================
+-- EVENT SEQUENCE
+-- ============================================================================
+
+-- iCalendar SEQUENCE: revision number of the event as seen by calendar apps
+ALTER TABLE events ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE FUNCTION bump_event_sequence()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.title IS DISTINCT FROM OLD.title
+        OR NEW.start_time IS DISTINCT FROM OLD.start_time
+        OR NEW.end_time IS DISTINCT FROM OLD.end_time
+        OR NEW.location_name IS DISTINCT FROM OLD.location_name
+        OR NEW.location_address IS DISTINCT FROM OLD.location_address
+        OR NEW.status IS DISTINCT FROM OLD.status THEN
+        NEW.sequence := OLD.sequence + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS bump_events_sequence ON events;
+CREATE TRIGGER bump_events_sequence BEFORE UPDATE ON events
+    FOR EACH ROW EXECUTE FUNCTION bump_event_sequence();
+
+-- ============================================================================
+-- CALENDAR FEED TOKENS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+    user_id UUID PRIMARY KEY,  -- References users(id) from user service
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Tables created:
+-- 1. calendar_feed_tokens - One secret feed token per user
+--
+-- Columns added:
+-- 1. events.sequence (kept up to date by bump_events_sequence trigger)
+-- ============================================================================
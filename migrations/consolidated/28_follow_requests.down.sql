@@ -0,0 +1,5 @@
+-- ============================================================================
+-- ROLLBACK: Follow Requests
+-- ============================================================================
+
+DROP TABLE IF EXISTS follow_requests;
@@ -0,0 +1,29 @@
+-- ============================================================================
+-- MIGRATION: Follow Requests
+-- ============================================================================
+-- This migration backs privacy enforcement for follows:
+-- 1. Creates follow_requests table (pending follows of users who do not
+--    allow open following)
+-- ============================================================================
+
+-- ============================================================================
+-- FOLLOW REQUESTS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS follow_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    requester_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(requester_id, target_id),
+    CHECK (requester_id != target_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follow_requests_target ON follow_requests(target_id, created_at DESC);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Tables created:
+-- 1. follow_requests - Pending follow requests, removed once approved or declined
+-- ============================================================================
@@ -0,0 +1,14 @@
+-- ============================================================================
+-- ROLLBACK: User Blocks and Mutes
+-- ============================================================================
+
+DROP TRIGGER IF EXISTS reject_blocked_invitations ON invitations;
+DROP FUNCTION IF EXISTS reject_blocked_invitation();
+
+DROP TRIGGER IF EXISTS drop_blocked_notifications ON notifications;
+DROP FUNCTION IF EXISTS drop_blocked_notification();
+
+DROP FUNCTION IF EXISTS is_blocked_between(UUID, UUID);
+
+DROP TABLE IF EXISTS user_mutes;
+DROP TABLE IF EXISTS user_blocks;
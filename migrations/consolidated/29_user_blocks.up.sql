@@ -0,0 +1,98 @@
+-- ============================================================================
+-- MIGRATION: User Blocks and Mutes
+-- ============================================================================
+-- This migration adds blocking and muting between users:
+-- 1. Creates user_blocks table (blocks apply in both directions)
+-- 2. Creates user_mutes table (one-way, only affects the muter's feed and notifications)
+-- 3. Adds is_blocked_between() used by feed, comment, Q&A, search and attendee queries
+-- 4. Drops notifications from blocked or muted actors and rejects invitations between blocked users
+-- ============================================================================
+
+-- ============================================================================
+-- USER BLOCKS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id != blocked_id)
+);
+
+-- Reverse lookups ("who blocked me")
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked ON user_blocks(blocked_id, blocker_id);
+
+-- ============================================================================
+-- USER MUTES TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS user_mutes (
+    muter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (muter_id, muted_id),
+    CHECK (muter_id != muted_id)
+);
+
+-- ============================================================================
+-- FUNCTIONS
+-- ============================================================================
+
+-- True when either user blocked the other. Anonymous viewers (nil UUID) are never blocked.
+CREATE OR REPLACE FUNCTION is_blocked_between(a UUID, b UUID)
+RETURNS BOOLEAN AS $$
+    SELECT EXISTS(
+        SELECT 1 FROM user_blocks
+        WHERE (blocker_id = a AND blocked_id = b)
+           OR (blocker_id = b AND blocked_id = a)
+    )
+$$ LANGUAGE sql STABLE;
+
+-- Silently drop notifications the recipient must not see
+CREATE OR REPLACE FUNCTION drop_blocked_notification()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.actor_id IS NOT NULL AND (
+        is_blocked_between(NEW.user_id, NEW.actor_id)
+        OR EXISTS(SELECT 1 FROM user_mutes WHERE muter_id = NEW.user_id AND muted_id = NEW.actor_id)
+    ) THEN
+        RETURN NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS drop_blocked_notifications ON notifications;
+CREATE TRIGGER drop_blocked_notifications BEFORE INSERT ON notifications
+    FOR EACH ROW EXECUTE FUNCTION drop_blocked_notification();
+
+-- Reject invitations between blocked users
+CREATE OR REPLACE FUNCTION reject_blocked_invitation()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF is_blocked_between(NEW.inviter_id, NEW.invitee_id) THEN
+        RAISE EXCEPTION 'cannot invite a blocked user';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS reject_blocked_invitations ON invitations;
+CREATE TRIGGER reject_blocked_invitations BEFORE INSERT ON invitations
+    FOR EACH ROW EXECUTE FUNCTION reject_blocked_invitation();
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Tables created:
+-- 1. user_blocks - Blocks between users
+-- 2. user_mutes - Mutes between users
+--
+-- Functions created:
+-- 1. is_blocked_between(a, b)
+--
+-- Triggers created:
+-- 1. drop_blocked_notifications on notifications
+-- 2. reject_blocked_invitations on invitations
+-- ============================================================================
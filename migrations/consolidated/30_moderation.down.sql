@@ -0,0 +1,14 @@
+-- ============================================================================
+-- ROLLBACK: Content Reports and Moderation
+-- ============================================================================
+
+DROP FUNCTION IF EXISTS is_content_hidden(VARCHAR, UUID);
+
+DROP TABLE IF EXISTS moderation_actions;
+DROP TABLE IF EXISTS report_filings;
+DROP TABLE IF EXISTS reports;
+
+ALTER TABLE users DROP COLUMN IF EXISTS suspension_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_at;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,106 @@
+-- ============================================================================
+-- MIGRATION: Content Reports and Moderation
+-- ============================================================================
+-- This migration adds user reports and admin moderation:
+-- 1. Adds a platform role and suspension to users
+-- 2. Creates reports table (one open report per target, shared by all reporters)
+-- 3. Creates report_filings table (one filing per reporter and report)
+-- 4. Creates moderation_actions table (audit trail of every moderation decision)
+-- 5. Adds is_content_hidden() used by feed, comment, Q&A, event and search queries
+-- ============================================================================
+
+-- ============================================================================
+-- USERS
+-- ============================================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user'
+    CHECK (role IN ('user', 'admin'));
+
+-- NULL suspended_at means not suspended, NULL suspended_until means suspended indefinitely
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
+
+-- ============================================================================
+-- REPORTS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('post', 'comment', 'event', 'qna', 'user')),
+    target_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'dismissed', 'actioned')),
+    reports_count INTEGER NOT NULL DEFAULT 0,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    first_reported_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_reported_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Reports on the same target are deduplicated while open
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_open_target ON reports(target_type, target_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_reports_hidden_target ON reports(target_type, target_id) WHERE is_hidden = TRUE;
+CREATE INDEX IF NOT EXISTS idx_reports_queue ON reports(status, reports_count DESC, last_reported_at DESC);
+
+-- ============================================================================
+-- REPORT FILINGS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS report_filings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(30) NOT NULL CHECK (reason IN ('spam', 'harassment', 'hate_speech', 'violence', 'nudity', 'scam', 'misinformation', 'impersonation', 'other')),
+    details TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (report_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_filings_report ON report_filings(report_id, created_at);
+
+-- ============================================================================
+-- MODERATION ACTIONS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS moderation_actions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL for automatic actions
+    action VARCHAR(30) NOT NULL CHECK (action IN ('auto_hide', 'dismiss', 'remove_content', 'suspend_user', 'unsuspend_user')),
+    target_type VARCHAR(20) NOT NULL,
+    target_id UUID NOT NULL,
+    report_id UUID REFERENCES reports(id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_target ON moderation_actions(target_type, target_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_actor ON moderation_actions(actor_id, created_at DESC);
+
+-- ============================================================================
+-- FUNCTIONS
+-- ============================================================================
+
+-- True while a report keeps the content hidden (auto-hidden pending review, or removed by an admin)
+CREATE OR REPLACE FUNCTION is_content_hidden(t VARCHAR, id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT EXISTS(
+        SELECT 1 FROM reports
+        WHERE target_type = t AND target_id = id AND is_hidden = TRUE
+    )
+$$ LANGUAGE sql STABLE;
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Columns added:
+-- 1. users.role, users.suspended_at, users.suspended_until, users.suspension_reason
+--
+-- Tables created:
+-- 1. reports - Deduplicated reports per target
+-- 2. report_filings - Individual reports filed by users
+-- 3. moderation_actions - Audit trail of moderation decisions
+--
+-- Functions created:
+-- 1. is_content_hidden(target_type, target_id)
+-- ============================================================================
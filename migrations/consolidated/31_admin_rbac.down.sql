@@ -0,0 +1,28 @@
+-- ============================================================================
+-- ROLLBACK: Admin API and Role-Based Access Control
+-- ============================================================================
+
+CREATE OR REPLACE FUNCTION is_content_hidden(t VARCHAR, id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT EXISTS(
+        SELECT 1 FROM reports
+        WHERE target_type = $1 AND target_id = $2 AND is_hidden = TRUE
+    )
+$$ LANGUAGE sql STABLE;
+
+DELETE FROM moderation_actions
+WHERE action NOT IN ('auto_hide', 'dismiss', 'remove_content', 'suspend_user', 'unsuspend_user');
+
+ALTER TABLE moderation_actions DROP CONSTRAINT IF EXISTS moderation_actions_action_check;
+ALTER TABLE moderation_actions ADD CONSTRAINT moderation_actions_action_check
+    CHECK (action IN ('auto_hide', 'dismiss', 'remove_content', 'suspend_user', 'unsuspend_user'));
+
+DROP INDEX IF EXISTS idx_events_featured;
+ALTER TABLE events DROP COLUMN IF EXISTS unpublished_at;
+ALTER TABLE events DROP COLUMN IF EXISTS is_featured;
+
+DROP INDEX IF EXISTS idx_users_staff;
+UPDATE users SET role = 'user' WHERE role = 'moderator';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('user', 'admin'));
@@ -0,0 +1,75 @@
+-- ============================================================================
+-- MIGRATION: Admin API and Role-Based Access Control
+-- ============================================================================
+-- This migration adds platform staff roles and admin curation of events:
+-- 1. Adds the moderator role to users
+-- 2. Adds featuring and unpublishing to events
+-- 3. Hides unpublished events wherever is_content_hidden() is checked
+-- 4. Records admin actions in the moderation audit trail
+-- ============================================================================
+
+-- ============================================================================
+-- USERS
+-- ============================================================================
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('user', 'moderator', 'admin'));
+
+CREATE INDEX IF NOT EXISTS idx_users_staff ON users(role) WHERE role <> 'user';
+
+-- ============================================================================
+-- EVENTS
+-- ============================================================================
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS is_featured BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- NULL unpublished_at means the event is published
+ALTER TABLE events ADD COLUMN IF NOT EXISTS unpublished_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_events_featured ON events(start_time) WHERE is_featured = TRUE;
+
+-- ============================================================================
+-- MODERATION ACTIONS
+-- ============================================================================
+
+ALTER TABLE moderation_actions DROP CONSTRAINT IF EXISTS moderation_actions_action_check;
+ALTER TABLE moderation_actions ADD CONSTRAINT moderation_actions_action_check
+    CHECK (action IN (
+        'auto_hide', 'dismiss', 'remove_content', 'suspend_user', 'unsuspend_user',
+        'verify_user', 'unverify_user', 'set_role',
+        'feature_event', 'unfeature_event', 'unpublish_event', 'republish_event',
+        'force_refund',
+        'update_community', 'delete_community', 'transfer_community'
+    ));
+
+-- ============================================================================
+-- FUNCTIONS
+-- ============================================================================
+
+-- True while a report keeps the content hidden, or while an admin keeps the event unpublished
+-- Arguments are referenced by position since reports and events both have an id column
+CREATE OR REPLACE FUNCTION is_content_hidden(t VARCHAR, id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT EXISTS(
+        SELECT 1 FROM reports
+        WHERE target_type = $1 AND target_id = $2 AND is_hidden = TRUE
+    ) OR ($1 = 'event' AND EXISTS(
+        SELECT 1 FROM events
+        WHERE events.id = $2 AND unpublished_at IS NOT NULL
+    ))
+$$ LANGUAGE sql STABLE;
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Columns added:
+-- 1. events.is_featured, events.unpublished_at
+--
+-- Constraints changed:
+-- 1. users_role_check - Adds 'moderator'
+-- 2. moderation_actions_action_check - Adds admin actions
+--
+-- Functions replaced:
+-- 1. is_content_hidden(target_type, target_id) - Also true for unpublished events
+-- ============================================================================
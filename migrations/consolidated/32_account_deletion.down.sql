@@ -0,0 +1,9 @@
+-- ============================================================================
+-- ROLLBACK: Account Deletion and Personal Data Export
+-- ============================================================================
+
+DROP TABLE IF EXISTS data_exports CASCADE;
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
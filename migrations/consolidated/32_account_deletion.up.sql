@@ -0,0 +1,50 @@
+-- ============================================================================
+-- MIGRATION: Account Deletion and Personal Data Export
+-- ============================================================================
+-- This migration adds self-service account deletion and data exports:
+-- 1. Schedules account deletion after a cancellable grace period
+-- 2. Marks anonymized accounts, which are kept for financial records
+-- 3. Adds data export jobs holding the downloadable archive
+-- ============================================================================
+
+-- ============================================================================
+-- USERS
+-- ============================================================================
+
+-- Set while the user waits out the grace period, NULL otherwise
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP WITH TIME ZONE;
+-- Set once the account is anonymized; the row stays for tickets, transactions and payouts
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled ON users(deletion_scheduled_at)
+    WHERE deletion_scheduled_at IS NOT NULL;
+
+-- ============================================================================
+-- DATA EXPORTS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'ready', 'failed')),
+    archive BYTEA,  -- Zip archive, set once ready
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,  -- When the export job claimed it
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE  -- Archive is purged after this
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user ON data_exports(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_data_exports_pending ON data_exports(created_at) WHERE status = 'pending';
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Tables created:
+-- 1. data_exports - Personal data export jobs and their archives
+--
+-- Columns added:
+-- 1. users.deletion_scheduled_at, users.deleted_at
+-- ============================================================================
@@ -0,0 +1,10 @@
+-- ============================================================================
+-- ROLLBACK: User Handles
+-- ============================================================================
+
+DROP TABLE IF EXISTS post_mentions CASCADE;
+DROP TABLE IF EXISTS username_history CASCADE;
+
+DROP INDEX IF EXISTS idx_users_username_lower;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_format;
+ALTER TABLE users DROP COLUMN IF EXISTS username;
@@ -0,0 +1,101 @@
+-- ============================================================================
+-- MIGRATION: User Handles
+-- ============================================================================
+-- This migration brings back usernames as user-chosen handles:
+-- 1. Adds users.username, unique regardless of case
+-- 2. Backfills a handle for every existing user from their name
+-- 3. Keeps the handles a user gave up, so old profile links redirect
+-- 4. Stores the users mentioned in posts
+-- ============================================================================
+
+-- ============================================================================
+-- USERS
+-- ============================================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS username VARCHAR(50);
+
+-- Backfill from the name: lowercase letters and digits only, at most 21 characters
+-- so a suffix still fits in 30. Short names fall back to 'user'. Duplicates after the
+-- oldest account and reserved words get a suffix from the user ID.
+WITH candidates AS (
+    SELECT id, created_at,
+           CASE
+               WHEN LENGTH(LEFT(LOWER(REGEXP_REPLACE(name, '[^A-Za-z0-9]+', '', 'g')), 21)) < 3 THEN 'user'
+               ELSE LEFT(LOWER(REGEXP_REPLACE(name, '[^A-Za-z0-9]+', '', 'g')), 21)
+           END AS base
+    FROM users
+    WHERE username IS NULL
+),
+ranked AS (
+    SELECT id, base,
+           ROW_NUMBER() OVER (PARTITION BY base ORDER BY created_at, id) AS rn
+    FROM candidates
+)
+UPDATE users u
+SET username = CASE
+        WHEN r.rn = 1 AND r.base NOT IN (
+            -- Keep in sync with reservedUsernames in internal/domain/user/username.go
+            'about', 'account', 'admin', 'administrator', 'analytics', 'anigmaa', 'api',
+            'app', 'auth', 'blog', 'calendar', 'communities', 'community', 'deleted',
+            'dev', 'edit', 'event', 'events', 'explore', 'feed', 'help', 'home',
+            'login', 'logout', 'me', 'moderator', 'new', 'notifications', 'null',
+            'official', 'payments', 'post', 'posts', 'privacy', 'profile', 'qna',
+            'root', 'search', 'security', 'settings', 'signup', 'staff', 'support',
+            'system', 'team', 'terms', 'tickets', 'undefined', 'user', 'users', 'www'
+        ) THEN r.base
+        ELSE r.base || '_' || LEFT(REPLACE(u.id::text, '-', ''), 8)
+    END
+FROM ranked r
+WHERE u.id = r.id;
+
+-- Anonymized accounts never pick a handle
+UPDATE users SET username = 'deleted_' || REPLACE(id::text, '-', '')
+WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE users ALTER COLUMN username SET NOT NULL;
+
+-- Handles chosen by users are 3-30 characters, anonymized accounts use a longer form
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_format;
+ALTER TABLE users ADD CONSTRAINT users_username_format CHECK (username ~ '^[A-Za-z0-9_]{3,50}$');
+
+-- Handles keep the casing the user typed but are unique regardless of case
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_lower ON users(LOWER(username));
+
+-- ============================================================================
+-- USERNAME HISTORY TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS username_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL,  -- The handle given up
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_history_user ON username_history(user_id, changed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_username_history_username ON username_history(LOWER(username), changed_at DESC);
+
+-- ============================================================================
+-- POST MENTIONS TABLE
+-- ============================================================================
+
+-- Mentions point at users, not handles, so they survive handle changes
+CREATE TABLE IF NOT EXISTS post_mentions (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (post_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_mentions_user ON post_mentions(user_id, created_at DESC);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Tables created:
+-- 1. username_history - Handles given up by users, for redirects
+-- 2. post_mentions - Users mentioned in posts
+--
+-- Columns added:
+-- 1. users.username
+-- ============================================================================
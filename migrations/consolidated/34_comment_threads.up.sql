@@ -0,0 +1,80 @@
+-- ============================================================================
+-- MIGRATION: Comment Threads
+-- ============================================================================
+-- This migration prepares comments for threaded replies:
+-- 1. Stores each comment's depth in its thread, so reply depth can be limited
+-- 2. Turns deleted comments that still have replies into tombstones
+-- 3. Keeps posts.comments_count to live comments only
+-- 4. Indexes top-level comments and reply pages
+-- ============================================================================
+
+-- ============================================================================
+-- COMMENTS
+-- ============================================================================
+
+-- 0 for top-level comments, 1 for their replies, and so on
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS depth SMALLINT NOT NULL DEFAULT 0;
+
+-- Set when a comment with replies is deleted; its content is cleared
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Backfill depth from the existing reply chains
+WITH RECURSIVE thread AS (
+    SELECT id, 0 AS depth
+    FROM comments
+    WHERE parent_comment_id IS NULL
+    UNION ALL
+    SELECT c.id, t.depth + 1
+    FROM comments c
+    INNER JOIN thread t ON c.parent_comment_id = t.id
+)
+UPDATE comments c
+SET depth = t.depth
+FROM thread t
+WHERE c.id = t.id AND c.depth != t.depth;
+
+-- Top-level comments of a post and pages of replies
+CREATE INDEX IF NOT EXISTS idx_comments_post_top_level ON comments(post_id, created_at DESC) WHERE parent_comment_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_parent_created ON comments(parent_comment_id, created_at, id);
+
+-- ============================================================================
+-- FUNCTIONS
+-- ============================================================================
+
+-- Tombstones no longer count as comments of the post
+CREATE OR REPLACE FUNCTION update_comments_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE posts SET comments_count = comments_count + 1 WHERE id = NEW.post_id;
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+            UPDATE posts SET comments_count = GREATEST(comments_count - 1, 0) WHERE id = NEW.post_id;
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        IF OLD.deleted_at IS NULL THEN
+            UPDATE posts SET comments_count = GREATEST(comments_count - 1, 0) WHERE id = OLD.post_id;
+        END IF;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE 'plpgsql';
+
+DROP TRIGGER IF EXISTS update_comments_count_trigger ON comments;
+CREATE TRIGGER update_comments_count_trigger
+    AFTER INSERT OR DELETE OR UPDATE OF deleted_at ON comments
+    FOR EACH ROW EXECUTE FUNCTION update_comments_count();
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Columns added:
+-- 1. comments.depth - Position in the reply thread
+-- 2. comments.deleted_at - Tombstone marker
+--
+-- Functions replaced:
+-- 1. update_comments_count() - Skips tombstones
+-- ============================================================================
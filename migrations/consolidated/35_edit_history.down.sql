@@ -0,0 +1,9 @@
+-- ============================================================================
+-- ROLLBACK: Edit History
+-- ============================================================================
+
+DROP TABLE IF EXISTS comment_revisions CASCADE;
+DROP TABLE IF EXISTS post_revisions CASCADE;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS edited_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS edited_at;
@@ -0,0 +1,55 @@
+-- ============================================================================
+-- MIGRATION: Edit History
+-- ============================================================================
+-- This migration keeps the history of edited posts and comments:
+-- 1. Marks when a post or comment was last edited
+-- 2. Stores the content each edit replaced
+-- ============================================================================
+
+-- ============================================================================
+-- POSTS AND COMMENTS
+-- ============================================================================
+
+-- Set on content edits only; updated_at also moves on visibility changes
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE;
+
+-- ============================================================================
+-- POST REVISIONS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,  -- The content the edit replaced
+    written_at TIMESTAMP WITH TIME ZONE NOT NULL,  -- When that content was posted or last edited
+    replaced_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post ON post_revisions(post_id, replaced_at DESC);
+
+-- ============================================================================
+-- COMMENT REVISIONS TABLE
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS comment_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    comment_id UUID NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    written_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    replaced_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_revisions_comment ON comment_revisions(comment_id, replaced_at DESC);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Tables created:
+-- 1. post_revisions - Previous contents of edited posts
+-- 2. comment_revisions - Previous contents of edited comments
+--
+-- Columns added:
+-- 1. posts.edited_at
+-- 2. comments.edited_at
+-- ============================================================================
@@ -0,0 +1,38 @@
+-- ============================================================================
+-- ROLLBACK: Post Scheduling
+-- ============================================================================
+
+-- Unpublished posts would otherwise show up as published; drop them while they are still left out of posts_count
+DELETE FROM posts WHERE status != 'published';
+
+-- Restore the original posts count trigger
+CREATE OR REPLACE FUNCTION update_posts_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE user_stats
+        SET posts_count = posts_count + 1
+        WHERE user_id = NEW.author_id;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE user_stats
+        SET posts_count = GREATEST(posts_count - 1, 0)
+        WHERE user_id = OLD.author_id;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE 'plpgsql';
+
+DROP TRIGGER IF EXISTS update_posts_count_trigger ON posts;
+CREATE TRIGGER update_posts_count_trigger
+    AFTER INSERT OR DELETE ON posts
+    FOR EACH ROW EXECUTE FUNCTION update_posts_count();
+
+DROP INDEX IF EXISTS idx_posts_unpublished;
+DROP INDEX IF EXISTS idx_posts_scheduled;
+
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_scheduled_at_check;
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_status_check;
+ALTER TABLE posts DROP COLUMN IF EXISTS scheduled_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS status;
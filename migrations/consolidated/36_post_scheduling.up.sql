@@ -0,0 +1,77 @@
+-- ============================================================================
+-- MIGRATION: Post Scheduling
+-- ============================================================================
+-- This migration adds draft and scheduled posts:
+-- 1. Adds posts.status (published, draft, scheduled) and posts.scheduled_at
+-- 2. Counts only published posts in user_stats.posts_count
+-- 3. Indexes scheduled posts for the background publisher
+-- ============================================================================
+
+-- ============================================================================
+-- POSTS
+-- ============================================================================
+
+-- Existing posts are all published
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_status_check;
+ALTER TABLE posts ADD CONSTRAINT posts_status_check CHECK (status IN ('published', 'draft', 'scheduled'));
+
+-- Scheduled posts need a publish time
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_scheduled_at_check;
+ALTER TABLE posts ADD CONSTRAINT posts_scheduled_at_check CHECK (status != 'scheduled' OR scheduled_at IS NOT NULL);
+
+-- Due scheduled posts, and an author's unpublished posts
+CREATE INDEX IF NOT EXISTS idx_posts_scheduled ON posts(scheduled_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_posts_unpublished ON posts(author_id, updated_at DESC) WHERE status != 'published';
+
+-- ============================================================================
+-- FUNCTIONS
+-- ============================================================================
+
+-- Drafts and scheduled posts count once they are published
+CREATE OR REPLACE FUNCTION update_posts_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        IF NEW.status = 'published' THEN
+            UPDATE user_stats
+            SET posts_count = posts_count + 1
+            WHERE user_id = NEW.author_id;
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF OLD.status != 'published' AND NEW.status = 'published' THEN
+            UPDATE user_stats
+            SET posts_count = posts_count + 1
+            WHERE user_id = NEW.author_id;
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        IF OLD.status = 'published' THEN
+            UPDATE user_stats
+            SET posts_count = GREATEST(posts_count - 1, 0)
+            WHERE user_id = OLD.author_id;
+        END IF;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE 'plpgsql';
+
+DROP TRIGGER IF EXISTS update_posts_count_trigger ON posts;
+CREATE TRIGGER update_posts_count_trigger
+    AFTER INSERT OR DELETE OR UPDATE OF status ON posts
+    FOR EACH ROW EXECUTE FUNCTION update_posts_count();
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Columns added:
+-- 1. posts.status - published, draft or scheduled
+-- 2. posts.scheduled_at - When a scheduled post is published
+--
+-- Functions replaced:
+-- 1. update_posts_count() - Counts published posts only
+-- ============================================================================
@@ -0,0 +1,10 @@
+-- ============================================================================
+-- ROLLBACK: Promo Redemption Quantity
+-- ============================================================================
+
+UPDATE promo_codes pc
+SET redemption_count = (
+    SELECT COUNT(*) FROM promo_redemptions pr WHERE pr.promo_code_id = pc.id
+);
+
+ALTER TABLE promo_redemptions DROP COLUMN IF EXISTS quantity;
@@ -0,0 +1,39 @@
+-- ============================================================================
+-- MIGRATION: Promo Redemption Quantity
+-- ============================================================================
+-- A promo code redeemed on a group order discounts every ticket of the order,
+-- so redemption limits count discounted tickets rather than orders:
+-- 1. Records how many tickets each redemption discounted
+-- 2. Recounts promo_codes.redemption_count in tickets
+-- ============================================================================
+
+-- ============================================================================
+-- PROMO REDEMPTIONS TABLE
+-- ============================================================================
+
+ALTER TABLE promo_redemptions
+    ADD COLUMN IF NOT EXISTS quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0);
+
+-- Redemptions are recorded on the buyer's ticket; the order tells how many tickets it covered
+UPDATE promo_redemptions pr
+SET quantity = o.quantity
+FROM tickets t
+INNER JOIN ticket_orders o ON t.order_id = o.id
+WHERE pr.ticket_id = t.id AND o.quantity > 1;
+
+-- ============================================================================
+-- PROMO CODES TABLE
+-- ============================================================================
+
+UPDATE promo_codes pc
+SET redemption_count = COALESCE((
+    SELECT SUM(pr.quantity) FROM promo_redemptions pr WHERE pr.promo_code_id = pc.id
+), 0);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Added promo_redemptions.quantity - tickets discounted by the redemption
+-- 2. promo_codes.redemption_count now counts discounted tickets
+-- ============================================================================
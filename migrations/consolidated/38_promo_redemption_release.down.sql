@@ -0,0 +1,10 @@
+-- ============================================================================
+-- ROLLBACK: Promo Redemption Release
+-- ============================================================================
+
+DELETE FROM promo_redemptions WHERE released_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_promo_redemptions_code_user;
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_code_user ON promo_redemptions(promo_code_id, user_id);
+
+ALTER TABLE promo_redemptions DROP COLUMN IF EXISTS released_at;
@@ -0,0 +1,27 @@
+-- ============================================================================
+-- MIGRATION: Promo Redemption Release
+-- ============================================================================
+-- Redemptions of unpaid tickets are kept when their uses are given back, so a
+-- payment arriving after the ticket expired can take the code again:
+-- 1. Adds promo_redemptions.released_at - set while the uses are given back
+-- ============================================================================
+
+-- ============================================================================
+-- PROMO REDEMPTIONS TABLE
+-- ============================================================================
+
+ALTER TABLE promo_redemptions ADD COLUMN IF NOT EXISTS released_at TIMESTAMP WITH TIME ZONE;
+
+-- Per-user limits only count redemptions still in use
+DROP INDEX IF EXISTS idx_promo_redemptions_code_user;
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_code_user
+    ON promo_redemptions(promo_code_id, user_id)
+    WHERE released_at IS NULL;
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Added promo_redemptions.released_at - released redemptions no longer count
+--    towards promo_codes.redemption_count or per-user limits
+-- ============================================================================
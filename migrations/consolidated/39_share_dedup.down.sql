@@ -0,0 +1,7 @@
+-- ============================================================================
+-- ROLLBACK: Share Deduplication
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_shares_user_post_platform_window;
+
+ALTER TABLE shares DROP COLUMN IF EXISTS window_start;
@@ -0,0 +1,51 @@
+-- ============================================================================
+-- MIGRATION: Share Deduplication
+-- ============================================================================
+-- Repeated shares of a post by the same user to the same platform only count
+-- once per hour, so tapping share again does not inflate shares_count:
+-- 1. Adds shares.window_start - the hour the share was made in
+-- 2. Removes repeated shares recorded within the same hour
+-- 3. Adds a unique index on user, post, platform and window
+-- ============================================================================
+
+-- ============================================================================
+-- SHARES TABLE
+-- ============================================================================
+
+ALTER TABLE shares ADD COLUMN IF NOT EXISTS window_start TIMESTAMP WITH TIME ZONE;
+
+UPDATE shares
+SET window_start = date_trunc('hour', COALESCE(created_at, CURRENT_TIMESTAMP) AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+WHERE window_start IS NULL;
+
+ALTER TABLE shares ALTER COLUMN window_start SET NOT NULL;
+
+-- Keep the first share of each window; the shares_count trigger takes the rest off the posts
+DELETE FROM shares
+WHERE id IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (
+            PARTITION BY user_id, post_id, COALESCE(platform, ''), window_start
+            ORDER BY created_at NULLS LAST, id
+        ) AS n
+        FROM shares
+    ) ranked
+    WHERE n > 1
+);
+
+-- ============================================================================
+-- INDEXES
+-- ============================================================================
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shares_user_post_platform_window
+    ON shares(user_id, post_id, COALESCE(platform, ''), window_start);
+
+-- ============================================================================
+-- SUMMARY
+-- ============================================================================
+-- Changes made:
+-- 1. Added shares.window_start - the hour a share counts in
+-- 2. Deleted repeated shares within the same hour
+-- 3. Created idx_shares_user_post_platform_window - one share per user, post,
+--    platform and hour
+-- ============================================================================
@@ -0,0 +1,162 @@
+// Package ical writes RFC 5545 iCalendar documents for calendar apps such as
+// Google Calendar and Apple Calendar.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	timeFormat = "20060102T150405Z"
+
+	// maxLineOctets is the longest content line allowed before folding
+	maxLineOctets = 75
+)
+
+// Status is the VEVENT status
+type Status string
+
+const (
+	StatusConfirmed Status = "CONFIRMED"
+	StatusCancelled Status = "CANCELLED"
+)
+
+// Event is a VEVENT. UID must stay the same across feed refreshes and Sequence
+// must grow whenever the time, place or status changes, so calendar apps update
+// the entry instead of adding a new one.
+type Event struct {
+	UID          string
+	Sequence     int
+	Summary      string
+	Description  string
+	Location     string
+	Lat          *float64
+	Lng          *float64
+	URL          string
+	Start        time.Time
+	End          time.Time
+	Status       Status
+	Created      time.Time
+	LastModified time.Time
+}
+
+// Calendar is a VCALENDAR
+type Calendar struct {
+	ProductID string
+	Name      string
+	// RefreshInterval hints how often subscribers should poll a feed, 0 to omit
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+// Write writes the calendar in iCalendar format
+func (c *Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	lw := &lineWriter{w: bw}
+
+	lw.line("BEGIN", "VCALENDAR")
+	lw.line("VERSION", "2.0")
+	lw.line("PRODID", c.ProductID)
+	lw.line("CALSCALE", "GREGORIAN")
+	lw.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		lw.line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		duration := fmt.Sprintf("PT%dM", int(c.RefreshInterval.Minutes()))
+		lw.line("REFRESH-INTERVAL;VALUE=DURATION", duration)
+		lw.line("X-PUBLISHED-TTL", duration)
+	}
+
+	now := time.Now()
+	for _, e := range c.Events {
+		lw.line("BEGIN", "VEVENT")
+		lw.line("UID", e.UID)
+		lw.line("DTSTAMP", formatTime(now))
+		lw.line("SEQUENCE", fmt.Sprint(e.Sequence))
+		lw.line("DTSTART", formatTime(e.Start))
+		lw.line("DTEND", formatTime(e.End))
+		lw.line("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			lw.line("DESCRIPTION", escapeText(e.Description))
+		}
+		if e.Location != "" {
+			lw.line("LOCATION", escapeText(e.Location))
+		}
+		if e.Lat != nil && e.Lng != nil {
+			lw.line("GEO", fmt.Sprintf("%.6f;%.6f", *e.Lat, *e.Lng))
+		}
+		if e.URL != "" {
+			lw.line("URL", e.URL)
+		}
+		status := e.Status
+		if status == "" {
+			status = StatusConfirmed
+		}
+		lw.line("STATUS", string(status))
+		if !e.Created.IsZero() {
+			lw.line("CREATED", formatTime(e.Created))
+		}
+		if !e.LastModified.IsZero() {
+			lw.line("LAST-MODIFIED", formatTime(e.LastModified))
+		}
+		lw.line("END", "VEVENT")
+	}
+
+	lw.line("END", "VCALENDAR")
+	if lw.err != nil {
+		return lw.err
+	}
+	return bw.Flush()
+}
+
+// lineWriter writes CRLF-terminated content lines, folded at 75 octets
+// without splitting UTF-8 characters, and keeps the first error
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) line(name, value string) {
+	if lw.err != nil {
+		return
+	}
+
+	line := name + ":" + value
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		if _, lw.err = lw.w.WriteString(line[:cut] + "\r\n "); lw.err != nil {
+			return
+		}
+		line = line[cut:]
+		limit = maxLineOctets - 1 // continuation lines start with a space
+	}
+	_, lw.err = lw.w.WriteString(line + "\r\n")
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escapeText escapes a TEXT value
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
@@ -15,8 +15,10 @@ var (
 
 // Claims represents JWT claims
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
+	UserID      uuid.UUID `json:"user_id"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role,omitempty"`
+	Permissions []string  `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -36,11 +38,14 @@ func NewJWTManager(secretKey string, tokenDuration, refreshDuration time.Duratio
 	}
 }
 
-// Generate generates a new access token
-func (m *JWTManager) Generate(userID uuid.UUID, email string) (string, error) {
+// Generate generates a new access token carrying the user's role and permissions
+// The claims are informational for clients; the API checks the role stored in the database
+func (m *JWTManager) Generate(userID uuid.UUID, email, role string, permissions []string) (string, error) {
 	claims := Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:      userID,
+		Email:       email,
+		Role:        role,
+		Permissions: permissions,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.tokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -1,14 +1,23 @@
 package qrcode
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/skip2/go-qrcode"
 )
 
+var (
+	ErrInvalidQR        = errors.New("invalid ticket QR code")
+	ErrInvalidSignature = errors.New("invalid ticket QR signature")
+)
+
 // TicketQRData represents the data encoded in a ticket QR code
 type TicketQRData struct {
 	TicketID       uuid.UUID `json:"ticket_id"`
@@ -17,24 +26,33 @@ type TicketQRData struct {
 	UserID         uuid.UUID `json:"user_id"`
 }
 
-// GenerateTicketQR generates a QR code for a ticket and returns it as a base64-encoded PNG
-func GenerateTicketQR(ticketID, eventID, userID uuid.UUID, attendanceCode string) (string, error) {
-	// Create QR data payload
-	data := TicketQRData{
+// Signer signs and verifies ticket QR payloads with HMAC-SHA256
+// QR content has the form base64url(json payload) + "." + base64url(signature)
+type Signer struct {
+	secretKey []byte
+}
+
+// NewSigner creates a new ticket QR signer
+func NewSigner(secretKey string) *Signer {
+	return &Signer{
+		secretKey: []byte(secretKey),
+	}
+}
+
+// GenerateTicketQR generates a signed QR code for a ticket and returns it as a base64-encoded PNG
+func (s *Signer) GenerateTicketQR(ticketID, eventID, userID uuid.UUID, attendanceCode string) (string, error) {
+	content, err := s.EncodeTicketQR(&TicketQRData{
 		TicketID:       ticketID,
 		EventID:        eventID,
 		AttendanceCode: attendanceCode,
 		UserID:         userID,
-	}
-
-	// Marshal to JSON
-	jsonData, err := json.Marshal(data)
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal QR data: %w", err)
+		return "", err
 	}
 
 	// Generate QR code (256x256 pixels, medium error correction)
-	qrCode, err := qrcode.Encode(string(jsonData), qrcode.Medium, 256)
+	qrCode, err := qrcode.Encode(content, qrcode.Medium, 256)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate QR code: %w", err)
 	}
@@ -46,11 +64,50 @@ func GenerateTicketQR(ticketID, eventID, userID uuid.UUID, attendanceCode string
 	return fmt.Sprintf("data:image/png;base64,%s", base64QR), nil
 }
 
-// DecodeTicketQR decodes a JSON string from QR code back into TicketQRData
-func DecodeTicketQR(qrContent string) (*TicketQRData, error) {
+// EncodeTicketQR returns the signed text content of a ticket QR code
+func (s *Signer) EncodeTicketQR(data *TicketQRData) (string, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal QR data: %w", err)
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(jsonData)
+	signature := base64.RawURLEncoding.EncodeToString(s.sign(payload))
+
+	return payload + "." + signature, nil
+}
+
+// DecodeTicketQR verifies the signature of scanned QR content and decodes it into TicketQRData
+func (s *Signer) DecodeTicketQR(qrContent string) (*TicketQRData, error) {
+	payload, signature, found := strings.Cut(strings.TrimSpace(qrContent), ".")
+	if !found {
+		return nil, ErrInvalidQR
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, ErrInvalidQR
+	}
+
+	if !hmac.Equal(sig, s.sign(payload)) {
+		return nil, ErrInvalidSignature
+	}
+
+	jsonData, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidQR
+	}
+
 	var data TicketQRData
-	if err := json.Unmarshal([]byte(qrContent), &data); err != nil {
+	if err := json.Unmarshal(jsonData, &data); err != nil {
 		return nil, fmt.Errorf("failed to decode QR data: %w", err)
 	}
 	return &data, nil
 }
+
+// sign computes the HMAC-SHA256 of a QR payload
+func (s *Signer) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secretKey)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
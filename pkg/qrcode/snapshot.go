@@ -0,0 +1,37 @@
+package qrcode
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+)
+
+var ErrInvalidSnapshotKey = errors.New("check-in snapshot key must be a base64 encoded 32 byte Ed25519 seed")
+
+// SnapshotSigner signs offline check-in snapshots with Ed25519
+// Scanner devices only get the public key, so they can verify a snapshot but not forge one
+type SnapshotSigner struct {
+	privateKey ed25519.PrivateKey
+}
+
+// NewSnapshotSigner creates a snapshot signer from a base64 encoded Ed25519 seed
+func NewSnapshotSigner(seed string) (*SnapshotSigner, error) {
+	raw, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil || len(raw) != ed25519.SeedSize {
+		return nil, ErrInvalidSnapshotKey
+	}
+
+	return &SnapshotSigner{
+		privateKey: ed25519.NewKeyFromSeed(raw),
+	}, nil
+}
+
+// Sign returns the base64url Ed25519 signature of data
+func (s *SnapshotSigner) Sign(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(ed25519.Sign(s.privateKey, data))
+}
+
+// PublicKey returns the base64url public key scanner devices verify snapshots with
+func (s *SnapshotSigner) PublicKey() string {
+	return base64.RawURLEncoding.EncodeToString(s.privateKey.Public().(ed25519.PublicKey))
+}
@@ -28,6 +28,21 @@ type PaginationMeta struct {
 	HasNext bool `json:"hasNext"`
 }
 
+// CursorMeta contains cursor pagination metadata
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"` // Pass back to get the next page
+	HasNext    bool   `json:"hasNext"`
+}
+
+// CursorPaginatedResponse represents a cursor paginated API response
+type CursorPaginatedResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message,omitempty"`
+	Data    interface{} `json:"data"`
+	Meta    *CursorMeta `json:"meta"`
+}
+
 // PaginatedResponse represents a paginated API response
 type PaginatedResponse struct {
 	Success bool            `json:"success"`
@@ -78,6 +93,25 @@ func NewPaginationMeta(total, limit, offset, currentCount int) *PaginationMeta {
 	}
 }
 
+// CursorPaginated sends a cursor paginated response
+func CursorPaginated(c *gin.Context, statusCode int, message string, data interface{}, meta *CursorMeta) {
+	c.JSON(statusCode, CursorPaginatedResponse{
+		Success: true,
+		Message: message,
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
+// NewCursorMeta creates cursor pagination metadata; an empty next cursor means the last page
+func NewCursorMeta(limit int, nextCursor string) *CursorMeta {
+	return &CursorMeta{
+		Limit:      limit,
+		NextCursor: nextCursor,
+		HasNext:    nextCursor != "",
+	}
+}
+
 // BadRequest sends a 400 Bad Request response
 func BadRequest(c *gin.Context, message string, details string) {
 	Error(c, http.StatusBadRequest, message, "BAD_REQUEST", details)
@@ -0,0 +1,349 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules used by
+// event series: FREQ=DAILY|WEEKLY|MONTHLY with INTERVAL, BYDAY (including
+// ordinals such as 1SA or -1FR for monthly rules), BYMONTHDAY, COUNT and UNTIL.
+// Weeks start on Monday.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRule   = errors.New("invalid recurrence rule")
+	ErrUnsupported   = errors.New("unsupported recurrence rule")
+	ErrMissingFreq   = errors.New("recurrence rule must set FREQ")
+	ErrCountAndUntil = errors.New("recurrence rule cannot set both COUNT and UNTIL")
+)
+
+// maxInterval keeps a malformed rule from producing an effectively empty series
+const maxInterval = 52
+
+// Frequency is how often the rule repeats
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// WeekdayNum is a BYDAY entry. N is the ordinal within the month for monthly
+// rules (1 = first, -1 = last); 0 means every such weekday.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Rule is a parsed recurrence rule
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Parse parses a rule such as "FREQ=WEEKLY;BYDAY=TU" or
+// "FREQ=MONTHLY;BYDAY=1SA". An optional "RRULE:" prefix is accepted.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(strings.ToUpper(s)), "RRULE:")
+	if s == "" {
+		return nil, ErrMissingFreq
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch Frequency(value) {
+			case Daily, Weekly, Monthly:
+				rule.Freq = Frequency(value)
+			default:
+				return nil, fmt.Errorf("%w: FREQ=%s", ErrUnsupported, value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > maxInterval {
+				return nil, fmt.Errorf("%w: INTERVAL=%s", ErrInvalidRule, value)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, err := parseWeekdayNum(code)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, code := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(code)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("%w: BYMONTHDAY=%s", ErrInvalidRule, code)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: COUNT=%s", ErrInvalidRule, value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: UNTIL=%s", ErrInvalidRule, value)
+			}
+			rule.Until = &until
+		case "WKST":
+			if value != "MO" {
+				return nil, fmt.Errorf("%w: WKST=%s", ErrUnsupported, value)
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupported, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, ErrMissingFreq
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, ErrCountAndUntil
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq != Monthly {
+		return nil, fmt.Errorf("%w: BYMONTHDAY requires FREQ=MONTHLY", ErrUnsupported)
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly {
+			return nil, fmt.Errorf("%w: BYDAY ordinals require FREQ=MONTHLY", ErrUnsupported)
+		}
+	}
+
+	return rule, nil
+}
+
+func parseWeekdayNum(code string) (WeekdayNum, error) {
+	if len(code) < 2 {
+		return WeekdayNum{}, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRule, code)
+	}
+
+	weekday, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRule, code)
+	}
+
+	n := 0
+	if prefix := code[:len(code)-2]; prefix != "" {
+		var err error
+		n, err = strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRule, code)
+		}
+	}
+
+	return WeekdayNum{Weekday: weekday, N: n}, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrInvalidRule
+}
+
+// String formats the rule back into RRULE syntax
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			code := strings.ToUpper(day.Weekday.String()[:2])
+			if day.N != 0 {
+				code = strconv.Itoa(day.N) + code
+			}
+			codes[i] = code
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returns the start times of the series that begins at dtstart,
+// in order, up to and including before, and at most limit of them. Times keep
+// dtstart's wall clock time in dtstart's location. dtstart itself is only
+// included when it matches the rule.
+func (r *Rule) Occurrences(dtstart, before time.Time, limit int) []time.Time {
+	if r.Until != nil && r.Until.Before(before) {
+		before = *r.Until
+	}
+
+	var occurrences []time.Time
+	seen := 0
+	for period := 0; ; period++ {
+		periodStart := r.periodStart(dtstart, period)
+		if periodStart.After(before) {
+			break
+		}
+
+		for _, candidate := range r.candidates(dtstart, periodStart) {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			if candidate.After(before) {
+				return occurrences
+			}
+
+			seen++
+			if r.Count > 0 && seen > r.Count {
+				return occurrences
+			}
+
+			occurrences = append(occurrences, candidate)
+			if limit > 0 && len(occurrences) >= limit {
+				return occurrences
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// periodStart returns the first day of the n-th period at dtstart's time of day
+func (r *Rule) periodStart(dtstart time.Time, n int) time.Time {
+	switch r.Freq {
+	case Weekly:
+		offset := (int(dtstart.Weekday()) + 6) % 7 // days since Monday
+		monday := dtstart.AddDate(0, 0, -offset)
+		return monday.AddDate(0, 0, 7*n*r.Interval)
+	case Monthly:
+		first := time.Date(dtstart.Year(), dtstart.Month(), 1,
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location())
+		return first.AddDate(0, n*r.Interval, 0)
+	default:
+		return dtstart.AddDate(0, 0, n*r.Interval)
+	}
+}
+
+// candidates returns the sorted occurrence times within the period starting at periodStart
+func (r *Rule) candidates(dtstart, periodStart time.Time) []time.Time {
+	var times []time.Time
+
+	switch r.Freq {
+	case Daily:
+		if len(r.ByDay) == 0 || r.matchesWeekday(periodStart.Weekday()) {
+			times = append(times, periodStart)
+		}
+
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			offset := (int(dtstart.Weekday()) + 6) % 7
+			times = append(times, periodStart.AddDate(0, 0, offset))
+			break
+		}
+		for i := 0; i < 7; i++ {
+			day := periodStart.AddDate(0, 0, i)
+			if r.matchesWeekday(day.Weekday()) {
+				times = append(times, day)
+			}
+		}
+
+	case Monthly:
+		daysInMonth := periodStart.AddDate(0, 1, -1).Day()
+		addDay := func(day int) {
+			if day >= 1 && day <= daysInMonth {
+				times = append(times, periodStart.AddDate(0, 0, day-1))
+			}
+		}
+
+		switch {
+		case len(r.ByMonthDay) > 0:
+			for _, day := range r.ByMonthDay {
+				if day < 0 {
+					day = daysInMonth + day + 1
+				}
+				addDay(day)
+			}
+		case len(r.ByDay) > 0:
+			for _, wd := range r.ByDay {
+				first := 1 + (int(wd.Weekday)-int(periodStart.Weekday())+7)%7
+				switch {
+				case wd.N > 0:
+					addDay(first + 7*(wd.N-1))
+				case wd.N < 0:
+					last := first + 7*((daysInMonth-first)/7)
+					addDay(last + 7*(wd.N+1))
+				default:
+					for day := first; day <= daysInMonth; day += 7 {
+						addDay(day)
+					}
+				}
+			}
+		default:
+			addDay(dtstart.Day())
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return dedupe(times)
+}
+
+func (r *Rule) matchesWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupe(times []time.Time) []time.Time {
+	if len(times) < 2 {
+		return times
+	}
+	out := times[:1]
+	for _, t := range times[1:] {
+		if !t.Equal(out[len(out)-1]) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOccurrences(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// Tuesday 7 January 2025, 19:00 WIB
+	dtstart := time.Date(2025, time.January, 7, 19, 0, 0, 0, jakarta)
+
+	tests := []struct {
+		name   string
+		rule   string
+		before time.Time
+		want   []string
+	}{
+		{
+			name:   "weekly on tuesday",
+			rule:   "FREQ=WEEKLY;BYDAY=TU",
+			before: dtstart.AddDate(0, 0, 21),
+			want:   []string{"2025-01-07", "2025-01-14", "2025-01-21", "2025-01-28"},
+		},
+		{
+			name:   "every other week",
+			rule:   "FREQ=WEEKLY;INTERVAL=2",
+			before: dtstart.AddDate(0, 0, 35),
+			want:   []string{"2025-01-07", "2025-01-21", "2025-02-04"},
+		},
+		{
+			name:   "monthly on the first saturday",
+			rule:   "FREQ=MONTHLY;BYDAY=1SA",
+			before: dtstart.AddDate(0, 3, 0),
+			want:   []string{"2025-02-01", "2025-03-01", "2025-04-05"},
+		},
+		{
+			name:   "monthly on the last friday",
+			rule:   "FREQ=MONTHLY;BYDAY=-1FR",
+			before: dtstart.AddDate(0, 2, 0),
+			want:   []string{"2025-01-31", "2025-02-28"},
+		},
+		{
+			name:   "monthly on the last day",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=-1",
+			before: dtstart.AddDate(0, 2, 0),
+			want:   []string{"2025-01-31", "2025-02-28"},
+		},
+		{
+			name:   "count limits the series",
+			rule:   "FREQ=DAILY;COUNT=3",
+			before: dtstart.AddDate(1, 0, 0),
+			want:   []string{"2025-01-07", "2025-01-08", "2025-01-09"},
+		},
+		{
+			name:   "until limits the series",
+			rule:   "FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20250114T235959Z",
+			before: dtstart.AddDate(1, 0, 0),
+			want:   []string{"2025-01-07", "2025-01-09", "2025-01-14"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.rule, err)
+			}
+
+			got := rule.Occurrences(dtstart, tt.before, 0)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %v", len(got), got, tt.want)
+			}
+			for i, occurrence := range got {
+				if day := occurrence.Format("2006-01-02"); day != tt.want[i] {
+					t.Errorf("occurrence %d = %s, want %s", i, day, tt.want[i])
+				}
+				if occurrence.Hour() != 19 || occurrence.Location() != jakarta {
+					t.Errorf("occurrence %d = %v, want 19:00 Asia/Jakarta", i, occurrence)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRejectsUnsupportedRules(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"BYDAY=TU",
+		"FREQ=YEARLY",
+		"FREQ=WEEKLY;BYDAY=1TU",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=3;UNTIL=20250101",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+	} {
+		if _, err := Parse(rule); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", rule)
+		}
+	}
+}
+
+func TestRuleString(t *testing.T) {
+	rule, err := Parse("rrule:freq=monthly;byday=1sa;interval=2")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got, want := rule.String(), "FREQ=MONTHLY;INTERVAL=2;BYDAY=1SA"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
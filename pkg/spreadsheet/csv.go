@@ -0,0 +1,69 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// csvWriter writes rows as RFC 4180 CSV
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter creates a CSV spreadsheet writer
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+// WriteHeader writes the column titles
+func (cw *csvWriter) WriteHeader(columns ...string) error {
+	return cw.w.Write(columns)
+}
+
+// WriteRow writes one row of values
+func (cw *csvWriter) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		text, kind, err := formatValue(v)
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case cellBool:
+			if text == "1" {
+				text = "yes"
+			} else {
+				text = "no"
+			}
+		case cellText:
+			text = escapeFormula(text)
+		}
+		record[i] = text
+	}
+
+	if err := cw.w.Write(record); err != nil {
+		return err
+	}
+
+	// Push each row out so the response streams instead of piling up in the buffer
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// Close flushes the remaining output
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// escapeFormula stops spreadsheet apps from running user-supplied text as a formula
+func escapeFormula(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	}
+	return s
+}
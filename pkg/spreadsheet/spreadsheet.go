@@ -0,0 +1,123 @@
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is a spreadsheet file format
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// TimeLayout is how time values are written to cells
+const TimeLayout = "2006-01-02 15:04:05"
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Writer streams rows to a spreadsheet one at a time
+// Nothing is buffered beyond the current row, so exports can be any size
+type Writer interface {
+	// WriteHeader writes the column titles
+	WriteHeader(columns ...string) error
+	// WriteRow writes one row of values
+	// Supported values: string, *string, int, int64, float64, *float64, bool, time.Time, *time.Time and nil
+	WriteRow(values ...interface{}) error
+	// Close flushes the remaining output; the underlying io.Writer is left open
+	Close() error
+}
+
+// ParseFormat parses a format name, defaulting to CSV when empty
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Filename returns name with the format's file extension
+func (f Format) Filename(name string) string {
+	return name + "." + string(f)
+}
+
+// NewWriter creates a writer for the given format
+// sheetName is only used by XLSX
+func NewWriter(format Format, w io.Writer, sheetName string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w, sheetName), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// cellKind tells the XLSX writer whether a value is a number or text
+type cellKind int
+
+const (
+	cellEmpty cellKind = iota
+	cellNumber
+	cellBool
+	cellText
+)
+
+// formatValue renders a cell value as text along with its kind
+func formatValue(v interface{}) (string, cellKind, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", cellEmpty, nil
+	case string:
+		return val, cellText, nil
+	case *string:
+		if val == nil {
+			return "", cellEmpty, nil
+		}
+		return *val, cellText, nil
+	case int:
+		return strconv.Itoa(val), cellNumber, nil
+	case int64:
+		return strconv.FormatInt(val, 10), cellNumber, nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), cellNumber, nil
+	case *float64:
+		if val == nil {
+			return "", cellEmpty, nil
+		}
+		return strconv.FormatFloat(*val, 'f', -1, 64), cellNumber, nil
+	case bool:
+		if val {
+			return "1", cellBool, nil
+		}
+		return "0", cellBool, nil
+	case time.Time:
+		return val.Format(TimeLayout), cellText, nil
+	case *time.Time:
+		if val == nil {
+			return "", cellEmpty, nil
+		}
+		return val.Format(TimeLayout), cellText, nil
+	default:
+		return "", cellEmpty, fmt.Errorf("unsupported cell value type %T", v)
+	}
+}
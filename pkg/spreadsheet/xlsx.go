@@ -0,0 +1,211 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxSheetNameLength is the longest sheet name Excel accepts
+const maxSheetNameLength = 31
+
+// xlsxWriter streams a single-sheet XLSX workbook
+// The fixed workbook parts are written up front and the sheet XML is streamed row by row
+// with inline strings, so no shared string table has to be held in memory
+type xlsxWriter struct {
+	out       io.Writer
+	sheetName string
+	zip       *zip.Writer
+	sheet     *bufio.Writer
+	rows      int
+	started   bool
+}
+
+// NewXLSXWriter creates an XLSX spreadsheet writer
+func NewXLSXWriter(w io.Writer, sheetName string) Writer {
+	return &xlsxWriter{
+		out:       w,
+		sheetName: sanitizeSheetName(sheetName),
+	}
+}
+
+// WriteHeader writes the column titles in bold
+func (xw *xlsxWriter) WriteHeader(columns ...string) error {
+	values := make([]interface{}, len(columns))
+	for i, c := range columns {
+		values[i] = c
+	}
+	return xw.writeRow(values, true)
+}
+
+// WriteRow writes one row of values
+func (xw *xlsxWriter) WriteRow(values ...interface{}) error {
+	return xw.writeRow(values, false)
+}
+
+// Close finishes the sheet and the zip archive
+func (xw *xlsxWriter) Close() error {
+	if err := xw.start(); err != nil {
+		return err
+	}
+	if _, err := xw.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := xw.sheet.Flush(); err != nil {
+		return err
+	}
+	return xw.zip.Close()
+}
+
+// start writes the workbook parts and opens the sheet
+// It runs on the first row so nothing reaches the output until there is data to send
+func (xw *xlsxWriter) start() error {
+	if xw.started {
+		return nil
+	}
+	xw.started = true
+	xw.zip = zip.NewWriter(xw.out)
+
+	var name strings.Builder
+	_ = xml.EscapeText(&name, []byte(xw.sheetName))
+
+	parts := []struct {
+		path    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", strings.Replace(workbookXML, "{{sheet}}", name.String(), 1)},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+		{"xl/styles.xml", stylesXML},
+	}
+	for _, p := range parts {
+		f, err := xw.zip.Create(p.path)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, p.content); err != nil {
+			return err
+		}
+	}
+
+	f, err := xw.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	xw.sheet = bufio.NewWriter(f)
+	_, err = xw.sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return err
+}
+
+// writeRow writes one <row> element
+func (xw *xlsxWriter) writeRow(values []interface{}, bold bool) error {
+	if err := xw.start(); err != nil {
+		return err
+	}
+
+	xw.rows++
+	rowNum := strconv.Itoa(xw.rows)
+
+	var b strings.Builder
+	b.WriteString(`<row r="` + rowNum + `">`)
+	for i, v := range values {
+		text, kind, err := formatValue(v)
+		if err != nil {
+			return err
+		}
+		if kind == cellEmpty {
+			continue
+		}
+
+		ref := columnName(i) + rowNum
+		style := ""
+		if bold {
+			style = ` s="1"`
+		}
+
+		switch kind {
+		case cellNumber:
+			b.WriteString(`<c r="` + ref + `"` + style + `><v>` + text + `</v></c>`)
+		case cellBool:
+			b.WriteString(`<c r="` + ref + `"` + style + ` t="b"><v>` + text + `</v></c>`)
+		default:
+			b.WriteString(`<c r="` + ref + `"` + style + ` t="inlineStr"><is><t xml:space="preserve">`)
+			_ = xml.EscapeText(&b, []byte(text))
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+
+	if _, err := xw.sheet.WriteString(b.String()); err != nil {
+		return err
+	}
+
+	// Keep memory flat by pushing rows into the zip stream as the buffer fills
+	if xw.sheet.Buffered() >= 32*1024 {
+		return xw.sheet.Flush()
+	}
+	return nil
+}
+
+// columnName converts a zero-based column index to its letter name (0 -> A, 26 -> AA)
+func columnName(i int) string {
+	name := ""
+	for i >= 0 {
+		name = string(rune('A'+i%26)) + name
+		i = i/26 - 1
+	}
+	return name
+}
+
+// sanitizeSheetName strips characters Excel does not allow in sheet names
+func sanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '\\', '/', '?', '*', '[', ']', ':':
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Sheet1"
+	}
+	if runes := []rune(name); len(runes) > maxSheetNameLength {
+		name = string(runes[:maxSheetNameLength])
+	}
+	return name
+}
+
+const contentTypesXML = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const rootRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookXML = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="{{sheet}}" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const workbookRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// stylesXML defines style 0 (default) and style 1 (bold, used for the header row)
+const stylesXML = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`